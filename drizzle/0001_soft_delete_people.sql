@@ -0,0 +1 @@
+ALTER TABLE `people` ADD `deleted_at` text;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE `relationships` ADD `relation_kind` text;
\ No newline at end of file
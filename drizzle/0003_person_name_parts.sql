@@ -0,0 +1,3 @@
+ALTER TABLE `people` ADD `middle_name` text;--> statement-breakpoint
+ALTER TABLE `people` ADD `maiden_name` text;--> statement-breakpoint
+ALTER TABLE `people` ADD `suffix` text;
\ No newline at end of file
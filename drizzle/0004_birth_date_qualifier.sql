@@ -0,0 +1 @@
+ALTER TABLE `people` ADD `birth_date_qualifier` text;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE `people` ADD `birth_place` text;--> statement-breakpoint
+ALTER TABLE `people` ADD `death_place` text;
\ No newline at end of file
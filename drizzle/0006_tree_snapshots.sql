@@ -0,0 +1,6 @@
+CREATE TABLE `snapshots` (
+	`id` integer PRIMARY KEY AUTOINCREMENT NOT NULL,
+	`label` text NOT NULL,
+	`data` text NOT NULL,
+	`created_at` text DEFAULT CURRENT_TIMESTAMP
+);
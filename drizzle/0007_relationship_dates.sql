@@ -0,0 +1,2 @@
+ALTER TABLE `relationships` ADD `start_date` text;--> statement-breakpoint
+ALTER TABLE `relationships` ADD `end_date` text;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE `relationships` ADD `sort_order` integer;
\ No newline at end of file
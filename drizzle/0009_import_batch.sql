@@ -0,0 +1,2 @@
+ALTER TABLE `people` ADD `import_batch` text;--> statement-breakpoint
+ALTER TABLE `relationships` ADD `import_batch` text;
\ No newline at end of file
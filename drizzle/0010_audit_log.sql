@@ -0,0 +1,8 @@
+CREATE TABLE `audit_log` (
+	`id` integer PRIMARY KEY AUTOINCREMENT NOT NULL,
+	`entity_type` text NOT NULL,
+	`entity_id` integer NOT NULL,
+	`action` text NOT NULL,
+	`payload` text,
+	`created_at` text DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,14 @@
+ALTER TABLE `people` ADD `updated_at` text;--> statement-breakpoint
+ALTER TABLE `relationships` ADD `updated_at` text;--> statement-breakpoint
+UPDATE `people` SET `updated_at` = `created_at`;--> statement-breakpoint
+UPDATE `relationships` SET `updated_at` = `created_at`;--> statement-breakpoint
+CREATE TRIGGER `people_updated_at_default` AFTER INSERT ON `people`
+WHEN NEW.`updated_at` IS NULL
+BEGIN
+	UPDATE `people` SET `updated_at` = NEW.`created_at` WHERE `id` = NEW.`id`;
+END;--> statement-breakpoint
+CREATE TRIGGER `relationships_updated_at_default` AFTER INSERT ON `relationships`
+WHEN NEW.`updated_at` IS NULL
+BEGIN
+	UPDATE `relationships` SET `updated_at` = NEW.`created_at` WHERE `id` = NEW.`id`;
+END;
\ No newline at end of file
@@ -0,0 +1,35 @@
+CREATE TABLE `kinship_cache` (
+	`id` integer PRIMARY KEY AUTOINCREMENT NOT NULL,
+	`home_id` integer NOT NULL,
+	`person_id` integer NOT NULL,
+	`label` text NOT NULL,
+	`degree` integer,
+	`kinship` text,
+	`computed_at` text DEFAULT CURRENT_TIMESTAMP
+);
+--> statement-breakpoint
+CREATE INDEX `kinship_cache_home_id_idx` ON `kinship_cache` (`home_id`);--> statement-breakpoint
+CREATE TRIGGER `kinship_cache_people_insert` AFTER INSERT ON `people`
+BEGIN
+	DELETE FROM `kinship_cache`;
+END;--> statement-breakpoint
+CREATE TRIGGER `kinship_cache_people_update` AFTER UPDATE OF `gender`, `deleted_at` ON `people`
+BEGIN
+	DELETE FROM `kinship_cache`;
+END;--> statement-breakpoint
+CREATE TRIGGER `kinship_cache_people_delete` AFTER DELETE ON `people`
+BEGIN
+	DELETE FROM `kinship_cache`;
+END;--> statement-breakpoint
+CREATE TRIGGER `kinship_cache_relationships_insert` AFTER INSERT ON `relationships`
+BEGIN
+	DELETE FROM `kinship_cache`;
+END;--> statement-breakpoint
+CREATE TRIGGER `kinship_cache_relationships_update` AFTER UPDATE ON `relationships`
+BEGIN
+	DELETE FROM `kinship_cache`;
+END;--> statement-breakpoint
+CREATE TRIGGER `kinship_cache_relationships_delete` AFTER DELETE ON `relationships`
+BEGIN
+	DELETE FROM `kinship_cache`;
+END;
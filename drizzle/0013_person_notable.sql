@@ -0,0 +1 @@
+ALTER TABLE `people` ADD `notable` integer DEFAULT false NOT NULL;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE `people` ADD `notes` text;
\ No newline at end of file
@@ -22,6 +22,7 @@ import Database from 'better-sqlite3'
 import { fileURLToPath } from 'url'
 import { dirname, join } from 'path'
 import { readFileSync } from 'fs'
+import { resolveServerConfig } from '../src/lib/server/config.js'
 
 // Get project root directory
 const __filename = fileURLToPath(import.meta.url)
@@ -29,7 +30,7 @@ const __dirname = dirname(__filename)
 const projectRoot = join(__dirname, '..')
 
 // Path to the production database
-const dbPath = join(projectRoot, 'familytree.db')
+const { dbPath } = resolveServerConfig()
 const migrationsFolder = join(projectRoot, 'drizzle')
 
 async function main() {
@@ -22,6 +22,7 @@ import Database from 'better-sqlite3'
 import { drizzle } from 'drizzle-orm/better-sqlite3'
 import { fileURLToPath } from 'url'
 import { dirname, join } from 'path'
+import { resolveServerConfig } from '../src/lib/server/config.js'
 import { applyMigrations, getMigrationStatus } from '../src/lib/db/migrations.js'
 
 // Get project root directory
@@ -30,7 +31,7 @@ const __dirname = dirname(__filename)
 const projectRoot = join(__dirname, '..')
 
 // Path to the production database
-const dbPath = join(projectRoot, 'familytree.db')
+const { dbPath } = resolveServerConfig()
 
 async function main() {
   console.log('🚀 Starting database migration...\n')
@@ -0,0 +1,74 @@
+/**
+ * SvelteKit server hooks
+ *
+ * Tracks in-flight requests so SIGINT/SIGTERM can drain them (up to 15
+ * seconds) before the database is closed, answers 503 when an /api
+ * request's asynchronous work outlasts REQUEST_TIMEOUT_MS (synchronous
+ * database work is not interrupted, see requestTimeout.js), records request
+ * counts and latencies for GET /metrics, and logs each request
+ * (LOG_FORMAT/LOG_LEVEL, see requestLogger.js). Every request gets an ID,
+ * echoed in the X-Request-Id header and in JSON error bodies (see requestId.js).
+ * Cross-origin /api calls are allowed for the origins in CORS_ORIGINS
+ * (see cors.js). Each client IP may make RATE_LIMIT requests per second,
+ * answered with 429 beyond that; health checks are exempt (see rateLimit.js).
+ */
+
+import { sqlite } from '$lib/db/client.js'
+import {
+  createRequestTracker,
+  createShutdown,
+  installShutdownHandlers
+} from '$lib/server/shutdown.js'
+import { withRequestTimeout } from '$lib/server/requestTimeout.js'
+import { metrics, measureRequest } from '$lib/server/metrics.js'
+import { createRequestLogger, formatLogLine, resolveLoggingConfig } from '$lib/server/requestLogger.js'
+import { resolveRequestId, applyRequestId } from '$lib/server/requestId.js'
+import { createCorsHandler, resolveCorsOptions } from '$lib/server/cors.js'
+import { createRateLimitHandler, resolveRateLimit } from '$lib/server/rateLimit.js'
+
+const tracker = createRequestTracker()
+const loggingConfig = resolveLoggingConfig()
+const logRequest = createRequestLogger(loggingConfig)
+const corsOptions = resolveCorsOptions()
+const cors = createCorsHandler(corsOptions)
+const rateLimitOptions = resolveRateLimit()
+const rateLimit = createRateLimitHandler(rateLimitOptions)
+
+// Startup settings, in the same format as the request log
+if (loggingConfig.level === 'info') {
+  process.stdout.write(`${formatLogLine({
+    time: new Date().toISOString(),
+    level: 'info',
+    msg: 'startup',
+    corsOrigins: corsOptions.origins,
+    rateLimitPerSecond: rateLimitOptions.enabled ? rateLimitOptions.ratePerSecond : 'disabled'
+  }, loggingConfig.format)}\n`)
+}
+
+// Install once per process (the dev server may reload this module)
+if (!globalThis.__familytreeShutdownInstalled) {
+  globalThis.__familytreeShutdownInstalled = true
+  installShutdownHandlers(createShutdown({ tracker, close: () => sqlite.close() }))
+}
+
+/** @type {import('@sveltejs/kit').Handle} */
+export async function handle({ event, resolve }) {
+  tracker.start()
+  event.locals.requestId = resolveRequestId(event.request)
+  try {
+    return await logRequest(event, async () => applyRequestId(
+      await measureRequest(metrics, event, () => {
+        // CORS wraps the limiter so cross-origin clients can read a 429 and its Retry-After
+        if (event.url.pathname.startsWith('/api/')) {
+          return cors(event, () => rateLimit(event, () =>
+            withRequestTimeout(() => resolve(event), { signal: event.request.signal })
+          ))
+        }
+        return rateLimit(event, () => resolve(event))
+      }),
+      event.locals.requestId
+    ))
+  } finally {
+    tracker.finish()
+  }
+}
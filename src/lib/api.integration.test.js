@@ -88,6 +88,24 @@ describe('API Client Configuration', () => {
       )
     })
 
+    it('should use relative path /api/people/:id for patchPerson', async () => {
+      fetchMock.mockResolvedValue({
+        ok: true,
+        status: 200,
+        json: async () => ({ id: 1, deathDate: '1970-05-05' })
+      })
+
+      await api.patchPerson(1, { deathDate: '1970-05-05' })
+
+      expect(fetchMock).toHaveBeenCalledWith(
+        '/api/people/1',
+        expect.objectContaining({
+          method: 'PATCH',
+          body: JSON.stringify({ deathDate: '1970-05-05' })
+        })
+      )
+    })
+
     it('should use relative path /api/people/:id for deletePerson', async () => {
       fetchMock.mockResolvedValue({
         ok: true,
@@ -321,6 +339,16 @@ describe('API Client Configuration', () => {
 
       await expect(api.deleteRelationship(1)).rejects.toThrow('Failed to delete relationship')
     })
+
+    it('should use the error field of a JSON error body as the message', async () => {
+      fetchMock.mockResolvedValue({
+        ok: false,
+        status: 404,
+        text: async () => JSON.stringify({ error: 'Person not found', status: 404 })
+      })
+
+      await expect(api.getPerson(1)).rejects.toThrow('Person not found')
+    })
   })
 
   describe('Response Parsing', () => {
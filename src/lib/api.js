@@ -55,6 +55,24 @@ async function loadStaticData(filename) {
   }
 }
 
+/**
+ * Extracts the message from an error response body
+ *
+ * @param {string} text - Response body
+ * @returns {string} The error field of a JSON { error, status } body, otherwise the text itself
+ */
+function parseErrorMessage(text) {
+  try {
+    const body = JSON.parse(text)
+    if (typeof body?.error === 'string') {
+      return body.error
+    }
+  } catch (e) {
+    // Not JSON, use the text as is
+  }
+  return text
+}
+
 /**
  * Creates an error object with HTTP status code attached
  * This allows the UI to handle different error types appropriately
@@ -66,10 +84,10 @@ async function loadStaticData(filename) {
 async function createApiError(response, defaultMessage) {
   let errorMessage = defaultMessage
   try {
-    // Try to get error message from response body
+    // Try to get error message from response body ({ error } JSON or plain text)
     const text = await response.text()
     if (text) {
-      errorMessage = text
+      errorMessage = parseErrorMessage(text)
     }
   } catch (e) {
     // If we can't read the response body, use default message
@@ -128,6 +146,22 @@ export const api = {
     return response.json()
   },
 
+  // Changes only the given fields (null clears a field), unlike updatePerson
+  async patchPerson(id, changes) {
+    // Story #148: Block write operations in viewer mode
+    if (isViewerMode()) {
+      throw new Error('Cannot update person in viewer mode (read-only)')
+    }
+
+    const response = await fetch(`${API_BASE}/people/${id}`, {
+      method: 'PATCH',
+      headers: { 'Content-Type': 'application/json' },
+      body: JSON.stringify(changes)
+    })
+    if (!response.ok) throw await createApiError(response, 'Failed to update person')
+    return response.json()
+  },
+
   async deletePerson(id) {
     // Story #148: Block write operations in viewer mode
     if (isViewerMode()) {
@@ -1,21 +1,30 @@
 import Database from 'better-sqlite3'
 import { drizzle } from 'drizzle-orm/better-sqlite3'
-import { fileURLToPath } from 'url'
-import { dirname, join } from 'path'
-
-// Get the directory of the current module
-const __filename = fileURLToPath(import.meta.url)
-const __dirname = dirname(__filename)
+import { resolveServerConfig } from '../server/config.js'
+import { REQUEST_TIMEOUT_MS } from '../server/requestTimeout.js'
 
 // Path to the SQLite database
-// Points to familytree.db in project root from src/lib/db/
-const dbPath = join(__dirname, '../../../familytree.db')
+// DB_PATH environment variable, defaulting to familytree.db in project root
+const { dbPath } = resolveServerConfig()
 
-// Create SQLite connection
-let sqlite = new Database(dbPath)
+/**
+ * Opens a SQLite connection configured for the app
+ *
+ * - foreign_keys = ON: SQLite ignores foreign keys (including ON DELETE CASCADE,
+ *   which removes a deleted person's relationships) unless enabled per connection
+ * - busy timeout: a locked database fails with SQLITE_BUSY instead of hanging
+ *
+ * @param {string} path - Database file path (or ':memory:')
+ * @returns {Database} Configured better-sqlite3 connection
+ */
+export function openDatabase(path) {
+  const connection = new Database(path, { timeout: REQUEST_TIMEOUT_MS })
+  connection.exec('PRAGMA foreign_keys = ON')
+  return connection
+}
 
-// Enable foreign key constraints
-sqlite.exec('PRAGMA foreign_keys = ON')
+// Create SQLite connection
+let sqlite = openDatabase(dbPath)
 
 // Create Drizzle ORM instance
 let db = drizzle(sqlite)
@@ -35,10 +44,7 @@ export function reconnectDatabase() {
   }
 
   // Create new connection
-  sqlite = new Database(dbPath)
-
-  // Enable foreign key constraints
-  sqlite.exec('PRAGMA foreign_keys = ON')
+  sqlite = openDatabase(dbPath)
 
   db = drizzle(sqlite)
 }
@@ -1,7 +1,10 @@
 import { describe, test, expect, beforeAll, afterAll } from 'vitest'
-import { db } from './client.js'
+import { db, openDatabase } from './client.js'
 import { people, relationships } from './schema.js'
 import { eq } from 'drizzle-orm'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { setupTestDatabase } from '../server/testHelpers.js'
+import { resolvePlaceholder } from '../server/placeholderResolution.js'
 
 describe('Database Client', () => {
   test('should have db client defined', () => {
@@ -131,3 +134,40 @@ describe('Database Client', () => {
     })
   })
 })
+
+describe('openDatabase', () => {
+  test('should enable foreign key enforcement on the connection', () => {
+    const connection = openDatabase(':memory:')
+
+    expect(connection.pragma('foreign_keys', { simple: true })).toBe(1)
+
+    connection.close()
+  })
+
+  test('should cascade a hard person delete to their relationships on the migrated schema', async () => {
+    const connection = openDatabase(':memory:')
+    const database = drizzle(connection)
+    await setupTestDatabase(connection, database)
+
+    connection.exec(`
+      INSERT INTO people (id, first_name, last_name) VALUES
+        (1, 'Unknown', 'Father'), (2, 'Jane', 'Doe'), (3, 'Junior', 'Doe'), (4, 'John', 'Doe');
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role) VALUES
+        (1, 2, 'spouse', NULL), (1, 3, 'parentOf', 'father'), (2, 3, 'parentOf', 'mother');
+    `)
+
+    // Resolving a placeholder hard-deletes it and relies on the cascade for its old rows
+    await resolvePlaceholder(1, 4, database)
+
+    const remaining = connection
+      .prepare('SELECT person1_id, person2_id, type FROM relationships ORDER BY id')
+      .all()
+    expect(remaining).toEqual([
+      { person1_id: 2, person2_id: 3, type: 'parentOf' },
+      { person1_id: 4, person2_id: 2, type: 'spouse' },
+      { person1_id: 4, person2_id: 3, type: 'parentOf' }
+    ])
+
+    connection.close()
+  })
+})
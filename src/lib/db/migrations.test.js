@@ -55,8 +55,8 @@ describe('Drizzle Migration System', () => {
         .prepare('SELECT hash FROM __drizzle_migrations ORDER BY id')
         .all()
 
-      // Should have 1 migration (initial schema with people and relationships)
-      expect(migrations).toHaveLength(1)
+      // Should have 15 migrations (initial schema plus incremental schema changes)
+      expect(migrations).toHaveLength(15)
     })
 
     it('should skip already-applied migrations on subsequent runs', async () => {
@@ -104,7 +104,18 @@ describe('Drizzle Migration System', () => {
         'photo_url',
         'birth_surname',
         'nickname',
-        'created_at'
+        'middle_name',
+        'maiden_name',
+        'suffix',
+        'birth_date_qualifier',
+        'birth_place',
+        'death_place',
+        'created_at',
+        'deleted_at',
+        'import_batch',
+        'updated_at',
+        'notable',
+        'notes'
       ].sort()
 
       expect(columns).toEqual(expectedColumns)
@@ -125,7 +136,13 @@ describe('Drizzle Migration System', () => {
         'person2_id',
         'type',
         'parent_role',
-        'created_at'
+        'relation_kind',
+        'created_at',
+        'start_date',
+        'end_date',
+        'sort_order',
+        'import_batch',
+        'updated_at'
       ].sort()
 
       expect(columns).toEqual(expectedColumns)
@@ -185,7 +202,7 @@ describe('Drizzle Migration System', () => {
       await applyMigrations(sqlite, db)
 
       const status = await getMigrationStatus(sqlite)
-      expect(status).toHaveLength(1)
+      expect(status).toHaveLength(15)
       expect(status[0]).toHaveProperty('hash')
       expect(status[0]).toHaveProperty('created_at')
     })
@@ -203,12 +220,12 @@ describe('Drizzle Migration System', () => {
       await applyMigrations(sqlite, db)
       await applyMigrations(sqlite, db)
 
-      // Should still have exactly 1 migration record
+      // Should still have exactly 15 migration records
       const count = sqlite
         .prepare('SELECT COUNT(*) as count FROM __drizzle_migrations')
         .get().count
 
-      expect(count).toBe(1)
+      expect(count).toBe(15)
 
       // Schema should still be intact
       const tables = sqlite
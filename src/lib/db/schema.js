@@ -1,4 +1,4 @@
-import { sqliteTable, integer, text } from 'drizzle-orm/sqlite-core'
+import { sqliteTable, integer, text, index } from 'drizzle-orm/sqlite-core'
 import { sql } from 'drizzle-orm'
 
 /**
@@ -12,6 +12,38 @@ import { sql } from 'drizzle-orm'
  * - birth_surname: Original family name before marriage (nullable)
  * - nickname: Common name or alternate name (nullable)
  *
+ * Name Parts:
+ * - middle_name: Middle name(s) (nullable)
+ * - maiden_name: Maiden name (nullable)
+ * - suffix: Generational or honorific suffix such as "Jr." or "III" (nullable)
+ *
+ * Approximate Dates:
+ * - birth_date_qualifier: "about", "before", or "after" when birth_date is not exact (nullable)
+ *
+ * Places:
+ * - birth_place, death_place: Free-text locations (nullable, trimmed on input)
+ *
+ * Notable:
+ * - notable: Marks a well-known person (such as a historical figure) so
+ *   relatives can find their connection to them (defaults to false)
+ *
+ * Notes:
+ * - notes: Free-text research notes (nullable, blank stored as null)
+ *
+ * Soft Delete:
+ * - deleted_at: Timestamp set when a person is deleted (nullable)
+ * - Rows with deleted_at set are excluded from all list and get queries
+ *
+ * Import Batches:
+ * - import_batch: ID of the import that created the row (nullable), so an
+ *   import can be listed and rolled back as a unit
+ *
+ * Timestamps:
+ * - updated_at: Starts equal to created_at and is set to CURRENT_TIMESTAMP by
+ *   every update made through Drizzle (rows inserted without updated_at,
+ *   e.g. with raw SQL, get it copied from created_at by a database trigger,
+ *   since SQLite cannot add a column defaulting to CURRENT_TIMESTAMP)
+ *
  * Note: userId removed - this is now a local-only app with no authentication
  */
 export const people = sqliteTable('people', {
@@ -24,7 +56,18 @@ export const people = sqliteTable('people', {
   photoUrl: text('photo_url'),
   birthSurname: text('birth_surname'),
   nickname: text('nickname'),
-  createdAt: text('created_at').default(sql`CURRENT_TIMESTAMP`)
+  middleName: text('middle_name'),
+  maidenName: text('maiden_name'),
+  suffix: text('suffix'),
+  birthDateQualifier: text('birth_date_qualifier'),
+  birthPlace: text('birth_place'),
+  deathPlace: text('death_place'),
+  deletedAt: text('deleted_at'),
+  createdAt: text('created_at').default(sql`CURRENT_TIMESTAMP`),
+  importBatch: text('import_batch'),
+  updatedAt: text('updated_at').default(sql`CURRENT_TIMESTAMP`).$onUpdate(() => sql`CURRENT_TIMESTAMP`),
+  notable: integer('notable', { mode: 'boolean' }).notNull().default(false),
+  notes: text('notes')
 })
 
 /**
@@ -40,6 +83,24 @@ export const people = sqliteTable('people', {
  * - Prevents duplicate parent and spouse relationships
  * - Handles NULL parent_role values (for spouse relationships)
  *
+ * Relation Kind:
+ * - relation_kind: "biological" or "adoptive" for parentOf relationships (nullable)
+ * - NULL is treated as biological for backward compatibility
+ *
+ * Dates:
+ * - start_date / end_date: YYYY-MM-DD (nullable). For spouse rows these are
+ *   the marriage and divorce/separation dates
+ *
+ * Child Order:
+ * - sort_order: user-defined position of the child among the parent's
+ *   children (parentOf rows only, nullable; unordered children sort last)
+ *
+ * Import Batches:
+ * - import_batch: ID of the import that created the row (nullable)
+ *
+ * Timestamps:
+ * - updated_at: Maintained the same way as people.updated_at
+ *
  * Note: userId removed - this is now a local-only app with no authentication
  */
 export const relationships = sqliteTable('relationships', {
@@ -52,7 +113,72 @@ export const relationships = sqliteTable('relationships', {
     .references(() => people.id, { onDelete: 'cascade' }),
   type: text('type').notNull(),
   parentRole: text('parent_role'),
+  relationKind: text('relation_kind'),
+  createdAt: text('created_at').default(sql`CURRENT_TIMESTAMP`),
+  startDate: text('start_date'),
+  endDate: text('end_date'),
+  sortOrder: integer('sort_order'),
+  importBatch: text('import_batch'),
+  updatedAt: text('updated_at').default(sql`CURRENT_TIMESTAMP`).$onUpdate(() => sql`CURRENT_TIMESTAMP`)
+})
+
+/**
+ * Snapshots table schema
+ * Labeled point-in-time copies of the whole tree
+ *
+ * - label: User-provided name for the snapshot
+ * - data: JSON document { people: [...], relationships: [...] } with full rows,
+ *   including soft-deleted people, so a restore is exact
+ */
+export const snapshots = sqliteTable('snapshots', {
+  id: integer('id').primaryKey({ autoIncrement: true }),
+  label: text('label').notNull(),
+  data: text('data').notNull(),
+  createdAt: text('created_at').default(sql`CURRENT_TIMESTAMP`)
+})
+
+/**
+ * Audit log table schema
+ * One row per write made through the people and relationships API
+ *
+ * - entityType: "person" or "relationship"
+ * - entityId: ID of the written row (no foreign key, so entries outlive deletes)
+ * - action: create, update, delete, restore, or merge
+ * - payload: JSON document describing the change (see auditLog.js)
+ */
+export const auditLog = sqliteTable('audit_log', {
+  id: integer('id').primaryKey({ autoIncrement: true }),
+  entityType: text('entity_type').notNull(),
+  entityId: integer('entity_id').notNull(),
+  action: text('action').notNull(),
+  payload: text('payload'),
   createdAt: text('created_at').default(sql`CURRENT_TIMESTAMP`)
 })
 
+/**
+ * Kinship cache table schema
+ * Precomputed kinship of every person to a home person (see kinshipCache.js)
+ *
+ * - homeId / personId: Home person and the person described (no foreign
+ *   keys; the whole cache is cleared on any change to the graph)
+ * - label: English kinship label, legal kinship mode
+ * - degree: Steps along the kinship path (null when unrelated)
+ * - kinship: JSON kinship structure from computeKinship (null when unrelated)
+ *
+ * Invalidation: triggers delete every row after any insert or delete on
+ * people or relationships, any relationship update, and any change to a
+ * person's gender or deleted_at, so imports and restores are covered too.
+ */
+export const kinshipCache = sqliteTable('kinship_cache', {
+  id: integer('id').primaryKey({ autoIncrement: true }),
+  homeId: integer('home_id').notNull(),
+  personId: integer('person_id').notNull(),
+  label: text('label').notNull(),
+  degree: integer('degree'),
+  kinship: text('kinship'),
+  computedAt: text('computed_at').default(sql`CURRENT_TIMESTAMP`)
+}, (table) => ({
+  homeIdIdx: index('kinship_cache_home_id_idx').on(table.homeId)
+}))
+
 // Users and sessions tables removed - no authentication in local-only app
@@ -0,0 +1,104 @@
+/**
+ * Audit Log Module
+ *
+ * Records writes made through the people and relationships API in the
+ * audit_log table. Handlers call recordAudit with their transaction, so an
+ * entry is committed exactly when the change it describes is.
+ *
+ * Payloads use the API format of the affected row:
+ * - create: { after }
+ * - update: { before, after } (child reorders record { after: { childIds } })
+ * - delete: { before }
+ * - restore: { after }
+ * - merge: operation-specific summary (see the merge and resolve handlers)
+ *
+ * Imports, snapshot restores, and admin repairs rewrite many rows at once and
+ * are tracked by their own records (import batches, snapshots) instead.
+ */
+
+import { auditLog } from '../db/schema.js'
+import { and, asc, desc, eq } from 'drizzle-orm'
+
+/**
+ * Entity types recorded in the audit log
+ */
+export const AUDIT_ENTITY_TYPES = {
+  person: 'person',
+  relationship: 'relationship'
+}
+
+/**
+ * Actions recorded in the audit log
+ */
+export const AUDIT_ACTIONS = {
+  create: 'create',
+  update: 'update',
+  delete: 'delete',
+  restore: 'restore',
+  merge: 'merge'
+}
+
+/**
+ * Writes one audit entry
+ *
+ * Synchronous so it can run inside a better-sqlite3 transaction callback.
+ *
+ * @param {Object} database - Drizzle database or transaction
+ * @param {string} entityType - Value from AUDIT_ENTITY_TYPES
+ * @param {number} entityId - ID of the written row
+ * @param {string} action - Value from AUDIT_ACTIONS
+ * @param {Object} payload - Description of the change (stored as JSON)
+ */
+export function recordAudit(database, entityType, entityId, action, payload) {
+  database
+    .insert(auditLog)
+    .values({ entityType, entityId, action, payload: JSON.stringify(payload) })
+    .run()
+}
+
+/**
+ * Lists the most recent audit entries, newest first
+ *
+ * @param {Object} database - Drizzle database instance
+ * @param {number} limit - Maximum number of entries
+ * @returns {Array<{id: number, entityType: string, entityId: number, action: string, payload: Object|null, createdAt: string}>}
+ *   Entries with payload parsed from JSON
+ */
+export function listAuditEntries(database, limit) {
+  return database
+    .select()
+    .from(auditLog)
+    .orderBy(desc(auditLog.id))
+    .limit(limit)
+    .all()
+    .map(parseAuditEntry)
+}
+
+/**
+ * Lists every audit entry for one entity, oldest first
+ *
+ * @param {Object} database - Drizzle database instance
+ * @param {string} entityType - Value from AUDIT_ENTITY_TYPES
+ * @param {number} entityId - Entity ID
+ * @returns {Array<Object>} Entries in the same shape as listAuditEntries
+ */
+export function listAuditEntriesFor(database, entityType, entityId) {
+  return database
+    .select()
+    .from(auditLog)
+    .where(and(eq(auditLog.entityType, entityType), eq(auditLog.entityId, entityId)))
+    .orderBy(asc(auditLog.id))
+    .all()
+    .map(parseAuditEntry)
+}
+
+/**
+ * Parses a stored audit entry's JSON payload
+ *
+ * @private
+ * @param {Object} entry - Audit log row
+ * @returns {Object} Entry with payload parsed (null stays null)
+ */
+function parseAuditEntry(entry) {
+  return { ...entry, payload: entry.payload === null ? null : JSON.parse(entry.payload) }
+}
@@ -0,0 +1,76 @@
+/**
+ * Server Configuration
+ *
+ * Resolves the listen address and database path from environment variables
+ * so the app can be deployed in containers without code changes.
+ *
+ * Environment variables:
+ * - PORT: Port to listen on (default: 5173)
+ * - ADDR: Listen address as "host:port" or ":port" (takes precedence over PORT)
+ * - DB_PATH: Path to the SQLite database file (default: familytree.db in project root)
+ *
+ * Kept free of $lib imports so vite.config.js and scripts/ can use it directly.
+ */
+
+import { fileURLToPath } from 'url'
+import { dirname, join } from 'path'
+
+const __filename = fileURLToPath(import.meta.url)
+const __dirname = dirname(__filename)
+
+export const DEFAULT_PORT = 5173
+export const DEFAULT_DB_PATH = join(__dirname, '../../../familytree.db')
+
+/**
+ * Parses a port string, failing fast with a clear error when invalid
+ *
+ * @param {string} value - Port value from the environment
+ * @param {string} name - Variable name for error messages
+ * @returns {number} Port number
+ * @throws {Error} If the port is not an integer between 1 and 65535
+ */
+function parsePort(value, name) {
+  const trimmed = String(value).trim()
+  const port = Number(trimmed)
+
+  if (!/^\d+$/.test(trimmed) || port < 1 || port > 65535) {
+    throw new Error(`Invalid ${name} "${value}": port must be a number between 1 and 65535`)
+  }
+
+  return port
+}
+
+/**
+ * Resolves server configuration from environment variables
+ *
+ * Unset or empty variables fall back to the defaults.
+ *
+ * @param {Object} [env=process.env] - Environment variables
+ * @returns {Object} { host: string|undefined, port: number, dbPath: string }
+ * @throws {Error} If PORT or the port part of ADDR is not numeric
+ *
+ * @example
+ * resolveServerConfig({ PORT: '8080', DB_PATH: '/data/familytree.db' })
+ * // { host: undefined, port: 8080, dbPath: '/data/familytree.db' }
+ */
+export function resolveServerConfig(env = process.env) {
+  let host
+  let port = DEFAULT_PORT
+
+  if (env.ADDR) {
+    const separator = env.ADDR.lastIndexOf(':')
+    if (separator === -1) {
+      throw new Error(`Invalid ADDR "${env.ADDR}": expected "host:port" or ":port"`)
+    }
+    host = env.ADDR.slice(0, separator) || undefined
+    port = parsePort(env.ADDR.slice(separator + 1), 'ADDR')
+  } else if (env.PORT) {
+    port = parsePort(env.PORT, 'PORT')
+  }
+
+  return {
+    host,
+    port,
+    dbPath: env.DB_PATH || DEFAULT_DB_PATH
+  }
+}
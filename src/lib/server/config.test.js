@@ -0,0 +1,47 @@
+/**
+ * Unit tests for Server Configuration
+ */
+
+import { describe, it, expect } from 'vitest'
+import { resolveServerConfig, DEFAULT_PORT, DEFAULT_DB_PATH } from './config.js'
+
+describe('resolveServerConfig', () => {
+  it('should use defaults when variables are unset', () => {
+    expect(resolveServerConfig({})).toEqual({
+      host: undefined,
+      port: DEFAULT_PORT,
+      dbPath: DEFAULT_DB_PATH
+    })
+  })
+
+  it('should treat empty variables as unset', () => {
+    expect(resolveServerConfig({ PORT: '', DB_PATH: '' })).toEqual({
+      host: undefined,
+      port: DEFAULT_PORT,
+      dbPath: DEFAULT_DB_PATH
+    })
+  })
+
+  it('should read PORT and DB_PATH', () => {
+    expect(resolveServerConfig({ PORT: '8080', DB_PATH: '/data/familytree.db' })).toEqual({
+      host: undefined,
+      port: 8080,
+      dbPath: '/data/familytree.db'
+    })
+  })
+
+  it('should prefer ADDR over PORT', () => {
+    expect(resolveServerConfig({ ADDR: '0.0.0.0:9000', PORT: '8080' })).toMatchObject({
+      host: '0.0.0.0',
+      port: 9000
+    })
+    expect(resolveServerConfig({ ADDR: ':9000' })).toMatchObject({ host: undefined, port: 9000 })
+  })
+
+  it('should fail fast on a non-numeric port', () => {
+    expect(() => resolveServerConfig({ PORT: 'abc' })).toThrow('Invalid PORT "abc"')
+    expect(() => resolveServerConfig({ PORT: '70000' })).toThrow('Invalid PORT')
+    expect(() => resolveServerConfig({ ADDR: 'localhost:http' })).toThrow('Invalid ADDR')
+    expect(() => resolveServerConfig({ ADDR: 'localhost' })).toThrow('Invalid ADDR')
+  })
+})
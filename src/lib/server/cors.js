@@ -0,0 +1,135 @@
+/**
+ * CORS
+ *
+ * Lets browser clients on other origins call /api. Allowed origins come
+ * from the environment so deployments don't need code changes.
+ *
+ * Environment variables:
+ * - CORS_ORIGINS: Comma-separated origins such as "https://tree.example.com",
+ *   or "*" to allow any origin (default: http://localhost:5173)
+ *
+ * Kept free of $lib imports so scripts can use it directly.
+ */
+
+export const DEFAULT_CORS_ORIGINS = ['http://localhost:5173']
+
+export const CORS_ALLOWED_METHODS = ['GET', 'POST', 'PUT', 'PATCH', 'DELETE', 'OPTIONS']
+
+export const CORS_ALLOWED_HEADERS = ['Content-Type', 'Accept', 'X-Request-Id']
+
+/**
+ * Response headers browsers may read besides the CORS-safelisted ones
+ * (Retry-After lets clients back off after a 429)
+ */
+export const CORS_EXPOSED_HEADERS = ['X-Request-Id', 'Retry-After']
+
+/**
+ * How long browsers may cache a preflight response, in seconds
+ */
+export const CORS_MAX_AGE_SECONDS = 600
+
+/**
+ * Parses one entry of CORS_ORIGINS
+ *
+ * @param {string} value - Entry, already trimmed
+ * @returns {string} "*" or the normalized origin (scheme://host[:port])
+ * @throws {Error} If the entry is not "*" or an http(s) origin without a path
+ */
+function parseOrigin(value) {
+  if (value === '*') return value
+
+  let url
+  try {
+    url = new URL(value)
+  } catch {
+    url = null
+  }
+
+  if (!url || !['http:', 'https:'].includes(url.protocol) || value.replace(/\/$/, '') !== url.origin) {
+    throw new Error(`Invalid CORS_ORIGINS entry "${value}": expected "*" or an origin like https://example.com`)
+  }
+
+  return url.origin
+}
+
+/**
+ * Resolves the CORS options from environment variables
+ *
+ * An unset or empty CORS_ORIGINS falls back to DEFAULT_CORS_ORIGINS.
+ *
+ * @param {Object} [env=process.env] - Environment variables
+ * @returns {{origins: string[], allowAll: boolean}} Allowed origins, and whether "*" was given
+ * @throws {Error} If an entry is not "*" or a valid origin
+ *
+ * @example
+ * resolveCorsOptions({ CORS_ORIGINS: 'https://a.example.com, https://b.example.com' })
+ * // { origins: ['https://a.example.com', 'https://b.example.com'], allowAll: false }
+ */
+export function resolveCorsOptions(env = process.env) {
+  const entries = (env.CORS_ORIGINS || '')
+    .split(',')
+    .map((entry) => entry.trim())
+    .filter(Boolean)
+
+  const origins = entries.length > 0 ? [...new Set(entries.map(parseOrigin))] : DEFAULT_CORS_ORIGINS
+
+  return { origins, allowAll: origins.includes('*') }
+}
+
+/**
+ * Picks the Access-Control-Allow-Origin value for a request origin
+ *
+ * @param {{origins: string[], allowAll: boolean}} options - From resolveCorsOptions
+ * @param {string|null} origin - Origin request header
+ * @returns {string|null} "*", the request origin, or null when not allowed
+ */
+export function allowedOrigin(options, origin) {
+  if (options.allowAll) return '*'
+  return origin && options.origins.includes(origin) ? origin : null
+}
+
+/**
+ * Creates a CORS wrapper for the server hooks
+ *
+ * Preflight requests (OPTIONS with Access-Control-Request-Method) are
+ * answered directly with 204; other responses get the allow-origin header
+ * when the request origin is allowed. Disallowed origins get no CORS
+ * headers, so the browser blocks the response.
+ *
+ * @param {{origins: string[], allowAll: boolean}} options - From resolveCorsOptions
+ * @returns {Function} async (event, resolve) => Response
+ *
+ * @example
+ * const cors = createCorsHandler(resolveCorsOptions())
+ * return cors(event, resolve)
+ */
+export function createCorsHandler(options) {
+  return async function cors(event, resolve) {
+    const allowOrigin = allowedOrigin(options, event.request.headers.get('Origin'))
+
+    const isPreflight = event.request.method === 'OPTIONS' &&
+      event.request.headers.has('Access-Control-Request-Method')
+
+    if (isPreflight) {
+      const headers = new Headers({ Vary: 'Origin' })
+      if (allowOrigin) {
+        headers.set('Access-Control-Allow-Origin', allowOrigin)
+        headers.set('Access-Control-Allow-Methods', CORS_ALLOWED_METHODS.join(', '))
+        headers.set('Access-Control-Allow-Headers', CORS_ALLOWED_HEADERS.join(', '))
+        headers.set('Access-Control-Max-Age', String(CORS_MAX_AGE_SECONDS))
+      }
+      return new Response(null, { status: 204, headers })
+    }
+
+    const response = await resolve(event)
+
+    // Copy so headers can be set even on immutable responses
+    const tagged = new Response(response.body, response)
+    tagged.headers.append('Vary', 'Origin')
+    if (allowOrigin) {
+      tagged.headers.set('Access-Control-Allow-Origin', allowOrigin)
+      tagged.headers.set('Access-Control-Expose-Headers', CORS_EXPOSED_HEADERS.join(', '))
+    }
+    return tagged
+  }
+}
@@ -0,0 +1,117 @@
+/**
+ * Unit tests for CORS
+ */
+
+import { describe, it, expect } from 'vitest'
+import {
+  resolveCorsOptions,
+  allowedOrigin,
+  createCorsHandler,
+  DEFAULT_CORS_ORIGINS
+} from './cors.js'
+import { createRateLimitHandler } from './rateLimit.js'
+
+describe('resolveCorsOptions', () => {
+  it('should default to the dev server origin when unset or empty', () => {
+    expect(resolveCorsOptions({})).toEqual({ origins: DEFAULT_CORS_ORIGINS, allowAll: false })
+    expect(resolveCorsOptions({ CORS_ORIGINS: ' ' })).toEqual({ origins: DEFAULT_CORS_ORIGINS, allowAll: false })
+  })
+
+  it('should parse a comma-separated list of origins', () => {
+    const options = resolveCorsOptions({
+      CORS_ORIGINS: 'https://tree.example.com, http://localhost:4173/,https://tree.example.com'
+    })
+
+    expect(options).toEqual({
+      origins: ['https://tree.example.com', 'http://localhost:4173'],
+      allowAll: false
+    })
+  })
+
+  it('should allow all origins for *', () => {
+    expect(resolveCorsOptions({ CORS_ORIGINS: '*' })).toEqual({ origins: ['*'], allowAll: true })
+  })
+
+  it('should reject entries that are not origins', () => {
+    expect(() => resolveCorsOptions({ CORS_ORIGINS: 'tree.example.com' })).toThrow('Invalid CORS_ORIGINS')
+    expect(() => resolveCorsOptions({ CORS_ORIGINS: 'https://tree.example.com/app' })).toThrow('Invalid CORS_ORIGINS')
+    expect(() => resolveCorsOptions({ CORS_ORIGINS: 'ftp://tree.example.com' })).toThrow('Invalid CORS_ORIGINS')
+  })
+})
+
+describe('allowedOrigin', () => {
+  const options = resolveCorsOptions({ CORS_ORIGINS: 'https://tree.example.com' })
+
+  it('should echo an allowed origin', () => {
+    expect(allowedOrigin(options, 'https://tree.example.com')).toBe('https://tree.example.com')
+  })
+
+  it('should return null for other or missing origins', () => {
+    expect(allowedOrigin(options, 'https://evil.example.com')).toBe(null)
+    expect(allowedOrigin(options, null)).toBe(null)
+  })
+
+  it('should return * when all origins are allowed', () => {
+    expect(allowedOrigin(resolveCorsOptions({ CORS_ORIGINS: '*' }), 'https://any.example.com')).toBe('*')
+  })
+})
+
+describe('createCorsHandler', () => {
+  const cors = createCorsHandler(resolveCorsOptions({ CORS_ORIGINS: 'https://tree.example.com' }))
+
+  const createEvent = (method, headers) => ({
+    request: new Request('http://localhost/api/people', { method, headers })
+  })
+
+  it('should answer preflight requests without calling the handler', async () => {
+    let called = false
+    const response = await cors(
+      createEvent('OPTIONS', { Origin: 'https://tree.example.com', 'Access-Control-Request-Method': 'POST' }),
+      async () => { called = true; return new Response('ok') }
+    )
+
+    expect(response.status).toBe(204)
+    expect(response.headers.get('Access-Control-Allow-Origin')).toBe('https://tree.example.com')
+    expect(response.headers.get('Access-Control-Allow-Methods')).toContain('POST')
+    expect(called).toBe(false)
+  })
+
+  it('should add the allow-origin header for allowed origins', async () => {
+    const response = await cors(
+      createEvent('GET', { Origin: 'https://tree.example.com' }),
+      async () => new Response('ok')
+    )
+
+    expect(response.headers.get('Access-Control-Allow-Origin')).toBe('https://tree.example.com')
+    expect(response.headers.get('Vary')).toContain('Origin')
+    expect(await response.text()).toBe('ok')
+  })
+
+  it('should let cross-origin clients read a rate-limited response', async () => {
+    const rateLimit = createRateLimitHandler({ ratePerSecond: 1, burst: 1, enabled: true }, { now: () => 0 })
+    const event = {
+      ...createEvent('GET', { Origin: 'https://tree.example.com' }),
+      url: new URL('http://localhost/api/people'),
+      getClientAddress: () => '10.0.0.1'
+    }
+    const handler = () => cors(event, () => rateLimit(event, async () => new Response('ok')))
+
+    await handler()
+    const response = await handler()
+
+    expect(response.status).toBe(429)
+    expect(response.headers.get('Access-Control-Allow-Origin')).toBe('https://tree.example.com')
+    expect(response.headers.get('Access-Control-Expose-Headers')).toContain('Retry-After')
+    expect(response.headers.get('Retry-After')).toBe('1')
+  })
+
+  it('should not add CORS headers for other origins', async () => {
+    const response = await cors(
+      createEvent('GET', { Origin: 'https://evil.example.com' }),
+      async () => new Response('ok')
+    )
+
+    expect(response.status).toBe(200)
+    expect(response.headers.get('Access-Control-Allow-Origin')).toBe(null)
+  })
+})
@@ -0,0 +1,121 @@
+/**
+ * CSV Module
+ *
+ * Minimal RFC 4180 helpers shared by the CSV export and import endpoints.
+ */
+
+/**
+ * Escapes a single CSV field
+ * Fields containing commas, quotes, or line breaks are quoted, with quotes doubled.
+ * null and undefined become empty fields.
+ *
+ * @param {*} field - Field value
+ * @returns {string} Escaped field
+ */
+export function escapeCsvField(field) {
+  if (field === null || field === undefined) {
+    return ''
+  }
+
+  const stringField = String(field)
+
+  if (/[",\r\n]/.test(stringField)) {
+    return `"${stringField.replace(/"/g, '""')}"`
+  }
+
+  return stringField
+}
+
+/**
+ * Serializes one row of values as a CSV line (with trailing newline)
+ *
+ * @param {Array} fields - Field values
+ * @returns {string} CSV line
+ */
+export function toCsvLine(fields) {
+  return fields.map(escapeCsvField).join(',') + '\n'
+}
+
+/**
+ * Serializes a header row and data rows as CSV text
+ *
+ * @param {Array<string>} headers - Column names
+ * @param {Array<Array>} rows - Data rows (values in header order)
+ * @returns {string} CSV content with a trailing newline
+ *
+ * @example
+ * toCsv(['id', 'name'], [[1, 'Smith, John']])
+ * // 'id,name\n1,"Smith, John"\n'
+ */
+export function toCsv(headers, rows) {
+  return [headers, ...rows].map(toCsvLine).join('')
+}
+
+/**
+ * Parses CSV text into records, each tagged with the (1-based) line it starts on
+ * Handles quoted fields (with doubled quotes and embedded commas or line breaks)
+ * and both LF and CRLF line endings. A trailing newline does not produce an empty record.
+ *
+ * @param {string} text - CSV content
+ * @returns {Array<{line: number, fields: Array<string>}>} Parsed records
+ */
+export function parseCsvRecords(text) {
+  const records = []
+  let fields = []
+  let field = ''
+  let inQuotes = false
+  let line = 1
+  let recordLine = 1
+
+  for (let i = 0; i < text.length; i++) {
+    const char = text[i]
+
+    if (inQuotes) {
+      if (char === '"' && text[i + 1] === '"') {
+        field += '"'
+        i++
+      } else if (char === '"') {
+        inQuotes = false
+      } else {
+        if (char === '\n') line++
+        field += char
+      }
+    } else if (char === '"') {
+      inQuotes = true
+    } else if (char === ',') {
+      fields.push(field)
+      field = ''
+    } else if (char === '\n' || char === '\r') {
+      if (char === '\r' && text[i + 1] === '\n') i++
+      fields.push(field)
+      records.push({ line: recordLine, fields })
+      fields = []
+      field = ''
+      line++
+      recordLine = line
+    } else {
+      field += char
+    }
+  }
+
+  if (field !== '' || fields.length > 0) {
+    fields.push(field)
+    records.push({ line: recordLine, fields })
+  }
+
+  return records
+}
+
+/**
+ * Parses CSV text into rows of string fields
+ *
+ * @param {string} text - CSV content
+ * @returns {Array<Array<string>>} Parsed rows
+ *
+ * @example
+ * parseCsv('id,name\n1,"Smith, John"\n')
+ * // [['id', 'name'], ['1', 'Smith, John']]
+ */
+export function parseCsv(text) {
+  return parseCsvRecords(text).map((record) => record.fields)
+}
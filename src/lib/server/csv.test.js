@@ -0,0 +1,66 @@
+/**
+ * Unit tests for CSV Module
+ */
+
+import { describe, it, expect } from 'vitest'
+import { escapeCsvField, toCsv, toCsvLine, parseCsv, parseCsvRecords } from './csv.js'
+
+describe('escapeCsvField', () => {
+  it('should leave plain values unquoted', () => {
+    expect(escapeCsvField('Smith')).toBe('Smith')
+    expect(escapeCsvField(0)).toBe('0')
+  })
+
+  it('should render null and undefined as empty', () => {
+    expect(escapeCsvField(null)).toBe('')
+    expect(escapeCsvField(undefined)).toBe('')
+  })
+
+  it('should quote fields with commas, quotes, or newlines', () => {
+    expect(escapeCsvField('Smith, John')).toBe('"Smith, John"')
+    expect(escapeCsvField('The "Kid"')).toBe('"The ""Kid"""')
+    expect(escapeCsvField('line1\nline2')).toBe('"line1\nline2"')
+  })
+})
+
+describe('toCsv', () => {
+  it('should join header and rows with a trailing newline', () => {
+    expect(toCsv(['id', 'name'], [[1, 'Smith, John'], [2, null]])).toBe(
+      'id,name\n1,"Smith, John"\n2,\n'
+    )
+  })
+})
+
+describe('toCsvLine', () => {
+  it('should escape fields and end with a newline', () => {
+    expect(toCsvLine([1, 'Smith, John', null])).toBe('1,"Smith, John",\n')
+  })
+})
+
+describe('parseCsv', () => {
+  it('should split rows and fields', () => {
+    expect(parseCsv('id,name\n1,Smith\n')).toEqual([['id', 'name'], ['1', 'Smith']])
+  })
+
+  it('should unescape quoted fields', () => {
+    expect(parseCsv('"Smith, John","The ""Kid""","a\nb"\n')).toEqual([['Smith, John', 'The "Kid"', 'a\nb']])
+  })
+
+  it('should handle CRLF line endings and a missing trailing newline', () => {
+    expect(parseCsv('a,b\r\n1,\r\n2,x')).toEqual([['a', 'b'], ['1', ''], ['2', 'x']])
+  })
+
+  it('should round-trip toCsv output', () => {
+    const rows = [['1', 'O\'Brien, "Pat"', ''], ['2', 'Smith', 'line1\nline2']]
+    expect(parseCsv(toCsv(['id', 'name', 'note'], rows))).toEqual([['id', 'name', 'note'], ...rows])
+  })
+})
+
+describe('parseCsvRecords', () => {
+  it('should tag each record with the line it starts on', () => {
+    const records = parseCsvRecords('a,b\n"multi\nline",x\r\nlast,y\n')
+
+    expect(records.map((record) => record.line)).toEqual([1, 2, 4])
+    expect(records[1].fields).toEqual(['multi\nline', 'x'])
+  })
+})
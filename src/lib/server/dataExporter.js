@@ -17,7 +17,7 @@ import { join } from 'path'
 
 /**
  * SQL query to select people data with sensitive fields excluded
- * Excludes: user_id, created_at
+ * Excludes: user_id, created_at, and soft-deleted people
  */
 const PEOPLE_QUERY = `
   SELECT
@@ -29,14 +29,21 @@ const PEOPLE_QUERY = `
     gender,
     photo_url as photoUrl,
     birth_surname as birthSurname,
-    nickname
+    nickname,
+    middle_name as middleName,
+    maiden_name as maidenName,
+    suffix,
+    birth_date_qualifier as birthDateQualifier,
+    birth_place as birthPlace,
+    death_place as deathPlace
   FROM people
+  WHERE deleted_at IS NULL
   ORDER BY id
 `
 
 /**
  * SQL query to select relationships data with sensitive fields excluded
- * Excludes: user_id, created_at
+ * Excludes: user_id, created_at, and relationships involving soft-deleted people
  */
 const RELATIONSHIPS_QUERY = `
   SELECT
@@ -46,6 +53,8 @@ const RELATIONSHIPS_QUERY = `
     type,
     parent_role as parentRole
   FROM relationships
+  WHERE person1_id NOT IN (SELECT id FROM people WHERE deleted_at IS NOT NULL)
+    AND person2_id NOT IN (SELECT id FROM people WHERE deleted_at IS NOT NULL)
   ORDER BY id
 `
 
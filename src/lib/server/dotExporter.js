@@ -0,0 +1,72 @@
+/**
+ * DOT Exporter Module
+ *
+ * Renders the family tree as a Graphviz DOT digraph:
+ * - one node per person, labeled with name and birth/death years
+ * - parent relationships as arrows from parent to child
+ * - spouse relationships as dashed lines without arrowheads
+ */
+
+/**
+ * Escapes a string for use inside a double-quoted DOT ID
+ *
+ * @param {string} value - Raw text
+ * @returns {string} Escaped text
+ */
+export function escapeDotString(value) {
+  return String(value).replace(/\\/g, '\\\\').replace(/"/g, '\\"').replace(/\r?\n/g, ' ')
+}
+
+/**
+ * Builds the node label for a person: full name, then years on a second line
+ *
+ * @param {Object} person - Person record
+ * @returns {string} Unescaped label text (lines joined with "\n")
+ */
+function personLabel(person) {
+  const name = [person.firstName, person.lastName].filter(Boolean).join(' ')
+  const birthYear = person.birthDate ? person.birthDate.slice(0, 4) : ''
+  const deathYear = person.deathDate ? person.deathDate.slice(0, 4) : ''
+
+  if (!birthYear && !deathYear) return name
+  return `${name}\n(${birthYear}-${deathYear})`
+}
+
+/**
+ * Builds a DOT digraph of people and relationships
+ *
+ * Relationships must reference people in the list. Spouse pairs stored in
+ * both directions produce a single edge.
+ *
+ * @param {Array} peopleRows - Person records
+ * @param {Array} relationshipRows - Relationship records (normalized database format)
+ * @returns {string} DOT document
+ *
+ * @example
+ * buildDotGraph([{ id: 1, firstName: 'John', lastName: 'Smith', birthDate: '1900-01-01' }], [])
+ * // 'digraph familytree {\n  node [shape=box];\n  p1 [label="John Smith\\n(1900-)"];\n}\n'
+ */
+export function buildDotGraph(peopleRows, relationshipRows) {
+  const lines = ['digraph familytree {', '  node [shape=box];']
+
+  const sortedPeople = [...peopleRows].sort((a, b) => a.id - b.id)
+  for (const person of sortedPeople) {
+    const label = personLabel(person).split('\n').map(escapeDotString).join('\\n')
+    lines.push(`  p${person.id} [label="${label}"];`)
+  }
+
+  const seenSpouses = new Set()
+  for (const rel of relationshipRows) {
+    if (rel.type === 'spouse') {
+      const key = [rel.person1Id, rel.person2Id].sort((a, b) => a - b).join('-')
+      if (seenSpouses.has(key)) continue
+      seenSpouses.add(key)
+      lines.push(`  p${rel.person1Id} -> p${rel.person2Id} [dir=none, style=dashed];`)
+    } else {
+      lines.push(`  p${rel.person1Id} -> p${rel.person2Id};`)
+    }
+  }
+
+  lines.push('}')
+  return lines.join('\n') + '\n'
+}
@@ -0,0 +1,46 @@
+/**
+ * Unit tests for DOT Exporter Module
+ */
+
+import { describe, it, expect } from 'vitest'
+import { buildDotGraph, escapeDotString } from './dotExporter.js'
+
+describe('escapeDotString', () => {
+  it('should escape quotes and backslashes', () => {
+    expect(escapeDotString('The "Kid" \\ Jr')).toBe('The \\"Kid\\" \\\\ Jr')
+  })
+})
+
+describe('buildDotGraph', () => {
+  it('should label nodes with name and years', () => {
+    const dot = buildDotGraph(
+      [
+        { id: 1, firstName: 'John', lastName: 'Smith', birthDate: '1900-01-15', deathDate: '1980-06-01' },
+        { id: 2, firstName: 'Mary', lastName: 'Smith', birthDate: null, deathDate: '1990-01-01' },
+        { id: 3, firstName: 'Baby', lastName: 'Smith', birthDate: null, deathDate: null }
+      ],
+      []
+    )
+
+    expect(dot).toContain('  p1 [label="John Smith\\n(1900-1980)"];')
+    expect(dot).toContain('  p2 [label="Mary Smith\\n(-1990)"];')
+    expect(dot).toContain('  p3 [label="Baby Smith"];')
+  })
+
+  it('should draw one dashed undirected edge per spouse pair', () => {
+    const dot = buildDotGraph(
+      [{ id: 1, firstName: 'A', lastName: 'B' }, { id: 2, firstName: 'C', lastName: 'D' }],
+      [
+        { person1Id: 1, person2Id: 2, type: 'spouse' },
+        { person1Id: 2, person2Id: 1, type: 'spouse' }
+      ]
+    )
+
+    expect(dot.match(/dir=none/g)).toHaveLength(1)
+    expect(dot).toContain('  p1 -> p2 [dir=none, style=dashed];')
+  })
+
+  it('should produce a well-formed digraph', () => {
+    expect(buildDotGraph([], [])).toBe('digraph familytree {\n  node [shape=box];\n}\n')
+  })
+})
@@ -384,3 +384,126 @@ export function findDuplicatesForPerson(targetPerson, allPeople, threshold = CON
 
   return duplicates
 }
+
+/**
+ * Group confidence by how well the birth dates of a name match agree:
+ * identical dates, dates that overlap (one is a partial date containing the
+ * other, e.g. "1950" and "1950-01-15"), or at least one date unknown
+ */
+const GROUP_CONFIDENCE = {
+  identical: 100,
+  overlapping: 90,
+  unknown: 70
+}
+
+/**
+ * Normalizes a person's name for exact duplicate matching
+ *
+ * Lowercases, strips accents and punctuation, and collapses whitespace, so
+ * "José  O'Brien" and "jose obrien" produce the same key.
+ *
+ * @param {string} firstName - First name
+ * @param {string} lastName - Last name
+ * @returns {string} Normalized "first last" key ('' when both are empty)
+ */
+export function normalizePersonName(firstName, lastName) {
+  return `${firstName || ''} ${lastName || ''}`
+    .normalize('NFD')
+    .replace(/[\u0300-\u036f]/g, '')
+    .toLowerCase()
+    .replace(/[^\p{L}\p{N}\s]/gu, '')
+    .replace(/\s+/g, ' ')
+    .trim()
+}
+
+/**
+ * Scores how well two birth dates agree for duplicate grouping
+ *
+ * @private
+ * @param {string|null} date1 - First birth date (YYYY, YYYY-MM, or YYYY-MM-DD)
+ * @param {string|null} date2 - Second birth date
+ * @returns {number|null} Confidence from GROUP_CONFIDENCE, or null when the dates conflict
+ */
+function birthDateConfidence(date1, date2) {
+  if (!date1 || !date2) return GROUP_CONFIDENCE.unknown
+  if (date1 === date2) return GROUP_CONFIDENCE.identical
+  if (date1.startsWith(date2) || date2.startsWith(date1)) return GROUP_CONFIDENCE.overlapping
+  return null
+}
+
+/**
+ * Groups likely-duplicate people within a single array of people
+ *
+ * People are candidates when their normalized first and last names match
+ * exactly and their birth dates are identical, overlap, or are unknown on
+ * one side. Candidates are linked transitively into groups; a group's
+ * confidence is the weakest link between its members.
+ *
+ * @param {Array} people - Array of people from database
+ * @param {number} threshold - Minimum group confidence (default: 70)
+ * @returns {Array<{name: string, personIds: number[], confidence: number}>}
+ *   Groups of two or more people, highest confidence first
+ */
+export function findDuplicateGroups(people, threshold = CONFIDENCE_THRESHOLD) {
+  if (!people || people.length <= 1) {
+    return []
+  }
+
+  // Bucket people by normalized name
+  const byName = new Map()
+  for (const person of people) {
+    const key = normalizePersonName(person.firstName, person.lastName)
+    if (!key) continue
+    if (!byName.has(key)) byName.set(key, [])
+    byName.get(key).push(person)
+  }
+
+  const groups = []
+
+  for (const [name, candidates] of byName) {
+    if (candidates.length < 2) continue
+
+    // Union-find over pairs whose birth dates agree
+    const parent = candidates.map((_, index) => index)
+    const find = (index) => (parent[index] === index ? index : (parent[index] = find(parent[index])))
+    const linkConfidence = new Map()
+
+    for (let i = 0; i < candidates.length; i++) {
+      for (let j = i + 1; j < candidates.length; j++) {
+        const confidence = birthDateConfidence(candidates[i].birthDate, candidates[j].birthDate)
+        if (confidence === null || confidence < threshold) continue
+
+        const rootI = find(i)
+        const rootJ = find(j)
+        const weakest = Math.min(
+          confidence,
+          linkConfidence.get(rootI) ?? confidence,
+          linkConfidence.get(rootJ) ?? confidence
+        )
+        parent[rootJ] = rootI
+        linkConfidence.set(rootI, weakest)
+      }
+    }
+
+    const members = new Map()
+    candidates.forEach((person, index) => {
+      const root = find(index)
+      if (!members.has(root)) members.set(root, [])
+      members.get(root).push(person.id)
+    })
+
+    for (const [root, personIds] of members) {
+      if (personIds.length < 2) continue
+      groups.push({
+        name,
+        personIds: personIds.sort((a, b) => a - b),
+        confidence: linkConfidence.get(root)
+      })
+    }
+  }
+
+  // Sort by confidence (highest first), then by lowest person ID
+  groups.sort((a, b) => b.confidence - a.confidence || a.personIds[0] - b.personIds[0])
+
+  return groups
+}
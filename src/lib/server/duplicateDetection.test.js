@@ -14,7 +14,9 @@ import {
   compareDates,
   compareParents,
   findAllDuplicates,
-  findDuplicatesForPerson
+  findDuplicatesForPerson,
+  findDuplicateGroups,
+  normalizePersonName
 } from './duplicateDetection.js'
 
 describe('duplicateDetection - compareNames', () => {
@@ -509,3 +511,48 @@ describe('duplicateDetection - findDuplicatesForPerson (Story #108)', () => {
     expect(duplicates[0].matchingFields).toContain('birthDate')
   })
 })
+
+describe('duplicateDetection - normalizePersonName', () => {
+  it('should ignore case, accents, punctuation, and extra whitespace', () => {
+    expect(normalizePersonName('  José ', "O'Brien")).toBe('jose obrien')
+    expect(normalizePersonName('JOSE', 'OBRIEN')).toBe('jose obrien')
+  })
+})
+
+describe('duplicateDetection - findDuplicateGroups', () => {
+  it('should group matching names with identical or overlapping birth dates', () => {
+    const groups = findDuplicateGroups([
+      { id: 1, firstName: 'John', lastName: 'Smith', birthDate: '1950-01-15' },
+      { id: 2, firstName: 'john', lastName: 'SMITH', birthDate: '1950' },
+      { id: 3, firstName: 'John', lastName: 'Smith', birthDate: '1950-01-15' },
+      { id: 4, firstName: 'Jane', lastName: 'Doe', birthDate: '1960-05-20' },
+      { id: 5, firstName: 'Jane', lastName: 'Doe', birthDate: '1960-05-20' }
+    ])
+
+    expect(groups).toEqual([
+      { name: 'jane doe', personIds: [4, 5], confidence: 100 },
+      { name: 'john smith', personIds: [1, 2, 3], confidence: 90 }
+    ])
+  })
+
+  it('should not group matching names with conflicting birth dates', () => {
+    const groups = findDuplicateGroups([
+      { id: 1, firstName: 'John', lastName: 'Smith', birthDate: '1950-01-15' },
+      { id: 2, firstName: 'John', lastName: 'Smith', birthDate: '1982-07-04' }
+    ])
+
+    expect(groups).toEqual([])
+  })
+
+  it('should group with lower confidence when a birth date is unknown', () => {
+    const people = [
+      { id: 1, firstName: 'John', lastName: 'Smith', birthDate: '1950-01-15' },
+      { id: 2, firstName: 'John', lastName: 'Smith', birthDate: null }
+    ]
+
+    expect(findDuplicateGroups(people)).toEqual([
+      { name: 'john smith', personIds: [1, 2], confidence: 70 }
+    ])
+    expect(findDuplicateGroups(people, 80)).toEqual([])
+  })
+})
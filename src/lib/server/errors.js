@@ -0,0 +1,56 @@
+/**
+ * Error Responses
+ *
+ * Handlers report errors as JSON so clients can parse every response the
+ * same way. The server hooks add the request ID to these bodies (see
+ * requestId.js).
+ */
+
+import { json } from '@sveltejs/kit'
+
+/**
+ * Builds a JSON error response
+ *
+ * @param {number} status - HTTP status code
+ * @param {string} message - Human-readable error message
+ * @param {Object} [details] - Extra fields for the body (e.g. { index })
+ * @returns {Response} JSON { error, status, ...details } with the given status
+ *
+ * @example
+ * return jsonError(404, 'Person not found')
+ */
+export function jsonError(status, message, details = {}) {
+  return json({ error: message, status, ...details }, { status })
+}
+
+/**
+ * Returns true for errors that mean the database was too busy to answer
+ *
+ * @param {Error} error - Error thrown by a query
+ * @returns {boolean}
+ */
+export function isDatabaseBusyError(error) {
+  return error?.code === 'SQLITE_BUSY' || error?.code === 'SQLITE_LOCKED'
+}
+
+/**
+ * Builds the response for an unexpected error caught by a handler
+ *
+ * A busy or locked database is temporary, so it gets 503 (the client may
+ * retry); anything else is a 500.
+ *
+ * @param {Error} error - Caught error
+ * @returns {Response} JSON { error, status } with status 503 or 500
+ *
+ * @example
+ * } catch (error) {
+ *   console.error('Error fetching people:', error)
+ *   return internalError(error)
+ * }
+ */
+export function internalError(error) {
+  if (isDatabaseBusyError(error)) {
+    return jsonError(503, 'Service Unavailable: database is busy')
+  }
+  return jsonError(500, 'Internal Server Error')
+}
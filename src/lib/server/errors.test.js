@@ -0,0 +1,48 @@
+/**
+ * Unit tests for JSON error responses
+ */
+
+import { describe, it, expect } from 'vitest'
+import { jsonError, internalError } from './errors.js'
+
+describe('jsonError', () => {
+  it('should return the status and message as a JSON body', async () => {
+    const response = jsonError(404, 'Person not found')
+
+    expect(response.status).toBe(404)
+    expect(response.headers.get('content-type')).toContain('application/json')
+    expect(await response.json()).toEqual({ error: 'Person not found', status: 404 })
+  })
+
+  it('should keep the status code it is given', async () => {
+    const response = jsonError(503, 'Service Unavailable')
+
+    expect(response.status).toBe(503)
+    expect((await response.json()).status).toBe(503)
+  })
+
+  it('should add extra detail fields to the body', async () => {
+    const response = jsonError(400, 'Person already has a mother', { index: 2 })
+
+    expect(response.status).toBe(400)
+    expect(await response.json()).toEqual({ error: 'Person already has a mother', status: 400, index: 2 })
+  })
+})
+
+describe('internalError', () => {
+  it('should return 503 when the database is busy or locked', async () => {
+    for (const code of ['SQLITE_BUSY', 'SQLITE_LOCKED']) {
+      const response = internalError(Object.assign(new Error('database is locked'), { code }))
+
+      expect(response.status).toBe(503)
+      expect(await response.json()).toEqual({ error: 'Service Unavailable: database is busy', status: 503 })
+    }
+  })
+
+  it('should return 500 for any other error', async () => {
+    const response = internalError(new Error('boom'))
+
+    expect(response.status).toBe(500)
+    expect(await response.json()).toEqual({ error: 'Internal Server Error', status: 500 })
+  })
+})
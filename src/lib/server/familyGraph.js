@@ -0,0 +1,721 @@
+/**
+ * Family Graph Module
+ *
+ * Builds an in-memory adjacency representation of the family tree from
+ * Person and Relationship records so graph computations (centrality,
+ * ancestor walks, kinship) can run without repeated database queries.
+ */
+
+import { people, relationships } from '../db/schema.js'
+import { isNull } from 'drizzle-orm'
+import { PARENT_ROLES, baseParentRole, effectiveRelationKind } from './relationshipHelpers.js'
+
+/**
+ * Kinship modes controlling which parent edges count
+ * - blood: adoptive parent edges are ignored
+ * - legal: adoptive parent edges are treated like biological ones
+ * Step-parent edges are ignored in both modes.
+ */
+export const KINSHIP_MODES = ['blood', 'legal']
+
+/**
+ * Parses the ?kinship= query parameter
+ *
+ * @param {URL} url - Request URL
+ * @returns {Object} Result { valid: boolean, mode: string|null, error: string|null }
+ */
+export function parseKinshipMode(url) {
+  const mode = url?.searchParams?.get('kinship') || 'legal'
+
+  if (!KINSHIP_MODES.includes(mode)) {
+    return { valid: false, mode: null, error: 'Invalid kinship parameter (must be "blood" or "legal")' }
+  }
+
+  return { valid: true, mode, error: null }
+}
+
+/**
+ * Loads all active (not soft-deleted) people and all relationships
+ * and builds the family graph from them
+ *
+ * @param {Object} database - Drizzle database instance
+ * @param {Object} [options] - Options passed to buildFamilyGraph
+ * @returns {Promise<Object>} Graph from buildFamilyGraph
+ */
+export async function loadFamilyGraph(database, options = {}) {
+  const allPeople = await database
+    .select()
+    .from(people)
+    .where(isNull(people.deletedAt))
+
+  const allRelationships = await database
+    .select()
+    .from(relationships)
+
+  return buildFamilyGraph(allPeople, allRelationships, options)
+}
+
+/**
+ * Builds adjacency maps from person and relationship records
+ *
+ * Relationships referencing people that are not in the people list are ignored,
+ * so callers can pass a filtered people list and get a consistent subgraph.
+ *
+ * @param {Array} peopleRows - Person records (database or API format)
+ * @param {Array} relationshipRows - Relationship records (normalized database format)
+ * @param {Object} [options]
+ * @param {string} [options.kinship='legal'] - "blood" ignores adoptive parent edges (step-parent edges are always ignored)
+ * @returns {Object} Graph { people, parents, children, spouses }
+ *
+ * @example
+ * const graph = buildFamilyGraph(allPeople, allRelationships)
+ * graph.parents.get(3) // [{ id: 1, role: 'father', kind: 'biological' }, ...]
+ */
+export function buildFamilyGraph(peopleRows, relationshipRows, options = {}) {
+  const kinship = options.kinship || 'legal'
+  const peopleById = new Map()
+  const parents = new Map()
+  const children = new Map()
+  const spouses = new Map()
+
+  for (const person of peopleRows) {
+    peopleById.set(person.id, person)
+    parents.set(person.id, [])
+    children.set(person.id, new Set())
+    spouses.set(person.id, new Set())
+  }
+
+  for (const rel of relationshipRows) {
+    if (!peopleById.has(rel.person1Id) || !peopleById.has(rel.person2Id)) {
+      continue
+    }
+
+    if (rel.type === 'parentOf' || PARENT_ROLES.includes(rel.type)) {
+      const recordedRole = rel.parentRole || (rel.type !== 'parentOf' ? rel.type : null)
+      const kind = effectiveRelationKind(recordedRole, rel.relationKind)
+
+      // Step-parents are neither blood nor legal parents
+      if (kind === 'step' || (kinship === 'blood' && kind === 'adoptive')) {
+        continue
+      }
+
+      // Adoptive roles count as plain mother/father within the graph
+      const role = baseParentRole(recordedRole)
+      parents.get(rel.person2Id).push({ id: rel.person1Id, role, kind })
+      children.get(rel.person1Id).add(rel.person2Id)
+    } else if (rel.type === 'spouse') {
+      spouses.get(rel.person1Id).add(rel.person2Id)
+      spouses.get(rel.person2Id).add(rel.person1Id)
+    }
+  }
+
+  return { people: peopleById, parents, children, spouses }
+}
+
+/**
+ * Returns the IDs of everyone directly connected to a person
+ * (parents, children, and spouses), without duplicates
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} personId - Person ID
+ * @returns {Set<number>} Set of neighbor IDs
+ */
+export function getNeighbors(graph, personId) {
+  const neighbors = new Set()
+
+  for (const parent of graph.parents.get(personId) || []) {
+    neighbors.add(parent.id)
+  }
+  for (const childId of graph.children.get(personId) || []) {
+    neighbors.add(childId)
+  }
+  for (const spouseId of graph.spouses.get(personId) || []) {
+    neighbors.add(spouseId)
+  }
+
+  return neighbors
+}
+
+/**
+ * Computes degree centrality for every person in the graph
+ *
+ * Degree is the number of distinct people directly related to a person.
+ * The normalized score divides by (n - 1) so values fall between 0 and 1.
+ * Results are sorted by degree (highest first), then by ID for stable ordering.
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @returns {Array<{personId: number, degree: number, score: number}>} Ranked centrality entries
+ */
+export function computeDegreeCentrality(graph) {
+  const total = graph.people.size
+  const results = []
+
+  for (const personId of graph.people.keys()) {
+    const degree = getNeighbors(graph, personId).size
+    results.push({
+      personId,
+      degree,
+      score: total > 1 ? degree / (total - 1) : 0
+    })
+  }
+
+  results.sort((a, b) => b.degree - a.degree || a.personId - b.personId)
+
+  return results
+}
+
+/**
+ * Walks up the parentOf edges from a person, recording the shortest
+ * generational distance to every ancestor (1 = parent, 2 = grandparent, ...)
+ *
+ * Cycle-safe: each ancestor is visited once (breadth-first), so malformed
+ * data with parent loops cannot cause infinite recursion.
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} personId - Person whose ancestors to collect
+ * @param {number} [maxGenerations=Infinity] - Stop after this many generations
+ * @returns {Map<number, number>} Map of ancestor ID to generational distance
+ */
+export function getAncestors(graph, personId, maxGenerations = Infinity) {
+  const distances = new Map()
+  const visited = new Set([personId])
+  let frontier = [personId]
+  let generation = 0
+
+  while (frontier.length > 0 && generation < maxGenerations) {
+    generation++
+    const next = []
+
+    for (const id of frontier) {
+      for (const parent of graph.parents.get(id) || []) {
+        if (visited.has(parent.id)) continue
+        visited.add(parent.id)
+        distances.set(parent.id, generation)
+        next.push(parent.id)
+      }
+    }
+
+    frontier = next
+  }
+
+  return distances
+}
+
+/**
+ * Returns a person's ancestors including the person themselves at distance 0
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} personId - Person ID
+ * @returns {Map<number, number>} Map of ancestor ID to distance
+ */
+export function getAncestorsIncludingSelf(graph, personId) {
+  const ancestors = new Map([[personId, 0]])
+  for (const [id, distance] of getAncestors(graph, personId)) {
+    ancestors.set(id, distance)
+  }
+  return ancestors
+}
+
+/**
+ * Walks down the parentOf edges from a person, recording the shortest
+ * generational distance to every descendant (1 = child, 2 = grandchild, ...)
+ *
+ * Cycle-safe in the same way as getAncestors.
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} personId - Person whose descendants to collect
+ * @param {number} [maxGenerations=Infinity] - Stop after this many generations
+ * @returns {Map<number, number>} Map of descendant ID to generational distance
+ */
+export function getDescendants(graph, personId, maxGenerations = Infinity) {
+  const distances = new Map()
+  const visited = new Set([personId])
+  let frontier = [personId]
+  let generation = 0
+
+  while (frontier.length > 0 && generation < maxGenerations) {
+    generation++
+    const next = []
+
+    for (const id of frontier) {
+      for (const childId of graph.children.get(id) || []) {
+        if (visited.has(childId)) continue
+        visited.add(childId)
+        distances.set(childId, generation)
+        next.push(childId)
+      }
+    }
+
+    frontier = next
+  }
+
+  return distances
+}
+
+/**
+ * Finds the ancestors shared by two people with the distance from each
+ *
+ * Either person may be the common ancestor (distance 0) when they are a
+ * direct ancestor of the other. The most recent common ancestor(s) are
+ * those with the smallest combined distance; they are flagged with
+ * isMostRecent. Results are sorted by combined distance, then by ancestor ID.
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} person1Id - First person ID
+ * @param {number} person2Id - Second person ID
+ * @returns {Array<{ancestorId: number, distance1: number, distance2: number, combinedDistance: number, isMostRecent: boolean}>}
+ */
+export function findCommonAncestors(graph, person1Id, person2Id) {
+  const ancestors1 = getAncestorsIncludingSelf(graph, person1Id)
+  const ancestors2 = getAncestorsIncludingSelf(graph, person2Id)
+  const common = []
+
+  for (const [ancestorId, distance1] of ancestors1) {
+    if (!ancestors2.has(ancestorId)) continue
+    const distance2 = ancestors2.get(ancestorId)
+    common.push({
+      ancestorId,
+      distance1,
+      distance2,
+      combinedDistance: distance1 + distance2,
+      isMostRecent: false
+    })
+  }
+
+  common.sort((a, b) => a.combinedDistance - b.combinedDistance || a.ancestorId - b.ancestorId)
+
+  if (common.length > 0) {
+    const closest = common[0].combinedDistance
+    for (const entry of common) {
+      entry.isMostRecent = entry.combinedDistance === closest
+    }
+  }
+
+  return common
+}
+
+/**
+ * Finds the ancestors shared by every person in a group
+ *
+ * Each entry lists the generational distance from each person
+ * (in the order of personIds) and the sum of those distances.
+ * Entries are sorted by combined distance, so the first entry is the
+ * most recent common ancestor; ties (e.g. both members of a couple)
+ * are flagged with isMostRecent. A member who is an ancestor of all the
+ * others is itself a common ancestor (distance 0).
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {Array<number>} personIds - Group members (at least one)
+ * @returns {Array} Entries [{ ancestorId, distances: [{ personId, distance }], combinedDistance, isMostRecent }]
+ */
+export function findGroupCommonAncestors(graph, personIds) {
+  const ancestorMaps = personIds.map((personId) => getAncestorsIncludingSelf(graph, personId))
+  const [first, ...rest] = ancestorMaps
+  const common = []
+
+  if (!first) {
+    return common
+  }
+
+  for (const ancestorId of first.keys()) {
+    if (!rest.every((ancestors) => ancestors.has(ancestorId))) continue
+
+    const distances = personIds.map((personId, index) => ({
+      personId,
+      distance: ancestorMaps[index].get(ancestorId)
+    }))
+
+    common.push({
+      ancestorId,
+      distances,
+      combinedDistance: distances.reduce((sum, entry) => sum + entry.distance, 0),
+      isMostRecent: false
+    })
+  }
+
+  common.sort((a, b) => a.combinedDistance - b.combinedDistance || a.ancestorId - b.ancestorId)
+
+  if (common.length > 0) {
+    const closest = common[0].combinedDistance
+    for (const entry of common) {
+      entry.isMostRecent = entry.combinedDistance === closest
+    }
+  }
+
+  return common
+}
+
+/**
+ * Finds people in a person's direct line who share their first name
+ *
+ * Walks the ancestor and descendant lines and compares first names
+ * case-insensitively (surrounding whitespace ignored), revealing naming
+ * traditions such as a grandson named after his grandfather.
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} personId - Person whose namesakes to find
+ * @returns {{ancestors: Array<{personId: number, generation: number}>, descendants: Array<{personId: number, generation: number}>}}
+ *   Entries ordered by generation, then person ID; empty when the person has no first name
+ */
+export function findNamesakesInLine(graph, personId) {
+  const normalize = (name) => (name || '').trim().toLowerCase()
+  const firstName = normalize(graph.people.get(personId)?.firstName)
+
+  const matching = (line) =>
+    [...line]
+      .filter(([id]) => firstName && normalize(graph.people.get(id)?.firstName) === firstName)
+      .map(([id, generation]) => ({ personId: id, generation }))
+      .sort((a, b) => a.generation - b.generation || a.personId - b.personId)
+
+  return {
+    ancestors: matching(getAncestors(graph, personId)),
+    descendants: matching(getDescendants(graph, personId))
+  }
+}
+
+/**
+ * Finds the longest chain of people linked purely by spouse edges
+ *
+ * A chain is a simple path (no person repeated), e.g. serial remarriages
+ * A + B, B + C, C + D. Each person is tried as a starting point with an
+ * exhaustive depth-first search, which is fine for the small spouse
+ * components real families produce. Among equally long chains the one
+ * with the lexicographically smallest ID sequence wins, read from its
+ * lower-ID end.
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @returns {number[]} Person IDs along the chain, empty when nobody has a spouse
+ */
+export function findLongestSpouseChain(graph) {
+  let best = []
+
+  const isBetter = (chain) => {
+    if (chain.length !== best.length) return chain.length > best.length
+    for (let i = 0; i < chain.length; i++) {
+      if (chain[i] !== best[i]) return chain[i] < best[i]
+    }
+    return false
+  }
+
+  const extend = (chain, visited) => {
+    const last = chain[chain.length - 1]
+    let extended = false
+
+    for (const spouseId of [...graph.spouses.get(last)].sort((a, b) => a - b)) {
+      if (visited.has(spouseId)) continue
+      extended = true
+      visited.add(spouseId)
+      chain.push(spouseId)
+      extend(chain, visited)
+      chain.pop()
+      visited.delete(spouseId)
+    }
+
+    if (!extended && chain.length > 1 && chain[0] < last && isBetter(chain)) {
+      best = [...chain]
+    }
+  }
+
+  for (const personId of [...graph.people.keys()].sort((a, b) => a - b)) {
+    if (graph.spouses.get(personId).size === 0) continue
+    extend([personId], new Set([personId]))
+  }
+
+  return best
+}
+
+/**
+ * Assigns a generation number to everyone connected to a root person
+ *
+ * The root is generation 0; each parent edge climbed subtracts one and
+ * each child edge descended adds one, so ancestors are negative and
+ * descendants positive. Spouses share the generation of the person they
+ * married. The walk is breadth-first, so each person keeps the number
+ * from their shortest connection to the root and parent loops cannot
+ * recurse forever. People with no connection to the root are omitted.
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} rootId - Person at generation 0
+ * @returns {Map<number, number>} Map of person ID to generation, in visiting order
+ */
+export function assignGenerations(graph, rootId) {
+  const generations = new Map([[rootId, 0]])
+  const queue = [rootId]
+
+  for (let i = 0; i < queue.length; i++) {
+    const id = queue[i]
+    const generation = generations.get(id)
+    const neighbors = [
+      ...(graph.parents.get(id) || []).map((parent) => [parent.id, generation - 1]),
+      ...[...(graph.children.get(id) || [])].map((childId) => [childId, generation + 1]),
+      ...[...(graph.spouses.get(id) || [])].map((spouseId) => [spouseId, generation])
+    ]
+
+    for (const [neighborId, neighborGeneration] of neighbors) {
+      if (generations.has(neighborId)) continue
+      generations.set(neighborId, neighborGeneration)
+      queue.push(neighborId)
+    }
+  }
+
+  return generations
+}
+
+/**
+ * Finds the ancestors through whom all (or most) of the tree's youngest
+ * generation traces: the narrow points where lines of descent converge
+ *
+ * The youngest generation ("leaves") is everyone with recorded parents and
+ * no recorded children; childless people who married in without parents do
+ * not count. An ancestor's coverage is the share of leaves descending from
+ * them, and they qualify when coverage reaches the threshold.
+ *
+ * In the spirit of dominator analysis, only the lowest point of each funnel
+ * is reported: an ancestor is skipped when one of their children covers
+ * exactly the same leaves, since every one of those lines then also passes
+ * through that child. A tree funneling through one couple therefore reports
+ * the couple, not their own parents and grandparents.
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} [threshold=1] - Minimum coverage (0-1]; 1 means every leaf
+ * @returns {{leafCount: number, pinchPoints: Array<{personId: number, leaves: number, coverage: number}>}}
+ *   Pinch points ordered by leaves covered (most first), then person ID
+ */
+export function findPinchPoints(graph, threshold = 1) {
+  const leafIds = new Set()
+  for (const personId of graph.people.keys()) {
+    if (graph.children.get(personId).size === 0 && graph.parents.get(personId).length > 0) {
+      leafIds.add(personId)
+    }
+  }
+
+  const leavesCovered = new Map()
+  for (const personId of graph.people.keys()) {
+    if (graph.children.get(personId).size === 0) continue
+    let count = 0
+    for (const descendantId of getDescendants(graph, personId).keys()) {
+      if (leafIds.has(descendantId)) count++
+    }
+    leavesCovered.set(personId, count)
+  }
+
+  const pinchPoints = []
+  for (const [personId, leaves] of leavesCovered) {
+    if (leaves === 0 || leaves / leafIds.size < threshold) continue
+
+    const funnelsThroughChild = [...graph.children.get(personId)].some(
+      (childId) => leavesCovered.get(childId) === leaves
+    )
+    if (funnelsThroughChild) continue
+
+    pinchPoints.push({ personId, leaves, coverage: leaves / leafIds.size })
+  }
+
+  pinchPoints.sort((a, b) => b.leaves - a.leaves || a.personId - b.personId)
+
+  return { leafCount: leafIds.size, pinchPoints }
+}
+
+/**
+ * Finds the earliest ancestors at the top of the tree's longest lines of descent
+ *
+ * Every person without recorded parents who has children starts a line; its
+ * depth is the number of generations down to their most distant descendant
+ * (parent → child = 1). The ancestors whose lines are deepest are the usual
+ * "brick walls" worth researching further back. Parent cycles in bad data
+ * are cut where they loop back, so they cannot inflate a depth.
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @returns {{depth: number, ancestors: Array<{personId: number, depth: number, descendantId: number}>}}
+ *   depth is the deepest line's depth (0 when there are no parent edges); ancestors are all
+ *   roots of that depth ordered by person ID, with descendantId the lowest-ID person at the
+ *   bottom of their line
+ */
+export function findDeepestAncestors(graph) {
+  // personId -> { depth, descendantId } for the deepest line below the person
+  const lines = new Map()
+  const visiting = new Set()
+
+  const deepestLine = (startId) => {
+    // Iterative post-order walk so long lines cannot overflow the call stack
+    const stack = [startId]
+    while (stack.length > 0) {
+      const personId = stack[stack.length - 1]
+      if (lines.has(personId)) {
+        stack.pop()
+        continue
+      }
+
+      if (!visiting.has(personId)) {
+        visiting.add(personId)
+        for (const childId of graph.children.get(personId)) {
+          if (!lines.has(childId) && !visiting.has(childId)) stack.push(childId)
+        }
+        continue
+      }
+
+      let line = { depth: 0, descendantId: personId }
+      for (const childId of graph.children.get(personId)) {
+        const childLine = lines.get(childId)
+        if (!childLine) continue // Back edge of a parent cycle
+        const depth = childLine.depth + 1
+        if (depth > line.depth || (depth === line.depth && childLine.descendantId < line.descendantId)) {
+          line = { depth, descendantId: childLine.descendantId }
+        }
+      }
+
+      visiting.delete(personId)
+      lines.set(personId, line)
+      stack.pop()
+    }
+    return lines.get(startId)
+  }
+
+  let depth = 0
+  let ancestors = []
+  for (const personId of [...graph.people.keys()].sort((a, b) => a - b)) {
+    if (graph.parents.get(personId).length > 0 || graph.children.get(personId).size === 0) continue
+
+    const line = deepestLine(personId)
+    if (line.depth > depth) {
+      depth = line.depth
+      ancestors = []
+    }
+    if (line.depth === depth) {
+      ancestors.push({ personId, depth: line.depth, descendantId: line.descendantId })
+    }
+  }
+
+  return { depth, ancestors }
+}
+
+/**
+ * Finds the shortest line of descent from each notable ancestor down to a person
+ *
+ * Walks up the parent edges breadth-first (cycle-safe like getAncestors),
+ * remembering which child each ancestor was first reached from, so every
+ * path is one of the shortest. The person themselves is not included even
+ * when notable.
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph (people need a notable flag)
+ * @param {number} personId - Person whose notable ancestors to find
+ * @returns {Array<{ancestorId: number, generations: number, path: Array<number>}>}
+ *   One entry per notable ancestor, nearest first then by ID; path runs from
+ *   the person up to the ancestor, both included
+ */
+export function findNotableAncestorPaths(graph, personId) {
+  const reachedFrom = new Map([[personId, null]])
+  const results = []
+  let frontier = [personId]
+  let generation = 0
+
+  while (frontier.length > 0) {
+    generation++
+    const next = []
+
+    for (const id of frontier) {
+      for (const parent of graph.parents.get(id) || []) {
+        if (reachedFrom.has(parent.id)) continue
+        reachedFrom.set(parent.id, id)
+        next.push(parent.id)
+
+        if (graph.people.get(parent.id)?.notable) {
+          const path = [parent.id]
+          for (let step = id; step !== null; step = reachedFrom.get(step)) path.push(step)
+          results.push({ ancestorId: parent.id, generations: generation, path: path.reverse() })
+        }
+      }
+    }
+
+    frontier = next
+  }
+
+  return results.sort((a, b) => a.generations - b.generations || a.ancestorId - b.ancestorId)
+}
+
+/**
+ * Counts a person's descendants by birth decade, with running totals
+ *
+ * Every decade from the earliest to the latest descendant birth is listed,
+ * including decades with no births, so the cumulative counts chart how the
+ * line grew. Descendants without a parseable birth year are counted
+ * separately as undated.
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} personId - Person whose descendants to count
+ * @returns {{total: number, undated: number, decades: Array<{decade: number, born: number, cumulative: number}>}}
+ *   decade is the first year of the decade (1920 covers 1920-1929)
+ */
+export function computeDescendantGrowth(graph, personId) {
+  const bornByDecade = new Map()
+  let total = 0
+  let undated = 0
+
+  for (const descendantId of getDescendants(graph, personId).keys()) {
+    total++
+    const birthDate = graph.people.get(descendantId).birthDate
+    const year = birthDate ? parseInt(birthDate.slice(0, 4), 10) : NaN
+
+    if (Number.isNaN(year)) {
+      undated++
+      continue
+    }
+
+    const decade = Math.floor(year / 10) * 10
+    bornByDecade.set(decade, (bornByDecade.get(decade) || 0) + 1)
+  }
+
+  const decades = []
+  if (bornByDecade.size > 0) {
+    const first = Math.min(...bornByDecade.keys())
+    const last = Math.max(...bornByDecade.keys())
+    let cumulative = 0
+
+    for (let decade = first; decade <= last; decade += 10) {
+      const born = bornByDecade.get(decade) || 0
+      cumulative += born
+      decades.push({ decade, born, cumulative })
+    }
+  }
+
+  return { total, undated, decades }
+}
+
+/**
+ * Returns the IDs of a person's siblings: everyone sharing at least one
+ * parent with them (full and half siblings alike), excluding the person
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} personId - Person ID
+ * @returns {Set<number>} Sibling IDs
+ */
+export function getSiblings(graph, personId) {
+  const siblings = new Set()
+
+  for (const parent of graph.parents.get(personId) || []) {
+    for (const childId of graph.children.get(parent.id) || []) {
+      if (childId !== personId) siblings.add(childId)
+    }
+  }
+
+  return siblings
+}
+
+/**
+ * Counts a person's ancestors, descendants, and siblings
+ *
+ * Uses the cycle-safe walks of getAncestors and getDescendants, so people
+ * reached along several paths (such as through cousin marriages) are
+ * counted once.
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} personId - Person ID
+ * @returns {{ancestors: number, descendants: number, siblings: number}}
+ */
+export function countRelatives(graph, personId) {
+  return {
+    ancestors: getAncestors(graph, personId).size,
+    descendants: getDescendants(graph, personId).size,
+    siblings: getSiblings(graph, personId).size
+  }
+}
@@ -0,0 +1,508 @@
+/**
+ * Unit tests for Family Graph Module
+ */
+
+import { describe, it, expect } from 'vitest'
+import { findMostRecentCommonAncestor } from './kinship.js'
+import {
+  buildFamilyGraph,
+  getNeighbors,
+  computeDegreeCentrality,
+  getAncestors,
+  getAncestorsIncludingSelf,
+  getDescendants,
+  findCommonAncestors,
+  findGroupCommonAncestors,
+  findNamesakesInLine,
+  findLongestSpouseChain,
+  assignGenerations,
+  findPinchPoints,
+  findDeepestAncestors,
+  findNotableAncestorPaths,
+  computeDescendantGrowth,
+  getSiblings,
+  countRelatives
+} from './familyGraph.js'
+
+function person(id, firstName, gender = null) {
+  return { id, firstName, lastName: 'Test', gender }
+}
+
+function parentOf(person1Id, person2Id, parentRole) {
+  return { person1Id, person2Id, type: 'parentOf', parentRole }
+}
+
+function spouse(person1Id, person2Id) {
+  return { person1Id, person2Id, type: 'spouse', parentRole: null }
+}
+
+describe('buildFamilyGraph', () => {
+  it('should build parent, child, and spouse adjacency', () => {
+    const graph = buildFamilyGraph(
+      [person(1, 'Dad', 'male'), person(2, 'Mom', 'female'), person(3, 'Kid')],
+      [parentOf(1, 3, 'father'), parentOf(2, 3, 'mother'), spouse(1, 2)]
+    )
+
+    expect(graph.parents.get(3)).toEqual([
+      { id: 1, role: 'father', kind: 'biological' },
+      { id: 2, role: 'mother', kind: 'biological' }
+    ])
+    expect([...graph.children.get(1)]).toEqual([3])
+    expect([...graph.spouses.get(2)]).toEqual([1])
+  })
+
+  it('should skip adoptive parent edges in blood mode only', () => {
+    const people = [person(1, 'Adopter', 'female'), person(2, 'Kid')]
+    const relationships = [{ ...parentOf(1, 2, 'mother'), relationKind: 'adoptive' }]
+
+    const legal = buildFamilyGraph(people, relationships)
+    const blood = buildFamilyGraph(people, relationships, { kinship: 'blood' })
+
+    expect(legal.parents.get(2)).toEqual([{ id: 1, role: 'mother', kind: 'adoptive' }])
+    expect(blood.parents.get(2)).toEqual([])
+  })
+
+  it('should treat adoptive roles as adoptive mother/father edges', () => {
+    const people = [person(1, 'BirthMom', 'female'), person(2, 'AdoptiveMom', 'female'), person(3, 'Kid')]
+    const relationships = [parentOf(1, 3, 'mother'), parentOf(2, 3, 'adoptiveMother')]
+
+    const legal = buildFamilyGraph(people, relationships)
+    const blood = buildFamilyGraph(people, relationships, { kinship: 'blood' })
+
+    expect(legal.parents.get(3)).toEqual([
+      { id: 1, role: 'mother', kind: 'biological' },
+      { id: 2, role: 'mother', kind: 'adoptive' }
+    ])
+    expect(blood.parents.get(3)).toEqual([{ id: 1, role: 'mother', kind: 'biological' }])
+  })
+
+  it('should ignore step-parent edges in both modes', () => {
+    const people = [person(1, 'StepDad', 'male'), person(2, 'Kid')]
+    const relationships = [parentOf(1, 2, 'stepFather')]
+
+    expect(buildFamilyGraph(people, relationships).parents.get(2)).toEqual([])
+    expect(buildFamilyGraph(people, relationships, { kinship: 'blood' }).parents.get(2)).toEqual([])
+  })
+
+  it('should ignore relationships referencing unknown people', () => {
+    const graph = buildFamilyGraph([person(1, 'Only')], [parentOf(1, 99, 'mother')])
+
+    expect(graph.children.get(1).size).toBe(0)
+    expect(graph.parents.has(99)).toBe(false)
+  })
+})
+
+describe('getNeighbors', () => {
+  it('should return distinct directly related people', () => {
+    const graph = buildFamilyGraph(
+      [person(1, 'A'), person(2, 'B')],
+      [spouse(1, 2), spouse(2, 1)]
+    )
+
+    expect([...getNeighbors(graph, 1)]).toEqual([2])
+  })
+})
+
+describe('computeDegreeCentrality', () => {
+  it('should rank the most connected person first', () => {
+    const graph = buildFamilyGraph(
+      [person(1, 'Leaf'), person(2, 'Hub'), person(3, 'Leaf2'), person(4, 'Leaf3')],
+      [parentOf(2, 1, 'father'), parentOf(2, 3, 'father'), spouse(2, 4)]
+    )
+
+    const ranked = computeDegreeCentrality(graph)
+
+    expect(ranked[0]).toEqual({ personId: 2, degree: 3, score: 1 })
+    expect(ranked.slice(1).map((r) => r.personId)).toEqual([1, 3, 4])
+  })
+})
+
+describe('getAncestors', () => {
+  it('should return ancestors with generational distance', () => {
+    const graph = buildFamilyGraph(
+      [person(1, 'Grandpa'), person(2, 'Dad'), person(3, 'Kid')],
+      [parentOf(1, 2, 'father'), parentOf(2, 3, 'father')]
+    )
+
+    const ancestors = getAncestors(graph, 3)
+
+    expect(ancestors.get(2)).toBe(1)
+    expect(ancestors.get(1)).toBe(2)
+    expect(ancestors.has(3)).toBe(false)
+  })
+
+  it('should terminate on cyclic parent data', () => {
+    const graph = buildFamilyGraph(
+      [person(1, 'A'), person(2, 'B')],
+      [parentOf(1, 2, 'father'), parentOf(2, 1, 'father')]
+    )
+
+    const ancestors = getAncestors(graph, 1)
+
+    expect([...ancestors.keys()]).toEqual([2])
+  })
+
+  it('should respect the maximum generation limit', () => {
+    const graph = buildFamilyGraph(
+      [person(1, 'Grandpa'), person(2, 'Dad'), person(3, 'Kid')],
+      [parentOf(1, 2, 'father'), parentOf(2, 3, 'father')]
+    )
+
+    expect([...getAncestors(graph, 3, 1).keys()]).toEqual([2])
+  })
+})
+
+describe('getDescendants', () => {
+  it('should return descendants with generational distance', () => {
+    const graph = buildFamilyGraph(
+      [person(1, 'Grandpa'), person(2, 'Dad'), person(3, 'Me'), person(4, 'Aunt')],
+      [parentOf(1, 2, 'father'), parentOf(1, 4, 'father'), parentOf(2, 3, 'father')]
+    )
+
+    expect([...getDescendants(graph, 1).entries()]).toEqual([[2, 1], [4, 1], [3, 2]])
+    expect([...getDescendants(graph, 1, 1).keys()]).toEqual([2, 4])
+  })
+})
+
+describe('getAncestorsIncludingSelf', () => {
+  it('should include the person at distance 0 ahead of their ancestors', () => {
+    const graph = buildFamilyGraph(
+      [person(1, 'Grandpa'), person(2, 'Dad'), person(3, 'Me')],
+      [parentOf(1, 2, 'father'), parentOf(2, 3, 'father')]
+    )
+
+    expect([...getAncestorsIncludingSelf(graph, 3).entries()]).toEqual([[3, 0], [2, 1], [1, 2]])
+  })
+})
+
+describe('findCommonAncestors', () => {
+  it('should flag shared grandparents of first cousins as most recent', () => {
+    const graph = buildFamilyGraph(
+      [
+        person(1, 'Grandpa'), person(2, 'Grandma'),
+        person(3, 'Uncle'), person(4, 'Dad'),
+        person(5, 'Cousin'), person(6, 'Me'),
+        person(7, 'GreatGrandpa')
+      ],
+      [
+        parentOf(7, 1, 'father'),
+        parentOf(1, 3, 'father'), parentOf(2, 3, 'mother'),
+        parentOf(1, 4, 'father'), parentOf(2, 4, 'mother'),
+        parentOf(3, 5, 'father'), parentOf(4, 6, 'father')
+      ]
+    )
+
+    const common = findCommonAncestors(graph, 5, 6)
+
+    expect(common.map((c) => c.ancestorId)).toEqual([1, 2, 7])
+    expect(common[0]).toEqual({
+      ancestorId: 1,
+      distance1: 2,
+      distance2: 2,
+      combinedDistance: 4,
+      isMostRecent: true
+    })
+    expect(common[1].isMostRecent).toBe(true)
+    expect(common[2].isMostRecent).toBe(false)
+  })
+
+  it('should return empty array for unrelated people', () => {
+    const graph = buildFamilyGraph([person(1, 'A'), person(2, 'B')], [])
+
+    expect(findCommonAncestors(graph, 1, 2)).toEqual([])
+  })
+
+  it('should treat a direct ancestor as the most recent common ancestor', () => {
+    const graph = buildFamilyGraph(
+      [person(1, 'Grandpa'), person(2, 'Dad'), person(3, 'Me')],
+      [parentOf(1, 2, 'father'), parentOf(2, 3, 'father')]
+    )
+
+    const common = findCommonAncestors(graph, 2, 3)
+
+    expect(common[0]).toEqual({
+      ancestorId: 2,
+      distance1: 0,
+      distance2: 1,
+      combinedDistance: 1,
+      isMostRecent: true
+    })
+    expect(common.map((c) => c.ancestorId)).toEqual([2, 1])
+    expect(findMostRecentCommonAncestor(graph, 2, 3)).toEqual({ ancestorId: 2, distance1: 0, distance2: 1 })
+  })
+})
+
+describe('findGroupCommonAncestors', () => {
+  const graph = buildFamilyGraph(
+    [
+      person(1, 'Grandpa'), person(2, 'Grandma'),
+      person(3, 'Aunt'), person(4, 'Dad'),
+      person(5, 'Cousin'), person(6, 'Me'), person(7, 'Sister'),
+      person(8, 'Stranger')
+    ],
+    [
+      parentOf(1, 3, 'father'), parentOf(2, 3, 'mother'),
+      parentOf(1, 4, 'father'), parentOf(2, 4, 'mother'),
+      parentOf(3, 5, 'mother'), parentOf(4, 6, 'father'), parentOf(4, 7, 'father')
+    ]
+  )
+
+  it('should find the grandparents shared by three grandchildren', () => {
+    const common = findGroupCommonAncestors(graph, [5, 6, 7])
+
+    expect(common.map((c) => c.ancestorId)).toEqual([1, 2])
+    expect(common[0]).toEqual({
+      ancestorId: 1,
+      distances: [
+        { personId: 5, distance: 2 },
+        { personId: 6, distance: 2 },
+        { personId: 7, distance: 2 }
+      ],
+      combinedDistance: 6,
+      isMostRecent: true
+    })
+    expect(common[1].isMostRecent).toBe(true)
+  })
+
+  it('should return empty array when one member is unrelated', () => {
+    expect(findGroupCommonAncestors(graph, [5, 6, 8])).toEqual([])
+  })
+
+  it('should find a member who is an ancestor of the others', () => {
+    const common = findGroupCommonAncestors(graph, [1, 5, 6])
+
+    expect(common[0]).toEqual({
+      ancestorId: 1,
+      distances: [
+        { personId: 1, distance: 0 },
+        { personId: 5, distance: 2 },
+        { personId: 6, distance: 2 }
+      ],
+      combinedDistance: 4,
+      isMostRecent: true
+    })
+    expect(common.filter((c) => c.isMostRecent)).toHaveLength(1)
+  })
+})
+
+describe('findNamesakesInLine', () => {
+  // John(1) -> Pete(2) -> john(3) -> Pete(4); John(5) is an unrelated namesake
+  const graph = buildFamilyGraph(
+    [person(1, 'John'), person(2, 'Pete'), person(3, ' john '), person(4, 'Pete'), person(5, 'John')],
+    [parentOf(1, 2, 'father'), parentOf(2, 3, 'father'), parentOf(3, 4, 'father')]
+  )
+
+  it('should find namesakes up and down the direct line, ignoring case', () => {
+    expect(findNamesakesInLine(graph, 3)).toEqual({
+      ancestors: [{ personId: 1, generation: 2 }],
+      descendants: []
+    })
+    expect(findNamesakesInLine(graph, 1)).toEqual({
+      ancestors: [],
+      descendants: [{ personId: 3, generation: 2 }]
+    })
+  })
+
+  it('should ignore people with the same name outside the direct line', () => {
+    expect(findNamesakesInLine(graph, 5)).toEqual({ ancestors: [], descendants: [] })
+  })
+})
+
+describe('findLongestSpouseChain', () => {
+  it('should follow serial remarriages end to end', () => {
+    // 4 + 2, 2 + 5, 5 + 1 (chain of four); 3 + 6 (chain of two)
+    const graph = buildFamilyGraph(
+      [1, 2, 3, 4, 5, 6].map((id) => person(id, `P${id}`)),
+      [spouse(4, 2), spouse(2, 5), spouse(1, 5), spouse(3, 6), parentOf(4, 3, 'father')]
+    )
+
+    expect(findLongestSpouseChain(graph)).toEqual([1, 5, 2, 4])
+  })
+
+  it('should count a couple stored in both directions once', () => {
+    const graph = buildFamilyGraph([person(1, 'A'), person(2, 'B')], [spouse(1, 2), spouse(2, 1)])
+
+    expect(findLongestSpouseChain(graph)).toEqual([1, 2])
+  })
+
+  it('should return an empty chain when nobody is married', () => {
+    const graph = buildFamilyGraph([person(1, 'A'), person(2, 'B')], [parentOf(1, 2, 'father')])
+
+    expect(findLongestSpouseChain(graph)).toEqual([])
+  })
+})
+
+describe('assignGenerations', () => {
+  // Grandpa(1) -> Dad(2) + Mom(3) -> Kid(4); Uncle(5) is Dad's brother; Loner(6)
+  const graph = buildFamilyGraph(
+    [1, 2, 3, 4, 5, 6].map((id) => person(id, `P${id}`)),
+    [parentOf(1, 2, 'father'), parentOf(1, 5, 'father'), parentOf(2, 4, 'father'), parentOf(3, 4, 'mother'), spouse(2, 3)]
+  )
+
+  it('should number ancestors negative and descendants positive', () => {
+    const generations = assignGenerations(graph, 2)
+
+    expect(Object.fromEntries(generations)).toEqual({ 1: -1, 2: 0, 3: 0, 4: 1, 5: 0 })
+  })
+
+  it('should omit people not connected to the root', () => {
+    expect(assignGenerations(graph, 4).has(6)).toBe(false)
+    expect(Object.fromEntries(assignGenerations(graph, 6))).toEqual({ 6: 0 })
+  })
+})
+
+describe('findPinchPoints', () => {
+  // Grandparents 1 + 2 -> Dad(3); 4 + 5 -> Mom(6); Dad(3) + Mom(6) -> Kids 7, 8
+  // Kid 7 + in-law 9 (no parents) -> Grandkid 10
+  const people = [1, 2, 3, 4, 5, 6, 7, 8, 9, 10].map((id) => person(id, `P${id}`))
+  const relationships = [
+    parentOf(1, 3, 'father'), parentOf(2, 3, 'mother'),
+    parentOf(4, 6, 'father'), parentOf(5, 6, 'mother'),
+    parentOf(3, 7, 'father'), parentOf(6, 7, 'mother'),
+    parentOf(3, 8, 'father'), parentOf(6, 8, 'mother'),
+    parentOf(7, 10, 'father'), parentOf(9, 10, 'mother')
+  ]
+
+  it('should report the couple a tree funnels through, not their ancestors', () => {
+    const result = findPinchPoints(buildFamilyGraph(people, relationships))
+
+    expect(result.leafCount).toBe(2)
+    expect(result.pinchPoints).toEqual([
+      { personId: 3, leaves: 2, coverage: 1 },
+      { personId: 6, leaves: 2, coverage: 1 }
+    ])
+  })
+
+  it('should include partial funnels at a lower threshold', () => {
+    const result = findPinchPoints(buildFamilyGraph(people, relationships), 0.5)
+
+    expect(result.pinchPoints.map((entry) => entry.personId)).toEqual([3, 6, 7, 9])
+  })
+
+  it('should return no pinch points for a tree without parent links', () => {
+    expect(findPinchPoints(buildFamilyGraph(people.slice(0, 2), []))).toEqual({ leafCount: 0, pinchPoints: [] })
+  })
+})
+
+describe('findDeepestAncestors', () => {
+  // 1 -> 3 -> 5 -> 7; 2 -> 3 (co-parent); 4 -> 6 -> 8 -> 9; 10 -> 11 (shallow line)
+  const people = [1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11].map((id) => person(id, `P${id}`))
+  const relationships = [
+    parentOf(1, 3, 'father'), parentOf(2, 3, 'mother'), parentOf(3, 5, 'father'), parentOf(5, 7, 'father'),
+    parentOf(4, 6, 'father'), parentOf(6, 8, 'father'), parentOf(8, 9, 'father'),
+    parentOf(10, 11, 'father')
+  ]
+
+  it('should return every root at the top of the deepest lines', () => {
+    const result = findDeepestAncestors(buildFamilyGraph(people, relationships))
+
+    expect(result).toEqual({
+      depth: 3,
+      ancestors: [
+        { personId: 1, depth: 3, descendantId: 7 },
+        { personId: 2, depth: 3, descendantId: 7 },
+        { personId: 4, depth: 3, descendantId: 9 }
+      ]
+    })
+  })
+
+  it('should not loop on a parent cycle below a root', () => {
+    const cyclic = [...relationships, parentOf(7, 5, 'father')]
+
+    expect(findDeepestAncestors(buildFamilyGraph(people, cyclic)).depth).toBe(3)
+  })
+
+  it('should return no ancestors for a tree without parent links', () => {
+    expect(findDeepestAncestors(buildFamilyGraph(people.slice(0, 2), []))).toEqual({ depth: 0, ancestors: [] })
+  })
+})
+
+describe('findNotableAncestorPaths', () => {
+  // 1 -> 2 -> 3 -> 4 (child); 5 -> 3 (other parent); 1 and 5 are notable, and so is 6 (unrelated)
+  const people = [1, 2, 3, 4, 5, 6].map((id) => ({ ...person(id, `P${id}`), notable: [1, 5, 6].includes(id) }))
+  const relationships = [
+    parentOf(1, 2, 'father'), parentOf(2, 3, 'father'), parentOf(5, 3, 'mother'), parentOf(3, 4, 'father')
+  ]
+
+  it('should return the line from the person up to each notable ancestor, nearest first', () => {
+    expect(findNotableAncestorPaths(buildFamilyGraph(people, relationships), 4)).toEqual([
+      { ancestorId: 5, generations: 2, path: [4, 3, 5] },
+      { ancestorId: 1, generations: 3, path: [4, 3, 2, 1] }
+    ])
+  })
+
+  it('should return nothing when no ancestor is notable', () => {
+    expect(findNotableAncestorPaths(buildFamilyGraph(people, relationships), 1)).toEqual([])
+  })
+})
+
+describe('computeDescendantGrowth', () => {
+  // 1 -> 2 (1921), 3 (1925); 2 -> 4 (1950), 5 (undated); 3 -> 6 (1958)
+  const people = [
+    { ...person(1, 'Root'), birthDate: '1895-01-01' },
+    { ...person(2, 'A'), birthDate: '1921-03-04' },
+    { ...person(3, 'B'), birthDate: '1925' },
+    { ...person(4, 'C'), birthDate: '1950-07-01' },
+    { ...person(5, 'D'), birthDate: null },
+    { ...person(6, 'E'), birthDate: '1958-12-31' }
+  ]
+  const relationships = [
+    parentOf(1, 2, 'father'), parentOf(1, 3, 'father'), parentOf(2, 4, 'father'),
+    parentOf(2, 5, 'father'), parentOf(3, 6, 'mother')
+  ]
+
+  it('should count births per decade with running totals, filling empty decades', () => {
+    expect(computeDescendantGrowth(buildFamilyGraph(people, relationships), 1)).toEqual({
+      total: 5,
+      undated: 1,
+      decades: [
+        { decade: 1920, born: 2, cumulative: 2 },
+        { decade: 1930, born: 0, cumulative: 2 },
+        { decade: 1940, born: 0, cumulative: 2 },
+        { decade: 1950, born: 2, cumulative: 4 }
+      ]
+    })
+  })
+
+  it('should return no decades for a person without descendants', () => {
+    expect(computeDescendantGrowth(buildFamilyGraph(people, relationships), 6)).toEqual({
+      total: 0,
+      undated: 0,
+      decades: []
+    })
+  })
+})
+
+describe('getSiblings', () => {
+  it('should include full and half siblings once', () => {
+    const graph = buildFamilyGraph(
+      [person(1, 'Dad'), person(2, 'Mom'), person(3, 'Me'), person(4, 'Sister'), person(5, 'HalfBrother')],
+      [
+        parentOf(1, 3, 'father'), parentOf(2, 3, 'mother'),
+        parentOf(1, 4, 'father'), parentOf(2, 4, 'mother'),
+        parentOf(2, 5, 'mother')
+      ]
+    )
+
+    expect([...getSiblings(graph, 3)].sort()).toEqual([4, 5])
+    expect(getSiblings(graph, 1).size).toBe(0)
+  })
+})
+
+describe('countRelatives', () => {
+  it('should count an ancestor reached through two lines once', () => {
+    // Cousins 3 and 4 (grandchildren of 1) marry; their child 5 reaches 1 twice
+    const graph = buildFamilyGraph(
+      [person(1, 'Founder'), person(2, 'SonA'), person(6, 'SonB'), person(3, 'CousinA'), person(4, 'CousinB'), person(5, 'Kid')],
+      [
+        parentOf(1, 2, 'father'), parentOf(1, 6, 'father'),
+        parentOf(2, 3, 'father'), parentOf(6, 4, 'father'),
+        parentOf(3, 5, 'father'), parentOf(4, 5, 'mother')
+      ]
+    )
+
+    expect(countRelatives(graph, 5)).toEqual({ ancestors: 5, descendants: 0, siblings: 0 })
+    expect(countRelatives(graph, 1)).toEqual({ ancestors: 0, descendants: 5, siblings: 0 })
+    expect(countRelatives(graph, 2)).toEqual({ ancestors: 1, descendants: 2, siblings: 1 })
+  })
+})
@@ -4,6 +4,9 @@
  * Implements GEDCOM 5.5.1 and 7.0 export functionality for family tree data.
  * Converts Person and Relationship records into standard GEDCOM format.
  *
+ * Person notes become NOTE sub-records, and sources passed to
+ * buildGedcomFile become SOUR records cited from the facts they support.
+ *
  * Story #96: Export Family Tree as GEDCOM
  */
 
@@ -139,22 +142,89 @@ export function generateGedcomHeader(version, userName, exportDate) {
   return lines.join('\n')
 }
 
+/**
+ * Formats a note as GEDCOM lines, continuing extra lines with CONT
+ *
+ * @param {number} level - Level of the NOTE line
+ * @param {string} text - Note text, possibly spanning several lines
+ * @returns {Array<string>} GEDCOM lines
+ *
+ * @example
+ * formatGedcomNote(1, 'Veteran\nFarmer') // ['1 NOTE Veteran', '2 CONT Farmer']
+ */
+export function formatGedcomNote(level, text) {
+  const [first, ...rest] = String(text).split(/\r?\n/)
+  return [
+    `${level} NOTE ${first}`.trimEnd(),
+    ...rest.map((line) => `${level + 1} CONT ${line}`.trimEnd())
+  ]
+}
+
+/**
+ * Formats a source citation as GEDCOM lines
+ *
+ * @param {number} level - Level of the SOUR line
+ * @param {Object} citation - { sourceGedcomId, page }
+ * @returns {Array<string>} GEDCOM lines
+ */
+function formatGedcomCitation(level, citation) {
+  const lines = [`${level} SOUR ${citation.sourceGedcomId}`]
+  if (citation.page) {
+    lines.push(`${level + 1} PAGE ${citation.page}`)
+  }
+  return lines
+}
+
+/**
+ * Generates a GEDCOM source record
+ *
+ * @param {Object} source - Source with title and optional author, publication, and note
+ * @param {string} gedcomId - GEDCOM ID for this source (e.g., "@SR1@")
+ * @returns {string} GEDCOM source record
+ */
+export function generateGedcomSource(source, gedcomId) {
+  const lines = [`0 ${gedcomId} SOUR`]
+
+  if (source.title) {
+    lines.push(`1 TITL ${source.title}`)
+  }
+  if (source.author) {
+    lines.push(`1 AUTH ${source.author}`)
+  }
+  if (source.publication) {
+    lines.push(`1 PUBL ${source.publication}`)
+  }
+  if (source.note) {
+    lines.push(...formatGedcomNote(1, source.note))
+  }
+
+  return lines.join('\n')
+}
+
 /**
  * Generates a GEDCOM individual record
  *
+ * Citations with fact "BIRT" or "DEAT" are placed under that event; other
+ * citations support the individual as a whole.
+ *
  * @param {Object} person - Person object from database
  * @param {string} gedcomId - GEDCOM ID for this individual (e.g., "@I1@")
+ * @param {Array<Object>} [citations=[]] - [{ sourceGedcomId, fact, page }]
  * @returns {string} GEDCOM individual record
  */
-export function generateGedcomIndividual(person, gedcomId) {
+export function generateGedcomIndividual(person, gedcomId, citations = []) {
   const lines = []
 
   lines.push(`0 ${gedcomId} INDI`)
   lines.push(`1 NAME ${formatGedcomName(person.firstName, person.lastName)}`)
   lines.push(`1 SEX ${formatGedcomGender(person.gender)}`)
 
+  const citationsFor = (fact) => citations.filter((citation) => (citation.fact || null) === fact)
+  const birthCitations = citationsFor('BIRT')
+  const deathCitations = citationsFor('DEAT')
+
   // Birth information
-  if (person.birthDate || person.birthPlace) {
+  if (person.birthDate || person.birthPlace || birthCitations.length > 0) {
     lines.push('1 BIRT')
 
     if (person.birthDate) {
@@ -167,10 +237,12 @@ export function generateGedcomIndividual(person, gedcomId) {
     if (person.birthPlace) {
       lines.push(`2 PLAC ${person.birthPlace}`)
     }
+
+    birthCitations.forEach((citation) => lines.push(...formatGedcomCitation(2, citation)))
   }
 
   // Death information
-  if (person.deathDate || person.deathPlace) {
+  if (person.deathDate || person.deathPlace || deathCitations.length > 0) {
     lines.push('1 DEAT')
 
     if (person.deathDate) {
@@ -183,13 +255,20 @@ export function generateGedcomIndividual(person, gedcomId) {
     if (person.deathPlace) {
       lines.push(`2 PLAC ${person.deathPlace}`)
     }
+
+    deathCitations.forEach((citation) => lines.push(...formatGedcomCitation(2, citation)))
   }
 
   // Notes
   if (person.notes) {
-    lines.push(`1 NOTE ${person.notes}`)
+    lines.push(...formatGedcomNote(1, person.notes))
   }
 
+  // Sources supporting the individual as a whole
+  citations
+    .filter((citation) => citation.fact !== 'BIRT' && citation.fact !== 'DEAT')
+    .forEach((citation) => lines.push(...formatGedcomCitation(1, citation)))
+
   // Photo/media
   if (person.photoUrl) {
     lines.push('1 OBJE')
@@ -202,7 +281,11 @@ export function generateGedcomIndividual(person, gedcomId) {
 /**
  * Generates a GEDCOM family record
  *
- * @param {Object} family - Family object with husband, wife, and children IDs
+ * Emits MARR and DIV events when the family has marriage or divorce dates
+ * (the start_date and end_date of the spouse relationship).
+ *
+ * @param {Object} family - Family object with husband, wife, and children IDs,
+ *   and optional marriageDate/divorceDate (YYYY-MM-DD)
  * @param {string} gedcomId - GEDCOM ID for this family (e.g., "@F1@")
  * @returns {string} GEDCOM family record
  */
@@ -225,6 +308,19 @@ export function generateGedcomFamily(family, gedcomId) {
     })
   }
 
+  // Marriage and divorce events
+  const marriageDate = formatGedcomDate(family.marriageDate)
+  if (marriageDate) {
+    lines.push('1 MARR')
+    lines.push(`2 DATE ${marriageDate}`)
+  }
+
+  const divorceDate = formatGedcomDate(family.divorceDate)
+  if (divorceDate) {
+    lines.push('1 DIV')
+    lines.push(`2 DATE ${divorceDate}`)
+  }
+
   return lines.join('\n')
 }
 
@@ -282,7 +378,9 @@ function buildFamilies(people, relationships, personIdMap) {
       familyMap.set(familyKey, {
         husbandId,
         wifeId,
-        childrenIds: []
+        childrenIds: [],
+        marriageDate: rel.startDate || null,
+        divorceDate: rel.endDate || null
       })
     }
   })
@@ -350,10 +448,14 @@ function buildFamilies(people, relationships, personIdMap) {
  * @param {string} options.version - GEDCOM version ("5.5.1" or "7.0")
  * @param {string} options.userName - Submitter name
  * @param {string} options.exportDate - Export date (YYYY-MM-DD)
+ * @param {Array<Object>} [options.sources=[]] - Sources to export as SOUR records:
+ *   [{ title, author, publication, note, citations: [{ personId, fact, page }] }]
+ *   where fact is "BIRT", "DEAT", or null for the individual as a whole.
+ *   Citations of people not in the export are dropped.
  * @returns {string} Complete GEDCOM file content
  */
 export function buildGedcomFile(people, relationships, options) {
-  const { version = '5.5.1', userName = 'Unknown', exportDate } = options
+  const { version = '5.5.1', userName = 'Unknown', exportDate, sources = [] } = options
 
   const lines = []
 
@@ -366,10 +468,22 @@ export function buildGedcomFile(people, relationships, options) {
     personIdMap.set(person.id, formatGedcomId('I', index + 1))
   })
 
+  // Group citations by person, pointing at each source's GEDCOM ID
+  // (SR prefix, since @S1@ is the submitter)
+  const citationsByPerson = new Map()
+  sources.forEach((source, index) => {
+    const sourceGedcomId = formatGedcomId('SR', index + 1)
+    for (const citation of source.citations || []) {
+      if (!personIdMap.has(citation.personId)) continue
+      if (!citationsByPerson.has(citation.personId)) citationsByPerson.set(citation.personId, [])
+      citationsByPerson.get(citation.personId).push({ ...citation, sourceGedcomId })
+    }
+  })
+
   // Generate individual records
   people.forEach((person, index) => {
     const gedcomId = formatGedcomId('I', index + 1)
-    lines.push(generateGedcomIndividual(person, gedcomId))
+    lines.push(generateGedcomIndividual(person, gedcomId, citationsByPerson.get(person.id)))
   })
 
   // Build families from relationships
@@ -381,6 +495,11 @@ export function buildGedcomFile(people, relationships, options) {
     lines.push(generateGedcomFamily(family, gedcomId))
   })
 
+  // Generate source records
+  sources.forEach((source, index) => {
+    lines.push(generateGedcomSource(source, formatGedcomId('SR', index + 1)))
+  })
+
   // Generate trailer
   lines.push(generateGedcomTrailer())
 
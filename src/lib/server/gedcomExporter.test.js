@@ -8,6 +8,8 @@ import {
   generateGedcomIndividual,
   generateGedcomFamily,
   generateGedcomTrailer,
+  generateGedcomSource,
+  formatGedcomNote,
   buildGedcomFile
 } from './gedcomExporter.js'
 
@@ -345,6 +347,30 @@ describe('generateGedcomFamily', () => {
   })
 })
 
+describe('generateGedcomFamily marriage events', () => {
+  it('should emit MARR and DIV dates when present', () => {
+    const family = {
+      husbandId: '@I1@',
+      wifeId: '@I2@',
+      childrenIds: [],
+      marriageDate: '1975-06-14',
+      divorceDate: '1990-02-01'
+    }
+
+    const famRecord = generateGedcomFamily(family, '@F1@')
+
+    expect(famRecord).toContain('1 MARR\n2 DATE 14 JUN 1975')
+    expect(famRecord).toContain('1 DIV\n2 DATE 1 FEB 1990')
+  })
+
+  it('should omit events without dates', () => {
+    const famRecord = generateGedcomFamily({ husbandId: '@I1@', wifeId: '@I2@', childrenIds: [] }, '@F1@')
+
+    expect(famRecord).not.toContain('MARR')
+    expect(famRecord).not.toContain('DIV')
+  })
+})
+
 describe('generateGedcomTrailer', () => {
   it('should generate GEDCOM trailer', () => {
     const trailer = generateGedcomTrailer()
@@ -411,6 +437,21 @@ describe('buildGedcomFile', () => {
     expect(gedcom).toContain('0 TRLR')
   })
 
+  it('should include the marriage date of a spouse relationship in its FAM record', () => {
+    const people = [
+      { id: 1, firstName: 'John', lastName: 'Smith', gender: 'male' },
+      { id: 2, firstName: 'Jane', lastName: 'Doe', gender: 'female' }
+    ]
+    const relationships = [
+      { person1Id: 1, person2Id: 2, type: 'spouse', startDate: '1975-06-14', endDate: null }
+    ]
+
+    const gedcom = buildGedcomFile(people, relationships, { exportDate: '2026-01-09' })
+
+    expect(gedcom).toContain('0 @F1@ FAM\n1 HUSB @I1@\n1 WIFE @I2@\n1 MARR\n2 DATE 14 JUN 1975')
+    expect(gedcom).not.toContain('1 DIV')
+  })
+
   it('should handle parent-child relationships', () => {
     const people = [
       { id: 1, firstName: 'John', lastName: 'Smith', gender: 'male', birthDate: null, deathDate: null, photoUrl: null },
@@ -464,4 +505,56 @@ describe('buildGedcomFile', () => {
 
     expect(gedcom).toContain('2 VERS 7.0')
   })
+
+  it('should export notes and cited sources with cross-references', () => {
+    const people = [
+      { id: 7, firstName: 'John', lastName: 'Smith', gender: 'male', birthDate: '1950-01-15', notes: 'Farmer\nServed in the navy' },
+      { id: 8, firstName: 'Jane', lastName: 'Doe', gender: 'female' }
+    ]
+    const sources = [
+      {
+        title: 'Parish register, St. Mary',
+        author: 'Rev. Brown',
+        citations: [
+          { personId: 7, fact: 'BIRT', page: 'p. 12' },
+          { personId: 8, fact: null },
+          { personId: 99, fact: 'BIRT' }
+        ]
+      }
+    ]
+
+    const gedcom = buildGedcomFile(people, [], { exportDate: '2026-01-09', sources })
+
+    expect(gedcom).toContain('1 NOTE Farmer\n2 CONT Served in the navy')
+    expect(gedcom).toContain('1 BIRT\n2 DATE 15 JAN 1950\n2 SOUR @SR1@\n3 PAGE p. 12')
+    expect(gedcom).toContain('0 @I2@ INDI\n1 NAME Jane /Doe/\n1 SEX F\n1 SOUR @SR1@')
+    expect(gedcom).toContain('0 @SR1@ SOUR\n1 TITL Parish register, St. Mary\n1 AUTH Rev. Brown')
+    expect(gedcom.indexOf('0 @SR1@ SOUR')).toBeLessThan(gedcom.indexOf('0 TRLR'))
+  })
+})
+
+describe('formatGedcomNote', () => {
+  it('should continue multi-line notes with CONT', () => {
+    expect(formatGedcomNote(1, 'Veteran\r\nFarmer')).toEqual(['1 NOTE Veteran', '2 CONT Farmer'])
+  })
+
+  it('should keep blank lines without trailing spaces', () => {
+    expect(formatGedcomNote(1, 'One\n\nTwo')).toEqual(['1 NOTE One', '2 CONT', '2 CONT Two'])
+  })
+})
+
+describe('generateGedcomSource', () => {
+  it('should include only the fields that are present', () => {
+    expect(generateGedcomSource({ title: '1900 Census' }, '@SR1@')).toBe('0 @SR1@ SOUR\n1 TITL 1900 Census')
+  })
+
+  it('should cite a source on a death with no recorded date', () => {
+    const individual = generateGedcomIndividual(
+      { id: 1, firstName: 'John', lastName: 'Smith', gender: 'male' },
+      '@I1@',
+      [{ sourceGedcomId: '@SR2@', fact: 'DEAT' }]
+    )
+
+    expect(individual).toContain('1 DEAT\n2 SOUR @SR2@')
+  })
 })
@@ -0,0 +1,134 @@
+/**
+ * Import Batches Module
+ *
+ * Every import stamps the people and relationships it creates with a batch
+ * ID (the import_batch column), so a whole import can be listed and rolled
+ * back as a unit. Rows an import merely updated (e.g. GEDCOM merges) keep
+ * their original batch and are not affected by a rollback.
+ */
+
+import crypto from 'crypto'
+import { people, relationships } from '../db/schema.js'
+import { asc, eq, inArray, isNull, or } from 'drizzle-orm'
+import { transformPeopleToAPI } from './personHelpers.js'
+import { transformRelationshipsToAPI } from './relationshipHelpers.js'
+import { findDuplicateGroups } from './duplicateDetection.js'
+
+/**
+ * Generates a new import batch ID
+ *
+ * @returns {string} Random UUID
+ */
+export function createImportBatchId() {
+  return crypto.randomUUID()
+}
+
+/**
+ * Loads the people and relationships created by an import
+ *
+ * @param {Object} database - Drizzle database instance
+ * @param {string} batchId - Import batch ID
+ * @returns {Promise<{batchId: string, people: Array, relationships: Array}|null>}
+ *   Rows in API format, or null when the batch created nothing
+ */
+export async function getImportBatch(database, batchId) {
+  const batchPeople = await database
+    .select()
+    .from(people)
+    .where(eq(people.importBatch, batchId))
+    .orderBy(asc(people.id))
+
+  const batchRelationships = await database
+    .select()
+    .from(relationships)
+    .where(eq(relationships.importBatch, batchId))
+    .orderBy(asc(relationships.id))
+
+  if (batchPeople.length === 0 && batchRelationships.length === 0) {
+    return null
+  }
+
+  return {
+    batchId,
+    people: transformPeopleToAPI(batchPeople),
+    relationships: transformRelationshipsToAPI(batchRelationships)
+  }
+}
+
+/**
+ * Deletes everything an import created, in one transaction
+ *
+ * Relationships added since the import that point at the batch's people are
+ * removed too, since they cannot outlive the people they reference.
+ *
+ * @param {Object} database - Drizzle database instance
+ * @param {string} batchId - Import batch ID
+ * @returns {{batchId: string, peopleDeleted: number, relationshipsDeleted: number}|null}
+ *   Deletion counts, or null when the batch created nothing
+ */
+export function rollbackImportBatch(database, batchId) {
+  return database.transaction((tx) => {
+    const batchPeople = tx
+      .select({ id: people.id })
+      .from(people)
+      .where(eq(people.importBatch, batchId))
+
+    const relationshipsDeleted = tx
+      .delete(relationships)
+      .where(
+        or(
+          eq(relationships.importBatch, batchId),
+          inArray(relationships.person1Id, batchPeople),
+          inArray(relationships.person2Id, batchPeople)
+        )
+      )
+      .run().changes
+
+    const peopleDeleted = tx
+      .delete(people)
+      .where(eq(people.importBatch, batchId))
+      .run().changes
+
+    if (peopleDeleted === 0 && relationshipsDeleted === 0) {
+      return null
+    }
+
+    return { batchId, peopleDeleted, relationshipsDeleted }
+  }, { behavior: 'immediate' })
+}
+
+/**
+ * Finds duplicate groups in which an import's people collide with existing people
+ *
+ * Runs the same grouping as GET /api/people/duplicates?groups=true over all
+ * active people and keeps only groups mixing people from the batch with
+ * people from outside it. Duplicates entirely within the batch, or entirely
+ * within existing data, are not the import's doing and are left out.
+ *
+ * @param {Object} database - Drizzle database instance
+ * @param {string} batchId - Import batch ID
+ * @returns {Promise<Array<{name: string, personIds: number[], confidence: number,
+ *   importedPersonIds: number[], existingPersonIds: number[]}>>}
+ *   Groups in findDuplicateGroups order
+ */
+export async function findImportMergeSuggestions(database, batchId) {
+  const activePeople = await database
+    .select()
+    .from(people)
+    .where(isNull(people.deletedAt))
+
+  const batchPersonIds = new Set(
+    activePeople.filter((person) => person.importBatch === batchId).map((person) => person.id)
+  )
+
+  const suggestions = []
+  for (const group of findDuplicateGroups(activePeople)) {
+    const importedPersonIds = group.personIds.filter((id) => batchPersonIds.has(id))
+    const existingPersonIds = group.personIds.filter((id) => !batchPersonIds.has(id))
+    if (importedPersonIds.length === 0 || existingPersonIds.length === 0) continue
+
+    suggestions.push({ ...group, importedPersonIds, existingPersonIds })
+  }
+
+  return suggestions
+}
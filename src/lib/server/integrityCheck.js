@@ -0,0 +1,95 @@
+/**
+ * Integrity Check Module
+ *
+ * Runs SQLite's built-in consistency checks for the admin integrity-check
+ * endpoint and can repair the one problem the app knows how to fix:
+ * relationships left pointing at people that no longer exist (possible when
+ * rows were written with foreign key enforcement off).
+ */
+
+import { people, relationships } from '../db/schema.js'
+import { asc, inArray, notInArray, or, sql } from 'drizzle-orm'
+
+/**
+ * Runs PRAGMA integrity_check and PRAGMA foreign_key_check
+ *
+ * @param {Object} database - Drizzle database instance
+ * @param {Object} [options]
+ * @param {boolean} [options.repair=false] - Delete relationships that reference missing people
+ * @returns {Object} { ok, integrityCheck: Array<string>, foreignKeyViolations: [{ table, rowid, parent, fkid }],
+ *   repairedRelationships } where ok reflects the state after any repair
+ */
+export function runIntegrityCheck(database, options = {}) {
+  const integrityCheck = database
+    .all(sql`PRAGMA integrity_check`)
+    .map((row) => row.integrity_check)
+
+  let foreignKeyViolations = database.all(sql`PRAGMA foreign_key_check`)
+  let repairedRelationships = 0
+
+  if (options.repair) {
+    const danglingIds = [
+      ...new Set(
+        foreignKeyViolations
+          .filter((violation) => violation.table === 'relationships')
+          .map((violation) => violation.rowid)
+      )
+    ]
+
+    if (danglingIds.length > 0) {
+      repairedRelationships = database
+        .delete(relationships)
+        .where(inArray(relationships.id, danglingIds))
+        .run().changes
+      foreignKeyViolations = database.all(sql`PRAGMA foreign_key_check`)
+    }
+  }
+
+  return {
+    ok: integrityCheck.length === 1 && integrityCheck[0] === 'ok' && foreignKeyViolations.length === 0,
+    integrityCheck,
+    foreignKeyViolations,
+    repairedRelationships
+  }
+}
+
+/**
+ * Builds the condition matching relationships whose person1 or person2 row
+ * no longer exists (soft-deleted people still exist and do not count)
+ *
+ * @private
+ * @param {Object} database - Drizzle database instance
+ * @returns {Object} Drizzle where condition
+ */
+function orphanedCondition(database) {
+  const personIds = database.select({ id: people.id }).from(people)
+  return or(notInArray(relationships.person1Id, personIds), notInArray(relationships.person2Id, personIds))
+}
+
+/**
+ * Lists relationships that reference a person who no longer exists
+ *
+ * @param {Object} database - Drizzle database instance
+ * @returns {Array} Stored relationship rows, ordered by ID
+ */
+export function findOrphanedRelationships(database) {
+  return database
+    .select()
+    .from(relationships)
+    .where(orphanedCondition(database))
+    .orderBy(asc(relationships.id))
+    .all()
+}
+
+/**
+ * Deletes every relationship that references a person who no longer exists,
+ * in one transaction
+ *
+ * @param {Object} database - Drizzle database instance
+ * @returns {number} Number of relationships deleted
+ */
+export function deleteOrphanedRelationships(database) {
+  return database.transaction((tx) => {
+    return tx.delete(relationships).where(orphanedCondition(tx)).run().changes
+  }, { behavior: 'immediate' })
+}
@@ -0,0 +1,1059 @@
+/**
+ * Kinship Module
+ *
+ * Derives the structural relationship between two people (steps up to a
+ * common ancestor, steps down to the target, spouse hops) and turns it into
+ * a human-readable kinship term such as "paternal grandmother",
+ * "first cousin once removed", or "brother-in-law".
+ *
+ * Labels always describe the second person relative to the first:
+ * computeKinship(graph, me, you) answers "you are my ___".
+ */
+
+import { getAncestors, getAncestorsIncludingSelf, getDescendants } from './familyGraph.js'
+import { baseParentRole, parentRoleKind } from './relationshipHelpers.js'
+
+/**
+ * Label returned when no blood or marriage connection exists
+ */
+export const NO_RELATIONSHIP_LABEL = 'no known relationship'
+
+/**
+ * Gendered English terms for each relationship type
+ * Keys: male, female, neutral
+ */
+const TERMS = {
+  self: { male: 'self', female: 'self', neutral: 'self' },
+  parent: { male: 'father', female: 'mother', neutral: 'parent' },
+  child: { male: 'son', female: 'daughter', neutral: 'child' },
+  sibling: { male: 'brother', female: 'sister', neutral: 'sibling' },
+  grandparent: { male: 'grandfather', female: 'grandmother', neutral: 'grandparent' },
+  grandchild: { male: 'grandson', female: 'granddaughter', neutral: 'grandchild' },
+  auntUncle: { male: 'uncle', female: 'aunt', neutral: 'aunt/uncle' },
+  nieceNephew: { male: 'nephew', female: 'niece', neutral: 'niece/nephew' },
+  cousin: { male: 'cousin', female: 'cousin', neutral: 'cousin' },
+  spouse: { male: 'husband', female: 'wife', neutral: 'spouse' }
+}
+
+const ORDINALS = ['zeroth', 'first', 'second', 'third', 'fourth', 'fifth', 'sixth', 'seventh', 'eighth', 'ninth', 'tenth']
+
+const REMOVALS = ['', 'once', 'twice', 'thrice']
+
+/**
+ * Languages kinship labels can be written in; other languages fall back to English
+ */
+export const KINSHIP_LANGUAGES = ['en', 'es']
+
+export const DEFAULT_KINSHIP_LANGUAGE = 'en'
+
+/**
+ * Spanish terms for each relationship type, keyed like TERMS
+ * Neutral terms are built from the male and female forms (see formatSpanishKinshipLabel)
+ */
+const SPANISH_TERMS = {
+  self: { male: 'uno mismo', female: 'una misma' },
+  parent: { male: 'padre', female: 'madre' },
+  child: { male: 'hijo', female: 'hija' },
+  sibling: { male: 'hermano', female: 'hermana' },
+  grandparent: { male: 'abuelo', female: 'abuela' },
+  grandchild: { male: 'nieto', female: 'nieta' },
+  auntUncle: { male: 'tío', female: 'tía' },
+  nieceNephew: { male: 'sobrino', female: 'sobrina' },
+  cousin: { male: 'primo', female: 'prima' },
+  spouse: { male: 'esposo', female: 'esposa' }
+}
+
+/**
+ * Spanish words replacing the whole term for in-law and step relationships;
+ * other affinities add "político"/"política" (e.g. "tío político")
+ */
+const SPANISH_IN_LAW_TERMS = {
+  parent: { male: 'suegro', female: 'suegra' },
+  child: { male: 'yerno', female: 'nuera' },
+  sibling: { male: 'cuñado', female: 'cuñada' }
+}
+
+const SPANISH_STEP_TERMS = {
+  parent: { male: 'padrastro', female: 'madrastra' },
+  child: { male: 'hijastro', female: 'hijastra' }
+}
+
+/**
+ * Spanish prefixes for grandparents and grandchildren by number of "greats"
+ * (bisabuelo, tatarabuelo, trastatarabuelo)
+ */
+const SPANISH_GREAT_PREFIXES = ['', 'bis', 'tatara', 'trastatara']
+
+const SPANISH_ORDINALS = ['', 'primero', 'segundo', 'tercero', 'cuarto', 'quinto', 'sexto', 'séptimo', 'octavo', 'noveno', 'décimo']
+
+const SPANISH_NUMBERS = ['', 'una', 'dos', 'tres']
+
+const SPANISH_NO_RELATIONSHIP_LABEL = 'sin relación conocida'
+
+/**
+ * Finds the closest blood connection between two people
+ *
+ * Either person may be the common ancestor (distance 0), so direct
+ * ancestors and descendants are handled. The connection minimizes the
+ * combined distance; all ancestors tied at that (up, down) are returned.
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} fromId - Subject person ID
+ * @param {number} toId - Target person ID
+ * @returns {{up: number, down: number, ancestorIds: Array<number>}|null} Connection or null if unrelated by blood
+ */
+export function findBloodRelation(graph, fromId, toId) {
+  const fromAncestors = getAncestorsIncludingSelf(graph, fromId)
+  const toAncestors = getAncestorsIncludingSelf(graph, toId)
+  let best = null
+
+  for (const [ancestorId, up] of fromAncestors) {
+    const down = toAncestors.get(ancestorId)
+    if (down === undefined) continue
+
+    if (!best || up + down < best.up + best.down) {
+      best = { up, down, ancestorIds: [ancestorId] }
+    } else if (up + down === best.up + best.down && up === best.up) {
+      best.ancestorIds.push(ancestorId)
+    }
+  }
+
+  if (best) {
+    best.ancestorIds.sort((a, b) => a - b)
+  }
+
+  return best
+}
+
+/**
+ * Finds the most recent common ancestor of two people
+ *
+ * The ancestor minimizing the combined generational distance wins; when
+ * one person descends from the other, the older person is the ancestor
+ * (at distance 0 from themselves). Ties, such as both members of a couple,
+ * go to the lowest ID.
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} person1Id - First person ID
+ * @param {number} person2Id - Second person ID
+ * @returns {{ancestorId: number, distance1: number, distance2: number}|null}
+ *   Ancestor and generations from each person to them, or null when not related by blood
+ *
+ * @example
+ * findMostRecentCommonAncestor(graph, me, firstCousin) // { ancestorId: grandpa, distance1: 2, distance2: 2 }
+ * findMostRecentCommonAncestor(graph, dad, me) // { ancestorId: dad, distance1: 0, distance2: 1 }
+ */
+export function findMostRecentCommonAncestor(graph, person1Id, person2Id) {
+  const blood = findBloodRelation(graph, person1Id, person2Id)
+  if (!blood) return null
+
+  return { ancestorId: blood.ancestorIds[0], distance1: blood.up, distance2: blood.down }
+}
+
+/**
+ * Classifies a blood connection by its up/down step counts
+ *
+ * @param {number} up - Generations from the subject up to the common ancestor
+ * @param {number} down - Generations from the common ancestor down to the target
+ * @returns {Object} { type, greats, degree, removed }
+ *
+ * @example
+ * classifyBloodRelation(2, 0) // { type: 'grandparent', greats: 0, ... }
+ * classifyBloodRelation(3, 2) // { type: 'cousin', degree: 1, removed: 1, ... }
+ */
+export function classifyBloodRelation(up, down) {
+  const base = { greats: 0, degree: null, removed: null }
+
+  if (up === 0 && down === 0) return { ...base, type: 'self' }
+  if (down === 0) return up === 1 ? { ...base, type: 'parent' } : { ...base, type: 'grandparent', greats: up - 2 }
+  if (up === 0) return down === 1 ? { ...base, type: 'child' } : { ...base, type: 'grandchild', greats: down - 2 }
+  if (up === 1 && down === 1) return { ...base, type: 'sibling' }
+  if (down === 1) return { ...base, type: 'auntUncle', greats: up - 2 }
+  if (up === 1) return { ...base, type: 'nieceNephew', greats: down - 2 }
+
+  return {
+    ...base,
+    type: 'cousin',
+    degree: Math.min(up, down) - 1,
+    removed: Math.abs(up - down)
+  }
+}
+
+/**
+ * Determines whether an ancestor is on the subject's father's or mother's side
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} personId - Subject person ID
+ * @param {Array<number>} ancestorIds - Common ancestor IDs
+ * @param {number} up - Distance from subject to the ancestors
+ * @returns {string|null} "paternal", "maternal", or null when unknown or both
+ */
+function determineLineage(graph, personId, ancestorIds, up) {
+  const sides = new Set()
+
+  for (const parent of graph.parents.get(personId) || []) {
+    const reachable = getAncestorsIncludingSelf(graph, parent.id)
+    if (ancestorIds.some((id) => reachable.get(id) === up - 1)) {
+      sides.add(parent.role)
+    }
+  }
+
+  if (sides.size !== 1) return null
+  const [role] = sides
+  if (role === 'father') return 'paternal'
+  if (role === 'mother') return 'maternal'
+  return null
+}
+
+/**
+ * Determines whether two siblings share only one of their recorded parents
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} fromId - First sibling ID
+ * @param {number} toId - Second sibling ID
+ * @returns {boolean} True if both have two parents recorded and share exactly one
+ */
+function isHalfSibling(graph, fromId, toId) {
+  const fromParents = new Set((graph.parents.get(fromId) || []).map((p) => p.id))
+  const toParents = new Set((graph.parents.get(toId) || []).map((p) => p.id))
+
+  if (fromParents.size < 2 || toParents.size < 2) return false
+
+  const shared = [...fromParents].filter((id) => toParents.has(id)).length
+  return shared === 1
+}
+
+/**
+ * Builds a structured kinship description from a blood connection
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} fromId - Subject person ID
+ * @param {number} toId - Target person ID
+ * @param {Object} blood - Result of findBloodRelation
+ * @returns {Object} Kinship structure
+ */
+function buildBloodKinship(graph, fromId, toId, blood) {
+  const classification = classifyBloodRelation(blood.up, blood.down)
+
+  return {
+    ...classification,
+    up: blood.up,
+    down: blood.down,
+    spouseHops: 0,
+    half: classification.type === 'sibling' && isHalfSibling(graph, fromId, toId),
+    lineage: classification.type === 'grandparent'
+      ? determineLineage(graph, fromId, blood.ancestorIds, blood.up)
+      : null,
+    affinity: null,
+    commonAncestorIds: blood.ancestorIds
+  }
+}
+
+/**
+ * Relationship types that take the "-in-law" suffix when reached through marriage
+ */
+const IN_LAW_VIA_SPOUSE = ['parent', 'grandparent', 'sibling']
+const IN_LAW_VIA_RELATIVE = ['child', 'grandchild', 'sibling']
+
+/**
+ * Relationship types that take the "step" prefix when reached through marriage
+ */
+const STEP_VIA_SPOUSE = ['child', 'grandchild']
+const STEP_VIA_RELATIVE = ['parent', 'grandparent']
+
+/**
+ * Finds the closest relationship that passes through exactly one marriage
+ *
+ * Considers both the target being a blood relative of the subject's spouse
+ * (e.g., wife's father → father-in-law) and the target being the spouse of
+ * the subject's blood relative (e.g., sister's husband → brother-in-law).
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} fromId - Subject person ID
+ * @param {number} toId - Target person ID
+ * @returns {Object|null} Kinship structure or null
+ */
+function findAffinityKinship(graph, fromId, toId) {
+  const candidates = []
+
+  // Target is a blood relative of the subject's spouse
+  for (const spouseId of graph.spouses.get(fromId) || []) {
+    const blood = findBloodRelation(graph, spouseId, toId)
+    if (blood && (blood.up > 0 || blood.down > 0)) {
+      candidates.push({ blood, via: 'spouse' })
+    }
+  }
+
+  // Target is the spouse of one of the subject's blood relatives
+  for (const spouseId of graph.spouses.get(toId) || []) {
+    const blood = findBloodRelation(graph, fromId, spouseId)
+    if (blood && (blood.up > 0 || blood.down > 0)) {
+      candidates.push({ blood, via: 'relative' })
+    }
+  }
+
+  if (candidates.length === 0) return null
+
+  candidates.sort((a, b) => (a.blood.up + a.blood.down) - (b.blood.up + b.blood.down))
+  const { blood, via } = candidates[0]
+  const classification = classifyBloodRelation(blood.up, blood.down)
+
+  let affinity = 'byMarriage'
+  if (via === 'spouse') {
+    if (IN_LAW_VIA_SPOUSE.includes(classification.type)) affinity = 'inLaw'
+    else if (STEP_VIA_SPOUSE.includes(classification.type)) affinity = 'step'
+  } else {
+    if (IN_LAW_VIA_RELATIVE.includes(classification.type)) affinity = 'inLaw'
+    else if (STEP_VIA_RELATIVE.includes(classification.type)) affinity = 'step'
+  }
+
+  return {
+    ...classification,
+    up: blood.up,
+    down: blood.down,
+    spouseHops: 1,
+    half: false,
+    lineage: null,
+    affinity,
+    commonAncestorIds: blood.ancestorIds
+  }
+}
+
+/**
+ * Computes the structural kinship of the target relative to the subject
+ *
+ * Blood relationships take precedence; otherwise a direct spouse, then a
+ * single-marriage (in-law, step, by-marriage) connection is considered.
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} fromId - Subject person ID
+ * @param {number} toId - Target person ID
+ * @returns {Object|null} Kinship structure, or null when there is no known relationship
+ */
+export function computeKinship(graph, fromId, toId) {
+  const blood = findBloodRelation(graph, fromId, toId)
+  if (blood) {
+    return buildBloodKinship(graph, fromId, toId, blood)
+  }
+
+  if ((graph.spouses.get(fromId) || new Set()).has(toId)) {
+    return {
+      type: 'spouse',
+      greats: 0,
+      degree: null,
+      removed: null,
+      up: 0,
+      down: 0,
+      spouseHops: 1,
+      half: false,
+      lineage: null,
+      affinity: null,
+      commonAncestorIds: []
+    }
+  }
+
+  return findAffinityKinship(graph, fromId, toId)
+}
+
+/**
+ * Maps a stored gender value to a term key
+ *
+ * @param {string|null} gender - Person gender
+ * @returns {string} "male", "female", or "neutral"
+ */
+function genderKey(gender) {
+  if (gender === 'male' || gender === 'female') return gender
+  return 'neutral'
+}
+
+/**
+ * Formats a cousin degree and removal in English
+ *
+ * @param {number} degree - Cousin degree (1 = first cousin)
+ * @param {number} removed - Generations removed
+ * @returns {string} e.g. "second cousin twice removed"
+ */
+function formatCousin(degree, removed) {
+  const ordinal = ORDINALS[degree] || `${degree}th`
+  if (removed === 0) return `${ordinal} cousin`
+  const removal = REMOVALS[removed] || `${removed} times`
+  return `${ordinal} cousin ${removal} removed`
+}
+
+/**
+ * Resolves a requested label language to a supported one
+ *
+ * @param {string|null} lang - Requested language code (e.g. "es", "es-MX")
+ * @returns {string} A KINSHIP_LANGUAGES value, English when unsupported or missing
+ */
+export function resolveKinshipLanguage(lang) {
+  const base = (lang || '').trim().toLowerCase().split(/[-_]/)[0]
+  return KINSHIP_LANGUAGES.includes(base) ? base : DEFAULT_KINSHIP_LANGUAGE
+}
+
+/**
+ * Formats a kinship structure as a label using the target's gender
+ *
+ * @param {Object|null} kinship - Result of computeKinship
+ * @param {string|null} gender - Target person's gender
+ * @param {string} [lang] - Label language (see resolveKinshipLanguage), English by default
+ * @returns {string} Human-readable kinship term
+ *
+ * @example
+ * formatKinshipLabel({ type: 'grandparent', greats: 0, lineage: 'paternal', ... }, 'female')
+ * // "paternal grandmother"
+ * formatKinshipLabel({ type: 'cousin', degree: 1, removed: 0, ... }, 'male', 'es')
+ * // "primo"
+ */
+export function formatKinshipLabel(kinship, gender, lang = DEFAULT_KINSHIP_LANGUAGE) {
+  if (resolveKinshipLanguage(lang) === 'es') {
+    return formatSpanishKinshipLabel(kinship, gender)
+  }
+
+  if (!kinship) return NO_RELATIONSHIP_LABEL
+
+  let label
+  if (kinship.type === 'cousin') {
+    label = formatCousin(kinship.degree, kinship.removed)
+  } else {
+    label = TERMS[kinship.type][genderKey(gender)]
+    if (kinship.greats > 0) {
+      label = 'great-'.repeat(kinship.greats) + label
+    }
+  }
+
+  if (kinship.half) label = `half-${label}`
+  if (kinship.lineage) label = `${kinship.lineage} ${label}`
+
+  if (kinship.affinity === 'inLaw') label = `${label}-in-law`
+  else if (kinship.affinity === 'step') label = `step${label}`
+  else if (kinship.affinity === 'byMarriage') label = `${label} by marriage`
+
+  return label
+}
+
+/**
+ * Builds a Spanish grandparent or grandchild term
+ *
+ * @param {string} type - "grandparent" or "grandchild"
+ * @param {number} greats - Number of "greats" (0 = abuelo, 1 = bisabuelo)
+ * @param {string} key - "male" or "female"
+ * @returns {string} e.g. "tatarabuela", or "abuelo de 6.ª generación" beyond trastatarabuelo
+ */
+function spanishGrandTerm(type, greats, key) {
+  const term = SPANISH_TERMS[type][key]
+  if (greats < SPANISH_GREAT_PREFIXES.length) return SPANISH_GREAT_PREFIXES[greats] + term
+  return `${term} de ${greats + 2}.ª generación`
+}
+
+/**
+ * Formats a Spanish cousin term, e.g. "prima segunda con una generación de diferencia"
+ *
+ * @param {number} degree - Cousin degree (1 = primo hermano, written as plain "primo")
+ * @param {number} removed - Generations removed
+ * @param {string} key - "male" or "female"
+ * @returns {string} Cousin term
+ */
+function spanishCousin(degree, removed, key) {
+  let label = SPANISH_TERMS.cousin[key]
+  if (degree > 1) {
+    const ordinal = SPANISH_ORDINALS[degree] || `${degree}.º`
+    label += ` ${key === 'female' ? ordinal.replace(/o$/, 'a').replace(/º$/, 'ª') : ordinal}`
+  }
+  if (removed > 0) {
+    const count = SPANISH_NUMBERS[removed] || String(removed)
+    label += ` con ${count} ${removed === 1 ? 'generación' : 'generaciones'} de diferencia`
+  }
+  return label
+}
+
+/**
+ * Formats a Spanish term for one grammatical gender
+ *
+ * @param {Object} kinship - Result of computeKinship
+ * @param {string} key - "male" or "female"
+ * @returns {string} Spanish kinship term
+ */
+function formatSpanishTerm(kinship, key) {
+  const ending = key === 'female' ? 'a' : 'o'
+
+  if (kinship.greats === 0 && kinship.affinity === 'inLaw' && SPANISH_IN_LAW_TERMS[kinship.type]) {
+    return SPANISH_IN_LAW_TERMS[kinship.type][key]
+  }
+  if (kinship.greats === 0 && kinship.affinity === 'step' && SPANISH_STEP_TERMS[kinship.type]) {
+    return SPANISH_STEP_TERMS[kinship.type][key]
+  }
+
+  let label
+  if (kinship.type === 'cousin') {
+    label = spanishCousin(kinship.degree, kinship.removed, key)
+  } else if (kinship.type === 'grandparent' || kinship.type === 'grandchild') {
+    label = spanishGrandTerm(kinship.type, kinship.greats, key)
+  } else if (kinship.greats > 0) {
+    // Great-aunt is "tía abuela", great-nephew is "sobrino nieto"
+    const grandType = kinship.type === 'auntUncle' ? 'grandparent' : 'grandchild'
+    label = `${SPANISH_TERMS[kinship.type][key]} ${spanishGrandTerm(grandType, kinship.greats - 1, key)}`
+  } else {
+    label = SPANISH_TERMS[kinship.type][key]
+  }
+
+  if (kinship.half) label = `medi${ending} ${label}`
+  if (kinship.lineage === 'paternal') label = `${label} patern${ending}`
+  else if (kinship.lineage === 'maternal') label = `${label} matern${ending}`
+
+  if (kinship.affinity) label = `${label} polític${ending}`
+
+  return label
+}
+
+/**
+ * Formats a kinship structure as a Spanish label
+ *
+ * Spanish has no gender-neutral kinship terms, so for unknown genders both
+ * forms are given, e.g. "tío/tía".
+ *
+ * @param {Object|null} kinship - Result of computeKinship
+ * @param {string|null} gender - Target person's gender
+ * @returns {string} Spanish kinship term
+ */
+function formatSpanishKinshipLabel(kinship, gender) {
+  if (!kinship) return SPANISH_NO_RELATIONSHIP_LABEL
+
+  const key = genderKey(gender)
+  if (key !== 'neutral') return formatSpanishTerm(kinship, key)
+
+  return `${formatSpanishTerm(kinship, 'male')}/${formatSpanishTerm(kinship, 'female')}`
+}
+/**
+ * Computes the human-readable kinship label of the target relative to the subject
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} fromId - Subject person ID
+ * @param {number} toId - Target person ID
+ * @param {string} [lang] - Label language (see resolveKinshipLanguage), English by default
+ * @returns {{label: string, kinship: Object|null}} Label and underlying structure
+ */
+export function describeKinship(graph, fromId, toId, lang = DEFAULT_KINSHIP_LANGUAGE) {
+  const kinship = computeKinship(graph, fromId, toId)
+  const target = graph.people.get(toId)
+
+  return {
+    label: formatKinshipLabel(kinship, target?.gender, lang),
+    kinship
+  }
+}
+
+/**
+ * Counts the steps along the kinship path: generations up and down
+ * plus marriages crossed (parent = 1, sibling = 2, first cousin = 4)
+ *
+ * @param {Object|null} kinship - Result from computeKinship
+ * @returns {number|null} Step count, or null when unrelated
+ */
+export function kinshipDegree(kinship) {
+  if (!kinship) return null
+  return kinship.up + kinship.down + kinship.spouseHops
+}
+
+/**
+ * Describes everyone in the graph relative to a root person
+ *
+ * Related people come first ordered by degree, then unrelated people;
+ * ties are ordered by person ID. The root person is excluded.
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} rootId - Root ("home") person ID
+ * @returns {Array<{personId: number, label: string, degree: number|null, kinship: Object|null}>}
+ */
+export function describeKinshipToAll(graph, rootId) {
+  const results = []
+
+  for (const personId of graph.people.keys()) {
+    if (personId === rootId) continue
+
+    const { label, kinship } = describeKinship(graph, rootId, personId)
+    results.push({ personId, label, degree: kinshipDegree(kinship), kinship })
+  }
+
+  results.sort((a, b) =>
+    (a.degree ?? Infinity) - (b.degree ?? Infinity) || a.personId - b.personId
+  )
+
+  return results
+}
+
+/**
+ * Ranks candidate people by how closely they are related to a person
+ *
+ * Uses the same degree as describeKinshipToAll (path length in generations
+ * plus marriages crossed); unrelated candidates rank last and ties are
+ * ordered by person ID. The person themself is skipped if listed.
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} personId - Person to compare against
+ * @param {number[]} candidateIds - Candidate person IDs (must exist in the graph)
+ * @returns {{closest: Object|null, ranking: Array<{personId: number, label: string, degree: number|null}>}}
+ *   closest is the first related entry of ranking, or null when none is related
+ */
+export function findClosestRelative(graph, personId, candidateIds) {
+  const ranking = candidateIds
+    .filter((candidateId) => candidateId !== personId)
+    .map((candidateId) => {
+      const { label, kinship } = describeKinship(graph, personId, candidateId)
+      return { personId: candidateId, label, degree: kinshipDegree(kinship) }
+    })
+
+  ranking.sort((a, b) =>
+    (a.degree ?? Infinity) - (b.degree ?? Infinity) || a.personId - b.personId
+  )
+
+  return {
+    closest: ranking.find((entry) => entry.degree !== null) || null,
+    ranking
+  }
+}
+
+/**
+ * Lists the edges leaving a person for affinity path search
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} personId - Person ID
+ * @returns {Array<[number, string]>} [neighborId, "up" | "down" | "spouse"]
+ */
+function affinityEdges(graph, personId) {
+  const edges = []
+  for (const parent of graph.parents.get(personId) || []) edges.push([parent.id, 'up'])
+  for (const childId of graph.children.get(personId) || []) edges.push([childId, 'down'])
+  for (const spouseId of graph.spouses.get(personId) || []) edges.push([spouseId, 'spouse'])
+  return edges
+}
+
+/**
+ * Finds a connecting path between two people that may cross marriages
+ *
+ * Paths crossing the fewest marriages win, then the fewest steps. Going down
+ * to a child and back up to that child's other parent counts as crossing a
+ * marriage too. The path is split into links at each such crossing and each
+ * link is labeled relative to its start, giving chains like
+ * "sister's husband's brother".
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} fromId - Subject person ID
+ * @param {number} toId - Target person ID
+ * @returns {Object|null} { personIds, marriages, links: [{ fromId, toId, label }], label }, or null when unconnected
+ */
+export function findAffinityPath(graph, fromId, toId) {
+  if (!graph.people.has(fromId) || !graph.people.has(toId)) return null
+
+  // Dijkstra ordered by (marriages, steps) over states of person + edge taken into them,
+  // since whether an "up" edge crosses a marriage depends on the previous edge.
+  // Family graphs are small enough for a sorted array queue.
+  const stateKey = (id, edge) => `${id}:${edge}`
+  const startKey = stateKey(fromId, null)
+  const best = new Map([[startKey, { id: fromId, edge: null, marriages: 0, steps: 0, previousKey: null }]])
+  const queue = [startKey]
+  let targetKey = null
+
+  while (queue.length > 0) {
+    queue.sort((a, b) => {
+      const x = best.get(a)
+      const y = best.get(b)
+      return x.marriages - y.marriages || x.steps - y.steps || x.id - y.id
+    })
+    const currentKey = queue.shift()
+    const current = best.get(currentKey)
+    if (current.id === toId) {
+      targetKey = currentKey
+      break
+    }
+
+    for (const [nextId, edge] of affinityEdges(graph, current.id)) {
+      const crossesMarriage = edge === 'spouse' || (edge === 'up' && current.edge === 'down')
+      const marriages = current.marriages + (crossesMarriage ? 1 : 0)
+      const steps = current.steps + 1
+      const nextKey = stateKey(nextId, edge)
+      const prior = best.get(nextKey)
+      if (!prior || marriages < prior.marriages || (marriages === prior.marriages && steps < prior.steps)) {
+        best.set(nextKey, { id: nextId, edge, marriages, steps, previousKey: currentKey })
+        if (!queue.includes(nextKey)) queue.push(nextKey)
+      }
+    }
+  }
+
+  if (targetKey === null) return null
+
+  // Walk back from the target to recover the path and the edge taken into each person
+  const personIds = []
+  const edges = []
+  for (let key = targetKey; key !== null; key = best.get(key).previousKey) {
+    const state = best.get(key)
+    personIds.unshift(state.id)
+    if (state.edge !== null) edges.unshift(state.edge)
+  }
+
+  // Split into links at marriages and down-then-up turns
+  const links = []
+  let linkStart = 0
+  for (let i = 0; i < edges.length; i++) {
+    const previousEdge = i > linkStart ? edges[i - 1] : null
+    const breaksLink = edges[i] === 'spouse' || (edges[i] === 'up' && previousEdge === 'down')
+    if (breaksLink && i > linkStart) {
+      links.push([personIds[linkStart], personIds[i]])
+      linkStart = i
+    }
+    if (edges[i] === 'spouse') {
+      links.push([personIds[i], personIds[i + 1]])
+      linkStart = i + 1
+    }
+  }
+  if (linkStart < edges.length) {
+    links.push([personIds[linkStart], personIds[edges.length]])
+  }
+
+  const labeledLinks = links.map(([linkFromId, linkToId]) => ({
+    fromId: linkFromId,
+    toId: linkToId,
+    label: describeKinship(graph, linkFromId, linkToId).label
+  }))
+
+  return {
+    personIds,
+    marriages: best.get(targetKey).marriages,
+    links: labeledLinks,
+    label: labeledLinks.map((link) => link.label).join("'s ")
+  }
+}
+
+/**
+ * Finds the shortest blood path between two people that avoids some people
+ *
+ * The path climbs from the subject to a common ancestor and then descends to
+ * the target, never visiting an excluded person. Leaving out a known
+ * intermediary answers "how else are we related". Among equally short paths
+ * the lowest person IDs win, so results are stable.
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} fromId - Subject person ID
+ * @param {number} toId - Target person ID
+ * @param {Iterable<number>} [excludeIds=[]] - People the path may not pass through
+ * @returns {Object|null} { personIds, up, down, label }, or null when no such path exists
+ *
+ * @example
+ * // Siblings through their father when their mother (4) is left out
+ * findBloodPath(graph, 5, 6, [4]) // { personIds: [5, 3, 6], up: 1, down: 1, label: 'sister' }
+ */
+export function findBloodPath(graph, fromId, toId, excludeIds = []) {
+  const excluded = new Set(excludeIds)
+  if (!graph.people.has(fromId) || !graph.people.has(toId)) return null
+  if (excluded.has(fromId) || excluded.has(toId)) return null
+
+  // Breadth-first over states of person + phase: while climbing ("up") a path
+  // may go to a parent or turn down to a child; once descending it only goes down
+  const stateKey = (id, phase) => `${id}:${phase}`
+  const startKey = stateKey(fromId, 'up')
+  const states = new Map([[startKey, { id: fromId, phase: 'up', up: 0, down: 0, previousKey: null }]])
+  let frontier = [startKey]
+  let targetKey = null
+
+  while (frontier.length > 0 && targetKey === null) {
+    const next = []
+    for (const currentKey of frontier) {
+      const current = states.get(currentKey)
+      if (current.id === toId) {
+        targetKey = currentKey
+        break
+      }
+
+      const steps = []
+      if (current.phase === 'up') {
+        const parentIds = (graph.parents.get(current.id) || []).map((parent) => parent.id)
+        for (const parentId of parentIds.sort((a, b) => a - b)) steps.push([parentId, 'up'])
+      }
+      for (const childId of [...(graph.children.get(current.id) || [])].sort((a, b) => a - b)) {
+        steps.push([childId, 'down'])
+      }
+
+      for (const [nextId, phase] of steps) {
+        const nextKey = stateKey(nextId, phase)
+        if (excluded.has(nextId) || states.has(nextKey)) continue
+        states.set(nextKey, {
+          id: nextId,
+          phase,
+          up: current.up + (phase === 'up' ? 1 : 0),
+          down: current.down + (phase === 'down' ? 1 : 0),
+          previousKey: currentKey
+        })
+        next.push(nextKey)
+      }
+    }
+    frontier = next
+  }
+
+  if (targetKey === null) return null
+
+  const personIds = []
+  for (let key = targetKey; key !== null; key = states.get(key).previousKey) {
+    personIds.unshift(states.get(key).id)
+  }
+
+  const { up, down } = states.get(targetKey)
+  const kinship = { ...classifyBloodRelation(up, down), half: false, lineage: null, affinity: null }
+
+  return {
+    personIds,
+    up,
+    down,
+    label: formatKinshipLabel(kinship, graph.people.get(toId).gender)
+  }
+}
+
+/**
+ * Relates two family lines: each root together with all of their descendants
+ *
+ * When some people descend from both roots the lines have already merged,
+ * and those people are listed nearest first. Otherwise the closest pair of
+ * members (fewest parent, child, or spouse steps apart) is found, and the
+ * connection between them is described as findAffinityPath does, e.g.
+ * "husband's sister".
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} rootAId - First root person ID
+ * @param {number} rootBId - Second root person ID
+ * @returns {Object} { merged, sharedDescendants: [{ personId, generationsFromA, generationsFromB }], closest }
+ *   where closest is { fromId, toId, steps, path, label } with fromId in line A and toId in line B,
+ *   or null when the lines merged or are not connected at all
+ */
+export function relateBranches(graph, rootAId, rootBId) {
+  const lineA = new Map([[rootAId, 0], ...getDescendants(graph, rootAId)])
+  const lineB = new Map([[rootBId, 0], ...getDescendants(graph, rootBId)])
+
+  const sharedDescendants = [...lineA.keys()]
+    .filter((id) => lineB.has(id))
+    .map((id) => ({ personId: id, generationsFromA: lineA.get(id), generationsFromB: lineB.get(id) }))
+    .sort((x, y) =>
+      (x.generationsFromA + x.generationsFromB) - (y.generationsFromA + y.generationsFromB) ||
+      x.personId - y.personId
+    )
+
+  if (sharedDescendants.length > 0) {
+    return { merged: true, sharedDescendants, closest: null }
+  }
+
+  // Breadth-first from every member of line A at once; the first member of
+  // line B reached is the closest
+  const previous = new Map()
+  let frontier = [...lineA.keys()].sort((x, y) => x - y)
+  for (const id of frontier) previous.set(id, null)
+  let reachedId = null
+
+  while (frontier.length > 0 && reachedId === null) {
+    const next = []
+    for (const id of frontier) {
+      const neighborIds = affinityEdges(graph, id).map(([neighborId]) => neighborId).sort((x, y) => x - y)
+      for (const neighborId of neighborIds) {
+        if (previous.has(neighborId)) continue
+        previous.set(neighborId, id)
+        if (lineB.has(neighborId)) {
+          reachedId = neighborId
+          break
+        }
+        next.push(neighborId)
+      }
+      if (reachedId !== null) break
+    }
+    frontier = next
+  }
+
+  if (reachedId === null) {
+    return { merged: false, sharedDescendants: [], closest: null }
+  }
+
+  let fromId = reachedId
+  while (previous.get(fromId) !== null) fromId = previous.get(fromId)
+
+  // Describe the pair along the path findAffinityPath prefers (fewest marriages)
+  const connection = findAffinityPath(graph, fromId, reachedId)
+
+  return {
+    merged: false,
+    sharedDescendants: [],
+    closest: {
+      fromId,
+      toId: reachedId,
+      steps: connection.personIds.length - 1,
+      path: connection.personIds,
+      label: connection.label
+    }
+  }
+}
+
+/**
+ * Computes how many generations above (positive) or below (negative) the
+ * subject a person sits, following the connecting path from findAffinityPath
+ *
+ * Parent steps count +1, child steps -1, and marriages 0, so people who are
+ * not directly related are aligned through their shared ancestors or spouses.
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} fromId - Subject person ID
+ * @param {number} toId - Target person ID
+ * @returns {number|null} Generation gap, or null when unconnected
+ *
+ * @example
+ * generationGap(graph, me, grandmother) // 2
+ * generationGap(graph, me, secondCousin) // 0
+ */
+export function generationGap(graph, fromId, toId) {
+  if (fromId === toId) return graph.people.has(fromId) ? 0 : null
+
+  const path = findAffinityPath(graph, fromId, toId)
+  if (!path) return null
+
+  let gap = 0
+  for (let i = 1; i < path.personIds.length; i++) {
+    const previousId = path.personIds[i - 1]
+    const id = path.personIds[i]
+    if ((graph.parents.get(previousId) || []).some((parent) => parent.id === id)) gap++
+    else if ((graph.children.get(previousId) || new Set()).has(id)) gap--
+  }
+
+  return gap
+}
+
+/**
+ * Formats a generation gap as a label addressed to the subject
+ *
+ * @param {number} gap - Result of generationGap
+ * @returns {string} e.g. "your generation", "your grandparent's generation"
+ *
+ * @example
+ * formatGenerationLabel(3) // "your great-grandparent's generation"
+ * formatGenerationLabel(-1) // "your child's generation"
+ */
+export function formatGenerationLabel(gap) {
+  if (gap === 0) return 'your generation'
+
+  const distance = Math.abs(gap)
+  const base = gap > 0 ? ['parent', 'grandparent'] : ['child', 'grandchild']
+  const term = distance === 1 ? base[0] : 'great-'.repeat(distance - 2) + base[1]
+
+  return `your ${term}'s generation`
+}
+
+/**
+ * Maps a person's cousins: everyone descended from their aunts and uncles,
+ * grouped by cousin degree and removal
+ *
+ * Aunts and uncles are the grandparents' children other than the person's
+ * own ancestors (half-aunts and half-uncles included). Each descendant is
+ * classified by its closest blood connection, so people who turn out to be
+ * more closely related through another line are left out.
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} personId - Subject person ID
+ * @returns {Object} { auntUncleIds, groups: [{ degree, removed, label, cousins: [{ personId, auntUncleIds }] }] }
+ *   with groups ordered by degree then removal and cousins by ID
+ */
+export function buildCousinMap(graph, personId) {
+  const ancestors = getAncestors(graph, personId, 2)
+  const auntUncleIds = new Set()
+
+  for (const [grandparentId, distance] of ancestors) {
+    if (distance !== 2) continue
+    for (const childId of graph.children.get(grandparentId) || []) {
+      if (childId !== personId && !ancestors.has(childId)) auntUncleIds.add(childId)
+    }
+  }
+
+  const cousins = new Map()
+  for (const auntUncleId of auntUncleIds) {
+    for (const descendantId of getDescendants(graph, auntUncleId).keys()) {
+      if (descendantId === personId) continue
+      if (!cousins.has(descendantId)) cousins.set(descendantId, [])
+      cousins.get(descendantId).push(auntUncleId)
+    }
+  }
+
+  const groups = new Map()
+  for (const [cousinId, via] of cousins) {
+    const blood = findBloodRelation(graph, personId, cousinId)
+    const kinship = blood && classifyBloodRelation(blood.up, blood.down)
+    if (!kinship || kinship.type !== 'cousin') continue
+
+    const key = `${kinship.degree}:${kinship.removed}`
+    if (!groups.has(key)) {
+      groups.set(key, {
+        degree: kinship.degree,
+        removed: kinship.removed,
+        label: formatCousin(kinship.degree, kinship.removed),
+        cousins: []
+      })
+    }
+    groups.get(key).cousins.push({ personId: cousinId, auntUncleIds: via.sort((a, b) => a - b) })
+  }
+
+  const sortedGroups = [...groups.values()].sort((a, b) => a.degree - b.degree || a.removed - b.removed)
+  for (const group of sortedGroups) {
+    group.cousins.sort((a, b) => a.personId - b.personId)
+  }
+
+  return {
+    auntUncleIds: [...auntUncleIds].sort((a, b) => a - b),
+    groups: sortedGroups
+  }
+}
+
+/**
+ * Labels the other side of a direct relationship from one person's perspective
+ *
+ * Works on a single stored relationship row rather than the family graph,
+ * so step parents (which the graph ignores) are labeled too. A parent's
+ * term comes from the parent role, falling back to their gender; a child's
+ * or spouse's term comes from their gender.
+ *
+ * @param {Object} relationship - Relationship row (type, person1Id, person2Id, parentRole, relationKind)
+ * @param {number} fromId - Person whose perspective is used (one side of the relationship)
+ * @param {string|null} otherGender - Gender of the person on the other side
+ * @returns {{relation: string, label: string}} relation is "parent", "child", or "spouse"
+ *
+ * @example
+ * describeConnection({ type: 'parentOf', person1Id: 1, person2Id: 2, parentRole: 'stepMother' }, 2, 'female')
+ * // { relation: 'parent', label: 'stepmother' }
+ */
+export function describeConnection(relationship, fromId, otherGender) {
+  if (relationship.type === 'spouse') {
+    return { relation: 'spouse', label: TERMS.spouse[genderKey(otherGender)] }
+  }
+
+  const kind = relationship.relationKind || parentRoleKind(relationship.parentRole)
+
+  let relation
+  let label
+  if (relationship.person2Id === fromId) {
+    relation = 'parent'
+    const role = baseParentRole(relationship.parentRole)
+    label = TERMS.parent[role === 'mother' ? 'female' : role === 'father' ? 'male' : genderKey(otherGender)]
+  } else {
+    relation = 'child'
+    label = TERMS.child[genderKey(otherGender)]
+  }
+
+  if (kind === 'step') label = `step${label}`
+  else if (kind === 'adoptive') label = `adoptive ${label}`
+
+  return { relation, label }
+}
+
+/**
+ * Labels the former spouse of a person's current spouse
+ *
+ * @param {string|null} spouseGender - Gender of the person's current spouse
+ * @param {string|null} formerSpouseGender - Gender of the spouse's former spouse
+ * @returns {string} Label such as "wife's former husband"
+ *
+ * @example
+ * describeSpouseFormerSpouse('female', 'male') // "wife's former husband"
+ */
+export function describeSpouseFormerSpouse(spouseGender, formerSpouseGender) {
+  return `${TERMS.spouse[genderKey(spouseGender)]}'s former ${TERMS.spouse[genderKey(formerSpouseGender)]}`
+}
@@ -0,0 +1,447 @@
+/**
+ * Unit tests for Kinship Module
+ */
+
+import { describe, it, expect } from 'vitest'
+import { buildFamilyGraph } from './familyGraph.js'
+import {
+  classifyBloodRelation,
+  findBloodRelation,
+  findMostRecentCommonAncestor,
+  computeKinship,
+  formatKinshipLabel,
+  describeKinship,
+  resolveKinshipLanguage,
+  describeKinshipToAll,
+  findAffinityPath,
+  findBloodPath,
+  relateBranches,
+  generationGap,
+  formatGenerationLabel,
+  buildCousinMap,
+  findClosestRelative,
+  describeConnection,
+  describeSpouseFormerSpouse,
+  NO_RELATIONSHIP_LABEL
+} from './kinship.js'
+
+/**
+ * Fixture family (Me = 5):
+ *
+ *   Grandpa(1) + Grandma(2)                 MaternalGrandma(15)
+ *        |                                         |
+ *   +----+---------------+                         |
+ *   Dad(3) + Mom(4) -------------------------------+
+ *        |              Uncle(7) + AuntByMarriage(8)
+ *   +----+-----+               |
+ *   Me(5)  Sister(6)+BIL(14)  Cousin(9)
+ *    + Wife(11)                    |
+ *       |                     CousinSon(10)
+ *    Son(16)
+ *
+ *   FatherInLaw(12) -> Wife(11), WifesBrother(13)
+ *   Stranger(17)
+ */
+function buildFixture() {
+  const people = [
+    [1, 'Grandpa', 'male'], [2, 'Grandma', 'female'], [3, 'Dad', 'male'], [4, 'Mom', 'female'],
+    [5, 'Me', 'male'], [6, 'Sister', 'female'], [7, 'Uncle', 'male'], [8, 'AuntByMarriage', 'female'],
+    [9, 'Cousin', 'female'], [10, 'CousinSon', 'male'], [11, 'Wife', 'female'], [12, 'FatherInLaw', 'male'],
+    [13, 'WifesBrother', 'male'], [14, 'SistersHusband', 'male'], [15, 'MaternalGrandma', 'female'],
+    [16, 'Son', 'male'], [17, 'Stranger', null]
+  ].map(([id, firstName, gender]) => ({ id, firstName, lastName: 'Test', gender }))
+
+  const parent = (p, c, role) => ({ person1Id: p, person2Id: c, type: 'parentOf', parentRole: role })
+  const spouse = (a, b) => ({ person1Id: a, person2Id: b, type: 'spouse', parentRole: null })
+
+  const relationships = [
+    parent(1, 3, 'father'), parent(2, 3, 'mother'), parent(1, 7, 'father'), parent(2, 7, 'mother'),
+    parent(3, 5, 'father'), parent(4, 5, 'mother'), parent(3, 6, 'father'), parent(4, 6, 'mother'),
+    parent(15, 4, 'mother'), parent(7, 9, 'father'), parent(8, 9, 'mother'), parent(9, 10, 'mother'),
+    parent(12, 11, 'father'), parent(12, 13, 'father'), parent(5, 16, 'father'), parent(11, 16, 'mother'),
+    spouse(1, 2), spouse(3, 4), spouse(7, 8), spouse(5, 11), spouse(6, 14)
+  ]
+
+  return buildFamilyGraph(people, relationships)
+}
+
+describe('describeKinship', () => {
+  const graph = buildFixture()
+
+  const cases = [
+    // [from, to, expected label]
+    [5, 3, 'father'],
+    [5, 4, 'mother'],
+    [5, 16, 'son'],
+    [5, 1, 'paternal grandfather'],
+    [5, 2, 'paternal grandmother'],
+    [5, 15, 'maternal grandmother'],
+    [16, 1, 'paternal great-grandfather'],
+    [1, 16, 'great-grandson'],
+    [5, 6, 'sister'],
+    [6, 5, 'brother'],
+    [5, 7, 'uncle'],
+    [9, 3, 'uncle'],
+    [7, 5, 'nephew'],
+    [3, 10, 'great-nephew'],
+    [5, 9, 'first cousin'],
+    [9, 5, 'first cousin'],
+    [5, 10, 'first cousin once removed'],
+    [10, 5, 'first cousin once removed'],
+    [5, 11, 'wife'],
+    [11, 5, 'husband'],
+    [5, 12, 'father-in-law'],
+    [5, 13, 'brother-in-law'],
+    [5, 14, 'brother-in-law'],
+    [12, 5, 'son-in-law'],
+    [5, 8, 'aunt by marriage'],
+    [5, 17, NO_RELATIONSHIP_LABEL]
+  ]
+
+  it.each(cases)('person %i sees person %i as "%s"', (from, to, expected) => {
+    expect(describeKinship(graph, from, to).label).toBe(expected)
+  })
+
+  it('should return null kinship when unrelated', () => {
+    expect(describeKinship(graph, 5, 17).kinship).toBeNull()
+  })
+})
+
+describe('half and step relationships', () => {
+  const people = [
+    { id: 1, firstName: 'Mom', gender: 'female' },
+    { id: 2, firstName: 'Dad1', gender: 'male' },
+    { id: 3, firstName: 'Dad2', gender: 'male' },
+    { id: 4, firstName: 'Kid1', gender: 'male' },
+    { id: 5, firstName: 'Kid2', gender: 'female' }
+  ]
+  const relationships = [
+    { person1Id: 1, person2Id: 4, type: 'parentOf', parentRole: 'mother' },
+    { person1Id: 2, person2Id: 4, type: 'parentOf', parentRole: 'father' },
+    { person1Id: 1, person2Id: 5, type: 'parentOf', parentRole: 'mother' },
+    { person1Id: 3, person2Id: 5, type: 'parentOf', parentRole: 'father' },
+    { person1Id: 1, person2Id: 3, type: 'spouse', parentRole: null }
+  ]
+  const graph = buildFamilyGraph(people, relationships)
+
+  it('should label siblings sharing one of two recorded parents as half-siblings', () => {
+    expect(describeKinship(graph, 4, 5).label).toBe('half-sister')
+  })
+
+  it('should label a parent\'s spouse who is not a parent as step-parent', () => {
+    expect(describeKinship(graph, 4, 3).label).toBe('stepfather')
+  })
+
+  it('should label a spouse\'s child who is not one\'s own as step-child', () => {
+    expect(describeKinship(graph, 3, 4).label).toBe('stepson')
+  })
+})
+
+describe('Spanish labels', () => {
+  const graph = buildFixture()
+
+  const cases = [
+    // [from, to, expected label]
+    [5, 3, 'padre'],
+    [5, 2, 'abuela paterna'],
+    [16, 1, 'bisabuelo paterno'],
+    [5, 7, 'tío'],
+    [3, 10, 'sobrino nieto'],
+    [9, 5, 'primo'],
+    [5, 9, 'prima'],
+    [5, 10, 'primo con una generación de diferencia'],
+    [5, 11, 'esposa'],
+    [5, 12, 'suegro'],
+    [5, 13, 'cuñado'],
+    [12, 5, 'yerno'],
+    [5, 8, 'tía política'],
+    [5, 17, 'sin relación conocida']
+  ]
+
+  it.each(cases)('person %i sees person %i as "%s"', (from, to, expected) => {
+    expect(describeKinship(graph, from, to, 'es').label).toBe(expected)
+  })
+
+  it('should give both forms when gender is unknown', () => {
+    const kinship = computeKinship(graph, 3, 5)
+
+    expect(formatKinshipLabel(kinship, null, 'es')).toBe('hijo/hija')
+  })
+
+  it('should agree ordinals with gender for distant cousins', () => {
+    expect(formatKinshipLabel({ type: 'cousin', greats: 0, degree: 2, removed: 2 }, 'female', 'es'))
+      .toBe('prima segunda con dos generaciones de diferencia')
+  })
+})
+
+describe('resolveKinshipLanguage', () => {
+  it('should accept supported languages and regional variants', () => {
+    expect(resolveKinshipLanguage('es')).toBe('es')
+    expect(resolveKinshipLanguage('ES-mx')).toBe('es')
+  })
+
+  it('should fall back to English', () => {
+    expect(resolveKinshipLanguage('fr')).toBe('en')
+    expect(resolveKinshipLanguage(null)).toBe('en')
+    expect(describeKinship(buildFixture(), 5, 9, 'fr').label).toBe('first cousin')
+  })
+})
+
+describe('classifyBloodRelation', () => {
+  it('should compute cousin degree and removal', () => {
+    expect(classifyBloodRelation(3, 3)).toMatchObject({ type: 'cousin', degree: 2, removed: 0 })
+    expect(classifyBloodRelation(2, 4)).toMatchObject({ type: 'cousin', degree: 1, removed: 2 })
+  })
+})
+
+describe('findBloodRelation', () => {
+  it('should treat a direct ancestor as the common ancestor', () => {
+    const graph = buildFixture()
+
+    expect(findBloodRelation(graph, 16, 1)).toEqual({ up: 3, down: 0, ancestorIds: [1] })
+  })
+})
+
+describe('findMostRecentCommonAncestor', () => {
+  const graph = buildFixture()
+
+  it('should find the shared grandparent of first cousins', () => {
+    expect(findMostRecentCommonAncestor(graph, 5, 9)).toEqual({ ancestorId: 1, distance1: 2, distance2: 2 })
+  })
+
+  it('should return the parent for a parent/child pair', () => {
+    expect(findMostRecentCommonAncestor(graph, 3, 5)).toEqual({ ancestorId: 3, distance1: 0, distance2: 1 })
+    expect(findMostRecentCommonAncestor(graph, 16, 1)).toEqual({ ancestorId: 1, distance1: 3, distance2: 0 })
+  })
+
+  it('should return null for people not related by blood', () => {
+    expect(findMostRecentCommonAncestor(graph, 5, 11)).toBeNull()
+  })
+})
+
+describe('formatKinshipLabel', () => {
+  it('should use neutral terms when gender is unknown', () => {
+    const kinship = computeKinship(buildFixture(), 3, 5)
+
+    expect(formatKinshipLabel(kinship, null)).toBe('child')
+  })
+
+  it('should format distant cousins', () => {
+    expect(formatKinshipLabel({ type: 'cousin', degree: 2, removed: 2 }, 'male')).toBe('second cousin twice removed')
+    expect(formatKinshipLabel({ type: 'cousin', degree: 12, removed: 5 }, 'male')).toBe('12th cousin 5 times removed')
+  })
+})
+
+describe('describeKinshipToAll', () => {
+  it('should order related people by degree and leave unrelated last', () => {
+    const graph = buildFamilyGraph(
+      [
+        { id: 1, firstName: 'Grandpa', gender: 'male' },
+        { id: 2, firstName: 'Dad', gender: 'male' },
+        { id: 3, firstName: 'Me', gender: 'female' },
+        { id: 4, firstName: 'Stranger', gender: null }
+      ],
+      [
+        { person1Id: 1, person2Id: 2, type: 'parentOf', parentRole: 'father' },
+        { person1Id: 2, person2Id: 3, type: 'parentOf', parentRole: 'father' }
+      ]
+    )
+
+    const results = describeKinshipToAll(graph, 3)
+
+    expect(results.map(({ personId, label, degree }) => ({ personId, label, degree }))).toEqual([
+      { personId: 2, label: 'father', degree: 1 },
+      { personId: 1, label: 'paternal grandfather', degree: 2 },
+      { personId: 4, label: 'no known relationship', degree: null }
+    ])
+  })
+})
+
+describe('findAffinityPath', () => {
+  const graph = buildFixture()
+
+  it('should chain labels across a marriage', () => {
+    const path = findAffinityPath(graph, 14, 10)
+
+    expect(path.label).toBe("wife's first cousin once removed")
+    expect(path.marriages).toBe(1)
+  })
+
+  it('should prefer crossing a marriage over going through a shared child', () => {
+    const path = findAffinityPath(graph, 13, 5)
+
+    expect(path.links.map((link) => link.label)).toEqual(['sister', 'husband'])
+    expect(path.marriages).toBe(1)
+  })
+
+  it('should return null when no path exists', () => {
+    expect(findAffinityPath(graph, 5, 17)).toBeNull()
+  })
+})
+
+describe('findBloodPath', () => {
+  const graph = buildFixture()
+
+  it('should find the shortest path up to a common ancestor and back down', () => {
+    const path = findBloodPath(graph, 5, 9)
+
+    expect(path).toEqual({ personIds: [5, 3, 1, 7, 9], up: 2, down: 2, label: 'first cousin' })
+  })
+
+  it('should route around an excluded intermediary', () => {
+    expect(findBloodPath(graph, 5, 6, [3]).personIds).toEqual([5, 4, 6])
+    expect(findBloodPath(graph, 5, 9, [1]).personIds).toEqual([5, 3, 2, 7, 9])
+  })
+
+  it('should return null when every connection runs through the excluded person', () => {
+    expect(findBloodPath(graph, 5, 9, [3])).toBeNull()
+  })
+
+  it('should not connect people only related by marriage', () => {
+    expect(findBloodPath(graph, 5, 11)).toBeNull()
+  })
+})
+
+describe('relateBranches', () => {
+  // Smith(1) -> Son(2); Jones(3) -> Daughter(4); Son + Daughter married
+  const people = [
+    [1, 'Smith', 'male'], [2, 'Son', 'male'], [3, 'Jones', 'male'], [4, 'Daughter', 'female'],
+    [5, 'Grandchild', 'female'], [6, 'Stranger', null]
+  ].map(([id, firstName, gender]) => ({ id, firstName, lastName: 'Test', gender }))
+  const parent = (p, c, role) => ({ person1Id: p, person2Id: c, type: 'parentOf', parentRole: role })
+  const married = { person1Id: 2, person2Id: 4, type: 'spouse', parentRole: null }
+
+  it('should list people descending from both roots when the lines merged', () => {
+    const graph = buildFamilyGraph(people, [
+      parent(1, 2, 'father'), parent(3, 4, 'father'), married,
+      parent(2, 5, 'father'), parent(4, 5, 'mother')
+    ])
+
+    expect(relateBranches(graph, 1, 3)).toEqual({
+      merged: true,
+      sharedDescendants: [{ personId: 5, generationsFromA: 2, generationsFromB: 2 }],
+      closest: null
+    })
+  })
+
+  it('should find the closest members when the lines have not merged', () => {
+    const graph = buildFamilyGraph(people, [parent(1, 2, 'father'), parent(3, 4, 'father'), married])
+
+    expect(relateBranches(graph, 1, 3)).toEqual({
+      merged: false,
+      sharedDescendants: [],
+      closest: { fromId: 2, toId: 4, steps: 1, path: [2, 4], label: 'wife' }
+    })
+  })
+
+  it('should report no connection for unrelated lines', () => {
+    const graph = buildFamilyGraph(people, [parent(1, 2, 'father')])
+
+    expect(relateBranches(graph, 1, 6)).toEqual({ merged: false, sharedDescendants: [], closest: null })
+  })
+})
+
+describe('generationGap', () => {
+  const graph = buildFixture()
+
+  it('should count generations up and down along blood lines', () => {
+    expect(generationGap(graph, 5, 1)).toBe(2)
+    expect(generationGap(graph, 5, 16)).toBe(-1)
+    expect(generationGap(graph, 5, 9)).toBe(0)
+    expect(generationGap(graph, 5, 10)).toBe(-1)
+  })
+
+  it('should align people related only by marriage', () => {
+    expect(generationGap(graph, 5, 12)).toBe(1)
+    expect(generationGap(graph, 5, 8)).toBe(1)
+  })
+
+  it('should return null when unconnected', () => {
+    expect(generationGap(graph, 5, 17)).toBeNull()
+  })
+})
+
+describe('formatGenerationLabel', () => {
+  it.each([
+    [0, 'your generation'],
+    [1, "your parent's generation"],
+    [2, "your grandparent's generation"],
+    [4, "your great-great-grandparent's generation"],
+    [-1, "your child's generation"],
+    [-3, "your great-grandchild's generation"]
+  ])('should label a gap of %i as "%s"', (gap, expected) => {
+    expect(formatGenerationLabel(gap)).toBe(expected)
+  })
+})
+
+describe('buildCousinMap', () => {
+  const graph = buildFixture()
+
+  it('should group aunt and uncle descendants by cousin degree and removal', () => {
+    expect(buildCousinMap(graph, 5)).toEqual({
+      auntUncleIds: [7],
+      groups: [
+        { degree: 1, removed: 0, label: 'first cousin', cousins: [{ personId: 9, auntUncleIds: [7] }] },
+        { degree: 1, removed: 1, label: 'first cousin once removed', cousins: [{ personId: 10, auntUncleIds: [7] }] }
+      ]
+    })
+  })
+
+  it('should not treat parents as aunts or uncles, nor siblings as cousins', () => {
+    const map = buildCousinMap(graph, 9)
+    const cousinIds = map.groups.flatMap((group) => group.cousins.map((cousin) => cousin.personId))
+
+    expect(map.auntUncleIds).toEqual([3])
+    expect(cousinIds).toEqual([5, 6, 16])
+    expect(map.groups.map((group) => group.label)).toEqual(['first cousin', 'first cousin once removed'])
+  })
+
+  it('should return no groups when the person has no known aunts or uncles', () => {
+    expect(buildCousinMap(graph, 17)).toEqual({ auntUncleIds: [], groups: [] })
+  })
+})
+
+describe('findClosestRelative', () => {
+  const graph = buildFixture()
+
+  it('should pick the candidate with the shortest kinship path', () => {
+    const { closest, ranking } = findClosestRelative(graph, 5, [17, 9, 6])
+
+    expect(closest).toEqual({ personId: 6, label: 'sister', degree: 2 })
+    expect(ranking.map((entry) => entry.personId)).toEqual([6, 9, 17])
+  })
+
+  it('should return null when no candidate is related', () => {
+    expect(findClosestRelative(graph, 5, [17, 5]).closest).toBeNull()
+  })
+})
+
+describe('describeConnection', () => {
+  const parentOf = (parentRole, relationKind = null) =>
+    ({ type: 'parentOf', person1Id: 1, person2Id: 2, parentRole, relationKind })
+
+  it.each([
+    // [relationship, fromId, other gender, expected]
+    [parentOf('mother'), 2, 'female', { relation: 'parent', label: 'mother' }],
+    [parentOf('father'), 2, null, { relation: 'parent', label: 'father' }],
+    [parentOf('stepMother'), 2, 'female', { relation: 'parent', label: 'stepmother' }],
+    [parentOf('father', 'adoptive'), 2, 'male', { relation: 'parent', label: 'adoptive father' }],
+    [parentOf('mother'), 1, 'male', { relation: 'child', label: 'son' }],
+    [parentOf('mother'), 1, 'female', { relation: 'child', label: 'daughter' }],
+    [parentOf('stepFather'), 1, null, { relation: 'child', label: 'stepchild' }],
+    [{ type: 'spouse', person1Id: 1, person2Id: 2 }, 1, 'female', { relation: 'spouse', label: 'wife' }]
+  ])('should label %o from person %i (other gender %s)', (relationship, fromId, gender, expected) => {
+    expect(describeConnection(relationship, fromId, gender)).toEqual(expected)
+  })
+})
+
+describe('describeSpouseFormerSpouse', () => {
+  it.each([
+    ['female', 'male', "wife's former husband"],
+    ['male', 'female', "husband's former wife"],
+    [null, 'female', "spouse's former wife"],
+    ['male', null, "husband's former spouse"]
+  ])('should label a %s spouse\'s %s former spouse', (spouseGender, formerGender, expected) => {
+    expect(describeSpouseFormerSpouse(spouseGender, formerGender)).toBe(expected)
+  })
+})
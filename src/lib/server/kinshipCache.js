@@ -0,0 +1,88 @@
+/**
+ * Kinship Cache Module
+ *
+ * Stores every person's kinship to a home person in the kinship_cache table
+ * so large trees can be listed without recomputing each path. Entries use
+ * the default (legal) kinship mode and English labels.
+ *
+ * The cache is never updated in place: database triggers clear it on any
+ * change to people or relationships (see schema.js), and reads fall back to
+ * live computation until it is recomputed.
+ */
+
+import { kinshipCache, people, relationships } from '../db/schema.js'
+import { and, asc, eq, isNull, sql } from 'drizzle-orm'
+import { buildFamilyGraph } from './familyGraph.js'
+import { describeKinshipToAll } from './kinship.js'
+
+/**
+ * Rows inserted per statement, keeping well under SQLite's bound-parameter limit
+ */
+const INSERT_CHUNK_SIZE = 200
+
+/**
+ * Recomputes and stores the kinship of everyone in the tree to a home person
+ *
+ * Replaces the home person's existing entries in one transaction; entries
+ * for other home people are kept.
+ *
+ * @param {Object} database - Drizzle database instance
+ * @param {number} homeId - Home person ID
+ * @returns {number|null} Number of entries stored, or null when the home person does not exist
+ */
+export function recomputeKinshipCache(database, homeId) {
+  return database.transaction((tx) => {
+    const activePeople = tx.select().from(people).where(isNull(people.deletedAt)).all()
+    const allRelationships = tx.select().from(relationships).all()
+    const graph = buildFamilyGraph(activePeople, allRelationships)
+
+    if (!graph.people.has(homeId)) return null
+
+    const rows = describeKinshipToAll(graph, homeId).map((entry) => ({
+      homeId,
+      personId: entry.personId,
+      label: entry.label,
+      degree: entry.degree,
+      kinship: entry.kinship ? JSON.stringify(entry.kinship) : null
+    }))
+
+    tx.delete(kinshipCache).where(eq(kinshipCache.homeId, homeId)).run()
+    for (let i = 0; i < rows.length; i += INSERT_CHUNK_SIZE) {
+      tx.insert(kinshipCache).values(rows.slice(i, i + INSERT_CHUNK_SIZE)).run()
+    }
+
+    return rows.length
+  }, { behavior: 'immediate' })
+}
+
+/**
+ * Reads the cached kinships to a home person
+ *
+ * Entries are ordered like describeKinshipToAll: by degree with unrelated
+ * people last, then by person ID.
+ *
+ * @param {Object} database - Drizzle database instance
+ * @param {number} homeId - Home person ID
+ * @returns {Array<{personId: number, firstName: string|null, lastName: string|null, label: string, degree: number|null, kinship: Object|null}>|null}
+ *   Cached entries, or null when nothing is cached for the home person
+ */
+export function getCachedKinships(database, homeId) {
+  const rows = database
+    .select({
+      personId: kinshipCache.personId,
+      firstName: people.firstName,
+      lastName: people.lastName,
+      label: kinshipCache.label,
+      degree: kinshipCache.degree,
+      kinship: kinshipCache.kinship
+    })
+    .from(kinshipCache)
+    .innerJoin(people, and(eq(people.id, kinshipCache.personId), isNull(people.deletedAt)))
+    .where(eq(kinshipCache.homeId, homeId))
+    .orderBy(sql`${kinshipCache.degree} IS NULL`, asc(kinshipCache.degree), asc(kinshipCache.personId))
+    .all()
+
+  if (rows.length === 0) return null
+
+  return rows.map((row) => ({ ...row, kinship: row.kinship ? JSON.parse(row.kinship) : null }))
+}
@@ -55,7 +55,7 @@ export function selectBestValue(sourceValue, targetValue) {
  * Validates whether two people can be merged
  *
  * Validation rules:
- * 1. Gender must match or one must be "unspecified" or null
+ * 1. Gender must match or one must be "unspecified", "unknown", or null
  *
  * @param {Object} source - Source person
  * @param {Object} target - Target person
@@ -68,11 +68,9 @@ export function validateMerge(source, target) {
   const sourceGender = source.gender
   const targetGender = target.gender
 
-  // Genders must match, or one must be unspecified/null
-  if (sourceGender && targetGender &&
-      sourceGender !== 'unspecified' &&
-      targetGender !== 'unspecified' &&
-      sourceGender !== targetGender) {
+  // Genders must match, or one must be unspecified/unknown/null
+  const isSpecified = (gender) => gender && gender !== 'unspecified' && gender !== 'unknown'
+  if (isSpecified(sourceGender) && isSpecified(targetGender) && sourceGender !== targetGender) {
     errors.push(`Gender mismatch: Cannot merge ${sourceGender} into ${targetGender}`)
   }
 
@@ -165,7 +163,14 @@ export function generateMergePreview(source, target, _user, sourceRelationships,
     gender: selectBestValue(source.gender, target.gender),
     photoUrl: selectBestValue(source.photoUrl, target.photoUrl),
     birthSurname: selectBestValue(source.birthSurname, target.birthSurname),
-    nickname: selectBestValue(source.nickname, target.nickname)
+    nickname: selectBestValue(source.nickname, target.nickname),
+    middleName: selectBestValue(source.middleName, target.middleName),
+    maidenName: selectBestValue(source.maidenName, target.maidenName),
+    suffix: selectBestValue(source.suffix, target.suffix),
+    birthPlace: selectBestValue(source.birthPlace, target.birthPlace),
+    deathPlace: selectBestValue(source.deathPlace, target.deathPlace),
+    notable: Boolean(source.notable || target.notable),
+    notes: selectBestValue(source.notes, target.notes)
   }
 
   // Build comparison table
@@ -177,7 +182,14 @@ export function generateMergePreview(source, target, _user, sourceRelationships,
     gender: { source: source.gender, target: target.gender, merged: merged.gender },
     photoUrl: { source: source.photoUrl, target: target.photoUrl, merged: merged.photoUrl },
     birthSurname: { source: source.birthSurname, target: target.birthSurname, merged: merged.birthSurname },
-    nickname: { source: source.nickname, target: target.nickname, merged: merged.nickname }
+    nickname: { source: source.nickname, target: target.nickname, merged: merged.nickname },
+    middleName: { source: source.middleName, target: target.middleName, merged: merged.middleName },
+    maidenName: { source: source.maidenName, target: target.maidenName, merged: merged.maidenName },
+    suffix: { source: source.suffix, target: target.suffix, merged: merged.suffix },
+    birthPlace: { source: source.birthPlace, target: target.birthPlace, merged: merged.birthPlace },
+    deathPlace: { source: source.deathPlace, target: target.deathPlace, merged: merged.deathPlace },
+    notable: { source: Boolean(source.notable), target: Boolean(target.notable), merged: merged.notable },
+    notes: { source: source.notes, target: target.notes, merged: merged.notes }
   }
 
   // Identify relationships to transfer (all source relationships)
@@ -198,7 +210,10 @@ export function generateMergePreview(source, target, _user, sourceRelationships,
       gender: source.gender,
       photoUrl: source.photoUrl,
       birthSurname: source.birthSurname,
-      nickname: source.nickname
+      nickname: source.nickname,
+      middleName: source.middleName,
+      maidenName: source.maidenName,
+      suffix: source.suffix
     },
     target: {
       id: target.id,
@@ -209,7 +224,10 @@ export function generateMergePreview(source, target, _user, sourceRelationships,
       gender: target.gender,
       photoUrl: target.photoUrl,
       birthSurname: target.birthSurname,
-      nickname: target.nickname
+      nickname: target.nickname,
+      middleName: target.middleName,
+      maidenName: target.maidenName,
+      suffix: target.suffix
     },
     merged,
     comparison,
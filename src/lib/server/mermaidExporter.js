@@ -0,0 +1,81 @@
+/**
+ * Mermaid Exporter Module
+ *
+ * Renders the family tree as a Mermaid `graph TD` definition for embedding
+ * in Markdown:
+ * - one node per person with a stable ID derived from the person ID (p<id>)
+ * - parent relationships as arrows from parent to child
+ * - spouse relationships as dotted links
+ */
+
+/**
+ * Makes text safe inside a quoted Mermaid node label
+ * Quotes become the #quot; entity and other characters Mermaid treats as
+ * markup inside labels are replaced; line breaks collapse to spaces.
+ *
+ * @param {string} value - Raw text
+ * @returns {string} Sanitized text
+ */
+export function sanitizeMermaidLabel(value) {
+  return String(value)
+    .replace(/"/g, '#quot;')
+    .replace(/[<>]/g, '')
+    .replace(/[\r\n]+/g, ' ')
+    .trim()
+}
+
+/**
+ * Builds the label for a person: full name and birth/death years
+ *
+ * @param {Object} person - Person record
+ * @returns {string} Unsanitized label
+ */
+function personLabel(person) {
+  const name = [person.firstName, person.lastName].filter(Boolean).join(' ')
+  const birthYear = person.birthDate ? person.birthDate.slice(0, 4) : ''
+  const deathYear = person.deathDate ? person.deathDate.slice(0, 4) : ''
+
+  if (!birthYear && !deathYear) return name
+  return `${name} (${birthYear}-${deathYear})`
+}
+
+/**
+ * Builds a Mermaid flowchart of people and relationships
+ *
+ * Relationships referencing people outside the list are skipped, so callers
+ * can pass a subset of people (e.g. one person's descendants). Spouse pairs
+ * stored in both directions produce a single link.
+ *
+ * @param {Array} peopleRows - Person records
+ * @param {Array} relationshipRows - Relationship records (normalized database format)
+ * @returns {string} Mermaid definition
+ *
+ * @example
+ * buildMermaidGraph([{ id: 1, firstName: 'John', lastName: 'Smith' }], [])
+ * // 'graph TD\n  p1["John Smith"]\n'
+ */
+export function buildMermaidGraph(peopleRows, relationshipRows) {
+  const lines = ['graph TD']
+  const ids = new Set(peopleRows.map((person) => person.id))
+
+  const sortedPeople = [...peopleRows].sort((a, b) => a.id - b.id)
+  for (const person of sortedPeople) {
+    lines.push(`  p${person.id}["${sanitizeMermaidLabel(personLabel(person))}"]`)
+  }
+
+  const seenSpouses = new Set()
+  for (const rel of relationshipRows) {
+    if (!ids.has(rel.person1Id) || !ids.has(rel.person2Id)) continue
+
+    if (rel.type === 'spouse') {
+      const key = [rel.person1Id, rel.person2Id].sort((a, b) => a - b).join('-')
+      if (seenSpouses.has(key)) continue
+      seenSpouses.add(key)
+      lines.push(`  p${rel.person1Id} -.- p${rel.person2Id}`)
+    } else {
+      lines.push(`  p${rel.person1Id} --> p${rel.person2Id}`)
+    }
+  }
+
+  return lines.join('\n') + '\n'
+}
@@ -0,0 +1,48 @@
+/**
+ * Unit tests for Mermaid Exporter Module
+ */
+
+import { describe, it, expect } from 'vitest'
+import { buildMermaidGraph, sanitizeMermaidLabel } from './mermaidExporter.js'
+
+describe('sanitizeMermaidLabel', () => {
+  it('should replace quotes and strip markup characters', () => {
+    expect(sanitizeMermaidLabel('Mary "Molly" <b>Smith</b>')).toBe('Mary #quot;Molly#quot; bSmith/b')
+  })
+
+  it('should collapse line breaks', () => {
+    expect(sanitizeMermaidLabel('John\nSmith')).toBe('John Smith')
+  })
+})
+
+describe('buildMermaidGraph', () => {
+  const people = [
+    { id: 2, firstName: 'Mary', lastName: 'Smith', birthDate: '1905-03-10' },
+    { id: 1, firstName: 'John', lastName: 'Smith', birthDate: '1900-01-15', deathDate: '1980-06-01' },
+    { id: 3, firstName: 'Junior', lastName: 'Smith' }
+  ]
+
+  it('should emit nodes in ID order followed by edges', () => {
+    const mermaid = buildMermaidGraph(people, [
+      { person1Id: 1, person2Id: 2, type: 'spouse' },
+      { person1Id: 2, person2Id: 1, type: 'spouse' },
+      { person1Id: 1, person2Id: 3, type: 'parentOf' }
+    ])
+
+    expect(mermaid).toBe([
+      'graph TD',
+      '  p1["John Smith (1900-1980)"]',
+      '  p2["Mary Smith (1905-)"]',
+      '  p3["Junior Smith"]',
+      '  p1 -.- p2',
+      '  p1 --> p3',
+      ''
+    ].join('\n'))
+  })
+
+  it('should skip edges to people outside the list', () => {
+    const mermaid = buildMermaidGraph(people.slice(2), [{ person1Id: 1, person2Id: 3, type: 'parentOf' }])
+
+    expect(mermaid).toBe('graph TD\n  p3["Junior Smith"]\n')
+  })
+})
@@ -0,0 +1,167 @@
+/**
+ * Prometheus Metrics
+ *
+ * Collects per-request counts and latencies in memory and renders them in
+ * the Prometheus text exposition format for GET /metrics. Requests are
+ * labeled by SvelteKit route ID (e.g. "/api/people/[id]") rather than the
+ * raw path, so person IDs do not create a new series each.
+ *
+ * There is no client library dependency; only counters, histograms, and
+ * scrape-time gauges are needed, and the format is plain text.
+ */
+
+/**
+ * Content type of the Prometheus text exposition format
+ */
+export const METRICS_CONTENT_TYPE = 'text/plain; version=0.0.4; charset=utf-8'
+
+/**
+ * Upper bounds (seconds) of the request duration histogram buckets
+ */
+export const DURATION_BUCKETS = [0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10]
+
+/**
+ * Route label for requests that did not match a route
+ */
+const UNMATCHED_ROUTE = 'unmatched'
+
+/**
+ * Escapes a label value for the exposition format
+ *
+ * @param {string} value - Raw label value
+ * @returns {string} Escaped value
+ */
+function escapeLabel(value) {
+  return String(value).replace(/\\/g, '\\\\').replace(/"/g, '\\"').replace(/\n/g, '\\n')
+}
+
+/**
+ * Formats a label set as {name="value",...}
+ *
+ * @param {Object} labels - Label names to values
+ * @returns {string} Label block, or an empty string when there are no labels
+ */
+function formatLabels(labels) {
+  const entries = Object.entries(labels)
+  if (entries.length === 0) return ''
+  return `{${entries.map(([name, value]) => `${name}="${escapeLabel(value)}"`).join(',')}}`
+}
+
+/**
+ * Creates an in-memory metrics registry
+ *
+ * @returns {Object} { observeRequest({ method, route, status, durationSeconds }), render(gauges), reset() }
+ *
+ * @example
+ * const metrics = createMetrics()
+ * metrics.observeRequest({ method: 'GET', route: '/api/people', status: 200, durationSeconds: 0.012 })
+ * metrics.render([{ name: 'familytree_people', help: 'Active people', value: 42 }])
+ */
+export function createMetrics() {
+  // Keyed by JSON of the label values so each series is stored once
+  let requestCounts = new Map()
+  let durations = new Map()
+
+  return {
+    /**
+     * Records one finished request
+     */
+    observeRequest({ method, route, status, durationSeconds }) {
+      const routeLabel = route || UNMATCHED_ROUTE
+
+      const countKey = JSON.stringify([method, routeLabel, String(status)])
+      requestCounts.set(countKey, (requestCounts.get(countKey) || 0) + 1)
+
+      const durationKey = JSON.stringify([method, routeLabel])
+      let histogram = durations.get(durationKey)
+      if (!histogram) {
+        histogram = { buckets: DURATION_BUCKETS.map(() => 0), sum: 0, count: 0 }
+        durations.set(durationKey, histogram)
+      }
+      DURATION_BUCKETS.forEach((bound, index) => {
+        if (durationSeconds <= bound) histogram.buckets[index]++
+      })
+      histogram.sum += durationSeconds
+      histogram.count++
+    },
+
+    /**
+     * Renders all metrics, plus the given gauges, in the text exposition format
+     *
+     * @param {Array<{name: string, help: string, value: number}>} [gauges] - Values read at scrape time
+     * @returns {string} Exposition text ending in a newline
+     */
+    render(gauges = []) {
+      const lines = []
+
+      lines.push('# HELP http_requests_total Total HTTP requests by method, route, and status')
+      lines.push('# TYPE http_requests_total counter')
+      for (const [key, count] of requestCounts) {
+        const [method, route, status] = JSON.parse(key)
+        lines.push(`http_requests_total${formatLabels({ method, route, status })} ${count}`)
+      }
+
+      lines.push('# HELP http_request_duration_seconds HTTP request latency by method and route')
+      lines.push('# TYPE http_request_duration_seconds histogram')
+      for (const [key, histogram] of durations) {
+        const [method, route] = JSON.parse(key)
+        DURATION_BUCKETS.forEach((bound, index) => {
+          const labels = formatLabels({ method, route, le: bound })
+          lines.push(`http_request_duration_seconds_bucket${labels} ${histogram.buckets[index]}`)
+        })
+        lines.push(`http_request_duration_seconds_bucket${formatLabels({ method, route, le: '+Inf' })} ${histogram.count}`)
+        lines.push(`http_request_duration_seconds_sum${formatLabels({ method, route })} ${histogram.sum}`)
+        lines.push(`http_request_duration_seconds_count${formatLabels({ method, route })} ${histogram.count}`)
+      }
+
+      for (const gauge of gauges) {
+        lines.push(`# HELP ${gauge.name} ${gauge.help}`)
+        lines.push(`# TYPE ${gauge.name} gauge`)
+        lines.push(`${gauge.name} ${gauge.value}`)
+      }
+
+      return lines.join('\n') + '\n'
+    },
+
+    /**
+     * Clears all recorded requests
+     */
+    reset() {
+      requestCounts = new Map()
+      durations = new Map()
+    }
+  }
+}
+
+/**
+ * Process-wide registry used by the server hooks and GET /metrics
+ */
+export const metrics = createMetrics()
+
+/**
+ * Resolves a request while recording its duration and status
+ *
+ * A handler that throws is recorded with status 500 and the error is rethrown.
+ *
+ * @param {Object} registry - Registry from createMetrics
+ * @param {Object} event - SvelteKit request event
+ * @param {Function} resolve - async (event) => Response
+ * @returns {Promise<Response>}
+ */
+export async function measureRequest(registry, event, resolve) {
+  const started = performance.now()
+  let status = 500
+
+  try {
+    const response = await resolve(event)
+    status = response.status
+    return response
+  } finally {
+    registry.observeRequest({
+      method: event.request?.method || 'GET',
+      route: event.route?.id,
+      status,
+      durationSeconds: (performance.now() - started) / 1000
+    })
+  }
+}
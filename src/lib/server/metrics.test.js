@@ -0,0 +1,64 @@
+/**
+ * Unit tests for Prometheus Metrics
+ */
+
+import { describe, it, expect } from 'vitest'
+import { createMetrics, measureRequest } from './metrics.js'
+
+describe('createMetrics', () => {
+  it('should count requests per method, route, and status', () => {
+    const metrics = createMetrics()
+    metrics.observeRequest({ method: 'GET', route: '/api/people/[id]', status: 200, durationSeconds: 0.02 })
+    metrics.observeRequest({ method: 'GET', route: '/api/people/[id]', status: 200, durationSeconds: 0.2 })
+    metrics.observeRequest({ method: 'GET', route: '/api/people/[id]', status: 404, durationSeconds: 0.01 })
+
+    const text = metrics.render()
+
+    expect(text).toContain('http_requests_total{method="GET",route="/api/people/[id]",status="200"} 2')
+    expect(text).toContain('http_requests_total{method="GET",route="/api/people/[id]",status="404"} 1')
+  })
+
+  it('should record cumulative latency buckets', () => {
+    const metrics = createMetrics()
+    metrics.observeRequest({ method: 'POST', route: '/api/people', status: 201, durationSeconds: 0.02 })
+    metrics.observeRequest({ method: 'POST', route: '/api/people', status: 201, durationSeconds: 0.2 })
+
+    const text = metrics.render()
+
+    expect(text).toContain('http_request_duration_seconds_bucket{method="POST",route="/api/people",le="0.01"} 0')
+    expect(text).toContain('http_request_duration_seconds_bucket{method="POST",route="/api/people",le="0.025"} 1')
+    expect(text).toContain('http_request_duration_seconds_bucket{method="POST",route="/api/people",le="0.25"} 2')
+    expect(text).toContain('http_request_duration_seconds_bucket{method="POST",route="/api/people",le="+Inf"} 2')
+    expect(text).toContain('http_request_duration_seconds_count{method="POST",route="/api/people"} 2')
+  })
+
+  it('should render gauges and label unmatched routes', () => {
+    const metrics = createMetrics()
+    metrics.observeRequest({ method: 'GET', route: null, status: 404, durationSeconds: 0.001 })
+
+    const text = metrics.render([{ name: 'familytree_people', help: 'People', value: 3 }])
+
+    expect(text).toContain('route="unmatched"')
+    expect(text).toContain('# TYPE familytree_people gauge\nfamilytree_people 3\n')
+  })
+})
+
+describe('measureRequest', () => {
+  const event = { request: { method: 'DELETE' }, route: { id: '/api/people/[id]' } }
+
+  it('should record the response status', async () => {
+    const metrics = createMetrics()
+
+    const response = await measureRequest(metrics, event, async () => new Response(null, { status: 204 }))
+
+    expect(response.status).toBe(204)
+    expect(metrics.render()).toContain('http_requests_total{method="DELETE",route="/api/people/[id]",status="204"} 1')
+  })
+
+  it('should record a thrown error as 500 and rethrow it', async () => {
+    const metrics = createMetrics()
+
+    await expect(measureRequest(metrics, event, async () => { throw new Error('boom') })).rejects.toThrow('boom')
+    expect(metrics.render()).toContain('status="500"} 1')
+  })
+})
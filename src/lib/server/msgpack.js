@@ -0,0 +1,330 @@
+/**
+ * MessagePack Module
+ *
+ * Minimal MessagePack (https://msgpack.org) encoder and decoder for the
+ * compact tree export. Covers the JSON data model only: null, booleans,
+ * numbers, strings, arrays, and plain objects (maps with string keys).
+ * Like JSON.stringify, object properties that are undefined are skipped
+ * and values with a toJSON method are encoded through it.
+ */
+
+export const MSGPACK_CONTENT_TYPE = 'application/msgpack'
+
+const textEncoder = new TextEncoder()
+const textDecoder = new TextDecoder()
+
+/**
+ * Growable byte buffer
+ */
+class ByteWriter {
+  constructor(initialSize = 1024) {
+    this.bytes = new Uint8Array(initialSize)
+    this.view = new DataView(this.bytes.buffer)
+    this.length = 0
+  }
+
+  ensure(size) {
+    if (this.length + size <= this.bytes.length) return
+    const grown = new Uint8Array(Math.max(this.bytes.length * 2, this.length + size))
+    grown.set(this.bytes.subarray(0, this.length))
+    this.bytes = grown
+    this.view = new DataView(grown.buffer)
+  }
+
+  uint8(value) {
+    this.ensure(1)
+    this.view.setUint8(this.length, value)
+    this.length += 1
+  }
+
+  uint16(value) {
+    this.ensure(2)
+    this.view.setUint16(this.length, value)
+    this.length += 2
+  }
+
+  uint32(value) {
+    this.ensure(4)
+    this.view.setUint32(this.length, value)
+    this.length += 4
+  }
+
+  int8(value) {
+    this.ensure(1)
+    this.view.setInt8(this.length, value)
+    this.length += 1
+  }
+
+  int16(value) {
+    this.ensure(2)
+    this.view.setInt16(this.length, value)
+    this.length += 2
+  }
+
+  int32(value) {
+    this.ensure(4)
+    this.view.setInt32(this.length, value)
+    this.length += 4
+  }
+
+  bigUint64(value) {
+    this.ensure(8)
+    this.view.setBigUint64(this.length, value)
+    this.length += 8
+  }
+
+  bigInt64(value) {
+    this.ensure(8)
+    this.view.setBigInt64(this.length, value)
+    this.length += 8
+  }
+
+  float64(value) {
+    this.ensure(8)
+    this.view.setFloat64(this.length, value)
+    this.length += 8
+  }
+
+  raw(bytes) {
+    this.ensure(bytes.length)
+    this.bytes.set(bytes, this.length)
+    this.length += bytes.length
+  }
+
+  result() {
+    return this.bytes.slice(0, this.length)
+  }
+}
+
+/**
+ * Writes a string, array, or map header with the smallest size prefix
+ *
+ * @param {ByteWriter} writer - Output buffer
+ * @param {number} size - Number of bytes (strings) or entries
+ * @param {number} fixBase - First byte of the fix format (size < fixLimit)
+ * @param {number} fixLimit - Exclusive size limit of the fix format
+ * @param {Array<number|null>} prefixes - 8-, 16-, and 32-bit size prefixes (null when unavailable)
+ */
+function writeHeader(writer, size, fixBase, fixLimit, [prefix8, prefix16, prefix32]) {
+  if (size < fixLimit) {
+    writer.uint8(fixBase + size)
+  } else if (prefix8 !== null && size <= 0xff) {
+    writer.uint8(prefix8)
+    writer.uint8(size)
+  } else if (size <= 0xffff) {
+    writer.uint8(prefix16)
+    writer.uint16(size)
+  } else {
+    writer.uint8(prefix32)
+    writer.uint32(size)
+  }
+}
+
+/**
+ * Writes a number using the smallest integer format, or float 64
+ *
+ * @param {ByteWriter} writer - Output buffer
+ * @param {number} value - Number to write
+ */
+function writeNumber(writer, value) {
+  if (!Number.isSafeInteger(value)) {
+    writer.uint8(0xcb)
+    writer.float64(value)
+  } else if (value >= 0) {
+    if (value < 0x80) {
+      writer.uint8(value)
+    } else if (value <= 0xff) {
+      writer.uint8(0xcc)
+      writer.uint8(value)
+    } else if (value <= 0xffff) {
+      writer.uint8(0xcd)
+      writer.uint16(value)
+    } else if (value <= 0xffffffff) {
+      writer.uint8(0xce)
+      writer.uint32(value)
+    } else {
+      writer.uint8(0xcf)
+      writer.bigUint64(BigInt(value))
+    }
+  } else if (value >= -0x20) {
+    writer.int8(value)
+  } else if (value >= -0x80) {
+    writer.uint8(0xd0)
+    writer.int8(value)
+  } else if (value >= -0x8000) {
+    writer.uint8(0xd1)
+    writer.int16(value)
+  } else if (value >= -0x80000000) {
+    writer.uint8(0xd2)
+    writer.int32(value)
+  } else {
+    writer.uint8(0xd3)
+    writer.bigInt64(BigInt(value))
+  }
+}
+
+/**
+ * Writes any supported value
+ *
+ * @param {ByteWriter} writer - Output buffer
+ * @param {*} value - Value to write
+ * @throws {TypeError} For values outside the JSON data model (functions, symbols, bigints)
+ */
+function writeValue(writer, value) {
+  if (value !== null && typeof value === 'object' && typeof value.toJSON === 'function') {
+    value = value.toJSON()
+  }
+
+  if (value === null || value === undefined) {
+    writer.uint8(0xc0)
+  } else if (typeof value === 'boolean') {
+    writer.uint8(value ? 0xc3 : 0xc2)
+  } else if (typeof value === 'number') {
+    writeNumber(writer, value)
+  } else if (typeof value === 'string') {
+    const bytes = textEncoder.encode(value)
+    writeHeader(writer, bytes.length, 0xa0, 32, [0xd9, 0xda, 0xdb])
+    writer.raw(bytes)
+  } else if (Array.isArray(value)) {
+    writeHeader(writer, value.length, 0x90, 16, [null, 0xdc, 0xdd])
+    for (const item of value) writeValue(writer, item)
+  } else if (typeof value === 'object') {
+    const entries = Object.entries(value).filter(([, item]) => item !== undefined)
+    writeHeader(writer, entries.length, 0x80, 16, [null, 0xde, 0xdf])
+    for (const [key, item] of entries) {
+      writeValue(writer, key)
+      writeValue(writer, item)
+    }
+  } else {
+    throw new TypeError(`Cannot encode ${typeof value} as MessagePack`)
+  }
+}
+
+/**
+ * Encodes a value as MessagePack
+ *
+ * @param {*} value - JSON-compatible value
+ * @returns {Uint8Array} Encoded bytes
+ *
+ * @example
+ * encodeMsgpack({ a: 1 }) // Uint8Array [0x81, 0xa1, 0x61, 0x01]
+ */
+export function encodeMsgpack(value) {
+  const writer = new ByteWriter()
+  writeValue(writer, value)
+  return writer.result()
+}
+
+/**
+ * Decodes MessagePack produced by encodeMsgpack (or any encoder using the
+ * same formats, plus float 32)
+ *
+ * @param {Uint8Array} bytes - Encoded bytes
+ * @returns {*} Decoded value
+ * @throws {Error} On truncated input or unsupported formats (bin, ext)
+ */
+export function decodeMsgpack(bytes) {
+  const view = new DataView(bytes.buffer, bytes.byteOffset, bytes.byteLength)
+  let offset = 0
+
+  const take = (size) => {
+    if (offset + size > bytes.length) throw new Error('Unexpected end of MessagePack data')
+    const start = offset
+    offset += size
+    return start
+  }
+
+  const readString = (size) => textDecoder.decode(bytes.subarray(take(size), offset))
+
+  const readArray = (size) => {
+    const array = []
+    for (let i = 0; i < size; i++) array.push(read())
+    return array
+  }
+
+  const readMap = (size) => {
+    const map = {}
+    for (let i = 0; i < size; i++) {
+      const key = read()
+      map[key] = read()
+    }
+    return map
+  }
+
+  const read = () => {
+    const type = view.getUint8(take(1))
+
+    if (type < 0x80) return type
+    if (type < 0x90) return readMap(type - 0x80)
+    if (type < 0xa0) return readArray(type - 0x90)
+    if (type < 0xc0) return readString(type - 0xa0)
+    if (type >= 0xe0) return type - 0x100
+
+    switch (type) {
+      case 0xc0: return null
+      case 0xc2: return false
+      case 0xc3: return true
+      case 0xca: return view.getFloat32(take(4))
+      case 0xcb: return view.getFloat64(take(8))
+      case 0xcc: return view.getUint8(take(1))
+      case 0xcd: return view.getUint16(take(2))
+      case 0xce: return view.getUint32(take(4))
+      case 0xcf: return Number(view.getBigUint64(take(8)))
+      case 0xd0: return view.getInt8(take(1))
+      case 0xd1: return view.getInt16(take(2))
+      case 0xd2: return view.getInt32(take(4))
+      case 0xd3: return Number(view.getBigInt64(take(8)))
+      case 0xd9: return readString(view.getUint8(take(1)))
+      case 0xda: return readString(view.getUint16(take(2)))
+      case 0xdb: return readString(view.getUint32(take(4)))
+      case 0xdc: return readArray(view.getUint16(take(2)))
+      case 0xdd: return readArray(view.getUint32(take(4)))
+      case 0xde: return readMap(view.getUint16(take(2)))
+      case 0xdf: return readMap(view.getUint32(take(4)))
+      default: throw new Error(`Unsupported MessagePack type 0x${type.toString(16)}`)
+    }
+  }
+
+  const value = read()
+  if (offset !== bytes.length) throw new Error('Unexpected trailing MessagePack data')
+  return value
+}
+
+/**
+ * Media types accepted for MessagePack responses
+ */
+const MSGPACK_MEDIA_TYPES = [MSGPACK_CONTENT_TYPE, 'application/x-msgpack']
+
+/**
+ * Decides from an Accept header whether the client prefers MessagePack over JSON
+ *
+ * MessagePack is chosen only when explicitly listed with a quality at
+ * least as high as JSON's (application/json, application/*, or *\/*);
+ * anything else falls back to JSON.
+ *
+ * @param {string|null} accept - Accept header value
+ * @returns {boolean} True to respond with MessagePack
+ *
+ * @example
+ * prefersMsgpack('application/msgpack') // true
+ * prefersMsgpack('application/json, application/msgpack;q=0.5') // false
+ */
+export function prefersMsgpack(accept) {
+  let msgpackQuality = 0
+  let jsonQuality = 0
+
+  for (const part of (accept || '').split(',')) {
+    const [mediaType, ...params] = part.split(';').map((item) => item.trim().toLowerCase())
+    const qualityParam = params.find((param) => param.startsWith('q='))
+    const quality = qualityParam ? Number(qualityParam.slice(2)) : 1
+    if (!Number.isFinite(quality)) continue
+
+    if (MSGPACK_MEDIA_TYPES.includes(mediaType)) {
+      msgpackQuality = Math.max(msgpackQuality, quality)
+    } else if (['application/json', 'application/*', '*/*'].includes(mediaType)) {
+      jsonQuality = Math.max(jsonQuality, quality)
+    }
+  }
+
+  return msgpackQuality > 0 && msgpackQuality >= jsonQuality
+}
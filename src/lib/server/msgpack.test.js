@@ -0,0 +1,56 @@
+/**
+ * Unit tests for MessagePack Module
+ */
+
+import { describe, it, expect } from 'vitest'
+import { encodeMsgpack, decodeMsgpack, prefersMsgpack } from './msgpack.js'
+
+describe('encodeMsgpack', () => {
+  it('should use the compact fix formats for small values', () => {
+    expect([...encodeMsgpack({ a: 1 })]).toEqual([0x81, 0xa1, 0x61, 0x01])
+    expect([...encodeMsgpack([null, true, false, -1])]).toEqual([0x94, 0xc0, 0xc3, 0xc2, 0xff])
+  })
+
+  it('should pick the smallest integer width', () => {
+    expect([...encodeMsgpack(200)]).toEqual([0xcc, 200])
+    expect([...encodeMsgpack(1000)]).toEqual([0xcd, 0x03, 0xe8])
+    expect([...encodeMsgpack(-100)]).toEqual([0xd0, 0x9c])
+  })
+
+  it('should skip undefined properties like JSON', () => {
+    expect(decodeMsgpack(encodeMsgpack({ a: undefined, b: 2 }))).toEqual({ b: 2 })
+  })
+
+  it('should reject values outside the JSON data model', () => {
+    expect(() => encodeMsgpack({ f: () => {} })).toThrow('Cannot encode function')
+  })
+})
+
+describe('decodeMsgpack', () => {
+  it('should round-trip nested values of every size class', () => {
+    const value = {
+      text: 'Zoë '.repeat(20),
+      long: 'x'.repeat(70000),
+      numbers: [0, 127, 255, 65535, 4294967295, 2 ** 40, -32, -33, -40000, -(2 ** 40), 1.5, -0.25],
+      list: Array.from({ length: 20 }, (_, index) => ({ index, flag: index % 2 === 0, none: null }))
+    }
+
+    expect(decodeMsgpack(encodeMsgpack(value))).toEqual(value)
+  })
+
+  it('should reject truncated data', () => {
+    const bytes = encodeMsgpack({ name: 'Jane' })
+
+    expect(() => decodeMsgpack(bytes.subarray(0, bytes.length - 1))).toThrow('Unexpected end')
+  })
+})
+
+describe('prefersMsgpack', () => {
+  it('should choose MessagePack only when it is explicitly preferred', () => {
+    expect(prefersMsgpack('application/msgpack')).toBe(true)
+    expect(prefersMsgpack('application/x-msgpack, application/json;q=0.9')).toBe(true)
+    expect(prefersMsgpack('application/json, application/msgpack;q=0.5')).toBe(false)
+    expect(prefersMsgpack('*/*')).toBe(false)
+    expect(prefersMsgpack(null)).toBe(false)
+  })
+})
@@ -0,0 +1,125 @@
+/**
+ * Parent Direction Repair Module
+ *
+ * Finds parentOf relationships stored the wrong way round (the "parent" was
+ * born after the "child", a common import mistake) and reverses them. Each
+ * reversal is checked first so the repair cannot introduce a parent cycle
+ * or a second biological mother or father.
+ */
+
+import { people, relationships } from '../db/schema.js'
+import { eq, isNull } from 'drizzle-orm'
+import { buildFamilyGraph, getAncestors } from './familyGraph.js'
+import { BIOLOGICAL_PARENT_ROLES } from './relationshipHelpers.js'
+
+/**
+ * Reasons an inverted relationship was left unchanged
+ */
+export const SKIP_REASONS = {
+  unknownGender: 'unknownGender',
+  parentRoleConflict: 'parentRoleConflict',
+  cycle: 'cycle'
+}
+
+/**
+ * Checks whether one date is clearly later than another
+ *
+ * Dates are compared at their common precision, so "1950" is not clearly
+ * later or earlier than "1950-03-01", but is clearly later than "1949-12-31".
+ *
+ * @param {string} date - Date that may be later (YYYY, YYYY-MM, or YYYY-MM-DD)
+ * @param {string} otherDate - Date to compare against
+ * @returns {boolean} True when date is strictly later at the common precision
+ */
+export function isClearlyLater(date, otherDate) {
+  const length = Math.min(date.length, otherDate.length)
+  return date.slice(0, length) > otherDate.slice(0, length)
+}
+
+/**
+ * Picks the parent role for the person who becomes the parent after a reversal
+ *
+ * Adoptive and step prefixes are kept; mother or father follows the new
+ * parent's gender.
+ *
+ * @param {string|null} currentRole - Role stored on the inverted relationship
+ * @param {string|null} gender - Gender of the new parent
+ * @returns {string|null} New role, or null when the gender does not determine one
+ */
+export function reversedParentRole(currentRole, gender) {
+  const base = gender === 'male' ? 'father' : gender === 'female' ? 'mother' : null
+  if (!base) return null
+
+  const prefix = /^(adoptive|step)/.exec(currentRole || '')?.[1]
+  return prefix ? `${prefix}${base[0].toUpperCase()}${base.slice(1)}` : base
+}
+
+/**
+ * Reverses parentOf relationships whose parent was born after the child
+ *
+ * Only relationships where both people are active and have birth dates are
+ * considered. All changes are made in one transaction; relationships that
+ * cannot be reversed safely are reported as skipped.
+ *
+ * @param {Object} database - Drizzle database instance
+ * @returns {{fixed: Array<{relationshipId: number, parentId: number, childId: number, parentRole: string}>,
+ *   skipped: Array<{relationshipId: number, reason: string}>}}
+ *   fixed lists each relationship's new direction; skipped reasons are SKIP_REASONS values
+ */
+export function fixParentDirections(database) {
+  return database.transaction((tx) => {
+    const activePeople = tx.select().from(people).where(isNull(people.deletedAt)).all()
+    const allRelationships = tx.select().from(relationships).all()
+    const peopleById = new Map(activePeople.map((person) => [person.id, person]))
+
+    const inverted = allRelationships.filter((relationship) => {
+      if (relationship.type !== 'parentOf') return false
+      const parent = peopleById.get(relationship.person1Id)
+      const child = peopleById.get(relationship.person2Id)
+      return parent?.birthDate && child?.birthDate && isClearlyLater(parent.birthDate, child.birthDate)
+    })
+
+    const fixed = []
+    const skipped = []
+
+    for (const relationship of inverted) {
+      const newParentId = relationship.person2Id
+      const newChildId = relationship.person1Id
+
+      const parentRole = reversedParentRole(relationship.parentRole, peopleById.get(newParentId).gender)
+      if (!parentRole) {
+        skipped.push({ relationshipId: relationship.id, reason: SKIP_REASONS.unknownGender })
+        continue
+      }
+
+      const roleTaken = BIOLOGICAL_PARENT_ROLES.includes(parentRole) && allRelationships.some((other) =>
+        other.type === 'parentOf' && other.person2Id === newChildId && other.parentRole === parentRole
+      )
+      if (roleTaken) {
+        skipped.push({ relationshipId: relationship.id, reason: SKIP_REASONS.parentRoleConflict })
+        continue
+      }
+
+      // The new parent must not already descend from the new child once this edge is removed
+      const graph = buildFamilyGraph(
+        activePeople,
+        allRelationships.filter((other) => other !== relationship)
+      )
+      if (getAncestors(graph, newParentId).has(newChildId)) {
+        skipped.push({ relationshipId: relationship.id, reason: SKIP_REASONS.cycle })
+        continue
+      }
+
+      tx.update(relationships)
+        .set({ person1Id: newParentId, person2Id: newChildId, parentRole })
+        .where(eq(relationships.id, relationship.id))
+        .run()
+
+      // Later checks see the corrected direction
+      Object.assign(relationship, { person1Id: newParentId, person2Id: newChildId, parentRole })
+      fixed.push({ relationshipId: relationship.id, parentId: newParentId, childId: newChildId, parentRole })
+    }
+
+    return { fixed, skipped }
+  }, { behavior: 'immediate' })
+}
@@ -0,0 +1,25 @@
+/**
+ * Unit tests for Parent Direction Repair Module
+ */
+
+import { describe, it, expect } from 'vitest'
+import { isClearlyLater, reversedParentRole } from './parentDirectionRepair.js'
+
+describe('isClearlyLater', () => {
+  it('should compare dates at their common precision', () => {
+    expect(isClearlyLater('1980-05-01', '1950-01-01')).toBe(true)
+    expect(isClearlyLater('1950', '1949-12-31')).toBe(true)
+    expect(isClearlyLater('1950', '1950-03-01')).toBe(false)
+    expect(isClearlyLater('1950-01-01', '1980-05-01')).toBe(false)
+  })
+})
+
+describe('reversedParentRole', () => {
+  it('should follow the new parent\'s gender and keep adoptive or step prefixes', () => {
+    expect(reversedParentRole('mother', 'male')).toBe('father')
+    expect(reversedParentRole('father', 'female')).toBe('mother')
+    expect(reversedParentRole('adoptiveFather', 'female')).toBe('adoptiveMother')
+    expect(reversedParentRole('stepMother', 'male')).toBe('stepFather')
+    expect(reversedParentRole('father', null)).toBeNull()
+  })
+})
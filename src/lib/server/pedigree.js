@@ -0,0 +1,371 @@
+/**
+ * Pedigree Module
+ *
+ * Lays out a person's direct ancestors using Ahnentafel numbering
+ * (1 = subject, 2n = father of n, 2n + 1 = mother of n) and renders
+ * a printable pedigree chart as SVG.
+ */
+
+/**
+ * Layout constants for the SVG chart (in pixels)
+ */
+const BOX_WIDTH = 180
+const BOX_HEIGHT = 44
+const COLUMN_GAP = 40
+const ROW_HEIGHT = 56
+const MARGIN = 20
+
+/**
+ * Most generations whose Ahnentafel numbers are still exact integers
+ */
+export const MAX_AHNENTAFEL_GENERATIONS = 53
+
+/**
+ * Returns the generation of an Ahnentafel number (subject = 0, parents = 1, ...)
+ *
+ * @param {number} number - Ahnentafel number (1-based)
+ * @returns {number} Generation index
+ */
+export function ahnentafelGeneration(number) {
+  return Math.floor(Math.log2(number))
+}
+
+/**
+ * Picks the father and mother of a person from their parent entries
+ *
+ * Biological parents fill the slots first, so an adoptive parent only
+ * appears when the biological one is unknown. Within each kind, explicit
+ * parent roles win; parents without a role are placed by gender, then into
+ * whichever slot is still free.
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} personId - Person ID
+ * @returns {Object} { fatherId: number|null, motherId: number|null }
+ */
+function pickParents(graph, personId) {
+  let fatherId = null
+  let motherId = null
+  const parents = graph.parents.get(personId) || []
+  const biological = parents.filter((parent) => parent.kind === 'biological')
+  const others = parents.filter((parent) => parent.kind !== 'biological')
+
+  for (const group of [biological, others]) {
+    const unplaced = []
+
+    for (const parent of group) {
+      if (parent.role === 'father' && fatherId === null) {
+        fatherId = parent.id
+      } else if (parent.role === 'mother' && motherId === null) {
+        motherId = parent.id
+      } else if (!parent.role) {
+        unplaced.push(parent.id)
+      }
+    }
+
+    for (const parentId of unplaced) {
+      const gender = graph.people.get(parentId)?.gender
+      if (fatherId === null && gender !== 'female') {
+        fatherId = parentId
+      } else if (motherId === null && gender !== 'male') {
+        motherId = parentId
+      }
+    }
+  }
+
+  return { fatherId, motherId }
+}
+
+/**
+ * Builds the Ahnentafel for a person up to a number of generations
+ *
+ * Only known ancestors are included, so missing slots are simply absent.
+ * A person reached twice through pedigree collapse appears in each slot.
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} personId - Subject person ID
+ * @param {number} generations - Generations to include, counting the subject (e.g. 4)
+ * @returns {Map<number, number>} Map of Ahnentafel number to person ID
+ *
+ * @example
+ * const slots = buildAhnentafel(graph, 3, 4)
+ * slots.get(1) // 3 (subject)
+ * slots.get(2) // father's ID
+ */
+export function buildAhnentafel(graph, personId, generations) {
+  const slots = new Map()
+  const maxNumber = 2 ** generations - 1
+
+  if (!graph.people.has(personId)) {
+    return slots
+  }
+
+  slots.set(1, personId)
+
+  // Only filled slots are visited, so deep trees stay cheap; numbers come out ascending
+  const queue = [1]
+  while (queue.length > 0) {
+    const number = queue.shift()
+    if (2 * number > maxNumber) continue
+
+    const { fatherId, motherId } = pickParents(graph, slots.get(number))
+    if (fatherId !== null) {
+      slots.set(2 * number, fatherId)
+      queue.push(2 * number)
+    }
+    if (motherId !== null) {
+      slots.set(2 * number + 1, motherId)
+      queue.push(2 * number + 1)
+    }
+  }
+
+  return slots
+}
+
+/**
+ * Lists the empty Ahnentafel slots in each generation above the subject
+ *
+ * A slot is empty when no person occupies it, including slots above an
+ * ancestor who is already missing (e.g. 10 and 11 when 5 is unknown).
+ *
+ * @param {Map<number, number>} slots - Ahnentafel from buildAhnentafel
+ * @param {number} generations - Generations covered, counting the subject
+ * @returns {Array<Object>} [{ generation, expected, known, missing: [number] }]
+ *
+ * @example
+ * findPedigreeGaps(new Map([[1, 7], [2, 8]]), 2)
+ * // [{ generation: 1, expected: 2, known: 1, missing: [3] }]
+ */
+export function findPedigreeGaps(slots, generations) {
+  const gaps = []
+
+  for (let generation = 1; generation < generations; generation++) {
+    const first = 2 ** generation
+    const missing = []
+
+    for (let number = first; number < 2 * first; number++) {
+      if (!slots.has(number)) missing.push(number)
+    }
+
+    gaps.push({ generation, expected: first, known: first - missing.length, missing })
+  }
+
+  return gaps
+}
+
+/**
+ * Lists the placeholders needed to fill every empty slot of a pedigree
+ *
+ * Even numbers are fathers and odd numbers mothers. Entries come in
+ * ascending order, so each slot's child is either already known or listed
+ * earlier.
+ *
+ * @param {Map<number, number>} slots - Ahnentafel from buildAhnentafel
+ * @param {number} generations - Generations to fill, counting the subject
+ * @returns {Array<Object>} [{ number, childNumber, role: 'father'|'mother', gender: 'male'|'female' }]
+ *
+ * @example
+ * planPedigreeSkeleton(new Map([[1, 7], [2, 8]]), 2)
+ * // [{ number: 3, childNumber: 1, role: 'mother', gender: 'female' }]
+ */
+export function planPedigreeSkeleton(slots, generations) {
+  return findPedigreeGaps(slots, generations)
+    .flatMap((level) => level.missing)
+    .map((number) => {
+      const isFather = number % 2 === 0
+      return {
+        number,
+        childNumber: Math.floor(number / 2),
+        role: isFather ? 'father' : 'mother',
+        gender: isFather ? 'male' : 'female'
+      }
+    })
+}
+
+/**
+ * Counts the distinct ancestors found in each generation above a person
+ *
+ * Generation 1 is parents, 2 grandparents, and so on; each expects
+ * 2^generation people. Ancestors are collected level by level, so someone
+ * reached through two lines (pedigree collapse) counts once per level.
+ * Percentages are rounded to one decimal and capped at 100 (adoptive
+ * parents can push a level past its expected size).
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} personId - Subject person ID
+ * @param {number} generations - Number of generations above the subject
+ * @returns {Array<Object>} [{ generation, expected, found, percent }]
+ *
+ * @example
+ * computeAncestorCompleteness(graph, 1, 2)
+ * // [{ generation: 1, expected: 2, found: 2, percent: 100 }, { generation: 2, expected: 4, found: 3, percent: 75 }]
+ */
+export function computeAncestorCompleteness(graph, personId, generations) {
+  const levels = []
+  let frontier = new Set([personId])
+
+  for (let generation = 1; generation <= generations; generation++) {
+    const next = new Set()
+    for (const id of frontier) {
+      for (const parent of graph.parents.get(id) || []) {
+        next.add(parent.id)
+      }
+    }
+
+    const expected = 2 ** generation
+    levels.push({
+      generation,
+      expected,
+      found: next.size,
+      percent: Math.min(100, Math.round((next.size / expected) * 1000) / 10)
+    })
+
+    frontier = next
+  }
+
+  return levels
+}
+
+/**
+ * Computes the share of a person's ancestry each ancestor theoretically contributes
+ *
+ * Each path to an ancestor contributes 1/2^generations (a parent 1/2, a
+ * grandparent 1/4), and the paths are summed, so an ancestor reached
+ * through two lines (pedigree collapse) contributes more than others of
+ * their generation. Walks at most one generation per person in the graph,
+ * which bounds the work if bad data contains a parent cycle.
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} personId - Subject person ID
+ * @returns {Array<{personId: number, contribution: number, generation: number, paths: number}>}
+ *   One entry per ancestor, where generation is the nearest one (1 = parent) and paths counts
+ *   the lines reaching them; ordered by contribution (largest first), generation, then person ID
+ *
+ * @example
+ * // Double first cousins' shared grandparents through both parents:
+ * computeAncestorContributions(graph, 5)
+ * // [..., { personId: 1, contribution: 0.5, generation: 2, paths: 2 }, ...]
+ */
+export function computeAncestorContributions(graph, personId) {
+  const ancestors = new Map()
+  // Frontier maps each person reached at this generation to the number of paths reaching them
+  let frontier = new Map([[personId, 1]])
+
+  for (let generation = 1; frontier.size > 0 && generation <= graph.people.size; generation++) {
+    const next = new Map()
+    for (const [id, paths] of frontier) {
+      for (const parent of graph.parents.get(id) || []) {
+        next.set(parent.id, (next.get(parent.id) || 0) + paths)
+      }
+    }
+
+    for (const [id, paths] of next) {
+      const entry = ancestors.get(id) || { personId: id, contribution: 0, generation, paths: 0 }
+      entry.contribution += paths / 2 ** generation
+      entry.paths += paths
+      ancestors.set(id, entry)
+    }
+
+    frontier = next
+  }
+
+  return [...ancestors.values()].sort((a, b) =>
+    b.contribution - a.contribution || a.generation - b.generation || a.personId - b.personId
+  )
+}
+
+/**
+ * Escapes text for inclusion in SVG/XML content
+ *
+ * @param {string} value - Raw text
+ * @returns {string} Escaped text
+ */
+function escapeXml(value) {
+  return String(value)
+    .replace(/&/g, '&amp;')
+    .replace(/</g, '&lt;')
+    .replace(/>/g, '&gt;')
+    .replace(/"/g, '&quot;')
+    .replace(/'/g, '&apos;')
+}
+
+/**
+ * Formats the life span line shown under a name (e.g. "1900 – 1980")
+ *
+ * @param {Object} person - Person record
+ * @returns {string} Life span or empty string when no dates are known
+ */
+function lifeSpan(person) {
+  const birth = person.birthDate ? person.birthDate.slice(0, 4) : ''
+  const death = person.deathDate ? person.deathDate.slice(0, 4) : ''
+
+  if (!birth && !death) return ''
+  return `${birth} – ${death}`.trim()
+}
+
+/**
+ * Renders a pedigree chart as a standalone SVG document
+ *
+ * The subject is drawn on the left with each earlier generation in a
+ * column to the right. Every known person gets one box (class "person")
+ * and a connecting line to the child they descend into.
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {Map<number, number>} slots - Ahnentafel from buildAhnentafel
+ * @param {number} generations - Generations in the chart, counting the subject
+ * @returns {string} SVG markup
+ */
+export function renderPedigreeSvg(graph, slots, generations) {
+  const rows = 2 ** (generations - 1)
+  const width = MARGIN * 2 + generations * BOX_WIDTH + (generations - 1) * COLUMN_GAP
+  const height = MARGIN * 2 + rows * ROW_HEIGHT
+
+  // Box position for an Ahnentafel number: column by generation, centered in its share of rows
+  const position = (number) => {
+    const generation = ahnentafelGeneration(number)
+    const index = number - 2 ** generation
+    const share = (rows * ROW_HEIGHT) / 2 ** generation
+    return {
+      x: MARGIN + generation * (BOX_WIDTH + COLUMN_GAP),
+      y: MARGIN + index * share + share / 2 - BOX_HEIGHT / 2
+    }
+  }
+
+  const lines = []
+  const boxes = []
+  const numbers = [...slots.keys()].sort((a, b) => a - b)
+
+  for (const number of numbers) {
+    const person = graph.people.get(slots.get(number))
+    const { x, y } = position(number)
+
+    if (number > 1) {
+      const child = position(Math.floor(number / 2))
+      const childX = child.x + BOX_WIDTH
+      const childY = child.y + BOX_HEIGHT / 2
+      const midX = childX + COLUMN_GAP / 2
+      const parentY = y + BOX_HEIGHT / 2
+      lines.push(
+        `<polyline class="connector" points="${childX},${childY} ${midX},${childY} ${midX},${parentY} ${x},${parentY}" fill="none" stroke="#555" />`
+      )
+    }
+
+    const name = [person.firstName, person.lastName].filter(Boolean).join(' ')
+    const dates = lifeSpan(person)
+    boxes.push(
+      [
+        `<g class="person" data-ahnentafel="${number}" data-person-id="${person.id}">`,
+        `<rect x="${x}" y="${y}" width="${BOX_WIDTH}" height="${BOX_HEIGHT}" rx="4" fill="#fff" stroke="#333" />`,
+        `<text x="${x + 8}" y="${y + 18}" font-size="13" font-weight="bold">${escapeXml(name)}</text>`,
+        dates ? `<text x="${x + 8}" y="${y + 35}" font-size="11">${escapeXml(dates)}</text>` : '',
+        '</g>'
+      ].join('')
+    )
+  }
+
+  return [
+    `<svg xmlns="http://www.w3.org/2000/svg" width="${width}" height="${height}" viewBox="0 0 ${width} ${height}" font-family="sans-serif">`,
+    ...lines,
+    ...boxes,
+    '</svg>'
+  ].join('\n')
+}
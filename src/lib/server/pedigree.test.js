@@ -0,0 +1,222 @@
+/**
+ * Unit tests for Pedigree Module
+ */
+
+import { describe, it, expect } from 'vitest'
+import { buildFamilyGraph } from './familyGraph.js'
+import {
+  ahnentafelGeneration,
+  buildAhnentafel,
+  MAX_AHNENTAFEL_GENERATIONS,
+  findPedigreeGaps,
+  planPedigreeSkeleton,
+  computeAncestorCompleteness,
+  computeAncestorContributions,
+  renderPedigreeSvg
+} from './pedigree.js'
+
+function person(id, firstName, gender = null) {
+  return { id, firstName, lastName: 'Test', gender }
+}
+
+function parentOf(person1Id, person2Id, parentRole) {
+  return { person1Id, person2Id, type: 'parentOf', parentRole }
+}
+
+describe('ahnentafelGeneration', () => {
+  it('should map Ahnentafel numbers to generations', () => {
+    expect(ahnentafelGeneration(1)).toBe(0)
+    expect(ahnentafelGeneration(2)).toBe(1)
+    expect(ahnentafelGeneration(3)).toBe(1)
+    expect(ahnentafelGeneration(7)).toBe(2)
+    expect(ahnentafelGeneration(8)).toBe(3)
+  })
+})
+
+describe('buildAhnentafel', () => {
+  const graph = buildFamilyGraph(
+    [
+      person(1, 'Me'), person(2, 'Dad', 'male'), person(3, 'Mom', 'female'),
+      person(4, 'Grandpa', 'male'), person(5, 'Grandma', 'female'), person(6, 'GreatGrandpa', 'male')
+    ],
+    [
+      parentOf(2, 1, 'father'), parentOf(3, 1, 'mother'),
+      parentOf(4, 3, null), parentOf(5, 3, null),
+      parentOf(6, 5, 'father')
+    ]
+  )
+
+  it('should number father as 2n and mother as 2n + 1', () => {
+    const slots = buildAhnentafel(graph, 1, 4)
+
+    expect([...slots.entries()]).toEqual([
+      [1, 1], [2, 2], [3, 3], [6, 4], [7, 5], [14, 6]
+    ])
+  })
+
+  it('should place the biological father ahead of an adoptive one', () => {
+    const adopted = buildFamilyGraph(
+      [person(1, 'Me'), person(2, 'Adoptive Dad', 'male'), person(3, 'Dad', 'male'), person(4, 'Grandpa', 'male')],
+      [
+        { ...parentOf(2, 1, 'father'), relationKind: 'adoptive' },
+        parentOf(3, 1, 'father'),
+        parentOf(4, 3, 'father')
+      ]
+    )
+
+    const slots = buildAhnentafel(adopted, 1, 3)
+
+    expect([...slots.entries()]).toEqual([[1, 1], [2, 3], [4, 4]])
+  })
+
+  it('should fall back to an adoptive father when the biological one is unknown', () => {
+    const adopted = buildFamilyGraph(
+      [person(1, 'Me'), person(2, 'Adoptive Dad', 'male')],
+      [{ ...parentOf(2, 1, 'father'), relationKind: 'adoptive' }]
+    )
+
+    expect(buildAhnentafel(adopted, 1, 2).get(2)).toBe(2)
+  })
+
+  it('should stop at the requested number of generations', () => {
+    const slots = buildAhnentafel(graph, 1, 3)
+
+    expect([...slots.keys()]).toEqual([1, 2, 3, 6, 7])
+  })
+
+  it('should return empty map for unknown person', () => {
+    expect(buildAhnentafel(graph, 99, 4).size).toBe(0)
+  })
+
+  it('should include every known ancestor at the generation limit', () => {
+    const slots = buildAhnentafel(graph, 1, MAX_AHNENTAFEL_GENERATIONS)
+
+    expect([...slots.keys()]).toEqual([1, 2, 3, 6, 7, 14])
+  })
+})
+
+describe('findPedigreeGaps', () => {
+  it('should list empty slots per generation', () => {
+    const slots = new Map([[1, 1], [2, 2], [3, 3], [6, 4], [7, 5], [14, 6]])
+
+    expect(findPedigreeGaps(slots, 4)).toEqual([
+      { generation: 1, expected: 2, known: 2, missing: [] },
+      { generation: 2, expected: 4, known: 2, missing: [4, 5] },
+      { generation: 3, expected: 8, known: 1, missing: [8, 9, 10, 11, 12, 13, 15] }
+    ])
+  })
+
+  it('should return no generations for the subject alone', () => {
+    expect(findPedigreeGaps(new Map([[1, 1]]), 1)).toEqual([])
+  })
+})
+
+describe('planPedigreeSkeleton', () => {
+  it('should fill every empty slot with a father or mother of its child slot', () => {
+    const slots = new Map([[1, 1], [3, 3]])
+
+    expect(planPedigreeSkeleton(slots, 3)).toEqual([
+      { number: 2, childNumber: 1, role: 'father', gender: 'male' },
+      { number: 4, childNumber: 2, role: 'father', gender: 'male' },
+      { number: 5, childNumber: 2, role: 'mother', gender: 'female' },
+      { number: 6, childNumber: 3, role: 'father', gender: 'male' },
+      { number: 7, childNumber: 3, role: 'mother', gender: 'female' }
+    ])
+  })
+
+  it('should plan nothing for a complete pedigree', () => {
+    expect(planPedigreeSkeleton(new Map([[1, 1], [2, 2], [3, 3]]), 2)).toEqual([])
+  })
+})
+
+describe('computeAncestorCompleteness', () => {
+  const graph = buildFamilyGraph(
+    [
+      person(1, 'Me'), person(2, 'Dad', 'male'), person(3, 'Mom', 'female'),
+      person(4, 'Grandpa', 'male'), person(5, 'Grandma', 'female'), person(6, 'GreatGrandpa', 'male')
+    ],
+    [
+      parentOf(2, 1, 'father'), parentOf(3, 1, 'mother'),
+      parentOf(4, 3, 'father'), parentOf(5, 3, 'mother'),
+      parentOf(6, 5, 'father')
+    ]
+  )
+
+  it('should count found ancestors against 2^generation', () => {
+    expect(computeAncestorCompleteness(graph, 1, 4)).toEqual([
+      { generation: 1, expected: 2, found: 2, percent: 100 },
+      { generation: 2, expected: 4, found: 2, percent: 50 },
+      { generation: 3, expected: 8, found: 1, percent: 12.5 },
+      { generation: 4, expected: 16, found: 0, percent: 0 }
+    ])
+  })
+
+  it('should count a shared ancestor once per generation', () => {
+    // Both of Kid's parents have Founder as their father
+    const collapsed = buildFamilyGraph(
+      [person(1, 'Kid'), person(2, 'Dad'), person(3, 'Mom'), person(4, 'Founder')],
+      [parentOf(2, 1, 'father'), parentOf(3, 1, 'mother'), parentOf(4, 2, 'father'), parentOf(4, 3, 'father')]
+    )
+
+    expect(computeAncestorCompleteness(collapsed, 1, 2)[1]).toEqual(
+      { generation: 2, expected: 4, found: 1, percent: 25 }
+    )
+  })
+})
+
+describe('computeAncestorContributions', () => {
+  it('should halve the contribution with each generation', () => {
+    const graph = buildFamilyGraph(
+      [person(1, 'Me'), person(2, 'Dad'), person(3, 'Mom'), person(4, 'Grandpa')],
+      [parentOf(2, 1, 'father'), parentOf(3, 1, 'mother'), parentOf(4, 2, 'father')]
+    )
+
+    expect(computeAncestorContributions(graph, 1)).toEqual([
+      { personId: 2, contribution: 0.5, generation: 1, paths: 1 },
+      { personId: 3, contribution: 0.5, generation: 1, paths: 1 },
+      { personId: 4, contribution: 0.25, generation: 2, paths: 1 }
+    ])
+  })
+
+  it('should sum every path to a collapsed ancestor', () => {
+    // Kid's parents are half-siblings through Founder; Founder is reached twice
+    const collapsed = buildFamilyGraph(
+      [person(1, 'Kid'), person(2, 'Dad'), person(3, 'Mom'), person(4, 'Founder'), person(5, 'OtherGrandma')],
+      [
+        parentOf(2, 1, 'father'), parentOf(3, 1, 'mother'),
+        parentOf(4, 2, 'father'), parentOf(4, 3, 'father'), parentOf(5, 3, 'mother')
+      ]
+    )
+
+    const contributions = computeAncestorContributions(collapsed, 1)
+
+    expect(contributions.find((entry) => entry.personId === 4)).toEqual(
+      { personId: 4, contribution: 0.5, generation: 2, paths: 2 }
+    )
+    expect(contributions.find((entry) => entry.personId === 5).contribution).toBe(0.25)
+  })
+
+  it('should stop on a parent cycle', () => {
+    const cyclic = buildFamilyGraph(
+      [person(1, 'A'), person(2, 'B')],
+      [parentOf(2, 1, 'father'), parentOf(1, 2, 'father')]
+    )
+
+    expect(computeAncestorContributions(cyclic, 1).map((entry) => entry.personId)).toEqual([2, 1])
+  })
+})
+
+describe('renderPedigreeSvg', () => {
+  it('should draw one box per known person and escape names', () => {
+    const graph = buildFamilyGraph(
+      [person(1, 'Tom & Jerry'), person(2, 'Dad', 'male')],
+      [parentOf(2, 1, 'father')]
+    )
+    const svg = renderPedigreeSvg(graph, buildAhnentafel(graph, 1, 4), 4)
+
+    expect(svg.startsWith('<svg')).toBe(true)
+    expect(svg).toContain('Tom &amp; Jerry')
+    expect(svg.match(/class="person"/g)).toHaveLength(2)
+    expect(svg.match(/class="connector"/g)).toHaveLength(1)
+  })
+})
@@ -0,0 +1,88 @@
+/**
+ * People CSV Module
+ *
+ * Column layout shared by the people CSV export and import endpoints:
+ *
+ *   id,firstName,lastName,birthDate,deathDate,gender
+ *
+ * On import the id column is informational only (new IDs are assigned);
+ * it is used to recognise a header row, whose id cell is not numeric.
+ */
+
+import { parseCsvRecords } from './csv.js'
+import { validatePersonData } from './personHelpers.js'
+
+/**
+ * Column order of people CSV files
+ */
+export const PEOPLE_CSV_COLUMNS = ['id', 'firstName', 'lastName', 'birthDate', 'deathDate', 'gender']
+
+/**
+ * Maximum number of data rows in one import
+ */
+export const MAX_CSV_IMPORT_ROWS = 2000
+
+/**
+ * Parses and validates a people CSV file
+ *
+ * Blank lines are ignored. A first row whose id cell is not numeric is
+ * treated as a header and skipped. Each remaining row is either accepted
+ * (as person data ready for buildPersonInsertValues) or rejected with the
+ * line it starts on and the reason.
+ *
+ * @param {string} text - CSV content
+ * @returns {{rows: Array<{line: number, data: Object}>, rejected: Array<{line: number, error: string}>}}
+ */
+export function parsePeopleCsv(text) {
+  const records = parseCsvRecords(text).filter(
+    (record) => !(record.fields.length === 1 && record.fields[0].trim() === '')
+  )
+
+  if (records.length > 0 && !isNumericId(records[0].fields[0])) {
+    records.shift()
+  }
+
+  const rows = []
+  const rejected = []
+
+  for (const { line, fields } of records) {
+    if (fields.length !== PEOPLE_CSV_COLUMNS.length) {
+      rejected.push({
+        line,
+        error: `Expected ${PEOPLE_CSV_COLUMNS.length} columns but found ${fields.length}`
+      })
+      continue
+    }
+
+    if (!isNumericId(fields[0])) {
+      rejected.push({ line, error: 'id must be numeric or empty' })
+      continue
+    }
+
+    const data = {}
+    PEOPLE_CSV_COLUMNS.slice(1).forEach((column, index) => {
+      const value = fields[index + 1].trim()
+      data[column] = value === '' ? null : value
+    })
+
+    const validation = validatePersonData(data)
+    if (!validation.valid) {
+      rejected.push({ line, error: validation.error })
+      continue
+    }
+
+    rows.push({ line, data })
+  }
+
+  return { rows, rejected }
+}
+
+/**
+ * An id cell counts as numeric when it is empty or all digits
+ *
+ * @param {string} value - Raw id cell
+ * @returns {boolean}
+ */
+function isNumericId(value) {
+  return /^\d*$/.test(value.trim())
+}
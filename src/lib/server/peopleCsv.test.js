@@ -0,0 +1,51 @@
+/**
+ * Unit tests for People CSV Module
+ */
+
+import { describe, it, expect } from 'vitest'
+import { parsePeopleCsv } from './peopleCsv.js'
+
+describe('parsePeopleCsv', () => {
+  it('should skip a header row and map columns to person data', () => {
+    const { rows, rejected } = parsePeopleCsv(
+      'id,firstName,lastName,birthDate,deathDate,gender\n' +
+      '1,John,"Smith, Jr",1900-01-15,,Male\n'
+    )
+
+    expect(rejected).toEqual([])
+    expect(rows).toEqual([
+      {
+        line: 2,
+        data: { firstName: 'John', lastName: 'Smith, Jr', birthDate: '1900-01-15', deathDate: null, gender: 'Male' }
+      }
+    ])
+  })
+
+  it('should keep the first row when its id is numeric or empty', () => {
+    expect(parsePeopleCsv('7,John,Smith,,,\n').rows).toHaveLength(1)
+    expect(parsePeopleCsv(',John,Smith,,,\n').rows).toHaveLength(1)
+  })
+
+  it('should reject rows with the wrong column count, a bad id, or invalid data', () => {
+    const { rows, rejected } = parsePeopleCsv(
+      'id,firstName,lastName,birthDate,deathDate,gender\n' +
+      '1,John,Smith,,,\n' +
+      '2,Mary,Smith\n' +
+      'x3,Anne,Smith,,,\n' +
+      '4,,Smith,,,\n' +
+      '5,Tom,Smith,1900-02-30,,\n'
+    )
+
+    expect(rows.map((row) => row.line)).toEqual([2])
+    expect(rejected.map((row) => row.line)).toEqual([3, 4, 5, 6])
+    expect(rejected[0].error).toContain('Expected 6 columns')
+    expect(rejected[2].error).toContain('firstName')
+    expect(rejected[3].error).toContain('birthDate')
+  })
+
+  it('should ignore blank lines while keeping line numbers accurate', () => {
+    const { rows } = parsePeopleCsv('\n1,John,Smith,,,\n\n2,Mary,Smith,,,\n')
+
+    expect(rows.map((row) => row.line)).toEqual([2, 4])
+  })
+})
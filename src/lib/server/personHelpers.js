@@ -20,17 +20,24 @@ function toRFC3339(sqliteDateTime) {
 /**
  * Transforms a person database record to API response format
  * Converts snake_case column names to camelCase for consistency with frontend
- * Always includes all fields (including null values) for consistent API interface
+ * Always includes all fields (including null values) for consistent API interface,
+ * except birthPlace and deathPlace which are omitted when not recorded
  *
  * Issue #72: Now includes userId for multi-user support
  * Story #77: Now includes photoUrl for photo storage
  * Issue #121: Now includes birthSurname and nickname
+ * Now includes middleName, maidenName, and suffix
+ * Now includes birthDateQualifier
+ * Now includes birthPlace and deathPlace (omitted when null)
+ * Now includes updatedAt
+ * Now includes notable
+ * Now includes notes
  *
  * @param {Object} person - Person record from database
  * @returns {Object} Transformed person object
  */
 export function transformPersonToAPI(person) {
-  return {
+  const transformed = {
     id: person.id,
     firstName: person.firstName,
     lastName: person.lastName,
@@ -40,9 +47,26 @@ export function transformPersonToAPI(person) {
     photoUrl: person.photoUrl !== undefined ? person.photoUrl : null,
     birthSurname: person.birthSurname !== undefined ? person.birthSurname : null,
     nickname: person.nickname !== undefined ? person.nickname : null,
+    middleName: person.middleName !== undefined ? person.middleName : null,
+    maidenName: person.maidenName !== undefined ? person.maidenName : null,
+    suffix: person.suffix !== undefined ? person.suffix : null,
+    birthDateQualifier: person.birthDateQualifier !== undefined ? person.birthDateQualifier : null,
     createdAt: toRFC3339(person.createdAt),
+    updatedAt: toRFC3339(person.updatedAt),
+    notable: Boolean(person.notable),
+    notes: person.notes !== undefined ? person.notes : null,
     userId: person.userId
   }
+
+  if (person.birthPlace !== undefined && person.birthPlace !== null) {
+    transformed.birthPlace = person.birthPlace
+  }
+
+  if (person.deathPlace !== undefined && person.deathPlace !== null) {
+    transformed.deathPlace = person.deathPlace
+  }
+
+  return transformed
 }
 
 /**
@@ -55,6 +79,265 @@ export function transformPeopleToAPI(people) {
   return people.map(transformPersonToAPI)
 }
 
+/**
+ * Person fields that may be requested via the ?fields= projection parameter
+ * Keys are API (camelCase) names matching the people schema columns
+ */
+export const PROJECTABLE_PERSON_FIELDS = [
+  'id',
+  'firstName',
+  'lastName',
+  'birthDate',
+  'deathDate',
+  'gender',
+  'photoUrl',
+  'birthSurname',
+  'nickname',
+  'middleName',
+  'maidenName',
+  'suffix',
+  'birthDateQualifier',
+  'birthPlace',
+  'deathPlace',
+  'createdAt',
+  'updatedAt',
+  'notable',
+  'notes'
+]
+
+/**
+ * Person fields a client may set, e.g. in a PATCH /api/people/[id] body
+ * Keys are API (camelCase) names matching the people schema columns
+ */
+export const EDITABLE_PERSON_FIELDS = [
+  'firstName',
+  'lastName',
+  'birthDate',
+  'deathDate',
+  'gender',
+  'photoUrl',
+  'birthSurname',
+  'nickname',
+  'middleName',
+  'maidenName',
+  'suffix',
+  'birthDateQualifier',
+  'birthPlace',
+  'deathPlace',
+  'notable',
+  'notes'
+]
+
+/**
+ * Parses a comma-separated ?fields= parameter into a list of whitelisted fields
+ * Whitespace and duplicate names are ignored
+ *
+ * @param {string} fieldsParam - Raw parameter value (e.g., "id,firstName,lastName")
+ * @returns {Object} Result { valid: boolean, fields: Array<string>, error: string|null }
+ */
+export function parseFieldsParam(fieldsParam) {
+  const fields = [...new Set(
+    fieldsParam
+      .split(',')
+      .map((field) => field.trim())
+      .filter((field) => field !== '')
+  )]
+
+  if (fields.length === 0) {
+    return { valid: false, fields: [], error: 'fields parameter must list at least one field' }
+  }
+
+  const invalid = fields.filter((field) => !PROJECTABLE_PERSON_FIELDS.includes(field))
+  if (invalid.length > 0) {
+    return {
+      valid: false,
+      fields: [],
+      error: `Invalid field(s): ${invalid.join(', ')}. Allowed fields: ${PROJECTABLE_PERSON_FIELDS.join(', ')}`
+    }
+  }
+
+  return { valid: true, fields, error: null }
+}
+
+/**
+ * Transforms a partial person record to API format containing only the requested fields
+ * Applies the same value normalization as transformPersonToAPI
+ *
+ * @param {Object} person - Partial person record selected from database
+ * @param {Array<string>} fields - Whitelisted field names to include
+ * @returns {Object} Projected person object
+ */
+export function projectPersonToAPI(person, fields) {
+  const full = transformPersonToAPI(person)
+  const projected = {}
+
+  for (const field of fields) {
+    projected[field] = full[field] !== undefined ? full[field] : null
+  }
+
+  return projected
+}
+
+/**
+ * Qualifiers marking a birth date as approximate
+ */
+export const DATE_QUALIFIERS = ['about', 'before', 'after']
+
+/**
+ * Allowed gender values; blank or null means unspecified
+ * "unspecified" is still accepted for records created by the GEDCOM importer
+ */
+export const GENDERS = ['male', 'female', 'other', 'unknown', 'unspecified']
+
+/**
+ * Longest notes text accepted for a person
+ */
+export const MAX_NOTES_LENGTH = 10000
+
+/**
+ * Normalizes a gender value from a request body
+ * Strings are trimmed and lowercased ("Male" becomes "male"), blank values
+ * become null, and non-string values are returned unchanged for validation
+ *
+ * @param {*} value - Raw gender value
+ * @returns {*} Normalized gender, null, or the original non-string value
+ */
+export function normalizeGender(value) {
+  if (typeof value !== 'string') {
+    return value === undefined ? null : value
+  }
+
+  const normalized = value.trim().toLowerCase()
+  return normalized === '' ? null : normalized
+}
+
+/**
+ * Normalizes an optional free-text value (place, notes) from a request body
+ * Surrounding whitespace is trimmed and blank values become null;
+ * line breaks inside the text are kept
+ *
+ * @param {string|null|undefined} value - Raw text value
+ * @returns {string|null} Trimmed text or null
+ */
+export function normalizeText(value) {
+  if (typeof value !== 'string') {
+    return null
+  }
+
+  const trimmed = value.trim()
+  return trimmed === '' ? null : trimmed
+}
+
+/**
+ * Builds the values to insert for a new person from validated request data
+ * Optional fields default to null; gender, places, and notes are normalized
+ *
+ * @param {Object} data - Person data that passed validatePersonData
+ * @returns {Object} Insert values for the people table
+ */
+export function buildPersonInsertValues(data) {
+  return {
+    firstName: data.firstName,
+    lastName: data.lastName,
+    birthDate: data.birthDate || null,
+    deathDate: data.deathDate || null,
+    gender: normalizeGender(data.gender),
+    photoUrl: data.photoUrl || null,
+    birthSurname: data.birthSurname || null,
+    nickname: data.nickname || null,
+    middleName: data.middleName || null,
+    maidenName: data.maidenName || null,
+    suffix: data.suffix || null,
+    birthDateQualifier: data.birthDateQualifier || null,
+    birthPlace: normalizeText(data.birthPlace),
+    deathPlace: normalizeText(data.deathPlace),
+    notable: data.notable === true,
+    notes: normalizeText(data.notes)
+  }
+}
+
+/**
+ * People born more than this many years ago without a recorded
+ * death date are presumed deceased
+ */
+export const MAX_LIFESPAN_YEARS = 110
+
+/**
+ * Determines whether a person is presumed living
+ *
+ * A person is living when no death date is recorded and they were either
+ * born within MAX_LIFESPAN_YEARS of the reference date or have no birth date.
+ *
+ * @param {Object} person - Person record (database or API format)
+ * @param {Date} [today=new Date()] - Reference date
+ * @returns {boolean} True if the person is presumed living
+ */
+export function isPersonLiving(person, today = new Date()) {
+  if (person.deathDate) {
+    return false
+  }
+
+  if (!person.birthDate) {
+    return true
+  }
+
+  const birthYear = parseInt(person.birthDate.slice(0, 4), 10)
+  if (isNaN(birthYear)) {
+    return true
+  }
+
+  return today.getUTCFullYear() - birthYear <= MAX_LIFESPAN_YEARS
+}
+
+/**
+ * Computes a person's age in whole years
+ *
+ * Deceased people get their age at death; others their age on the
+ * reference date. Missing month or day parts are treated as unknown, so
+ * "1950" born and "2000-06-01" today gives 50 (year difference only).
+ *
+ * @param {Object} person - Person record (database or API format)
+ * @param {Date} [today=new Date()] - Reference date for people without a death date
+ * @returns {number|null} Age, or null without a birth date or for a living
+ *   person presumed deceased (see isPersonLiving)
+ */
+export function computeAge(person, today = new Date()) {
+  if (!person.birthDate) {
+    return null
+  }
+  if (!person.deathDate && !isPersonLiving(person, today)) {
+    return null
+  }
+
+  const end = person.deathDate || today.toISOString().slice(0, 10)
+  const [birthYear, birthMonth, birthDay] = person.birthDate.split('-').map(Number)
+  const [endYear, endMonth, endDay] = end.split('-').map(Number)
+
+  let age = endYear - birthYear
+  if (birthMonth && endMonth && birthDay && endDay) {
+    if (endMonth < birthMonth || (endMonth === birthMonth && endDay < birthDay)) age--
+  } else if (birthMonth && endMonth && endMonth < birthMonth) {
+    age--
+  }
+
+  return Number.isNaN(age) ? null : age
+}
+
+/**
+ * Checks that a value is an absolute http or https URL
+ *
+ * @param {string} value - Candidate URL
+ * @returns {boolean} True when the value parses as an http(s) URL with a host
+ */
+export function isHttpUrl(value) {
+  try {
+    const url = new URL(value)
+    return (url.protocol === 'http:' || url.protocol === 'https:') && url.hostname !== ''
+  } catch {
+    return false
+  }
+}
+
 /**
  * Validates and parses an ID parameter from URL
  *
@@ -75,7 +358,7 @@ export function parseId(id) {
  * @param {string} dateString - Date string to validate
  * @returns {boolean} True if valid YYYY-MM-DD format and valid calendar date
  */
-function isValidDate(dateString) {
+export function isValidDate(dateString) {
   if (!dateString) return true // Optional dates are allowed
 
   // Check format YYYY-MM-DD
@@ -97,7 +380,29 @@ function isValidDate(dateString) {
 }
 
 /**
- * Validates a name field (birthSurname or nickname) for allowed characters and length
+ * Validates a date as the database may hold it: a full YYYY-MM-DD date, or
+ * a year-only (YYYY) or month (YYYY-MM) date as stored by GEDCOM imports
+ *
+ * @param {string} dateString - Date string to validate
+ * @returns {boolean} True if empty, a valid full date, or a valid partial date
+ */
+export function isValidStoredDate(dateString) {
+  if (!dateString) return true
+
+  if (/^\d{4}$/.test(dateString)) return true
+
+  const monthMatch = /^\d{4}-(\d{2})$/.exec(dateString)
+  if (monthMatch) {
+    const month = Number(monthMatch[1])
+    return month >= 1 && month <= 12
+  }
+
+  return isValidDate(dateString)
+}
+
+/**
+ * Validates an optional name field (birthSurname, nickname, middleName, maidenName)
+ * for allowed characters and length
  * Issue #121: AC7 validation requirements
  *
  * @param {string} value - The name field value
@@ -130,16 +435,55 @@ function validateNameField(value, fieldName) {
   return { valid: true, error: null }
 }
 
+/**
+ * Validates an optional suffix such as "Jr.", "III", or "3rd"
+ * Same string and length rules as validateNameField, but digits are allowed
+ * for ordinal suffixes
+ *
+ * @param {string} value - The suffix value
+ * @returns {Object} Validation result { valid: boolean, error: string|null }
+ */
+function validateSuffix(value) {
+  if (value === null || value === undefined || value === '') {
+    return { valid: true, error: null }
+  }
+
+  if (typeof value !== 'string') {
+    return { valid: false, error: 'suffix must be a string' }
+  }
+
+  if (value.length > 255) {
+    return { valid: false, error: 'suffix must not exceed 255 characters' }
+  }
+
+  if (!/^[a-zA-ZÀ-ÿ0-9\s.]+$/.test(value)) {
+    return { valid: false, error: 'suffix can only contain letters, digits, spaces, and periods' }
+  }
+
+  return { valid: true, error: null }
+}
+
 /**
  * Validates person data for create/update operations
  *
- * Story #77: Added photoUrl validation
+ * Story #77: Added photoUrl validation (http/https URLs only; blank clears)
  * Issue #121: Added birthSurname and nickname validation with AC7 requirements
+ * Added middleName, maidenName, and suffix validation
+ * Added birthDateQualifier validation
+ * Added birthPlace and deathPlace validation
+ * Added notable validation
+ * Added notes validation
+ *
+ * With storedValues, values the database may already hold are accepted
+ * too (partial GEDCOM dates, photo URLs saved before the http rule), so
+ * anything exported from the tree can be imported again.
  *
  * @param {Object} data - Person data from request body
+ * @param {Object} [options]
+ * @param {boolean} [options.storedValues=false] - Accept stored partial dates and photo URLs
  * @returns {Object} Validation result { valid: boolean, error: string|null }
  */
-export function validatePersonData(data) {
+export function validatePersonData(data, { storedValues = false } = {}) {
   if (!data.firstName || typeof data.firstName !== 'string' || data.firstName.trim() === '') {
     return { valid: false, error: 'firstName is required and must be a non-empty string' }
   }
@@ -148,18 +492,19 @@ export function validatePersonData(data) {
     return { valid: false, error: 'lastName is required and must be a non-empty string' }
   }
 
-  // Validate birthDate format if provided
-  if (data.birthDate && !isValidDate(data.birthDate)) {
-    return { valid: false, error: 'birthDate must be in YYYY-MM-DD format and a valid calendar date' }
-  }
-
-  // Validate deathDate format if provided
-  if (data.deathDate && !isValidDate(data.deathDate)) {
-    return { valid: false, error: 'deathDate must be in YYYY-MM-DD format and a valid calendar date' }
+  // Validate birthDate and deathDate format if provided
+  for (const field of ['birthDate', 'deathDate']) {
+    if (!data[field]) continue
+    if (storedValues && !isValidStoredDate(data[field])) {
+      return { valid: false, error: `${field} must be in YYYY, YYYY-MM, or YYYY-MM-DD format and a valid calendar date` }
+    }
+    if (!storedValues && !isValidDate(data[field])) {
+      return { valid: false, error: `${field} must be in YYYY-MM-DD format and a valid calendar date` }
+    }
   }
 
-  // Validate deathDate is not before birthDate
-  if (data.birthDate && data.deathDate) {
+  // Validate deathDate is not before birthDate (full dates only)
+  if (data.birthDate && data.deathDate && isValidDate(data.birthDate) && isValidDate(data.deathDate)) {
     const birth = new Date(data.birthDate + 'T00:00:00Z')
     const death = new Date(data.deathDate + 'T00:00:00Z')
     if (death < birth) {
@@ -167,14 +512,51 @@ export function validatePersonData(data) {
     }
   }
 
-  // Validate gender if provided (must be lowercase)
-  if (data.gender !== undefined && data.gender !== null && data.gender !== '') {
-    const validGenders = ['male', 'female', 'other', 'unspecified']
-    if (typeof data.gender !== 'string') {
+  // Validate gender if provided (case-insensitive)
+  const gender = normalizeGender(data.gender)
+  if (gender !== null) {
+    if (typeof gender !== 'string') {
       return { valid: false, error: 'gender must be a string' }
     }
-    if (!validGenders.includes(data.gender)) {
-      return { valid: false, error: 'gender must be one of: male, female, other, unspecified (lowercase)' }
+    if (!GENDERS.includes(gender)) {
+      return { valid: false, error: `gender must be one of: ${GENDERS.join(', ')}, or empty` }
+    }
+  }
+
+  // Validate birthDateQualifier if provided
+  if (data.birthDateQualifier !== undefined && data.birthDateQualifier !== null && data.birthDateQualifier !== '') {
+    if (!DATE_QUALIFIERS.includes(data.birthDateQualifier)) {
+      return { valid: false, error: `birthDateQualifier must be one of: ${DATE_QUALIFIERS.join(', ')}` }
+    }
+    if (!data.birthDate) {
+      return { valid: false, error: 'birthDateQualifier requires a birthDate' }
+    }
+  }
+
+  // Validate birthPlace and deathPlace if provided
+  for (const field of ['birthPlace', 'deathPlace']) {
+    if (data[field] !== undefined && data[field] !== null) {
+      if (typeof data[field] !== 'string') {
+        return { valid: false, error: `${field} must be a string` }
+      }
+      if (data[field].trim().length > 255) {
+        return { valid: false, error: `${field} must not exceed 255 characters` }
+      }
+    }
+  }
+
+  // Validate notable if provided
+  if (data.notable !== undefined && data.notable !== null && typeof data.notable !== 'boolean') {
+    return { valid: false, error: 'notable must be a boolean' }
+  }
+
+  // Validate notes if provided
+  if (data.notes !== undefined && data.notes !== null) {
+    if (typeof data.notes !== 'string') {
+      return { valid: false, error: 'notes must be a string' }
+    }
+    if (data.notes.trim().length > MAX_NOTES_LENGTH) {
+      return { valid: false, error: `notes must not exceed ${MAX_NOTES_LENGTH} characters` }
     }
   }
 
@@ -183,6 +565,9 @@ export function validatePersonData(data) {
     if (typeof data.photoUrl !== 'string') {
       return { valid: false, error: 'photoUrl must be a string' }
     }
+    if (!storedValues && data.photoUrl !== '' && !isHttpUrl(data.photoUrl)) {
+      return { valid: false, error: 'photoUrl must be a valid http or https URL' }
+    }
   }
 
   // Validate birthSurname if provided (Issue #121: AC7)
@@ -201,5 +586,66 @@ export function validatePersonData(data) {
     }
   }
 
+  // Validate middleName and maidenName if provided
+  for (const field of ['middleName', 'maidenName']) {
+    const fieldValidation = validateNameField(data[field], field)
+    if (!fieldValidation.valid) {
+      return fieldValidation
+    }
+  }
+
+  // Validate suffix if provided (digits allowed for "2nd", "3rd")
+  const suffixValidation = validateSuffix(data.suffix)
+  if (!suffixValidation.valid) {
+    return suffixValidation
+  }
+
+  return { valid: true, error: null }
+}
+
+/**
+ * Validates a partial update (PATCH) against the stored person
+ *
+ * Only the changed fields are checked, so values stored under looser rules
+ * (e.g. GEDCOM year-only dates, older photo URLs) do not block unrelated
+ * edits. Cross-field rules use the stored value of the other field and run
+ * only when one of their fields changes.
+ *
+ * @param {Object} existing - Stored person row
+ * @param {Object} changes - Fields present in the request body
+ * @returns {Object} Validation result { valid: boolean, error: string|null }
+ */
+export function validatePersonPatch(existing, changes) {
+  const candidate = { firstName: existing.firstName, lastName: existing.lastName, ...changes }
+
+  // Without a birthDate change the qualifier is checked against the stored date below
+  const qualifierOnly = 'birthDateQualifier' in changes && !('birthDate' in changes)
+  if (qualifierOnly) {
+    delete candidate.birthDateQualifier
+  }
+
+  const validation = validatePersonData(candidate)
+  if (!validation.valid) {
+    return validation
+  }
+
+  if (qualifierOnly && changes.birthDateQualifier) {
+    if (!DATE_QUALIFIERS.includes(changes.birthDateQualifier)) {
+      return { valid: false, error: `birthDateQualifier must be one of: ${DATE_QUALIFIERS.join(', ')}` }
+    }
+    if (!existing.birthDate) {
+      return { valid: false, error: 'birthDateQualifier requires a birthDate' }
+    }
+  }
+
+  // Compare with the stored counterpart when only one date changes
+  if ('birthDate' in changes !== 'deathDate' in changes) {
+    const birthDate = 'birthDate' in changes ? changes.birthDate : existing.birthDate
+    const deathDate = 'deathDate' in changes ? changes.deathDate : existing.deathDate
+    if (birthDate && deathDate && isValidDate(birthDate) && isValidDate(deathDate) && deathDate < birthDate) {
+      return { valid: false, error: 'deathDate cannot be before birthDate' }
+    }
+  }
+
   return { valid: true, error: null }
 }
@@ -1,5 +1,14 @@
 import { describe, it, expect } from 'vitest'
-import { validatePersonData } from './personHelpers.js'
+import {
+  validatePersonData,
+  validatePersonPatch,
+  isPersonLiving,
+  computeAge,
+  normalizeGender,
+  normalizeText,
+  isHttpUrl,
+  MAX_NOTES_LENGTH
+} from './personHelpers.js'
 
 describe('Person Data Validation - Birth Surname and Nickname (AC7)', () => {
   describe('Birth Surname Validation', () => {
@@ -292,3 +301,152 @@ describe('Person Data Validation - Birth Surname and Nickname (AC7)', () => {
     })
   })
 })
+
+describe('isPersonLiving', () => {
+  const today = new Date('2026-01-01T00:00:00Z')
+
+  it('should treat a person with a death date as deceased', () => {
+    expect(isPersonLiving({ birthDate: '1990-01-01', deathDate: '2020-01-01' }, today)).toBe(false)
+  })
+
+  it('should treat a person born beyond the maximum lifespan as deceased', () => {
+    expect(isPersonLiving({ birthDate: '1900-01-01', deathDate: null }, today)).toBe(false)
+  })
+
+  it('should treat a recently born person or one without dates as living', () => {
+    expect(isPersonLiving({ birthDate: '1950-06-01', deathDate: null }, today)).toBe(true)
+    expect(isPersonLiving({ birthDate: null, deathDate: null }, today)).toBe(true)
+  })
+})
+
+describe('computeAge', () => {
+  const today = new Date('2026-01-01T00:00:00Z')
+
+  it('should compute age at death for deceased people', () => {
+    expect(computeAge({ birthDate: '1900-05-10', deathDate: '1950-05-09' }, today)).toBe(49)
+    expect(computeAge({ birthDate: '1900-05-10', deathDate: '1950-05-10' }, today)).toBe(50)
+  })
+
+  it('should compute current age for living people', () => {
+    expect(computeAge({ birthDate: '1990-12-31', deathDate: null }, today)).toBe(35)
+    expect(computeAge({ birthDate: '1990', deathDate: null }, today)).toBe(36)
+  })
+
+  it('should return null without a birth date or when presumed deceased', () => {
+    expect(computeAge({ birthDate: null, deathDate: null }, today)).toBeNull()
+    expect(computeAge({ birthDate: '1800-01-01', deathDate: null }, today)).toBeNull()
+  })
+})
+
+describe('normalizeGender', () => {
+  it('should lowercase and trim gender strings', () => {
+    expect(normalizeGender('Male')).toBe('male')
+    expect(normalizeGender(' UNKNOWN ')).toBe('unknown')
+  })
+
+  it('should treat blank and missing values as unspecified', () => {
+    expect(normalizeGender('')).toBeNull()
+    expect(normalizeGender('  ')).toBeNull()
+    expect(normalizeGender(null)).toBeNull()
+    expect(normalizeGender(undefined)).toBeNull()
+  })
+
+  it('should pass non-string values through for validation to reject', () => {
+    expect(normalizeGender(1)).toBe(1)
+    expect(validatePersonData({ firstName: 'A', lastName: 'B', gender: 1 }).valid).toBe(false)
+  })
+})
+
+describe('isHttpUrl', () => {
+  it('should accept absolute http and https URLs', () => {
+    expect(isHttpUrl('https://example.com/photo.jpg')).toBe(true)
+    expect(isHttpUrl('http://localhost:5173/photo.png')).toBe(true)
+  })
+
+  it('should reject other schemes and relative or malformed URLs', () => {
+    expect(isHttpUrl('ftp://example.com/photo.jpg')).toBe(false)
+    expect(isHttpUrl('data:image/png;base64,AAAA')).toBe(false)
+    expect(isHttpUrl('/photos/john.jpg')).toBe(false)
+    expect(isHttpUrl('not a url')).toBe(false)
+  })
+})
+
+describe('notes', () => {
+  it('should trim notes and turn blank notes into null', () => {
+    expect(normalizeText('  Line one\nLine two \n')).toBe('Line one\nLine two')
+    expect(normalizeText('   ')).toBeNull()
+    expect(normalizeText(undefined)).toBeNull()
+  })
+
+  it('should trim places with the same helper', () => {
+    expect(normalizeText('  Boston, MA ')).toBe('Boston, MA')
+    expect(normalizeText('')).toBeNull()
+  })
+
+  it('should reject notes over the length limit', () => {
+    const result = validatePersonData({ firstName: 'A', lastName: 'B', notes: 'x'.repeat(MAX_NOTES_LENGTH + 1) })
+
+    expect(result).toEqual({ valid: false, error: `notes must not exceed ${MAX_NOTES_LENGTH} characters` })
+  })
+})
+
+describe('validatePersonPatch', () => {
+  const imported = { firstName: 'Ada', lastName: 'Gray', birthDate: '1950', deathDate: null, photoUrl: 'ada.jpg' }
+
+  it('should ignore stored values that fail the current rules', () => {
+    expect(validatePersonPatch(imported, { notes: 'x' })).toEqual({ valid: true, error: null })
+  })
+
+  it('should validate the changed fields', () => {
+    expect(validatePersonPatch(imported, { photoUrl: 'ada.jpg' }).valid).toBe(false)
+    expect(validatePersonPatch(imported, { firstName: '' }).valid).toBe(false)
+  })
+
+  it('should check death before birth against the stored date when one date changes', () => {
+    const stored = { ...imported, birthDate: '1950-06-01' }
+
+    expect(validatePersonPatch(stored, { deathDate: '1949-01-01' }).error).toBe('deathDate cannot be before birthDate')
+    expect(validatePersonPatch(stored, { deathDate: '2001-01-01' }).valid).toBe(true)
+    expect(validatePersonPatch(imported, { deathDate: '1949-01-01' }).valid).toBe(true)
+  })
+
+  it('should check a qualifier against the stored birth date', () => {
+    expect(validatePersonPatch(imported, { birthDateQualifier: 'about' }).valid).toBe(true)
+    expect(validatePersonPatch({ ...imported, birthDate: null }, { birthDateQualifier: 'about' }).error)
+      .toBe('birthDateQualifier requires a birthDate')
+    expect(validatePersonPatch(imported, { birthDateQualifier: 'circa' }).valid).toBe(false)
+  })
+})
+
+describe('validatePersonData with storedValues', () => {
+  const base = { firstName: 'Ada', lastName: 'Gray' }
+
+  it('should accept partial dates as stored by GEDCOM imports', () => {
+    expect(validatePersonData({ ...base, birthDate: '1950', deathDate: '2001-03' }, { storedValues: true }).valid).toBe(true)
+    expect(validatePersonData({ ...base, birthDate: '1950' }).valid).toBe(false)
+  })
+
+  it('should still reject malformed dates and impossible months', () => {
+    expect(validatePersonData({ ...base, birthDate: '1950-13' }, { storedValues: true }).valid).toBe(false)
+    expect(validatePersonData({ ...base, birthDate: '3 JAN 1950' }, { storedValues: true }).valid).toBe(false)
+  })
+
+  it('should accept photo URLs saved before the http rule', () => {
+    expect(validatePersonData({ ...base, photoUrl: 'photos/ada.jpg' }, { storedValues: true }).valid).toBe(true)
+  })
+})
+
+describe('suffix', () => {
+  const base = { firstName: 'John', lastName: 'Doe' }
+
+  it('should accept ordinal and abbreviated suffixes', () => {
+    for (const suffix of ['Jr.', 'Sr.', 'III', '2nd', '3rd', '4th']) {
+      expect(validatePersonData({ ...base, suffix }).valid).toBe(true)
+    }
+  })
+
+  it('should reject other characters', () => {
+    expect(validatePersonData({ ...base, suffix: '#3' }).error)
+      .toBe('suffix can only contain letters, digits, spaces, and periods')
+  })
+})
@@ -6,8 +6,10 @@
  */
 
 import { people, relationships } from '../db/schema.js'
-import { eq, or, and } from 'drizzle-orm'
+import { eq, or, and, isNull } from 'drizzle-orm'
 import { selectBestValue } from './mergePreview.js'
+import { transformPersonToAPI } from './personHelpers.js'
+import { recordAudit, AUDIT_ENTITY_TYPES, AUDIT_ACTIONS } from './auditLog.js'
 
 /**
  * Executes a merge operation within an atomic transaction
@@ -17,7 +19,8 @@ import { selectBestValue } from './mergePreview.js'
  * 2. Update target person fields (merged values)
  * 3. Transfer relationships (deduplicate)
  * 4. Delete source person (CASCADE removes old relationships)
- * 5. Return merge summary
+ * 5. Record a merge audit entry on the target person
+ * 6. Return merge summary
  *
  * @param {number} sourceId - ID of source person (will be deleted)
  * @param {number} targetId - ID of target person (will receive merged data)
@@ -35,12 +38,12 @@ export async function executeMerge(sourceId, targetId, db) {
     // Step 1: Load both people
     const source = tx.select()
       .from(people)
-      .where(eq(people.id, sourceId))
+      .where(and(eq(people.id, sourceId), isNull(people.deletedAt)))
       .get()
 
     const target = tx.select()
       .from(people)
-      .where(eq(people.id, targetId))
+      .where(and(eq(people.id, targetId), isNull(people.deletedAt)))
       .get()
 
     // Validate existence
@@ -78,7 +81,14 @@ export async function executeMerge(sourceId, targetId, db) {
       gender: selectBestValue(source.gender, target.gender),
       photoUrl: selectBestValue(source.photoUrl, target.photoUrl),
       birthSurname: selectBestValue(source.birthSurname, target.birthSurname),
-      nickname: selectBestValue(source.nickname, target.nickname)
+      nickname: selectBestValue(source.nickname, target.nickname),
+      middleName: selectBestValue(source.middleName, target.middleName),
+      maidenName: selectBestValue(source.maidenName, target.maidenName),
+      suffix: selectBestValue(source.suffix, target.suffix),
+      birthPlace: selectBestValue(source.birthPlace, target.birthPlace),
+      deathPlace: selectBestValue(source.deathPlace, target.deathPlace),
+      notable: Boolean(source.notable || target.notable),
+      notes: selectBestValue(source.notes, target.notes)
     }
 
     // Step 6: Update target person with merged data
@@ -166,7 +176,11 @@ export async function executeMerge(sourceId, targetId, db) {
           person1Id: newPerson1Id,
           person2Id: newPerson2Id,
           type: rel.type,
-          parentRole: rel.parentRole
+          parentRole: rel.parentRole,
+          relationKind: rel.relationKind,
+          startDate: rel.startDate,
+          endDate: rel.endDate,
+          sortOrder: rel.sortOrder
         }).run()
         relationshipsTransferred++
       }
@@ -177,6 +191,13 @@ export async function executeMerge(sourceId, targetId, db) {
       .where(eq(people.id, sourceId))
       .run()
 
+    recordAudit(tx, AUDIT_ENTITY_TYPES.person, targetId, AUDIT_ACTIONS.merge, {
+      source: transformPersonToAPI(source),
+      before: transformPersonToAPI(target),
+      after: transformPersonToAPI({ ...target, ...mergedData }),
+      relationshipsTransferred
+    })
+
     // Step 8: Return merge summary
     return {
       success: true,
@@ -135,6 +135,84 @@ describe('executeMerge', () => {
       expect(childRel.parentRole).toBe('father')
     })
 
+    it('should keep the relation kind of transferred parent links', async () => {
+      const source = await db.insert(people).values({ firstName: 'John', lastName: 'Smith' }).returning().get()
+      const target = await db.insert(people).values({ firstName: 'John', lastName: 'Smith' }).returning().get()
+      const adoptiveMother = await db.insert(people).values({
+        firstName: 'Ruth',
+        lastName: 'Smith',
+        gender: 'female'
+      }).returning().get()
+
+      await db.insert(relationships).values({
+        person1Id: adoptiveMother.id,
+        person2Id: source.id,
+        type: 'parentOf',
+        parentRole: 'mother',
+        relationKind: 'adoptive'
+      })
+
+      await executeMerge(source.id, target.id, db)
+
+      const transferred = await db.select()
+        .from(relationships)
+        .where(eq(relationships.person2Id, target.id))
+        .all()
+
+      expect(transferred).toHaveLength(1)
+      expect(transferred[0]).toMatchObject({
+        person1Id: adoptiveMother.id,
+        parentRole: 'mother',
+        relationKind: 'adoptive'
+      })
+    })
+
+    it('should keep marriage and divorce dates of transferred spouse links', async () => {
+      const source = await db.insert(people).values({ firstName: 'John', lastName: 'Smith' }).returning().get()
+      const target = await db.insert(people).values({ firstName: 'John', lastName: 'Smith' }).returning().get()
+      const wife = await db.insert(people).values({ firstName: 'Mary', lastName: 'Jones' }).returning().get()
+
+      await db.insert(relationships).values({
+        person1Id: source.id,
+        person2Id: wife.id,
+        type: 'spouse',
+        startDate: '1950-06-10',
+        endDate: '1962-01-31'
+      })
+
+      await executeMerge(source.id, target.id, db)
+
+      const marriage = await db.select()
+        .from(relationships)
+        .where(and(eq(relationships.person1Id, target.id), eq(relationships.type, 'spouse')))
+        .get()
+
+      expect(marriage).toMatchObject({ person2Id: wife.id, startDate: '1950-06-10', endDate: '1962-01-31' })
+    })
+
+    it('should keep the child order of transferred parent links', async () => {
+      const source = await db.insert(people).values({ firstName: 'John', lastName: 'Smith' }).returning().get()
+      const target = await db.insert(people).values({ firstName: 'John', lastName: 'Smith' }).returning().get()
+      const child = await db.insert(people).values({ firstName: 'Jane', lastName: 'Smith' }).returning().get()
+
+      await db.insert(relationships).values({
+        person1Id: source.id,
+        person2Id: child.id,
+        type: 'parentOf',
+        parentRole: 'father',
+        sortOrder: 3
+      })
+
+      await executeMerge(source.id, target.id, db)
+
+      const transferred = await db.select()
+        .from(relationships)
+        .where(eq(relationships.person1Id, target.id))
+        .get()
+
+      expect(transferred).toMatchObject({ person2Id: child.id, sortOrder: 3 })
+    })
+
     it('should deduplicate relationships during transfer', async () => {
       const source = await db.insert(people).values({
         firstName: 'John',
@@ -0,0 +1,71 @@
+/**
+ * Person Quality Module
+ *
+ * Scores how well a person is documented for profile badges. Each
+ * indicator is a yes/no check; the share of checks passed becomes a
+ * letter grade from A (everything recorded) to F (almost nothing).
+ *
+ * Sources are not stored in this app yet, so there is no source indicator.
+ */
+
+import { isPersonLiving } from './personHelpers.js'
+
+/**
+ * Lowest share of indicators passed for each grade, best grade first
+ */
+export const QUALITY_GRADES = [
+  { grade: 'A', minimum: 1 },
+  { grade: 'B', minimum: 0.75 },
+  { grade: 'C', minimum: 0.5 },
+  { grade: 'D', minimum: 0.25 },
+  { grade: 'F', minimum: 0 }
+]
+
+/**
+ * Converts the share of indicators passed to a letter grade
+ *
+ * @param {number} score - Share passed, from 0 to 1
+ * @returns {string} "A" through "F" (there is no "E")
+ */
+export function gradeForScore(score) {
+  return QUALITY_GRADES.find((entry) => score >= entry.minimum).grade
+}
+
+/**
+ * Checks a person's data quality indicators
+ *
+ * A person without a death date only counts as presumed living when their
+ * birth date shows they could still be alive; with no dates at all their
+ * status is simply unknown.
+ *
+ * @param {Object} person - Person record (database or API format)
+ * @param {number} parentCount - Number of distinct parents recorded
+ * @param {Date} [today=new Date()] - Reference date for presumed living
+ * @returns {{indicators: Object<string, boolean>, passed: number, total: number, grade: string}}
+ *   indicators: hasBirthDate, hasDeathDateOrPresumedLiving, hasBothParents, hasGender
+ *
+ * @example
+ * assessPersonQuality({ birthDate: '1990-01-01', gender: 'female' }, 2)
+ * // { indicators: { hasBirthDate: true, ... }, passed: 4, total: 4, grade: 'A' }
+ */
+export function assessPersonQuality(person, parentCount, today = new Date()) {
+  const gender = person.gender ? String(person.gender).toLowerCase() : null
+
+  const indicators = {
+    hasBirthDate: Boolean(person.birthDate),
+    hasDeathDateOrPresumedLiving: Boolean(person.deathDate) ||
+      (Boolean(person.birthDate) && isPersonLiving(person, today)),
+    hasBothParents: parentCount >= 2,
+    hasGender: gender !== null && gender !== 'unknown' && gender !== 'unspecified'
+  }
+
+  const values = Object.values(indicators)
+  const passed = values.filter(Boolean).length
+
+  return {
+    indicators,
+    passed,
+    total: values.length,
+    grade: gradeForScore(passed / values.length)
+  }
+}
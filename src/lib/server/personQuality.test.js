@@ -0,0 +1,53 @@
+/**
+ * Unit tests for Person Quality Module
+ */
+
+import { describe, it, expect } from 'vitest'
+import { assessPersonQuality, gradeForScore } from './personQuality.js'
+
+const today = new Date('2026-01-01T00:00:00Z')
+
+describe('assessPersonQuality', () => {
+  it('should grade a fully documented person A', () => {
+    const result = assessPersonQuality({ birthDate: '1900-01-01', deathDate: '1970-01-01', gender: 'male' }, 2, today)
+
+    expect(result).toEqual({
+      indicators: { hasBirthDate: true, hasDeathDateOrPresumedLiving: true, hasBothParents: true, hasGender: true },
+      passed: 4,
+      total: 4,
+      grade: 'A'
+    })
+  })
+
+  it('should grade a bare person F', () => {
+    const result = assessPersonQuality({ birthDate: null, deathDate: null, gender: null }, 0, today)
+
+    expect(result.passed).toBe(0)
+    expect(result.grade).toBe('F')
+  })
+
+  it('should count a recent birth without a death date as presumed living', () => {
+    const result = assessPersonQuality({ birthDate: '1990-05-05', gender: 'unknown' }, 1, today)
+
+    expect(result.indicators).toEqual({
+      hasBirthDate: true,
+      hasDeathDateOrPresumedLiving: true,
+      hasBothParents: false,
+      hasGender: false
+    })
+    expect(result.grade).toBe('C')
+  })
+
+  it('should not presume someone born long ago is living', () => {
+    const result = assessPersonQuality({ birthDate: '1850-01-01', gender: 'female' }, 2, today)
+
+    expect(result.indicators.hasDeathDateOrPresumedLiving).toBe(false)
+    expect(result.grade).toBe('B')
+  })
+})
+
+describe('gradeForScore', () => {
+  it('should map shares to letter grades', () => {
+    expect([1, 0.8, 0.5, 0.3, 0.1].map(gradeForScore)).toEqual(['A', 'B', 'C', 'D', 'F'])
+  })
+})
@@ -0,0 +1,152 @@
+/**
+ * Person Search
+ *
+ * Full-text search over people: names, places, and notes. Every word of the
+ * query must appear somewhere in the person (case-insensitive, matching
+ * inside words too), and results are ranked by where and how well the words
+ * matched. Names count most, then places, then notes; a whole-field match
+ * beats a word-start match, which beats a match inside a word.
+ *
+ * Trees are small enough to rank in memory, which keeps the search free of
+ * FTS tables and triggers.
+ */
+
+/**
+ * Searchable person fields and their ranking weights
+ */
+export const SEARCH_FIELD_WEIGHTS = {
+  firstName: 3,
+  lastName: 3,
+  middleName: 2,
+  nickname: 2,
+  birthSurname: 2,
+  maidenName: 2,
+  birthPlace: 2,
+  deathPlace: 2,
+  notes: 1
+}
+
+/**
+ * Characters of context kept on each side of the match in a snippet
+ */
+const SNIPPET_CONTEXT = 30
+
+/**
+ * Splits a search query into lowercase words
+ *
+ * @param {string} query - Raw query
+ * @returns {Array<string>} Distinct words, in order
+ */
+export function tokenizeQuery(query) {
+  return [...new Set(String(query ?? '').toLowerCase().split(/\s+/).filter(Boolean))]
+}
+
+/**
+ * Scores how well a word matches a field value
+ *
+ * @param {string} value - Lowercased field value
+ * @param {string} word - Lowercased query word
+ * @returns {number} 3 for the whole value, 2 for the start of a word, 1 inside a word, 0 for no match
+ */
+function matchQuality(value, word) {
+  if (value === word) return 3
+  if (!value.includes(word)) return 0
+  return new RegExp(`(^|[^\\p{L}\\p{N}])${escapeRegExp(word)}`, 'u').test(value) ? 2 : 1
+}
+
+/**
+ * Escapes a string for use inside a regular expression
+ *
+ * @param {string} text - Literal text
+ * @returns {string} Escaped pattern
+ */
+function escapeRegExp(text) {
+  return text.replace(/[.*+?^${}()|[\]\\]/g, '\\$&')
+}
+
+/**
+ * Builds a short excerpt of a field around the first match
+ *
+ * Long values are cut to SNIPPET_CONTEXT characters on each side of the
+ * match, with "…" marking the cuts.
+ *
+ * @param {string} value - Field value
+ * @param {string} word - Lowercased query word that matched
+ * @returns {string} Excerpt on a single line
+ *
+ * @example
+ * buildSnippet('Emigrated from Cork in 1848 aboard the Jeanie Johnston', 'cork')
+ * // "Emigrated from Cork in 1848 aboard the Jeanie Joh…"
+ */
+export function buildSnippet(value, word) {
+  const text = value.replace(/\s+/g, ' ').trim()
+  const index = Math.max(0, text.toLowerCase().indexOf(word))
+  const start = Math.max(0, index - SNIPPET_CONTEXT)
+  const end = Math.min(text.length, index + word.length + SNIPPET_CONTEXT)
+
+  return `${start > 0 ? '…' : ''}${text.slice(start, end).trim()}${end < text.length ? '…' : ''}`
+}
+
+/**
+ * Searches people by name, place, and notes
+ *
+ * @param {Array<Object>} people - Person records (database or API format)
+ * @param {string} query - Search text
+ * @returns {Array<{person: Object, score: number, field: string, snippet: string}>}
+ *   Matches ordered by score (highest first), then last name, first name, and ID.
+ *   field is the best-scoring field and snippet an excerpt of it.
+ *
+ * @example
+ * searchPeople(people, 'cork')
+ * // [{ person, score: 4, field: 'birthPlace', snippet: 'Cork, Ireland' }]
+ */
+export function searchPeople(people, query) {
+  const words = tokenizeQuery(query)
+  if (words.length === 0) return []
+
+  const results = []
+
+  for (const person of people) {
+    let score = 0
+    let best = null
+    let everyWordMatched = true
+
+    for (const word of words) {
+      let wordScore = 0
+      for (const [field, weight] of Object.entries(SEARCH_FIELD_WEIGHTS)) {
+        const value = person[field]
+        if (typeof value !== 'string' || value === '') continue
+
+        const fieldScore = matchQuality(value.toLowerCase(), word) * weight
+        if (fieldScore === 0) continue
+
+        wordScore += fieldScore
+        if (!best || fieldScore > best.score) {
+          best = { field, word, score: fieldScore }
+        }
+      }
+
+      if (wordScore === 0) {
+        everyWordMatched = false
+        break
+      }
+      score += wordScore
+    }
+
+    if (everyWordMatched) {
+      results.push({
+        person,
+        score,
+        field: best.field,
+        snippet: buildSnippet(person[best.field], best.word)
+      })
+    }
+  }
+
+  return results.sort((a, b) =>
+    b.score - a.score ||
+    String(a.person.lastName).localeCompare(String(b.person.lastName)) ||
+    String(a.person.firstName).localeCompare(String(b.person.firstName)) ||
+    a.person.id - b.person.id
+  )
+}
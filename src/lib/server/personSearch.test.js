@@ -0,0 +1,67 @@
+/**
+ * Unit tests for Person Search
+ */
+
+import { describe, it, expect } from 'vitest'
+import { searchPeople, tokenizeQuery, buildSnippet } from './personSearch.js'
+
+const people = [
+  { id: 1, firstName: 'Mary', lastName: 'Walsh', birthPlace: 'Cork, Ireland', notes: null },
+  { id: 2, firstName: 'Patrick', lastName: 'Corkery', birthPlace: 'Boston', notes: null },
+  { id: 3, firstName: 'John', lastName: 'Walsh', birthPlace: null, notes: 'Sailed from Cork in 1848' },
+  { id: 4, firstName: 'Cork', lastName: 'Smith', birthPlace: null, notes: null },
+  { id: 5, firstName: 'Ann', lastName: 'Byrne', birthPlace: 'Dublin', deathPlace: 'New York', notes: null }
+]
+
+describe('tokenizeQuery', () => {
+  it('should split on whitespace, lowercase, and drop duplicates', () => {
+    expect(tokenizeQuery('  Walsh  cork WALSH ')).toEqual(['walsh', 'cork'])
+    expect(tokenizeQuery('')).toEqual([])
+  })
+})
+
+describe('searchPeople', () => {
+  it('should rank name matches over places over notes', () => {
+    const results = searchPeople(people, 'cork')
+
+    expect(results.map((result) => result.person.id)).toEqual([4, 2, 1, 3])
+    expect(results.map((result) => result.field)).toEqual(['firstName', 'lastName', 'birthPlace', 'notes'])
+  })
+
+  it('should rank a whole-field match over a match inside a word', () => {
+    const results = searchPeople([
+      { id: 1, firstName: 'Ann', lastName: 'Mc Ann' },
+      { id: 2, firstName: 'Joanne', lastName: 'Doe' },
+      { id: 3, firstName: 'Ann', lastName: 'Doe' }
+    ], 'ann')
+
+    expect(results.map((result) => result.person.id)).toEqual([1, 3, 2])
+  })
+
+  it('should require every word to match', () => {
+    const results = searchPeople(people, 'walsh cork')
+
+    expect(results.map((result) => result.person.id)).toEqual([1, 3])
+  })
+
+  it('should match places and notes', () => {
+    expect(searchPeople(people, 'new york')[0].person.id).toBe(5)
+    expect(searchPeople(people, '1848')[0]).toMatchObject({ field: 'notes', snippet: 'Sailed from Cork in 1848' })
+  })
+
+  it('should return nothing for an empty query', () => {
+    expect(searchPeople(people, '   ')).toEqual([])
+  })
+})
+
+describe('buildSnippet', () => {
+  it('should keep short values whole', () => {
+    expect(buildSnippet('Cork, Ireland', 'cork')).toBe('Cork, Ireland')
+  })
+
+  it('should cut long values around the match', () => {
+    const notes = 'Served in the 69th regiment and later emigrated from Cork in 1848 aboard the Jeanie Johnston'
+
+    expect(buildSnippet(notes, 'cork')).toBe('…ment and later emigrated from Cork in 1848 aboard the Jeanie Joh…')
+  })
+})
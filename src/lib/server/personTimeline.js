@@ -0,0 +1,100 @@
+/**
+ * Person Timeline Module
+ *
+ * Lists the dated events of a person's life for a biography view: birth,
+ * marriages and divorces, children's births, and death. Events without a
+ * date are left out.
+ */
+
+/**
+ * Event types in the order they are listed when they share a date
+ */
+export const TIMELINE_EVENT_TYPES = ['birth', 'marriage', 'childBirth', 'divorce', 'death']
+
+/**
+ * Formats a person's name for event descriptions
+ *
+ * @param {Object} person - Person record
+ * @returns {string} "First Last"
+ */
+function displayName(person) {
+  return [person.firstName, person.lastName].filter(Boolean).join(' ')
+}
+
+/**
+ * Appends " in <place>" when a place is recorded
+ *
+ * @param {string} text - Description so far
+ * @param {string|null} place - Place, if any
+ * @returns {string} Description
+ */
+function withPlace(text, place) {
+  return place ? `${text} in ${place}` : text
+}
+
+/**
+ * Names a child by gender for event descriptions
+ *
+ * @param {string|null} gender - Child's gender
+ * @returns {string} "Son", "Daughter", or "Child"
+ */
+function childTerm(gender) {
+  if (gender === 'male') return 'Son'
+  if (gender === 'female') return 'Daughter'
+  return 'Child'
+}
+
+/**
+ * Builds a person's timeline
+ *
+ * @param {Object} person - Person record
+ * @param {Object} family - Related records
+ * @param {Array<{spouse: Object, startDate: string|null, endDate: string|null}>} family.marriages -
+ *   Spouse relationships, with start and end dates as marriage and divorce dates
+ * @param {Array<Object>} family.children - The person's children
+ * @returns {Array<{date: string, type: string, description: string, personId: number|null}>}
+ *   Events sorted by date, then by TIMELINE_EVENT_TYPES order; personId is the
+ *   other person involved (spouse or child), or null for the person's own events
+ *
+ * @example
+ * buildPersonTimeline(john, { marriages: [{ spouse: mary, startDate: '1950-06-10', endDate: null }], children: [] })
+ * // [{ date: '1925-03-02', type: 'birth', description: 'Born in Cork', personId: null },
+ * //  { date: '1950-06-10', type: 'marriage', description: 'Married Mary Walsh', personId: 2 }]
+ */
+export function buildPersonTimeline(person, { marriages = [], children = [] } = {}) {
+  const events = []
+
+  if (person.birthDate) {
+    events.push({ date: person.birthDate, type: 'birth', description: withPlace('Born', person.birthPlace), personId: null })
+  }
+
+  for (const { spouse, startDate, endDate } of marriages) {
+    if (startDate) {
+      events.push({ date: startDate, type: 'marriage', description: `Married ${displayName(spouse)}`, personId: spouse.id })
+    }
+    if (endDate) {
+      events.push({ date: endDate, type: 'divorce', description: `Divorced ${displayName(spouse)}`, personId: spouse.id })
+    }
+  }
+
+  for (const child of children) {
+    if (child.birthDate) {
+      events.push({
+        date: child.birthDate,
+        type: 'childBirth',
+        description: `${childTerm(child.gender)} ${displayName(child)} born`,
+        personId: child.id
+      })
+    }
+  }
+
+  if (person.deathDate) {
+    events.push({ date: person.deathDate, type: 'death', description: withPlace('Died', person.deathPlace), personId: null })
+  }
+
+  return events.sort((a, b) =>
+    a.date.localeCompare(b.date) ||
+    TIMELINE_EVENT_TYPES.indexOf(a.type) - TIMELINE_EVENT_TYPES.indexOf(b.type) ||
+    (a.personId ?? 0) - (b.personId ?? 0)
+  )
+}
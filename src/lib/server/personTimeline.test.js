@@ -0,0 +1,47 @@
+/**
+ * Unit tests for Person Timeline Module
+ */
+
+import { describe, it, expect } from 'vitest'
+import { buildPersonTimeline } from './personTimeline.js'
+
+const john = { id: 1, firstName: 'John', lastName: 'Walsh', birthDate: '1925-03-02', birthPlace: 'Cork', deathDate: '1990-11-20', deathPlace: null }
+const mary = { id: 2, firstName: 'Mary', lastName: 'Byrne' }
+
+describe('buildPersonTimeline', () => {
+  it('should order events by date and describe each one', () => {
+    const events = buildPersonTimeline(john, {
+      marriages: [{ spouse: mary, startDate: '1950-06-10', endDate: '1970-01-05' }],
+      children: [
+        { id: 4, firstName: 'Ann', lastName: 'Walsh', gender: 'female', birthDate: '1955-02-01' },
+        { id: 3, firstName: 'Pat', lastName: 'Walsh', gender: null, birthDate: '1952-08-15' }
+      ]
+    })
+
+    expect(events).toEqual([
+      { date: '1925-03-02', type: 'birth', description: 'Born in Cork', personId: null },
+      { date: '1950-06-10', type: 'marriage', description: 'Married Mary Byrne', personId: 2 },
+      { date: '1952-08-15', type: 'childBirth', description: 'Child Pat Walsh born', personId: 3 },
+      { date: '1955-02-01', type: 'childBirth', description: 'Daughter Ann Walsh born', personId: 4 },
+      { date: '1970-01-05', type: 'divorce', description: 'Divorced Mary Byrne', personId: 2 },
+      { date: '1990-11-20', type: 'death', description: 'Died', personId: null }
+    ])
+  })
+
+  it('should skip events without dates', () => {
+    const events = buildPersonTimeline({ id: 1, firstName: 'John', lastName: 'Walsh' }, {
+      marriages: [{ spouse: mary, startDate: null, endDate: null }],
+      children: [{ id: 3, firstName: 'Pat', lastName: 'Walsh', birthDate: null }]
+    })
+
+    expect(events).toEqual([])
+  })
+
+  it('should list same-day events birth first and death last', () => {
+    const events = buildPersonTimeline(
+      { id: 1, firstName: 'Jane', lastName: 'Walsh', birthDate: '1900-01-01', deathDate: '1900-01-01' }
+    )
+
+    expect(events.map((event) => event.type)).toEqual(['birth', 'death'])
+  })
+})
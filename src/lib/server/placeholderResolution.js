@@ -0,0 +1,194 @@
+/**
+ * Placeholder Resolution Module
+ *
+ * Replaces a placeholder person (e.g. "Unknown Father") with the real
+ * person once they are identified. Unlike a merge, the real person's data
+ * and relationships always win: the placeholder only fills in gaps.
+ */
+
+import { people, relationships } from '../db/schema.js'
+import { eq, or, and, isNull } from 'drizzle-orm'
+import { transformPersonToAPI } from './personHelpers.js'
+import { recordAudit, AUDIT_ENTITY_TYPES, AUDIT_ACTIONS } from './auditLog.js'
+import { findRelationshipConflict } from './relationshipCreation.js'
+
+/**
+ * Person fields the placeholder may contribute when the real person has no value
+ */
+const RESOLVABLE_FIELDS = [
+  'birthDate',
+  'birthDateQualifier',
+  'deathDate',
+  'gender',
+  'photoUrl',
+  'birthSurname',
+  'nickname',
+  'middleName',
+  'maidenName',
+  'suffix',
+  'birthPlace',
+  'deathPlace'
+]
+
+/**
+ * Returns true when a value is missing (null, undefined, or empty string)
+ *
+ * @param {*} value - Field value
+ * @returns {boolean}
+ */
+function isBlank(value) {
+  return value === null || value === undefined || value === ''
+}
+
+/**
+ * Resolves a placeholder to a real person within a single transaction
+ *
+ * Transaction steps:
+ * 1. Load both people (soft-deleted people are treated as missing)
+ * 2. Copy placeholder-only metadata onto the real person
+ * 3. Move the placeholder's relationships to the real person, skipping
+ *    any that findRelationshipConflict rejects (self-links, duplicates, a
+ *    second biological parent, parent cycles) and reversed spouse links
+ * 4. Delete the placeholder (CASCADE removes its old relationships)
+ * 5. Record a merge audit entry on the real person
+ *
+ * @param {number} placeholderId - ID of the placeholder person (will be deleted)
+ * @param {number} realId - ID of the real person (receives relationships)
+ * @param {Object} db - Drizzle database instance
+ * @returns {Promise<Object>} { success, placeholderId, realId, relationshipsTransferred, relationshipsSkipped, filledFields, person }
+ * @throws {Error} If either person is not found
+ */
+export async function resolvePlaceholder(placeholderId, realId, db) {
+  return db.transaction((tx) => {
+    // Step 1: Load both people
+    const placeholder = tx.select()
+      .from(people)
+      .where(and(eq(people.id, placeholderId), isNull(people.deletedAt)))
+      .get()
+
+    const real = tx.select()
+      .from(people)
+      .where(and(eq(people.id, realId), isNull(people.deletedAt)))
+      .get()
+
+    if (!placeholder) {
+      throw new Error('Placeholder person not found')
+    }
+
+    if (!real) {
+      throw new Error('Real person not found')
+    }
+
+    // Step 2: Fill gaps on the real person from the placeholder
+    const filled = {}
+    for (const field of RESOLVABLE_FIELDS) {
+      if (isBlank(real[field]) && !isBlank(placeholder[field])) {
+        filled[field] = placeholder[field]
+      }
+    }
+
+    // A qualifier only makes sense alongside the birth date it came with
+    if (filled.birthDateQualifier && !filled.birthDate) {
+      delete filled.birthDateQualifier
+    }
+
+    if (Object.keys(filled).length > 0) {
+      tx.update(people)
+        .set(filled)
+        .where(eq(people.id, realId))
+        .run()
+    }
+
+    // Step 3: Move relationships
+    const placeholderRelationships = tx.select()
+      .from(relationships)
+      .where(or(
+        eq(relationships.person1Id, placeholderId),
+        eq(relationships.person2Id, placeholderId)
+      ))
+      .all()
+
+    let relationshipsTransferred = 0
+    let relationshipsSkipped = 0
+
+    // The placeholder's own rows are about to be deleted, so the checks ignore them
+    const placeholderRelationshipIds = placeholderRelationships.map((rel) => rel.id)
+
+    for (const rel of placeholderRelationships) {
+      const person1Id = rel.person1Id === placeholderId ? realId : rel.person1Id
+      const person2Id = rel.person2Id === placeholderId ? realId : rel.person2Id
+
+      // The real person may already be married to the same spouse in the other direction
+      if (rel.type === 'spouse') {
+        const reverseSpouse = tx.select({ id: relationships.id })
+          .from(relationships)
+          .where(and(
+            eq(relationships.type, 'spouse'),
+            eq(relationships.person1Id, person2Id),
+            eq(relationships.person2Id, person1Id)
+          ))
+          .get()
+
+        if (reverseSpouse) {
+          relationshipsSkipped++
+          continue
+        }
+      }
+
+      // Same rules as every other write: self-links, duplicates, a second
+      // biological parent, parent cycles, and strict spouse gender are skipped
+      const conflict = findRelationshipConflict(tx, {
+        person1Id,
+        person2Id,
+        type: rel.type,
+        parentRole: rel.parentRole,
+        relationKind: rel.relationKind
+      }, placeholderRelationshipIds)
+
+      if (conflict) {
+        relationshipsSkipped++
+        continue
+      }
+
+      tx.insert(relationships).values({
+        person1Id,
+        person2Id,
+        type: rel.type,
+        parentRole: rel.parentRole,
+        relationKind: rel.relationKind,
+        startDate: rel.startDate,
+        endDate: rel.endDate,
+        sortOrder: rel.sortOrder
+      }).run()
+      relationshipsTransferred++
+    }
+
+    // Step 4: Delete placeholder (CASCADE removes its old relationships)
+    tx.delete(people)
+      .where(eq(people.id, placeholderId))
+      .run()
+
+    const person = tx.select()
+      .from(people)
+      .where(eq(people.id, realId))
+      .get()
+
+    recordAudit(tx, AUDIT_ENTITY_TYPES.person, realId, AUDIT_ACTIONS.merge, {
+      placeholder: transformPersonToAPI(placeholder),
+      before: transformPersonToAPI(real),
+      after: transformPersonToAPI(person),
+      relationshipsTransferred,
+      relationshipsSkipped
+    })
+
+    return {
+      success: true,
+      placeholderId,
+      realId,
+      relationshipsTransferred,
+      relationshipsSkipped,
+      filledFields: Object.keys(filled),
+      person
+    }
+  })
+}
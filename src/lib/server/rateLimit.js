@@ -0,0 +1,159 @@
+/**
+ * Rate Limiting
+ *
+ * Protects the server from runaway scripts with a token bucket per client
+ * IP. Each bucket holds up to one second's worth of requests and refills
+ * continuously; a request that finds its bucket empty gets 429 with a
+ * Retry-After header. Health checks are exempt so probes never fail
+ * because a client is busy.
+ *
+ * Environment variables:
+ * - RATE_LIMIT: Requests per second allowed per client IP, "0" to disable
+ *   (default: 20)
+ *
+ * Kept free of $lib imports so scripts can use it directly.
+ */
+
+import { jsonError } from './errors.js'
+
+export const DEFAULT_RATE_LIMIT = 20
+
+/**
+ * Paths that are never rate limited
+ */
+export const RATE_LIMIT_EXEMPT_PATHS = ['/healthz', '/readyz']
+
+/**
+ * Buckets kept before idle (full) ones are dropped
+ */
+const MAX_IDLE_BUCKETS = 10000
+
+/**
+ * Resolves the rate limit from environment variables
+ *
+ * An unset or empty RATE_LIMIT falls back to DEFAULT_RATE_LIMIT.
+ *
+ * @param {Object} [env=process.env] - Environment variables
+ * @returns {{ratePerSecond: number, burst: number, enabled: boolean}}
+ *   burst is the bucket size: one second's worth of requests, at least 1
+ * @throws {Error} If RATE_LIMIT is not a non-negative number
+ */
+export function resolveRateLimit(env = process.env) {
+  const raw = (env.RATE_LIMIT || '').trim()
+  const ratePerSecond = raw === '' ? DEFAULT_RATE_LIMIT : Number(raw)
+
+  if (!Number.isFinite(ratePerSecond) || ratePerSecond < 0) {
+    throw new Error(`Invalid RATE_LIMIT "${env.RATE_LIMIT}": expected requests per second (0 to disable)`)
+  }
+
+  return {
+    ratePerSecond,
+    burst: Math.max(1, Math.ceil(ratePerSecond)),
+    enabled: ratePerSecond > 0
+  }
+}
+
+/**
+ * Creates a token bucket limiter keyed by client
+ *
+ * @param {Object} options
+ * @param {number} options.ratePerSecond - Tokens added per second
+ * @param {number} options.burst - Bucket size
+ * @param {Function} [options.now=Date.now] - Clock in milliseconds (for tests)
+ * @returns {{take: Function}} take(key) => { allowed, retryAfterSeconds }
+ *
+ * @example
+ * const limiter = createRateLimiter({ ratePerSecond: 5, burst: 5 })
+ * limiter.take('203.0.113.7') // { allowed: true, retryAfterSeconds: 0 }
+ */
+export function createRateLimiter({ ratePerSecond, burst, now = Date.now }) {
+  const buckets = new Map()
+
+  // Refills a bucket up to the current time
+  function refill(bucket, time) {
+    const elapsedSeconds = (time - bucket.updatedAt) / 1000
+    bucket.tokens = Math.min(burst, bucket.tokens + elapsedSeconds * ratePerSecond)
+    bucket.updatedAt = time
+  }
+
+  // A full bucket behaves like a missing one, so idle clients can be forgotten
+  function pruneIdleBuckets(time) {
+    for (const [key, bucket] of buckets) {
+      refill(bucket, time)
+      if (bucket.tokens >= burst) buckets.delete(key)
+    }
+  }
+
+  function take(key) {
+    const time = now()
+
+    let bucket = buckets.get(key)
+    if (!bucket) {
+      if (buckets.size >= MAX_IDLE_BUCKETS) pruneIdleBuckets(time)
+      bucket = { tokens: burst, updatedAt: time }
+      buckets.set(key, bucket)
+    } else {
+      refill(bucket, time)
+    }
+
+    if (bucket.tokens >= 1) {
+      bucket.tokens -= 1
+      return { allowed: true, retryAfterSeconds: 0 }
+    }
+
+    return {
+      allowed: false,
+      retryAfterSeconds: Math.max(1, Math.ceil((1 - bucket.tokens) / ratePerSecond))
+    }
+  }
+
+  return { take }
+}
+
+/**
+ * Reads the client IP for a request
+ *
+ * @param {Object} event - SvelteKit request event
+ * @returns {string} Client address, or "unknown" when the adapter cannot tell
+ */
+function clientKey(event) {
+  try {
+    return event.getClientAddress()
+  } catch {
+    return 'unknown'
+  }
+}
+
+/**
+ * Creates a rate limiting wrapper for the server hooks
+ *
+ * When the limit is disabled every request passes straight through.
+ *
+ * @param {{ratePerSecond: number, burst: number, enabled: boolean}} options - From resolveRateLimit
+ * @param {Object} [limiterOptions] - Extra createRateLimiter options (such as now)
+ * @returns {Function} async (event, resolve) => Response
+ *
+ * @example
+ * const rateLimit = createRateLimitHandler(resolveRateLimit())
+ * return rateLimit(event, resolve)
+ */
+export function createRateLimitHandler(options, limiterOptions = {}) {
+  const limiter = options.enabled
+    ? createRateLimiter({ ratePerSecond: options.ratePerSecond, burst: options.burst, ...limiterOptions })
+    : null
+
+  return async function rateLimit(event, resolve) {
+    if (!limiter || RATE_LIMIT_EXEMPT_PATHS.includes(event.url.pathname)) {
+      return resolve(event)
+    }
+
+    const { allowed, retryAfterSeconds } = limiter.take(clientKey(event))
+    if (!allowed) {
+      const response = jsonError(429, 'Too Many Requests')
+      response.headers.set('Retry-After', String(retryAfterSeconds))
+      return response
+    }
+
+    return resolve(event)
+  }
+}
@@ -0,0 +1,101 @@
+/**
+ * Unit tests for Rate Limiting
+ */
+
+import { describe, it, expect } from 'vitest'
+import {
+  resolveRateLimit,
+  createRateLimiter,
+  createRateLimitHandler,
+  DEFAULT_RATE_LIMIT
+} from './rateLimit.js'
+
+describe('resolveRateLimit', () => {
+  it('should default when unset or empty', () => {
+    expect(resolveRateLimit({})).toEqual({ ratePerSecond: DEFAULT_RATE_LIMIT, burst: DEFAULT_RATE_LIMIT, enabled: true })
+    expect(resolveRateLimit({ RATE_LIMIT: ' ' }).ratePerSecond).toBe(DEFAULT_RATE_LIMIT)
+  })
+
+  it('should parse a requests-per-second value', () => {
+    expect(resolveRateLimit({ RATE_LIMIT: '5' })).toEqual({ ratePerSecond: 5, burst: 5, enabled: true })
+    expect(resolveRateLimit({ RATE_LIMIT: '0.5' })).toEqual({ ratePerSecond: 0.5, burst: 1, enabled: true })
+  })
+
+  it('should disable limiting for 0', () => {
+    expect(resolveRateLimit({ RATE_LIMIT: '0' }).enabled).toBe(false)
+  })
+
+  it('should reject invalid values', () => {
+    expect(() => resolveRateLimit({ RATE_LIMIT: 'fast' })).toThrow('Invalid RATE_LIMIT')
+    expect(() => resolveRateLimit({ RATE_LIMIT: '-1' })).toThrow('Invalid RATE_LIMIT')
+  })
+})
+
+describe('createRateLimiter', () => {
+  it('should allow a burst up to the bucket size, then refill over time', () => {
+    let time = 0
+    const limiter = createRateLimiter({ ratePerSecond: 2, burst: 2, now: () => time })
+
+    expect(limiter.take('a').allowed).toBe(true)
+    expect(limiter.take('a').allowed).toBe(true)
+    expect(limiter.take('a')).toEqual({ allowed: false, retryAfterSeconds: 1 })
+
+    time = 500
+    expect(limiter.take('a').allowed).toBe(true)
+    expect(limiter.take('a').allowed).toBe(false)
+  })
+
+  it('should keep a separate bucket per client', () => {
+    const limiter = createRateLimiter({ ratePerSecond: 1, burst: 1, now: () => 0 })
+
+    expect(limiter.take('a').allowed).toBe(true)
+    expect(limiter.take('a').allowed).toBe(false)
+    expect(limiter.take('b').allowed).toBe(true)
+  })
+
+  it('should ask slow limits to wait longer', () => {
+    const limiter = createRateLimiter({ ratePerSecond: 0.25, burst: 1, now: () => 0 })
+
+    limiter.take('a')
+    expect(limiter.take('a').retryAfterSeconds).toBe(4)
+  })
+})
+
+describe('createRateLimitHandler', () => {
+  const createEvent = (path, address = '203.0.113.7') => ({
+    url: new URL(`http://localhost${path}`),
+    getClientAddress: () => address
+  })
+  const ok = async () => new Response('ok')
+
+  it('should answer some requests in a burst above the limit with 429 and Retry-After', async () => {
+    const rateLimit = createRateLimitHandler(resolveRateLimit({ RATE_LIMIT: '5' }), { now: () => 0 })
+
+    const responses = []
+    for (let i = 0; i < 20; i++) {
+      responses.push(await rateLimit(createEvent('/api/people'), ok))
+    }
+    const limited = responses.filter((response) => response.status === 429)
+
+    expect(limited.length).toBe(15)
+    expect(limited[0].headers.get('Retry-After')).toBe('1')
+    expect(await limited[0].json()).toEqual({ error: 'Too Many Requests', status: 429 })
+  })
+
+  it('should not limit health checks', async () => {
+    const rateLimit = createRateLimitHandler(resolveRateLimit({ RATE_LIMIT: '1' }), { now: () => 0 })
+
+    for (let i = 0; i < 5; i++) {
+      expect((await rateLimit(createEvent('/healthz'), ok)).status).toBe(200)
+      expect((await rateLimit(createEvent('/readyz'), ok)).status).toBe(200)
+    }
+  })
+
+  it('should pass everything through when disabled', async () => {
+    const rateLimit = createRateLimitHandler(resolveRateLimit({ RATE_LIMIT: '0' }))
+
+    for (let i = 0; i < 50; i++) {
+      expect((await rateLimit(createEvent('/api/people'), ok)).status).toBe(200)
+    }
+  })
+})
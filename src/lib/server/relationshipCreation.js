@@ -0,0 +1,391 @@
+/**
+ * Relationship Creation Module
+ *
+ * Runs the business rules for adding or changing a relationship and writes
+ * it. Shared by POST /api/relationships, POST /api/relationships/bulk,
+ * PUT/PATCH /api/relationships/[id], and placeholder resolution; callers
+ * wrap it in an IMMEDIATE transaction so the checks and the write cannot
+ * interleave with another request.
+ *
+ * All helpers are synchronous so they can run inside better-sqlite3
+ * transactions.
+ */
+
+import { people, relationships } from '../db/schema.js'
+import { eq, and, or, ne, isNull, notInArray } from 'drizzle-orm'
+import {
+  transformRelationshipToAPI,
+  isStrictSpouseGender,
+  haveSameRecordedGender,
+  baseParentRole,
+  effectiveRelationKind,
+  SAME_GENDER_SPOUSE_ERROR,
+  SELF_RELATIONSHIP_ERROR
+} from './relationshipHelpers.js'
+import { recordAudit, AUDIT_ENTITY_TYPES, AUDIT_ACTIONS } from './auditLog.js'
+
+/**
+ * Rejection reasons returned with each conflict (and by checkCanLink)
+ */
+export const LINK_REJECTION_REASONS = {
+  self: 'self',
+  missingPerson: 'missingPerson',
+  duplicate: 'duplicate',
+  parentRoleConflict: 'parentRoleConflict',
+  cycle: 'cycle',
+  sameGenderSpouse: 'sameGenderSpouse'
+}
+
+/**
+ * Error returned when a parent link would make someone their own ancestor
+ */
+export const PARENT_CYCLE_ERROR = 'A person cannot be the parent of their own ancestor'
+
+/**
+ * Runs the checks a new or updated relationship must pass
+ *
+ * Rules, in order (reason in parentheses):
+ * - A person cannot be related to themselves (self)
+ * - Both people must exist, 404 otherwise (missingPerson)
+ * - With STRICT_SPOUSE_GENDER set, spouses cannot share a recorded gender (sameGenderSpouse)
+ * - A child has at most one biological mother and one father (by effective
+ *   kind, see effectiveRelationKind), and they must be different people (parentRoleConflict)
+ * - The relationship must not exist yet (duplicate)
+ * - A parent cannot be a descendant of their child (cycle)
+ *
+ * @param {Object} database - Drizzle transaction (or database)
+ * @param {Object} normalized - Relationship from normalizeRelationship
+ * @param {number|Array<number>|null} [excludeId=null] - Relationship(s) ignored by the checks
+ *   (the one being updated, or the rows of a placeholder being resolved)
+ * @returns {Object|null} { status, error, reason } for the first failed check, or null
+ */
+export function findRelationshipConflict(database, normalized, excludeId = null) {
+  const { person1Id, person2Id, type, parentRole, relationKind } = normalized
+
+  if (person1Id === person2Id) {
+    return conflict(400, SELF_RELATIONSHIP_ERROR, LINK_REJECTION_REASONS.self)
+  }
+
+  // Check if both people exist
+  const missingPerson = findMissingPerson(database, person1Id, person2Id)
+  if (missingPerson) {
+    return conflict(404, `${missingPerson} not found`, LINK_REJECTION_REASONS.missingPerson)
+  }
+
+  // In strict mode, spouses must not share a recorded gender
+  if (type === 'spouse' && isStrictSpouseGender() && spousesShareGender(database, person1Id, person2Id)) {
+    return conflict(400, SAME_GENDER_SPOUSE_ERROR, LINK_REJECTION_REASONS.sameGenderSpouse)
+  }
+
+  if (type === 'parentOf' && parentRole) {
+    // Only biological links are unique: a child may also have adoptive or step
+    // parents, whether stored by role ("adoptiveMother") or kind ("mother" + "adoptive")
+    const role = baseParentRole(parentRole)
+    if (
+      effectiveRelationKind(parentRole, relationKind) === 'biological' &&
+      hasBiologicalParent(database, person2Id, role, excludeId)
+    ) {
+      return conflict(400, `Person already has a ${role}`, LINK_REJECTION_REASONS.parentRoleConflict)
+    }
+
+    // Mother and father must be distinct people
+    if (isParentInOtherRole(database, person1Id, person2Id, parentRole, excludeId)) {
+      return conflict(
+        400,
+        'The same person cannot be both mother and father of a child',
+        LINK_REJECTION_REASONS.parentRoleConflict
+      )
+    }
+  }
+
+  // Check for duplicate relationships
+  if (relationshipExists(database, person1Id, person2Id, type, excludeId)) {
+    return conflict(400, 'This relationship already exists', LINK_REJECTION_REASONS.duplicate)
+  }
+
+  if (type === 'parentOf' && isAncestorOf(database, person2Id, person1Id, excludeId)) {
+    return conflict(400, PARENT_CYCLE_ERROR, LINK_REJECTION_REASONS.cycle)
+  }
+
+  return null
+}
+
+/**
+ * Builds a conflict result
+ *
+ * @param {number} status - HTTP status
+ * @param {string} error - Human-readable explanation
+ * @param {string} reason - One of LINK_REJECTION_REASONS
+ * @returns {Object} { status, error, reason }
+ */
+function conflict(status, error, reason) {
+  return { status, error, reason }
+}
+
+/**
+ * Checks and inserts one normalized relationship
+ *
+ * @param {Object} database - Drizzle transaction (or database)
+ * @param {Object} normalized - Relationship from normalizeRelationship
+ * @param {Object} [dates={}] - Date columns from relationshipDateValues
+ * @returns {Object} { relationship } with the inserted row, or { error, status }
+ */
+export function createRelationship(database, normalized, dates = {}) {
+  const conflict = findRelationshipConflict(database, normalized)
+  if (conflict) {
+    return conflict
+  }
+
+  // Insert relationship into database
+  const inserted = database
+    .insert(relationships)
+    .values({
+      person1Id: normalized.person1Id,
+      person2Id: normalized.person2Id,
+      type: normalized.type,
+      parentRole: normalized.parentRole,
+      relationKind: normalized.relationKind,
+      ...dates
+    })
+    .returning()
+    .get()
+
+  recordAudit(database, AUDIT_ENTITY_TYPES.relationship, inserted.id, AUDIT_ACTIONS.create, {
+    after: transformRelationshipToAPI(inserted)
+  })
+
+  return { relationship: inserted }
+}
+
+/**
+ * Checks and writes an update to an existing relationship
+ *
+ * @param {Object} database - Drizzle transaction (or database)
+ * @param {Object} existing - Relationship row before the update
+ * @param {Object} normalized - Relationship from normalizeRelationship
+ * @param {Object} [dates={}] - Date columns from relationshipDateValues
+ * @param {Object} [options]
+ * @param {boolean} [options.check=true] - Run findRelationshipConflict first
+ *   (PATCH skips it when only dates change)
+ * @returns {Object} { relationship } with the updated row, or { error, status }
+ */
+export function updateRelationship(database, existing, normalized, dates = {}, { check = true } = {}) {
+  if (check) {
+    const conflict = findRelationshipConflict(database, normalized, existing.id)
+    if (conflict) {
+      return conflict
+    }
+  }
+
+  const updated = database
+    .update(relationships)
+    .set({
+      person1Id: normalized.person1Id,
+      person2Id: normalized.person2Id,
+      type: normalized.type,
+      parentRole: normalized.parentRole,
+      relationKind: normalized.relationKind,
+      ...dates
+    })
+    .where(eq(relationships.id, existing.id))
+    .returning()
+    .get()
+
+  recordAudit(database, AUDIT_ENTITY_TYPES.relationship, existing.id, AUDIT_ACTIONS.update, {
+    before: transformRelationshipToAPI(existing),
+    after: transformRelationshipToAPI(updated)
+  })
+
+  return { relationship: updated }
+}
+
+/**
+ * Builds a condition that skips the relationship(s) being replaced
+ *
+ * @param {number|Array<number>|null} excludeId - Relationship ID or IDs, or null to skip nothing
+ * @returns {Object|undefined} Drizzle condition (undefined is ignored by and())
+ */
+function notExcluded(excludeId) {
+  if (excludeId === null) return undefined
+  if (Array.isArray(excludeId)) {
+    return excludeId.length === 0 ? undefined : notInArray(relationships.id, excludeId)
+  }
+  return ne(relationships.id, excludeId)
+}
+
+/**
+ * Check if a person already has a biological parent in a role
+ *
+ * A link counts when its role reduces to the same mother/father role and
+ * its effective kind is biological, so "mother" with relationKind "adoptive"
+ * does not hold the biological mother slot.
+ *
+ * @param {Database} database - Drizzle database or transaction
+ * @param {number} childId - ID of the child person
+ * @param {string} role - "mother" or "father"
+ * @param {number|Array<number>|null} [excludeId=null] - Relationship ID(s) to ignore (for updates)
+ * @returns {boolean} True if a biological parent in that role exists
+ */
+export function hasBiologicalParent(database, childId, role, excludeId = null) {
+  const parentLinks = database
+    .select()
+    .from(relationships)
+    .where(
+      and(
+        eq(relationships.person2Id, childId),
+        eq(relationships.type, 'parentOf'),
+        notExcluded(excludeId)
+      )
+    )
+    .all()
+
+  return parentLinks.some((link) =>
+    baseParentRole(link.parentRole) === role &&
+    effectiveRelationKind(link.parentRole, link.relationKind) === 'biological'
+  )
+}
+
+/**
+ * Check if a parent is already linked to the child under a different parent role
+ * Prevents the same person from being recorded as both mother and father
+ *
+ * @param {Database} database - Drizzle database or transaction
+ * @param {number} parentId - ID of the parent person
+ * @param {number} childId - ID of the child person
+ * @param {string} role - Parent role being assigned ("mother" or "father")
+ * @param {number|Array<number>|null} [excludeId=null] - Relationship ID(s) to ignore (for updates)
+ * @returns {boolean} True if the parent already holds another role for the child
+ */
+export function isParentInOtherRole(database, parentId, childId, role, excludeId = null) {
+  const result = database
+    .select()
+    .from(relationships)
+    .where(
+      and(
+        eq(relationships.person1Id, parentId),
+        eq(relationships.person2Id, childId),
+        eq(relationships.type, 'parentOf'),
+        ne(relationships.parentRole, role),
+        notExcluded(excludeId)
+      )
+    )
+    .all()
+
+  return result.length > 0
+}
+
+/**
+ * Check if a relationship already exists
+ *
+ * Parent links match any existing parent link between the pair, in either
+ * direction and any role. Spouse links only match the same direction: a
+ * couple may be stored both ways (person1->person2 and person2->person1).
+ *
+ * @param {Database} database - Drizzle database or transaction
+ * @param {number} person1Id - First person ID
+ * @param {number} person2Id - Second person ID
+ * @param {string} type - Relationship type
+ * @param {number|Array<number>|null} [excludeId=null] - Relationship ID(s) to ignore (for updates)
+ * @returns {boolean} True if relationship exists
+ */
+export function relationshipExists(database, person1Id, person2Id, type, excludeId = null) {
+  const pair = type === 'parentOf'
+    ? or(
+      and(eq(relationships.person1Id, person1Id), eq(relationships.person2Id, person2Id)),
+      and(eq(relationships.person1Id, person2Id), eq(relationships.person2Id, person1Id))
+    )
+    : and(eq(relationships.person1Id, person1Id), eq(relationships.person2Id, person2Id))
+
+  const result = database
+    .select()
+    .from(relationships)
+    .where(and(eq(relationships.type, type), pair, notExcluded(excludeId)))
+    .all()
+
+  return result.length > 0
+}
+
+/**
+ * Check if one person is an ancestor of another through parent links
+ *
+ * Walks every parentOf link (any kind, including links to soft-deleted
+ * people) so no loop can be closed through a hidden edge.
+ *
+ * @param {Database} database - Drizzle database or transaction
+ * @param {number} ancestorId - Possible ancestor
+ * @param {number} personId - Person whose ancestors are walked
+ * @param {number|Array<number>|null} [excludeId=null] - Relationship ID(s) to ignore (for updates)
+ * @returns {boolean} True if ancestorId is personId's parent, grandparent, and so on
+ */
+export function isAncestorOf(database, ancestorId, personId, excludeId = null) {
+  const parentLinks = database
+    .select({ person1Id: relationships.person1Id, person2Id: relationships.person2Id })
+    .from(relationships)
+    .where(and(eq(relationships.type, 'parentOf'), notExcluded(excludeId)))
+    .all()
+
+  const parentsOf = new Map()
+  for (const link of parentLinks) {
+    if (!parentsOf.has(link.person2Id)) parentsOf.set(link.person2Id, [])
+    parentsOf.get(link.person2Id).push(link.person1Id)
+  }
+
+  const visited = new Set([personId])
+  const queue = [personId]
+  while (queue.length > 0) {
+    for (const parentId of parentsOf.get(queue.shift()) || []) {
+      if (parentId === ancestorId) return true
+      if (!visited.has(parentId)) {
+        visited.add(parentId)
+        queue.push(parentId)
+      }
+    }
+  }
+
+  return false
+}
+
+/**
+ * Find which side of a relationship references a missing person
+ * (soft-deleted people do not count as existing)
+ *
+ * @param {Database} database - Drizzle database or transaction
+ * @param {number} person1Id - First person ID
+ * @param {number} person2Id - Second person ID
+ * @returns {string|null} "person1" or "person2" when missing, null when both exist
+ */
+export function findMissingPerson(database, person1Id, person2Id) {
+  const person1 = database
+    .select()
+    .from(people)
+    .where(and(eq(people.id, person1Id), isNull(people.deletedAt)))
+    .all()
+
+  const person2 = database
+    .select()
+    .from(people)
+    .where(and(eq(people.id, person2Id), isNull(people.deletedAt)))
+    .all()
+
+  if (person1.length === 0) return 'person1'
+  if (person2.length === 0) return 'person2'
+  return null
+}
+
+/**
+ * Check if two people have the same recorded gender (for STRICT_SPOUSE_GENDER)
+ *
+ * @param {Database} database - Drizzle database or transaction
+ * @param {number} person1Id - First person ID
+ * @param {number} person2Id - Second person ID
+ * @returns {boolean} True if both genders are recorded and equal
+ */
+export function spousesShareGender(database, person1Id, person2Id) {
+  const spouses = database
+    .select({ gender: people.gender })
+    .from(people)
+    .where(or(eq(people.id, person1Id), eq(people.id, person2Id)))
+    .all()
+
+  return spouses.length === 2 && haveSameRecordedGender(spouses[0].gender, spouses[1].gender)
+}
@@ -3,6 +3,63 @@
  * Provides reusable utilities for data transformation, validation, and business logic
  */
 
+import { isValidDate, normalizeGender } from './personHelpers.js'
+
+/**
+ * Biological parent roles; a child can have at most one of each
+ */
+export const BIOLOGICAL_PARENT_ROLES = ['mother', 'father']
+
+/**
+ * All parent roles accepted as a relationship type or parentRole
+ * Adoptive and step roles are stored as parentOf rows like biological ones
+ */
+export const PARENT_ROLES = [
+  ...BIOLOGICAL_PARENT_ROLES,
+  'adoptiveMother',
+  'adoptiveFather',
+  'stepMother',
+  'stepFather'
+]
+
+/**
+ * Reduces a parent role to "mother" or "father"
+ *
+ * @param {string|null} role - Parent role (e.g. "adoptiveMother")
+ * @returns {string|null} "mother", "father", or null when unknown
+ */
+export function baseParentRole(role) {
+  if (!role) return null
+  if (/mother$/i.test(role)) return 'mother'
+  if (/father$/i.test(role)) return 'father'
+  return null
+}
+
+/**
+ * Relation kind implied by a parent role
+ *
+ * @param {string|null} role - Parent role
+ * @returns {string|null} "adoptive" or "step" for those roles, otherwise null (biological)
+ */
+export function parentRoleKind(role) {
+  if (role === 'adoptiveMother' || role === 'adoptiveFather') return 'adoptive'
+  if (role === 'stepMother' || role === 'stepFather') return 'step'
+  return null
+}
+
+/**
+ * Effective relation kind of a parent link
+ * An explicit relationKind wins; otherwise the role decides, and plain
+ * mother/father roles are biological.
+ *
+ * @param {string|null} role - Parent role
+ * @param {string|null} relationKind - Stored relation kind, if any
+ * @returns {string} "biological", "adoptive", or "step"
+ */
+export function effectiveRelationKind(role, relationKind) {
+  return relationKind || parentRoleKind(role) || 'biological'
+}
+
 /**
  * Normalizes relationship type and direction for database storage
  * Converts "mother"/"father" to "parentOf" with parent_role
@@ -10,23 +67,28 @@
  * Business logic:
  * - type: "mother" → type: "parentOf", parent_role: "mother"
  * - type: "father" → type: "parentOf", parent_role: "father"
+ * - adoptive and step roles ("adoptiveMother", "stepFather", ...) map the same way;
+ *   their relationKind defaults to "adoptive" or "step"
  * - type: "parentOf" with parentRole → keep as-is (already normalized)
  * - type: "spouse" → type: "spouse", parent_role: null
+ * - relationKind ("biological"/"adoptive"/"step") is kept for parent relationships only
  *
  * @param {number} person1Id - First person ID
  * @param {number} person2Id - Second person ID (child for parent relationships)
- * @param {string} type - Relationship type ("mother", "father", "spouse", "parentOf")
+ * @param {string} type - Relationship type ("spouse", "parentOf", or a parent role such as "mother")
  * @param {string} parentRole - Parent role (for "parentOf" type)
- * @returns {Object} Normalized relationship { person1Id, person2Id, type, parentRole }
+ * @param {string} [relationKind] - Relation kind for parent relationships ("biological", "adoptive", or "step")
+ * @returns {Object} Normalized relationship { person1Id, person2Id, type, parentRole, relationKind }
  */
-export function normalizeRelationship(person1Id, person2Id, type, parentRole) {
-  if (type === 'mother' || type === 'father') {
-    // Person1 is mother/father of Person2
+export function normalizeRelationship(person1Id, person2Id, type, parentRole, relationKind = null) {
+  if (PARENT_ROLES.includes(type)) {
+    // Person1 is mother/father (or adoptive/step mother/father) of Person2
     return {
       person1Id,
       person2Id,
       type: 'parentOf',
-      parentRole: type
+      parentRole: type,
+      relationKind: relationKind || parentRoleKind(type)
     }
   }
 
@@ -36,7 +98,8 @@ export function normalizeRelationship(person1Id, person2Id, type, parentRole) {
       person1Id,
       person2Id,
       type: 'parentOf',
-      parentRole: parentRole
+      parentRole: parentRole,
+      relationKind: relationKind || parentRoleKind(parentRole)
     }
   }
 
@@ -45,7 +108,8 @@ export function normalizeRelationship(person1Id, person2Id, type, parentRole) {
     person1Id,
     person2Id,
     type,
-    parentRole: null
+    parentRole: null,
+    relationKind: null
   }
 }
 
@@ -83,14 +147,19 @@ export function denormalizeRelationship(relationship) {
     type = relationship.parentRole
   }
 
-  // Always return all fields including parentRole (even if null) and userId (Issue #72)
+  // Always return all fields including parentRole, dates, and child order (even if null) and userId (Issue #72)
   return {
     id: relationship.id,
     person1Id: relationship.person1Id,
     person2Id: relationship.person2Id,
     type: type,
     parentRole: parentRole,
+    relationKind: relationship.relationKind || null,
+    startDate: relationship.startDate || null,
+    endDate: relationship.endDate || null,
+    sortOrder: relationship.sortOrder ?? null,
     createdAt: toRFC3339(relationship.createdAt),
+    updatedAt: toRFC3339(relationship.updatedAt),
     userId: relationship.userId
   }
 }
@@ -118,15 +187,15 @@ export function transformRelationshipsToAPI(relationships) {
 
 /**
  * Validates relationship type
- * Only "mother", "father", "spouse", and "parentOf" are valid
- * "parentOf" requires a parentRole parameter ("mother" or "father")
+ * Only the parent roles (see PARENT_ROLES), "spouse", and "parentOf" are valid
+ * "parentOf" requires a parentRole parameter (one of PARENT_ROLES)
  *
  * @param {string} type - Relationship type
  * @param {string} parentRole - Parent role (only for parentOf type)
  * @returns {Object} Validation result { valid: boolean, error: string|null }
  */
 export function validateRelationshipType(type, parentRole) {
-  const validTypes = ['mother', 'father', 'spouse', 'parentOf']
+  const validTypes = [...PARENT_ROLES, 'spouse', 'parentOf']
 
   if (!type || typeof type !== 'string') {
     return { valid: false, error: 'type is required and must be a string' }
@@ -135,7 +204,7 @@ export function validateRelationshipType(type, parentRole) {
   if (!validTypes.includes(type)) {
     return {
       valid: false,
-      error: 'Invalid relationship type. Must be: mother, father, spouse, or parentOf'
+      error: `Invalid relationship type. Must be: ${PARENT_ROLES.join(', ')}, spouse, or parentOf`
     }
   }
 
@@ -144,14 +213,67 @@ export function validateRelationshipType(type, parentRole) {
     if (!parentRole || typeof parentRole !== 'string') {
       return { valid: false, error: 'parentOf type requires a parentRole parameter' }
     }
-    if (parentRole !== 'mother' && parentRole !== 'father') {
-      return { valid: false, error: 'parentRole must be "mother" or "father"' }
+    if (!PARENT_ROLES.includes(parentRole)) {
+      return { valid: false, error: `parentRole must be one of: ${PARENT_ROLES.join(', ')}` }
     }
   }
 
   return { valid: true, error: null }
 }
 
+/**
+ * Error returned when both sides of a relationship are the same person
+ */
+export const SELF_RELATIONSHIP_ERROR = 'A person cannot have a relationship with themselves'
+
+/**
+ * Error returned in strict spouse gender mode when both spouses have the same recorded gender
+ */
+export const SAME_GENDER_SPOUSE_ERROR = 'Spouses cannot have the same recorded gender (STRICT_SPOUSE_GENDER is enabled)'
+
+/**
+ * Genders that do not count as recorded for the strict spouse gender check
+ */
+const UNRECORDED_GENDERS = ['unknown', 'unspecified']
+
+/**
+ * Reads the STRICT_SPOUSE_GENDER environment variable
+ *
+ * Spouses may have any genders by default, to support all family
+ * structures. Setting STRICT_SPOUSE_GENDER (to anything but "false" or
+ * "0") rejects spouse relationships between people of the same recorded gender.
+ *
+ * @param {Object} [env=process.env] - Environment variables
+ * @returns {boolean} True when strict mode is enabled
+ */
+export function isStrictSpouseGender(env = process.env) {
+  const value = (env.STRICT_SPOUSE_GENDER || '').trim().toLowerCase()
+  return value !== '' && value !== 'false' && value !== '0'
+}
+
+/**
+ * Checks whether two people have the same recorded gender
+ * Blank, unknown, and unspecified genders never match
+ *
+ * @param {string|null} gender1 - First person's gender
+ * @param {string|null} gender2 - Second person's gender
+ * @returns {boolean} True when both genders are recorded and equal
+ */
+export function haveSameRecordedGender(gender1, gender2) {
+  const normalized1 = normalizeGender(gender1)
+  const normalized2 = normalizeGender(gender2)
+
+  return normalized1 !== null &&
+    !UNRECORDED_GENDERS.includes(normalized1) &&
+    normalized1 === normalized2
+}
+
+/**
+ * Valid relation kinds for parent relationships
+ * NULL/absent is treated as "biological"
+ */
+export const RELATION_KINDS = ['biological', 'adoptive', 'step']
+
 /**
  * Validates relationship data for create/update operations
  *
@@ -167,9 +289,10 @@ export function validateRelationshipData(data) {
     return { valid: false, error: 'person2Id is required and must be a number' }
   }
 
-  // Prevent self-referential relationships
+  // Prevent self-referential relationships (self-parent or self-spouse rows);
+  // normalization never changes the IDs, so this also holds after normalizing
   if (data.person1Id === data.person2Id) {
-    return { valid: false, error: 'A person cannot be related to themselves' }
+    return { valid: false, error: SELF_RELATIONSHIP_ERROR }
   }
 
   // Validate type (pass parentRole if provided)
@@ -178,9 +301,62 @@ export function validateRelationshipData(data) {
     return typeValidation
   }
 
+  // Validate relationKind if provided (parent relationships only)
+  if (data.relationKind !== undefined && data.relationKind !== null) {
+    if (!RELATION_KINDS.includes(data.relationKind)) {
+      return { valid: false, error: `relationKind must be one of: ${RELATION_KINDS.join(', ')}` }
+    }
+    if (data.type === 'spouse') {
+      return { valid: false, error: 'relationKind only applies to parent relationships' }
+    }
+
+    // Adoptive and step roles already name their kind; it must not contradict them
+    const role = data.type === 'parentOf' ? data.parentRole : data.type
+    const roleKind = parentRoleKind(role)
+    if (roleKind && data.relationKind !== roleKind) {
+      return { valid: false, error: `relationKind "${data.relationKind}" does not match parent role "${role}"` }
+    }
+  }
+
+  return validateRelationshipDates(data)
+}
+
+/**
+ * Validates the optional startDate/endDate of a relationship
+ * (marriage and divorce dates for spouses) and their order
+ *
+ * @param {Object} data - Relationship data with startDate and endDate
+ * @returns {Object} Validation result { valid: boolean, error: string|null }
+ */
+export function validateRelationshipDates(data) {
+  for (const field of ['startDate', 'endDate']) {
+    if (data[field] !== undefined && data[field] !== null) {
+      if (typeof data[field] !== 'string' || !isValidDate(data[field])) {
+        return { valid: false, error: `${field} must be in YYYY-MM-DD format and a valid calendar date` }
+      }
+    }
+  }
+
+  if (data.startDate && data.endDate && data.endDate < data.startDate) {
+    return { valid: false, error: 'endDate cannot be before startDate' }
+  }
+
   return { valid: true, error: null }
 }
 
+/**
+ * Extracts the optional relationship dates from request data for storage
+ *
+ * @param {Object} data - Relationship data that passed validateRelationshipData
+ * @returns {Object} { startDate, endDate } with absent or empty values as null
+ */
+export function relationshipDateValues(data) {
+  return {
+    startDate: data.startDate || null,
+    endDate: data.endDate || null
+  }
+}
+
 /**
  * Validates and parses an ID parameter from URL
  *
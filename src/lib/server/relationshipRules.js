@@ -0,0 +1,41 @@
+/**
+ * Relationship Rules Module
+ *
+ * Checks whether a proposed relationship could be created without conflict,
+ * without inserting anything. Each rejection carries a machine-readable
+ * reason so the UI can explain why a link is unavailable.
+ */
+
+import { findRelationshipConflict, LINK_REJECTION_REASONS } from './relationshipCreation.js'
+
+export { LINK_REJECTION_REASONS }
+
+/**
+ * Checks whether a normalized relationship can be linked
+ *
+ * Runs the same rules as creating the relationship (see
+ * findRelationshipConflict), so a link reported as allowed is one
+ * POST /api/relationships accepts. Reasons:
+ * - self: a person cannot be related to themselves
+ * - missingPerson: both people must exist (soft-deleted people do not count)
+ * - sameGenderSpouse: with STRICT_SPOUSE_GENDER set, spouses cannot have the
+ *   same recorded gender
+ * - parentRoleConflict: the child must not already have a biological parent
+ *   in this role (adoptive and step parents may repeat), and mother and
+ *   father must be different people
+ * - duplicate: the relationship must not exist yet; for parent links this covers
+ *   any existing parent link between the pair, in either direction or role
+ * - cycle: a parent cannot be a descendant of their child
+ *
+ * @param {Object} database - Drizzle database instance
+ * @param {Object} relationship - Normalized relationship { person1Id, person2Id, type, parentRole, relationKind }
+ * @returns {Promise<Object>} { allowed: true } or { allowed: false, reason, message }
+ */
+export async function checkCanLink(database, relationship) {
+  const conflict = findRelationshipConflict(database, relationship)
+  if (conflict) {
+    return { allowed: false, reason: conflict.reason, message: conflict.error }
+  }
+
+  return { allowed: true }
+}
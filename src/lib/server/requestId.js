@@ -0,0 +1,85 @@
+/**
+ * Request IDs
+ *
+ * Gives every request an ID so client reports can be matched to server
+ * logs. A well-formed incoming X-Request-Id (e.g. from a proxy) is kept,
+ * otherwise a UUID is generated. The server hooks store the ID in
+ * event.locals.requestId, echo it in the X-Request-Id response header, and
+ * include it in error bodies.
+ *
+ * Handlers return errors with jsonError (errors.js); the hooks add the
+ * request ID to those bodies here, so no handler needs to know it.
+ */
+
+import { jsonError } from './errors.js'
+
+export const REQUEST_ID_HEADER = 'X-Request-Id'
+
+/**
+ * Incoming IDs must be short and header-safe to be reused
+ */
+const VALID_REQUEST_ID = /^[A-Za-z0-9._:-]{1,128}$/
+
+/**
+ * Picks the ID for a request
+ *
+ * @param {Request} request - Incoming request
+ * @returns {string} The incoming X-Request-Id when well-formed, otherwise a new UUID
+ */
+export function resolveRequestId(request) {
+  const incoming = request.headers?.get(REQUEST_ID_HEADER)
+  return incoming && VALID_REQUEST_ID.test(incoming) ? incoming : crypto.randomUUID()
+}
+
+/**
+ * Adds the request ID to a response
+ *
+ * Every response gets the X-Request-Id header. Error responses (4xx/5xx)
+ * also get it in their body: JSON object bodies gain a requestId field,
+ * and plain-text bodies (from code that does not use jsonError) are
+ * rewritten as JSON { error, status, requestId }. Status and other headers
+ * are kept; other error bodies (such as HTML error pages) are left as they are.
+ *
+ * @param {Response} response - Response from the handler
+ * @param {string} requestId - Request ID
+ * @returns {Promise<Response>} Response carrying the request ID
+ */
+export async function applyRequestId(response, requestId) {
+  let tagged = response
+  if (response.status >= 400) {
+    const body = await errorBody(response)
+    if (body) {
+      tagged = Response.json({ ...body, requestId }, { status: response.status })
+      for (const [name, value] of response.headers) {
+        if (!['content-type', 'content-length'].includes(name)) tagged.headers.set(name, value)
+      }
+    }
+  }
+
+  // Copy so headers can be set even on immutable responses
+  if (tagged === response) tagged = new Response(response.body, response)
+  tagged.headers.set(REQUEST_ID_HEADER, requestId)
+  return tagged
+}
+
+/**
+ * Reads an error response body as an object the request ID can be added to
+ *
+ * @param {Response} response - Error response (read through a clone)
+ * @returns {Promise<Object|null>} JSON object body, a jsonError-shaped object for
+ *   plain text, or null for other bodies
+ */
+async function errorBody(response) {
+  const contentType = response.headers.get('content-type') || ''
+
+  if (contentType.startsWith('application/json')) {
+    const body = await response.clone().json().catch(() => null)
+    return body !== null && typeof body === 'object' && !Array.isArray(body) ? body : null
+  }
+
+  if (contentType === '' || contentType.startsWith('text/plain')) {
+    return jsonError(response.status, await response.clone().text()).json()
+  }
+
+  return null
+}
@@ -0,0 +1,66 @@
+/**
+ * Unit tests for Request IDs
+ */
+
+import { describe, it, expect } from 'vitest'
+import { resolveRequestId, applyRequestId, REQUEST_ID_HEADER } from './requestId.js'
+import { jsonError } from './errors.js'
+
+describe('resolveRequestId', () => {
+  it('should keep a well-formed incoming ID', () => {
+    const request = new Request('http://localhost/api/people', { headers: { 'X-Request-Id': 'proxy-42' } })
+
+    expect(resolveRequestId(request)).toBe('proxy-42')
+  })
+
+  it('should generate a UUID when the header is missing or malformed', () => {
+    const uuid = /^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$/
+
+    expect(resolveRequestId(new Request('http://localhost/'))).toMatch(uuid)
+    expect(resolveRequestId(new Request('http://localhost/', { headers: { 'X-Request-Id': 'bad id <script>' } }))).toMatch(uuid)
+  })
+
+  it('should generate a different ID per request', () => {
+    const request = new Request('http://localhost/')
+
+    expect(resolveRequestId(request)).not.toBe(resolveRequestId(request))
+  })
+})
+
+describe('applyRequestId', () => {
+  it('should add the header and the ID to JSON error bodies', async () => {
+    const response = await applyRequestId(jsonError(404, 'Person not found'), 'req-1')
+
+    expect(response.status).toBe(404)
+    expect(response.headers.get(REQUEST_ID_HEADER)).toBe('req-1')
+    expect(await response.json()).toEqual({ error: 'Person not found', status: 404, requestId: 'req-1' })
+  })
+
+  it('should rewrite plain-text error bodies as JSON', async () => {
+    const response = await applyRequestId(new Response('Person not found', { status: 404 }), 'req-1')
+
+    expect(response.headers.get('Content-Type')).toBe('application/json')
+    expect(await response.json()).toEqual({ error: 'Person not found', status: 404, requestId: 'req-1' })
+  })
+
+  it('should keep other headers on rewritten errors', async () => {
+    const original = new Response('Too many requests', { status: 429, headers: { 'Retry-After': '5' } })
+
+    const response = await applyRequestId(original, 'req-2')
+
+    expect(response.headers.get('Retry-After')).toBe('5')
+  })
+
+  it('should only add the header to successful and non-text responses', async () => {
+    const ok = await applyRequestId(Response.json({ id: 1 }), 'req-3')
+    const htmlError = await applyRequestId(
+      new Response('<h1>Not Found</h1>', { status: 404, headers: { 'Content-Type': 'text/html' } }),
+      'req-3'
+    )
+
+    expect(ok.headers.get(REQUEST_ID_HEADER)).toBe('req-3')
+    expect(await ok.json()).toEqual({ id: 1 })
+    expect(htmlError.headers.get(REQUEST_ID_HEADER)).toBe('req-3')
+    expect(await htmlError.text()).toBe('<h1>Not Found</h1>')
+  })
+})
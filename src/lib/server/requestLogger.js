@@ -0,0 +1,160 @@
+/**
+ * Request Logging
+ *
+ * Writes one line per request with the method, path, status, duration,
+ * response size, and request ID, either as JSON for log aggregators or as
+ * plain text for local development. Health checks and metrics scrapes are
+ * skipped to avoid noise.
+ *
+ * Environment variables:
+ * - LOG_FORMAT: "json" (default) or "text"
+ * - LOG_LEVEL: "info" (default) logs every request, "warn" only 4xx and 5xx
+ *   responses, "error" only 5xx responses
+ *
+ * Kept free of $lib imports so scripts can use it directly.
+ */
+
+export const LOG_FORMATS = ['json', 'text']
+
+export const LOG_LEVELS = ['info', 'warn', 'error']
+
+export const DEFAULT_LOG_FORMAT = 'json'
+
+export const DEFAULT_LOG_LEVEL = 'info'
+
+/**
+ * Paths that are never logged
+ */
+export const UNLOGGED_PATHS = ['/healthz', '/readyz', '/metrics']
+
+/**
+ * Resolves the logging configuration from environment variables
+ *
+ * Unset or empty variables fall back to the defaults.
+ *
+ * @param {Object} [env=process.env] - Environment variables
+ * @returns {{format: string, level: string}}
+ * @throws {Error} If LOG_FORMAT or LOG_LEVEL is not a known value
+ */
+export function resolveLoggingConfig(env = process.env) {
+  const format = (env.LOG_FORMAT || DEFAULT_LOG_FORMAT).trim().toLowerCase()
+  const level = (env.LOG_LEVEL || DEFAULT_LOG_LEVEL).trim().toLowerCase()
+
+  if (!LOG_FORMATS.includes(format)) {
+    throw new Error(`Invalid LOG_FORMAT "${env.LOG_FORMAT}": expected one of ${LOG_FORMATS.join(', ')}`)
+  }
+  if (!LOG_LEVELS.includes(level)) {
+    throw new Error(`Invalid LOG_LEVEL "${env.LOG_LEVEL}": expected one of ${LOG_LEVELS.join(', ')}`)
+  }
+
+  return { format, level }
+}
+
+/**
+ * Picks the log level for a response status
+ *
+ * @param {number} status - HTTP status
+ * @returns {string} "error" for 5xx, "warn" for 4xx, otherwise "info"
+ */
+function levelForStatus(status) {
+  if (status >= 500) return 'error'
+  if (status >= 400) return 'warn'
+  return 'info'
+}
+
+/**
+ * Formats a log entry as a single line
+ *
+ * Entries without a method (e.g. startup messages) are written in text
+ * form as the message followed by key=value pairs.
+ *
+ * @param {Object} entry - Log fields
+ * @param {string} format - "json" or "text"
+ * @returns {string} Line without a trailing newline
+ */
+export function formatLogLine(entry, format) {
+  if (format === 'json') return JSON.stringify(entry)
+
+  if (entry.method === undefined) {
+    const { time, level, msg, ...fields } = entry
+    return [
+      time,
+      level.toUpperCase(),
+      msg,
+      ...Object.entries(fields).map(([key, value]) => `${key}=${Array.isArray(value) ? value.join(',') : value}`)
+    ].join(' ')
+  }
+
+  return [
+    entry.time,
+    entry.level.toUpperCase(),
+    entry.method,
+    entry.path,
+    entry.status,
+    `${entry.durationMs}ms`,
+    entry.bytes === null ? '-' : `${entry.bytes}B`,
+    `requestId=${entry.requestId}`
+  ].join(' ')
+}
+
+/**
+ * Creates a request logging wrapper for the server hooks
+ *
+ * The request ID is read from event.locals.requestId, or generated and
+ * stored there when missing. A handler that throws is logged with status
+ * 500 and the error is rethrown for SvelteKit's error handling.
+ *
+ * @param {Object} [options]
+ * @param {string} [options.format=DEFAULT_LOG_FORMAT] - "json" or "text"
+ * @param {string} [options.level=DEFAULT_LOG_LEVEL] - Minimum level to write
+ * @param {Function} [options.write] - Receives each line (defaults to stdout)
+ * @returns {Function} async (event, resolve) => Response
+ *
+ * @example
+ * const logRequest = createRequestLogger(resolveLoggingConfig())
+ * return logRequest(event, resolve)
+ */
+export function createRequestLogger({
+  format = DEFAULT_LOG_FORMAT,
+  level = DEFAULT_LOG_LEVEL,
+  write = (line) => process.stdout.write(`${line}\n`)
+} = {}) {
+  const minimumLevel = LOG_LEVELS.indexOf(level)
+
+  return async function logRequest(event, resolve) {
+    if (UNLOGGED_PATHS.includes(event.url.pathname)) {
+      return resolve(event)
+    }
+
+    if (event.locals && !event.locals.requestId) {
+      event.locals.requestId = crypto.randomUUID()
+    }
+
+    const started = performance.now()
+    let status = 500
+    let bytes = null
+
+    try {
+      const response = await resolve(event)
+      status = response.status
+      const contentLength = response.headers.get('content-length')
+      bytes = contentLength === null ? null : Number(contentLength)
+      return response
+    } finally {
+      const entryLevel = levelForStatus(status)
+      if (LOG_LEVELS.indexOf(entryLevel) >= minimumLevel) {
+        write(formatLogLine({
+          time: new Date().toISOString(),
+          level: entryLevel,
+          msg: 'request',
+          requestId: event.locals?.requestId ?? null,
+          method: event.request.method,
+          path: event.url.pathname,
+          status,
+          durationMs: Math.round((performance.now() - started) * 1000) / 1000,
+          bytes
+        }, format))
+      }
+    }
+  }
+}
@@ -0,0 +1,113 @@
+/**
+ * Unit tests for Request Logging
+ */
+
+import { describe, it, expect } from 'vitest'
+import { createRequestLogger, resolveLoggingConfig, formatLogLine } from './requestLogger.js'
+
+function createEvent(path, method = 'GET', locals = {}) {
+  return { url: new URL(`http://localhost${path}`), request: { method }, locals }
+}
+
+function capture(options = {}) {
+  const lines = []
+  return { lines, logRequest: createRequestLogger({ ...options, write: (line) => lines.push(line) }) }
+}
+
+describe('createRequestLogger', () => {
+  it('should write each request as a JSON line with the expected fields', async () => {
+    const { lines, logRequest } = capture({ format: 'json' })
+    const event = createEvent('/api/people/1')
+
+    await logRequest(event, async () =>
+      new Response('{"id":1}', { status: 200, headers: { 'content-length': '8' } }))
+
+    expect(lines).toHaveLength(1)
+    const entry = JSON.parse(lines[0])
+    expect(entry).toMatchObject({
+      level: 'info',
+      msg: 'request',
+      method: 'GET',
+      path: '/api/people/1',
+      status: 200,
+      bytes: 8,
+      requestId: event.locals.requestId
+    })
+    expect(entry.requestId).toEqual(expect.any(String))
+    expect(entry.durationMs).toEqual(expect.any(Number))
+    expect(Number.isNaN(Date.parse(entry.time))).toBe(false)
+  })
+
+  it('should keep an existing request ID', async () => {
+    const { lines, logRequest } = capture()
+
+    await logRequest(createEvent('/api/people', 'POST', { requestId: 'abc-123' }), async () => new Response(null, { status: 201 }))
+
+    expect(JSON.parse(lines[0])).toMatchObject({ requestId: 'abc-123', method: 'POST', status: 201, bytes: null })
+  })
+
+  it('should log a thrown error as a 500 and rethrow it', async () => {
+    const { lines, logRequest } = capture()
+
+    await expect(logRequest(createEvent('/api/people'), async () => { throw new Error('boom') })).rejects.toThrow('boom')
+    expect(JSON.parse(lines[0])).toMatchObject({ level: 'error', status: 500 })
+  })
+
+  it('should skip health checks and metrics scrapes', async () => {
+    const { lines, logRequest } = capture()
+
+    for (const path of ['/healthz', '/readyz', '/metrics']) {
+      await logRequest(createEvent(path), async () => new Response('ok'))
+    }
+
+    expect(lines).toEqual([])
+  })
+
+  it('should only write entries at or above the configured level', async () => {
+    const { lines, logRequest } = capture({ level: 'warn' })
+
+    await logRequest(createEvent('/api/people'), async () => new Response('ok'))
+    await logRequest(createEvent('/api/people/999'), async () => new Response('Person not found', { status: 404 }))
+
+    expect(lines.map((line) => JSON.parse(line).status)).toEqual([404])
+  })
+
+  it('should write plain text lines when format is text', async () => {
+    const { lines, logRequest } = capture({ format: 'text' })
+
+    await logRequest(createEvent('/api/people', 'GET', { requestId: 'abc' }), async () => new Response('ok'))
+
+    expect(lines[0]).toMatch(/^\S+ INFO GET \/api\/people 200 [\d.]+ms - requestId=abc$/)
+  })
+})
+
+describe('formatLogLine', () => {
+  it('should include the byte count in text lines when known', () => {
+    const entry = { time: 't', level: 'warn', method: 'GET', path: '/x', status: 404, durationMs: 1.5, bytes: 16, requestId: 'r' }
+
+    expect(formatLogLine(entry, 'text')).toBe('t WARN GET /x 404 1.5ms 16B requestId=r')
+  })
+
+  it('should write message entries as key=value pairs in text lines', () => {
+    const entry = { time: 't', level: 'info', msg: 'startup', corsOrigins: ['a', 'b'], rateLimit: 'disabled' }
+
+    expect(formatLogLine(entry, 'text')).toBe('t INFO startup corsOrigins=a,b rateLimit=disabled')
+    expect(JSON.parse(formatLogLine(entry, 'json'))).toEqual(entry)
+  })
+})
+
+describe('resolveLoggingConfig', () => {
+  it('should default to JSON at info level', () => {
+    expect(resolveLoggingConfig({})).toEqual({ format: 'json', level: 'info' })
+    expect(resolveLoggingConfig({ LOG_FORMAT: '', LOG_LEVEL: '' })).toEqual({ format: 'json', level: 'info' })
+  })
+
+  it('should read LOG_FORMAT and LOG_LEVEL', () => {
+    expect(resolveLoggingConfig({ LOG_FORMAT: 'TEXT', LOG_LEVEL: 'error' })).toEqual({ format: 'text', level: 'error' })
+  })
+
+  it('should reject unknown values', () => {
+    expect(() => resolveLoggingConfig({ LOG_FORMAT: 'xml' })).toThrow(/LOG_FORMAT/)
+    expect(() => resolveLoggingConfig({ LOG_LEVEL: 'verbose' })).toThrow(/LOG_LEVEL/)
+  })
+})
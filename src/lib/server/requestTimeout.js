@@ -0,0 +1,92 @@
+/**
+ * Request Timeouts
+ *
+ * Keeps a locked SQLite database or a stalled request from piling up
+ * requests. Two layers cooperate:
+ * - The SQLite connection uses a busy timeout, so a statement waiting on a
+ *   lock fails with SQLITE_BUSY instead of waiting forever.
+ * - withRequestTimeout races the handler against a timer and the request's
+ *   abort signal, returning 503 when either fires first.
+ *
+ * better-sqlite3 runs queries synchronously and awaiting their results only
+ * yields to the microtask queue, so the timer cannot fire during database
+ * work at all, not even between queries: it can only fire while the handler
+ * awaits real asynchronous I/O (such as reading the request body). Long
+ * database work is bounded only by the busy timeout on lock waits; a slow
+ * query that holds no lock runs to completion.
+ * Handlers map SQLITE_BUSY to 503 themselves (see internalError in
+ * errors.js); withRequestTimeout does the same for errors that escape them.
+ *
+ * A 503 from the timer does not cancel the handler: it keeps running, and
+ * a write it has not reached yet still commits afterwards. Clients should
+ * treat a timed-out write as having an unknown outcome and re-read before
+ * retrying it.
+ */
+
+import { jsonError, isDatabaseBusyError } from './errors.js'
+
+export { isDatabaseBusyError }
+
+/**
+ * Request deadline (see withRequestTimeout for what it can interrupt),
+ * and the SQLite busy timeout
+ */
+export const REQUEST_TIMEOUT_MS = 5000
+
+/**
+ * Error raised when a request exceeds its time budget or is aborted
+ */
+export class RequestTimeoutError extends Error {
+  constructor(message = 'Request timed out') {
+    super(message)
+    this.name = 'RequestTimeoutError'
+  }
+}
+
+/**
+ * Runs request work with a deadline
+ *
+ * Resolves to the work's Response, or to a 503 Response when the deadline
+ * passes while the work awaits asynchronous I/O, the signal is (or becomes)
+ * aborted, or the database reports it is busy.
+ * An already-aborted signal returns immediately without starting the work;
+ * work that has started is not stopped, so its writes may still commit
+ * after the 503 is sent.
+ *
+ * @param {Function} work - async () => Response
+ * @param {Object} [options]
+ * @param {AbortSignal} [options.signal] - Request abort signal
+ * @param {number} [options.timeoutMs=REQUEST_TIMEOUT_MS] - Deadline in milliseconds
+ * @returns {Promise<Response>}
+ */
+export async function withRequestTimeout(work, { signal, timeoutMs = REQUEST_TIMEOUT_MS } = {}) {
+  let timer
+  let onAbort
+
+  const deadline = new Promise((resolve, reject) => {
+    if (signal?.aborted) {
+      reject(new RequestTimeoutError('Request was canceled'))
+      return
+    }
+
+    timer = setTimeout(() => reject(new RequestTimeoutError()), timeoutMs)
+    onAbort = () => reject(new RequestTimeoutError('Request was canceled'))
+    signal?.addEventListener('abort', onAbort, { once: true })
+  })
+
+  try {
+    if (signal?.aborted) {
+      await deadline
+    }
+    return await Promise.race([work(), deadline])
+  } catch (error) {
+    if (error instanceof RequestTimeoutError || isDatabaseBusyError(error)) {
+      return jsonError(503, `Service Unavailable: ${error.message}`)
+    }
+    throw error
+  } finally {
+    clearTimeout(timer)
+    if (onAbort) signal?.removeEventListener('abort', onAbort)
+    deadline.catch(() => {})
+  }
+}
@@ -0,0 +1,71 @@
+/**
+ * Unit tests for Request Timeouts
+ */
+
+import { describe, it, expect } from 'vitest'
+import { withRequestTimeout, isDatabaseBusyError } from './requestTimeout.js'
+
+describe('withRequestTimeout', () => {
+  it('should return the handler response when it finishes in time', async () => {
+    const response = await withRequestTimeout(async () => new Response('ok'))
+
+    expect(response.status).toBe(200)
+    expect(await response.text()).toBe('ok')
+  })
+
+  it('should return 503 immediately for an already-canceled request', async () => {
+    const controller = new AbortController()
+    controller.abort()
+    let started = false
+
+    const response = await withRequestTimeout(() => {
+      started = true
+      return new Promise(() => {}) // Would block forever
+    }, { signal: controller.signal })
+
+    expect(response.status).toBe(503)
+    expect(started).toBe(false)
+  })
+
+  it('should return 503 when the handler exceeds the deadline', async () => {
+    const response = await withRequestTimeout(() => new Promise(() => {}), { timeoutMs: 10 })
+
+    expect(response.status).toBe(503)
+  })
+
+  it('should return 503 when the request is canceled mid-flight', async () => {
+    const controller = new AbortController()
+    const pending = withRequestTimeout(() => new Promise(() => {}), { signal: controller.signal })
+
+    controller.abort()
+
+    expect((await pending).status).toBe(503)
+  })
+
+  it('should return 503 when the database is busy', async () => {
+    const busy = Object.assign(new Error('database is locked'), { code: 'SQLITE_BUSY' })
+
+    const response = await withRequestTimeout(async () => { throw busy })
+
+    expect(response.status).toBe(503)
+  })
+
+  it('should rethrow unrelated errors', async () => {
+    let caught = null
+    try {
+      await withRequestTimeout(async () => { throw new Error('boom') })
+    } catch (error) {
+      caught = error
+    }
+
+    expect(caught.message).toBe('boom')
+  })
+})
+
+describe('isDatabaseBusyError', () => {
+  it('should recognize SQLite busy and locked codes', () => {
+    expect(isDatabaseBusyError({ code: 'SQLITE_BUSY' })).toBe(true)
+    expect(isDatabaseBusyError({ code: 'SQLITE_LOCKED' })).toBe(true)
+    expect(isDatabaseBusyError(new Error('other'))).toBe(false)
+  })
+})
@@ -0,0 +1,142 @@
+/**
+ * Research Suggestions Module
+ *
+ * Computes a single prioritized "next research task" for a person based on
+ * gaps in their own record and their ancestry, turning data entry into a
+ * guided checklist.
+ */
+
+import { getAncestors } from './familyGraph.js'
+
+/**
+ * Priority of each suggestion type (lower is more important)
+ * Missing parents unlock whole branches, so they outrank missing facts.
+ */
+const PRIORITY = {
+  missingParent: 1,
+  missingBirthDate: 2,
+  missingGender: 3,
+  missingAncestorParent: 4,
+  missingAncestorBirthDate: 5
+}
+
+/**
+ * Formats a person's display name for suggestion text
+ *
+ * @param {Object} person - Person record
+ * @returns {string} "First Last"
+ */
+function displayName(person) {
+  return [person.firstName, person.lastName].filter(Boolean).join(' ')
+}
+
+/**
+ * Returns which parent roles ("mother"/"father") are missing for a person
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} personId - Person ID
+ * @returns {Array<string>} Missing roles
+ */
+function missingParentRoles(graph, personId) {
+  const roles = new Set((graph.parents.get(personId) || []).map((p) => p.role))
+  return ['father', 'mother'].filter((role) => !roles.has(role))
+}
+
+/**
+ * Collects all candidate research tasks for a person, unsorted
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} personId - Person ID
+ * @returns {Array<Object>} Candidate suggestions
+ */
+export function collectResearchTasks(graph, personId) {
+  const person = graph.people.get(personId)
+  const name = displayName(person)
+  const tasks = []
+
+  for (const role of missingParentRoles(graph, personId)) {
+    tasks.push({
+      type: 'missingParent',
+      personId,
+      task: `Find ${role} of ${name}`,
+      rationale: `${name} has no recorded ${role}; adding one opens an entire ancestral branch.`,
+      generation: 0,
+      priority: PRIORITY.missingParent
+    })
+  }
+
+  if (!person.birthDate) {
+    tasks.push({
+      type: 'missingBirthDate',
+      personId,
+      task: `Add birth date for ${name}`,
+      rationale: `A birth date anchors ${name} in time and helps distinguish them from namesakes.`,
+      generation: 0,
+      priority: PRIORITY.missingBirthDate
+    })
+  }
+
+  if (!person.gender) {
+    tasks.push({
+      type: 'missingGender',
+      personId,
+      task: `Record gender for ${name}`,
+      rationale: 'Gender is needed for accurate relationship labels and GEDCOM export.',
+      generation: 0,
+      priority: PRIORITY.missingGender
+    })
+  }
+
+  // Walk ancestors nearest-first so closer gaps are suggested before distant ones
+  const ancestors = [...getAncestors(graph, personId)].sort((a, b) => a[1] - b[1] || a[0] - b[0])
+
+  for (const [ancestorId, generation] of ancestors) {
+    const ancestor = graph.people.get(ancestorId)
+    const ancestorName = displayName(ancestor)
+
+    for (const role of missingParentRoles(graph, ancestorId)) {
+      tasks.push({
+        type: 'missingAncestorParent',
+        personId: ancestorId,
+        task: `Find ${role} of ${ancestorName}`,
+        rationale: `${ancestorName} is ${name}'s ancestor (${generation} generation${generation === 1 ? '' : 's'} up) with no recorded ${role}.`,
+        generation,
+        priority: PRIORITY.missingAncestorParent
+      })
+    }
+
+    if (!ancestor.birthDate) {
+      tasks.push({
+        type: 'missingAncestorBirthDate',
+        personId: ancestorId,
+        task: `Add birth date for ${ancestorName}`,
+        rationale: `${ancestorName} is ${name}'s ancestor (${generation} generation${generation === 1 ? '' : 's'} up) with no birth date.`,
+        generation,
+        priority: PRIORITY.missingAncestorBirthDate
+      })
+    }
+  }
+
+  return tasks
+}
+
+/**
+ * Picks the single most valuable research task for a person
+ *
+ * Tasks are ordered by generation (closest first), then by priority.
+ *
+ * @param {Object} graph - Graph from buildFamilyGraph
+ * @param {number} personId - Person ID
+ * @returns {Object|null} Suggestion or null when nothing is missing
+ */
+export function suggestNextResearch(graph, personId) {
+  const tasks = collectResearchTasks(graph, personId)
+
+  if (tasks.length === 0) {
+    return null
+  }
+
+  tasks.sort((a, b) => a.generation - b.generation || a.priority - b.priority)
+
+  return tasks[0]
+}
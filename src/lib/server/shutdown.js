@@ -0,0 +1,123 @@
+/**
+ * Graceful Shutdown
+ *
+ * On SIGINT/SIGTERM, waits for in-flight requests to finish (up to a timeout)
+ * before closing the database and exiting, so deploys do not drop requests.
+ *
+ * The pieces are created by factories with injectable dependencies
+ * (process, logger, exit) so the shutdown sequence can be tested without
+ * sending real signals.
+ */
+
+/**
+ * Maximum time to wait for active requests before forcing shutdown
+ */
+export const SHUTDOWN_TIMEOUT_MS = 15000
+
+/**
+ * Signals that trigger a graceful shutdown
+ */
+export const SHUTDOWN_SIGNALS = ['SIGINT', 'SIGTERM']
+
+/**
+ * Creates a counter of in-flight requests
+ *
+ * @returns {Object} { start(), finish(), active, idle(): Promise<void> }
+ *
+ * @example
+ * const tracker = createRequestTracker()
+ * tracker.start()
+ * tracker.finish()
+ * await tracker.idle() // resolves immediately when no requests are active
+ */
+export function createRequestTracker() {
+  let active = 0
+  const waiters = new Set()
+
+  return {
+    start() {
+      active++
+    },
+    finish() {
+      active = Math.max(0, active - 1)
+      if (active === 0) {
+        for (const resolve of waiters) resolve()
+        waiters.clear()
+      }
+    },
+    get active() {
+      return active
+    },
+    idle() {
+      if (active === 0) return Promise.resolve()
+      return new Promise((resolve) => waiters.add(resolve))
+    }
+  }
+}
+
+/**
+ * Creates the shutdown sequence
+ *
+ * Logs "shutting down", waits for the tracker to go idle or the timeout to
+ * elapse, runs close(), logs "server stopped", then exits. Repeated signals
+ * while shutting down are ignored.
+ *
+ * @param {Object} options
+ * @param {Object} options.tracker - Tracker from createRequestTracker
+ * @param {Function} options.close - Releases resources (e.g. closes the database)
+ * @param {number} [options.timeoutMs=SHUTDOWN_TIMEOUT_MS] - Maximum wait for active requests
+ * @param {Object} [options.logger=console] - Logger with log/warn/error
+ * @param {Function} [options.exit] - Exit function (defaults to process.exit)
+ * @returns {Function} async shutdown(signal)
+ */
+export function createShutdown({
+  tracker,
+  close,
+  timeoutMs = SHUTDOWN_TIMEOUT_MS,
+  logger = console,
+  exit = (code) => process.exit(code)
+}) {
+  let shuttingDown = false
+
+  return async function shutdown(signal) {
+    if (shuttingDown) return
+    shuttingDown = true
+
+    logger.log(`shutting down (${signal})`)
+
+    let timer
+    const timedOut = await Promise.race([
+      tracker.idle().then(() => false),
+      new Promise((resolve) => {
+        timer = setTimeout(() => resolve(true), timeoutMs)
+      })
+    ])
+    clearTimeout(timer)
+
+    if (timedOut) {
+      logger.warn(`shutdown timeout reached with ${tracker.active} request(s) still active`)
+    }
+
+    try {
+      close()
+    } catch (error) {
+      logger.error('Error closing resources during shutdown:', error)
+    }
+
+    logger.log('server stopped')
+    exit(0)
+  }
+}
+
+/**
+ * Registers the shutdown sequence for SIGINT and SIGTERM
+ *
+ * @param {Function} shutdown - Function from createShutdown
+ * @param {Object} [processRef=process] - Process (or any EventEmitter) to listen on
+ * @returns {void}
+ */
+export function installShutdownHandlers(shutdown, processRef = process) {
+  for (const signal of SHUTDOWN_SIGNALS) {
+    processRef.once(signal, () => shutdown(signal))
+  }
+}
@@ -0,0 +1,101 @@
+/**
+ * Unit tests for Graceful Shutdown
+ */
+
+import { describe, it, expect, vi } from 'vitest'
+import { EventEmitter } from 'events'
+import { createRequestTracker, createShutdown, installShutdownHandlers } from './shutdown.js'
+
+function createLogger() {
+  return { log: vi.fn(), warn: vi.fn(), error: vi.fn() }
+}
+
+describe('createRequestTracker', () => {
+  it('should resolve idle once all active requests finish', async () => {
+    const tracker = createRequestTracker()
+    tracker.start()
+    tracker.start()
+
+    let idle = false
+    const waiting = tracker.idle().then(() => { idle = true })
+
+    tracker.finish()
+    await Promise.resolve()
+    expect(idle).toBe(false)
+
+    tracker.finish()
+    await waiting
+    expect(idle).toBe(true)
+    expect(tracker.active).toBe(0)
+  })
+})
+
+describe('createShutdown', () => {
+  it('should wait for active requests before closing and exiting', async () => {
+    const tracker = createRequestTracker()
+    const logger = createLogger()
+    const events = []
+    const shutdown = createShutdown({
+      tracker,
+      logger,
+      close: () => events.push('close'),
+      exit: (code) => events.push(`exit ${code}`)
+    })
+
+    tracker.start()
+    const done = shutdown('SIGTERM')
+    await Promise.resolve()
+    expect(events).toEqual([])
+
+    events.push('request finished')
+    tracker.finish()
+    await done
+
+    expect(events).toEqual(['request finished', 'close', 'exit 0'])
+    expect(logger.log).toHaveBeenCalledWith('shutting down (SIGTERM)')
+    expect(logger.log).toHaveBeenCalledWith('server stopped')
+    expect(logger.warn).not.toHaveBeenCalled()
+  })
+
+  it('should force shutdown after the timeout', async () => {
+    const tracker = createRequestTracker()
+    const logger = createLogger()
+    const close = vi.fn()
+    const exit = vi.fn()
+    const shutdown = createShutdown({ tracker, logger, close, exit, timeoutMs: 10 })
+
+    tracker.start()
+    await shutdown('SIGINT')
+
+    expect(logger.warn).toHaveBeenCalled()
+    expect(close).toHaveBeenCalledTimes(1)
+    expect(exit).toHaveBeenCalledWith(0)
+  })
+
+  it('should ignore repeated signals', async () => {
+    const close = vi.fn()
+    const shutdown = createShutdown({
+      tracker: createRequestTracker(),
+      logger: createLogger(),
+      close,
+      exit: vi.fn()
+    })
+
+    await Promise.all([shutdown('SIGTERM'), shutdown('SIGTERM')])
+
+    expect(close).toHaveBeenCalledTimes(1)
+  })
+})
+
+describe('installShutdownHandlers', () => {
+  it('should run shutdown on SIGINT and SIGTERM', () => {
+    const processRef = new EventEmitter()
+    const shutdown = vi.fn()
+
+    installShutdownHandlers(shutdown, processRef)
+    processRef.emit('SIGTERM')
+
+    expect(shutdown).toHaveBeenCalledWith('SIGTERM')
+    expect(processRef.listenerCount('SIGINT')).toBe(1)
+  })
+})
@@ -0,0 +1,243 @@
+/**
+ * Snapshots Module
+ *
+ * Stores labeled point-in-time copies of the whole tree (all people and
+ * relationships) and restores the tree from them. Snapshot data keeps full
+ * database rows, including soft-deleted people and their IDs, so restoring
+ * brings back exactly the state that was captured.
+ */
+
+import { people, relationships, snapshots } from '../db/schema.js'
+import { eq, desc, sql } from 'drizzle-orm'
+
+/**
+ * Maximum length of a snapshot label
+ */
+export const MAX_SNAPSHOT_LABEL_LENGTH = 100
+
+/**
+ * Rows inserted per statement during restore, keeping well under
+ * SQLite's bound-parameter limit
+ */
+const RESTORE_CHUNK_SIZE = 200
+
+/**
+ * Converts SQLite datetime string to RFC3339 format
+ *
+ * @param {string} sqliteDateTime - SQLite datetime string ("YYYY-MM-DD HH:MM:SS")
+ * @returns {string} RFC3339 formatted datetime string
+ */
+function toRFC3339(sqliteDateTime) {
+  if (!sqliteDateTime) return sqliteDateTime
+  return sqliteDateTime.replace(' ', 'T') + 'Z'
+}
+
+/**
+ * Validates a snapshot label
+ *
+ * @param {*} label - Label from the request body
+ * @returns {Object} { valid: boolean, error: string|null }
+ */
+export function validateSnapshotLabel(label) {
+  if (typeof label !== 'string' || label.trim() === '') {
+    return { valid: false, error: 'label is required' }
+  }
+  if (label.trim().length > MAX_SNAPSHOT_LABEL_LENGTH) {
+    return { valid: false, error: `label must be at most ${MAX_SNAPSHOT_LABEL_LENGTH} characters` }
+  }
+  return { valid: true, error: null }
+}
+
+/**
+ * Transforms a snapshot row to API format, including its people and relationships
+ *
+ * @param {Object} row - Snapshot row
+ * @returns {Object} { id, label, createdAt, people, relationships }
+ */
+export function transformSnapshotToAPI(row) {
+  const data = JSON.parse(row.data)
+
+  return {
+    id: row.id,
+    label: row.label,
+    createdAt: toRFC3339(row.createdAt),
+    people: data.people,
+    relationships: data.relationships
+  }
+}
+
+/**
+ * Stores a snapshot of the current tree
+ *
+ * @param {Object} db - Drizzle database instance
+ * @param {string} label - Snapshot label (trimmed before storing)
+ * @returns {Promise<Object>} Created snapshot in API format
+ */
+export async function createSnapshot(db, label) {
+  return db.transaction((tx) => {
+    const data = {
+      people: tx.select().from(people).orderBy(people.id).all(),
+      relationships: tx.select().from(relationships).orderBy(relationships.id).all()
+    }
+
+    const row = tx
+      .insert(snapshots)
+      .values({ label: label.trim(), data: JSON.stringify(data) })
+      .returning()
+      .get()
+
+    return transformSnapshotToAPI(row)
+  })
+}
+
+/**
+ * Lists snapshots, newest first, without their data
+ *
+ * @param {Object} db - Drizzle database instance
+ * @returns {Promise<Array>} [{ id, label, createdAt, peopleCount, relationshipCount }]
+ */
+export async function listSnapshots(db) {
+  const rows = await db
+    .select({
+      id: snapshots.id,
+      label: snapshots.label,
+      createdAt: snapshots.createdAt,
+      peopleCount: sql`json_array_length(${snapshots.data}, '$.people')`.mapWith(Number),
+      relationshipCount: sql`json_array_length(${snapshots.data}, '$.relationships')`.mapWith(Number)
+    })
+    .from(snapshots)
+    .orderBy(desc(snapshots.id))
+
+  return rows.map((row) => ({ ...row, createdAt: toRFC3339(row.createdAt) }))
+}
+
+/**
+ * Loads a snapshot with its data
+ *
+ * @param {Object} db - Drizzle database instance
+ * @param {number} snapshotId - Snapshot ID
+ * @returns {Promise<Object|null>} Snapshot in API format, or null if not found
+ */
+export async function getSnapshot(db, snapshotId) {
+  const [row] = await db.select().from(snapshots).where(eq(snapshots.id, snapshotId)).limit(1)
+
+  return row ? transformSnapshotToAPI(row) : null
+}
+
+/**
+ * Inserts rows in chunks
+ *
+ * @param {Object} tx - Drizzle transaction
+ * @param {Object} table - Drizzle table
+ * @param {Array<Object>} rows - Rows to insert
+ */
+function insertInChunks(tx, table, rows) {
+  for (let i = 0; i < rows.length; i += RESTORE_CHUNK_SIZE) {
+    tx.insert(table).values(rows.slice(i, i + RESTORE_CHUNK_SIZE)).run()
+  }
+}
+
+/**
+ * Replaces the whole tree with the contents of a snapshot
+ *
+ * Runs in a single transaction: all current people and relationships are
+ * removed, then the snapshot rows are inserted with their original IDs.
+ * Snapshots themselves are left untouched.
+ *
+ * @param {Object} db - Drizzle database instance
+ * @param {number} snapshotId - Snapshot ID
+ * @returns {Promise<Object|null>} { snapshotId, label, peopleRestored, relationshipsRestored }, or null if not found
+ */
+export async function restoreSnapshot(db, snapshotId) {
+  return db.transaction((tx) => {
+    const row = tx.select().from(snapshots).where(eq(snapshots.id, snapshotId)).get()
+    if (!row) return null
+
+    const data = JSON.parse(row.data)
+
+    tx.delete(relationships).run()
+    tx.delete(people).run()
+
+    insertInChunks(tx, people, data.people)
+    insertInChunks(tx, relationships, data.relationships)
+
+    return {
+      snapshotId: row.id,
+      label: row.label,
+      peopleRestored: data.people.length,
+      relationshipsRestored: data.relationships.length
+    }
+  })
+}
+
+/**
+ * Lists the fields whose values differ between two versions of a row
+ *
+ * @param {Object} before - Row in the earlier snapshot
+ * @param {Object} after - Row in the later snapshot
+ * @returns {Object} { field: { from, to } } for each differing field
+ */
+function diffRow(before, after) {
+  const changes = {}
+  const fields = new Set([...Object.keys(before), ...Object.keys(after)])
+
+  for (const field of fields) {
+    const from = before[field] ?? null
+    const to = after[field] ?? null
+    if (from !== to) {
+      changes[field] = { from, to }
+    }
+  }
+
+  return changes
+}
+
+/**
+ * Diffs two lists of rows keyed by ID
+ *
+ * @param {Array<Object>} fromRows - Rows in the earlier snapshot
+ * @param {Array<Object>} toRows - Rows in the later snapshot
+ * @returns {Object} { added: [row], removed: [row], changed: [{ id, changes }] }, each ordered by ID
+ */
+function diffRows(fromRows, toRows) {
+  const fromById = new Map(fromRows.map((row) => [row.id, row]))
+  const toById = new Map(toRows.map((row) => [row.id, row]))
+  const byId = (a, b) => a.id - b.id
+
+  const added = toRows.filter((row) => !fromById.has(row.id)).sort(byId)
+  const removed = fromRows.filter((row) => !toById.has(row.id)).sort(byId)
+  const changed = []
+
+  for (const row of [...toRows].sort(byId)) {
+    const before = fromById.get(row.id)
+    if (!before) continue
+
+    const changes = diffRow(before, row)
+    if (Object.keys(changes).length > 0) {
+      changed.push({ id: row.id, changes })
+    }
+  }
+
+  return { added, removed, changed }
+}
+
+/**
+ * Computes the people and relationships added, removed, and changed
+ * between two snapshots
+ *
+ * Rows are matched by ID. A soft delete shows up as a change to deletedAt.
+ *
+ * @param {Object} from - Earlier snapshot (API format, with people and relationships)
+ * @param {Object} to - Later snapshot (API format, with people and relationships)
+ * @returns {Object} { people: { added, removed, changed }, relationships: { added, removed, changed } }
+ *
+ * @example
+ * diffSnapshots(before, after).people.changed
+ * // [{ id: 1, changes: { firstName: { from: 'John', to: 'Johnny' } } }]
+ */
+export function diffSnapshots(from, to) {
+  return {
+    people: diffRows(from.people, to.people),
+    relationships: diffRows(from.relationships, to.relationships)
+  }
+}
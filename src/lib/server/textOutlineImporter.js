@@ -0,0 +1,139 @@
+/**
+ * Text Outline Importer Module
+ *
+ * Parses a low-friction plain-text family tree: one person per line, with
+ * indentation (tabs or spaces) nesting children under their parent.
+ *
+ *   John Smith (M)
+ *       Mary
+ *       Robert Smith-Jones
+ *           Anne (F)
+ *
+ * - The last word of a name is the last name; a single word takes the
+ *   last name of the nearest ancestor that has one
+ * - An optional "(M)" or "(F)" suffix sets gender and the parent role
+ *   used when linking the person's children
+ * - Blank lines and lines starting with "#" are ignored
+ */
+
+import { validatePersonData } from './personHelpers.js'
+
+/**
+ * Columns a tab counts for when measuring indentation
+ */
+const TAB_WIDTH = 4
+
+/**
+ * Maximum number of people in one outline
+ */
+export const MAX_OUTLINE_PEOPLE = 2000
+
+const GENDER_MARKERS = { m: 'male', f: 'female' }
+const PARENT_ROLES = { male: 'father', female: 'mother' }
+
+/**
+ * Measures the indentation of a line in columns
+ *
+ * @param {string} line - Raw line
+ * @returns {number} Indentation width
+ */
+function indentWidth(line) {
+  let width = 0
+  for (const char of line) {
+    if (char === ' ') width++
+    else if (char === '\t') width += TAB_WIDTH
+    else break
+  }
+  return width
+}
+
+/**
+ * Splits a line's text into name parts and gender
+ *
+ * @param {string} text - Trimmed line text
+ * @returns {Object} { firstName, lastName: string|null, gender: string|null }
+ */
+function parseName(text) {
+  let gender = null
+  const marker = text.match(/\s*\(([mf])\)$/i)
+  if (marker) {
+    gender = GENDER_MARKERS[marker[1].toLowerCase()]
+    text = text.slice(0, marker.index)
+  }
+
+  const words = text.split(/\s+/).filter(Boolean)
+  if (words.length < 2) {
+    return { firstName: words[0] || '', lastName: null, gender }
+  }
+
+  return { firstName: words.slice(0, -1).join(' '), lastName: words[words.length - 1], gender }
+}
+
+/**
+ * Parses an indented outline into people with parent links
+ *
+ * Entries are returned in outline order; parentIndex points at an earlier
+ * entry (or is null for top-level people).
+ *
+ * @param {string} text - Outline text
+ * @returns {Object} { valid: true, entries: [{ line, firstName, lastName, gender, parentIndex }] }
+ *   or { valid: false, line, error }
+ *
+ * @example
+ * parseTextOutline('John Smith\n  Mary').entries
+ * // [{ line: 1, firstName: 'John', lastName: 'Smith', gender: null, parentIndex: null },
+ * //  { line: 2, firstName: 'Mary', lastName: 'Smith', gender: null, parentIndex: 0 }]
+ */
+export function parseTextOutline(text) {
+  const entries = []
+  // Open ancestors of the current line: [{ indent, index }]
+  const stack = []
+  const lines = String(text).split(/\r?\n/)
+
+  for (let i = 0; i < lines.length; i++) {
+    const raw = lines[i]
+    const content = raw.trim()
+    const line = i + 1
+    if (content === '' || content.startsWith('#')) continue
+
+    if (entries.length >= MAX_OUTLINE_PEOPLE) {
+      return { valid: false, line, error: `An outline can contain at most ${MAX_OUTLINE_PEOPLE} people` }
+    }
+
+    const indent = indentWidth(raw)
+    while (stack.length > 0 && stack[stack.length - 1].indent >= indent) {
+      stack.pop()
+    }
+    const parentIndex = stack.length > 0 ? stack[stack.length - 1].index : null
+
+    const { firstName, lastName, gender } = parseName(content)
+    const inheritedLastName = lastName ?? (parentIndex !== null ? entries[parentIndex].lastName : null)
+    if (!inheritedLastName) {
+      return { valid: false, line, error: 'A last name is required for people without a parent in the outline' }
+    }
+
+    const validation = validatePersonData({ firstName, lastName: inheritedLastName, gender })
+    if (!validation.valid) {
+      return { valid: false, line, error: validation.error }
+    }
+
+    entries.push({ line, firstName, lastName: inheritedLastName, gender, parentIndex })
+    stack.push({ indent, index: entries.length - 1 })
+  }
+
+  if (entries.length === 0) {
+    return { valid: false, line: null, error: 'The outline does not contain any people' }
+  }
+
+  return { valid: true, entries }
+}
+
+/**
+ * Returns the parent role implied by a parent's gender
+ *
+ * @param {string|null} gender - Parent gender
+ * @returns {string|null} "father", "mother", or null when unknown
+ */
+export function parentRoleForGender(gender) {
+  return PARENT_ROLES[gender] || null
+}
@@ -0,0 +1,53 @@
+/**
+ * Unit tests for Text Outline Importer Module
+ */
+
+import { describe, it, expect } from 'vitest'
+import { parseTextOutline, parentRoleForGender } from './textOutlineImporter.js'
+
+describe('parseTextOutline', () => {
+  it('should nest lines under the nearest less-indented line', () => {
+    const result = parseTextOutline('A Smith\n  B\n    C\n  D\nE Jones')
+
+    expect(result.valid).toBe(true)
+    expect(result.entries.map(({ firstName, parentIndex }) => [firstName, parentIndex])).toEqual([
+      ['A', null], ['B', 0], ['C', 1], ['D', 0], ['E', null]
+    ])
+  })
+
+  it('should treat a tab like four spaces', () => {
+    const result = parseTextOutline('A Smith\n\tB\n    C')
+
+    expect(result.entries.map((entry) => entry.parentIndex)).toEqual([null, 0, 0])
+  })
+
+  it('should inherit the last name from the nearest ancestor', () => {
+    const result = parseTextOutline('Old Smith\n  Young Jones\n    Baby')
+
+    expect(result.entries[2].lastName).toBe('Jones')
+  })
+
+  it('should use all but the last word as the first name', () => {
+    const result = parseTextOutline('Mary Ann Smith (f)')
+
+    expect(result.entries[0]).toMatchObject({ firstName: 'Mary Ann', lastName: 'Smith', gender: 'female' })
+  })
+
+  it('should skip blank and comment lines but report original line numbers', () => {
+    const result = parseTextOutline('# family\n\nA Smith\n\n  B')
+
+    expect(result.entries.map((entry) => entry.line)).toEqual([3, 5])
+  })
+
+  it('should report the line of a top-level person without a last name', () => {
+    expect(parseTextOutline('A Smith\nB')).toMatchObject({ valid: false, line: 2 })
+  })
+})
+
+describe('parentRoleForGender', () => {
+  it('should map gender to parent role', () => {
+    expect(parentRoleForGender('male')).toBe('father')
+    expect(parentRoleForGender('female')).toBe('mother')
+    expect(parentRoleForGender(null)).toBeNull()
+  })
+})
@@ -0,0 +1,208 @@
+/**
+ * Tree Export Module
+ *
+ * Loads the whole tree (non-deleted people and the relationships between
+ * them) in API format for the JSON export endpoints, serializes it
+ * deterministically so successive exports can be diffed as text, and
+ * imports a tree backup document back into the database.
+ */
+
+import { people, relationships } from '../db/schema.js'
+import { and, asc, isNull, isNotNull, notInArray } from 'drizzle-orm'
+import { transformPeopleToAPI, validatePersonData, buildPersonInsertValues } from './personHelpers.js'
+import {
+  transformRelationshipsToAPI,
+  validateRelationshipData,
+  normalizeRelationship,
+  relationshipDateValues
+} from './relationshipHelpers.js'
+import { createImportBatchId } from './importBatches.js'
+
+/**
+ * Format version of tree backup documents (GET /api/export/tree)
+ */
+export const TREE_EXPORT_VERSION = 1
+
+/**
+ * Loads all non-deleted people and their relationships, ordered by ID
+ *
+ * @param {Object} database - Drizzle database instance
+ * @returns {Promise<{people: Array, relationships: Array}>} Tree in API format
+ */
+export async function loadTree(database) {
+  const allPeople = await database
+    .select()
+    .from(people)
+    .where(isNull(people.deletedAt))
+    .orderBy(asc(people.id))
+
+  const deletedPeople = database
+    .select({ id: people.id })
+    .from(people)
+    .where(isNotNull(people.deletedAt))
+
+  const allRelationships = await database
+    .select()
+    .from(relationships)
+    .where(
+      and(
+        notInArray(relationships.person1Id, deletedPeople),
+        notInArray(relationships.person2Id, deletedPeople)
+      )
+    )
+    .orderBy(asc(relationships.id))
+
+  return {
+    people: transformPeopleToAPI(allPeople),
+    relationships: transformRelationshipsToAPI(allRelationships)
+  }
+}
+
+/**
+ * Serializes a value as indented JSON with object keys sorted at every level
+ * Arrays keep their order. The output ends with a newline, as text files
+ * under version control conventionally do.
+ *
+ * @param {*} value - JSON-compatible value
+ * @returns {string} Deterministic JSON text
+ */
+export function stableStringify(value) {
+  return JSON.stringify(sortKeys(value), null, 2) + '\n'
+}
+
+/**
+ * Recursively copies a value with object keys in sorted order
+ *
+ * @param {*} value - JSON-compatible value
+ * @returns {*} Copy with sorted keys
+ */
+function sortKeys(value) {
+  if (Array.isArray(value)) {
+    return value.map(sortKeys)
+  }
+
+  if (value !== null && typeof value === 'object') {
+    const sorted = {}
+    for (const key of Object.keys(value).sort()) {
+      if (value[key] !== undefined) {
+        sorted[key] = sortKeys(value[key])
+      }
+    }
+    return sorted
+  }
+
+  return value
+}
+
+/**
+ * Validates a tree backup document before import
+ *
+ * Every person must pass person validation (accepting stored values such as
+ * partial GEDCOM dates, so any export can be restored) and have a unique
+ * integer id;
+ * every relationship must pass relationship validation, reference people
+ * within the document, and have an integer sortOrder if it has one.
+ *
+ * @param {*} document - Parsed request body
+ * @returns {Object} { valid: boolean, error: string|null }
+ */
+export function validateTreeDocument(document) {
+  if (!document || typeof document !== 'object' || Array.isArray(document)) {
+    return { valid: false, error: 'Request body must be a tree export document' }
+  }
+
+  if (document.version !== TREE_EXPORT_VERSION) {
+    return { valid: false, error: `Unsupported version (expected ${TREE_EXPORT_VERSION})` }
+  }
+
+  if (!Array.isArray(document.people) || !Array.isArray(document.relationships)) {
+    return { valid: false, error: 'people and relationships must be arrays' }
+  }
+
+  const personIds = new Set()
+  for (const [index, person] of document.people.entries()) {
+    if (!person || typeof person !== 'object' || !Number.isInteger(person.id)) {
+      return { valid: false, error: `people[${index}]: id must be an integer` }
+    }
+    if (personIds.has(person.id)) {
+      return { valid: false, error: `people[${index}]: duplicate id ${person.id}` }
+    }
+    const validation = validatePersonData(person, { storedValues: true })
+    if (!validation.valid) {
+      return { valid: false, error: `people[${index}]: ${validation.error}` }
+    }
+    personIds.add(person.id)
+  }
+
+  for (const [index, relationship] of document.relationships.entries()) {
+    if (!relationship || typeof relationship !== 'object') {
+      return { valid: false, error: `relationships[${index}]: must be an object` }
+    }
+    const validation = validateRelationshipData(relationship)
+    if (!validation.valid) {
+      return { valid: false, error: `relationships[${index}]: ${validation.error}` }
+    }
+    if (!personIds.has(relationship.person1Id) || !personIds.has(relationship.person2Id)) {
+      return { valid: false, error: `relationships[${index}]: references a person not in the document` }
+    }
+    if (relationship.sortOrder !== undefined && relationship.sortOrder !== null && !Number.isInteger(relationship.sortOrder)) {
+      return { valid: false, error: `relationships[${index}]: sortOrder must be an integer` }
+    }
+  }
+
+  return { valid: true, error: null }
+}
+
+/**
+ * Imports a validated tree backup document in one transaction
+ *
+ * People get new IDs (so the document can be loaded into a database that
+ * already has data); relationships are remapped onto those IDs. All rows
+ * are tagged with a new import batch ID.
+ *
+ * @param {Object} database - Drizzle database instance
+ * @param {Object} document - Document that passed validateTreeDocument
+ * @returns {{batchId: string, peopleImported: number, relationshipsImported: number, idMap: Object}}
+ *   idMap maps document person IDs to the new database IDs
+ */
+export function importTree(database, document) {
+  const batchId = createImportBatchId()
+
+  return database.transaction((tx) => {
+    const idMap = {}
+
+    for (const person of document.people) {
+      const inserted = tx
+        .insert(people)
+        .values({ ...buildPersonInsertValues(person), importBatch: batchId })
+        .returning({ id: people.id })
+        .get()
+      idMap[person.id] = inserted.id
+    }
+
+    for (const relationship of document.relationships) {
+      const normalized = normalizeRelationship(
+        idMap[relationship.person1Id],
+        idMap[relationship.person2Id],
+        relationship.type,
+        relationship.parentRole,
+        relationship.relationKind
+      )
+      tx.insert(relationships)
+        .values({
+          ...normalized,
+          ...relationshipDateValues(relationship),
+          sortOrder: relationship.sortOrder ?? null,
+          importBatch: batchId
+        })
+        .run()
+    }
+
+    return {
+      batchId,
+      peopleImported: document.people.length,
+      relationshipsImported: document.relationships.length,
+      idMap
+    }
+  })
+}
@@ -0,0 +1,74 @@
+/**
+ * Unit tests for Tree Export Module
+ */
+
+import { describe, it, expect } from 'vitest'
+import { stableStringify, validateTreeDocument, TREE_EXPORT_VERSION } from './treeExport.js'
+
+describe('stableStringify', () => {
+  it('should sort object keys at every level and keep array order', () => {
+    const text = stableStringify({ b: 1, a: [{ z: true, y: null }, 2] })
+
+    expect(text).toBe('{\n  "a": [\n    {\n      "y": null,\n      "z": true\n    },\n    2\n  ],\n  "b": 1\n}\n')
+  })
+
+  it('should produce identical text regardless of insertion order', () => {
+    expect(stableStringify({ id: 1, name: 'John' })).toBe(stableStringify({ name: 'John', id: 1 }))
+  })
+
+  it('should drop undefined properties like JSON.stringify', () => {
+    expect(stableStringify({ a: undefined, b: 1 })).toBe('{\n  "b": 1\n}\n')
+  })
+})
+
+describe('validateTreeDocument', () => {
+  const document = (overrides = {}) => ({
+    version: TREE_EXPORT_VERSION,
+    people: [
+      { id: 10, firstName: 'John', lastName: 'Smith' },
+      { id: 11, firstName: 'Mary', lastName: 'Smith' }
+    ],
+    relationships: [{ person1Id: 10, person2Id: 11, type: 'father', parentRole: 'father' }],
+    ...overrides
+  })
+
+  it('should accept a well-formed document', () => {
+    expect(validateTreeDocument(document())).toEqual({ valid: true, error: null })
+  })
+
+  it('should accept partial dates as stored by GEDCOM imports', () => {
+    const imported = document({
+      people: [
+        { id: 10, firstName: 'John', lastName: 'Smith', birthDate: '1950', deathDate: '2001-03' },
+        { id: 11, firstName: 'Mary', lastName: 'Smith' }
+      ]
+    })
+
+    expect(validateTreeDocument(imported)).toEqual({ valid: true, error: null })
+  })
+
+  it('should reject an unsupported version', () => {
+    expect(validateTreeDocument(document({ version: 99 })).error).toContain('version')
+  })
+
+  it('should reject missing arrays', () => {
+    expect(validateTreeDocument(document({ people: undefined })).valid).toBe(false)
+    expect(validateTreeDocument([]).valid).toBe(false)
+  })
+
+  it('should report the index of an invalid or duplicate person', () => {
+    const invalid = document({ people: [{ id: 10, firstName: 'John', lastName: 'Smith' }, { id: 11, firstName: '' }] })
+    expect(validateTreeDocument(invalid).error).toMatch(/^people\[1\]: firstName/)
+
+    const duplicate = document({ people: [{ id: 10, firstName: 'A', lastName: 'B' }, { id: 10, firstName: 'C', lastName: 'D' }] })
+    expect(validateTreeDocument(duplicate).error).toBe('people[1]: duplicate id 10')
+  })
+
+  it('should reject relationships pointing outside the document', () => {
+    const result = validateTreeDocument(
+      document({ relationships: [{ person1Id: 10, person2Id: 99, type: 'spouse' }] })
+    )
+
+    expect(result.error).toBe('relationships[0]: references a person not in the document')
+  })
+})
@@ -0,0 +1,222 @@
+/**
+ * Tree Validation Module
+ *
+ * A read-only "lint" pass over the whole tree that reports data that cannot
+ * be right: impossible dates, conflicting parents, parent loops, and people
+ * related to themselves. Nothing is modified; see parentDirectionRepair.js
+ * and integrityCheck.js for the repairs the app can make.
+ */
+
+import { baseParentRole, effectiveRelationKind } from './relationshipHelpers.js'
+import { isClearlyLater } from './parentDirectionRepair.js'
+
+/**
+ * Issue types, in the order issues are reported
+ */
+export const ISSUE_TYPES = {
+  selfRelationship: 'selfRelationship',
+  deathBeforeBirth: 'deathBeforeBirth',
+  parentYoungerThanChild: 'parentYoungerThanChild',
+  multipleBiologicalParents: 'multipleBiologicalParents',
+  parentCycle: 'parentCycle'
+}
+
+const ISSUE_ORDER = Object.values(ISSUE_TYPES)
+
+/**
+ * Formats a person's name for issue messages
+ *
+ * @param {Object} person - Person record
+ * @returns {string} "First Last" followed by the ID
+ */
+function describePerson(person) {
+  return `${[person.firstName, person.lastName].filter(Boolean).join(' ')} (#${person.id})`
+}
+
+/**
+ * Finds groups of people whose parentOf edges form a loop
+ *
+ * Uses Tarjan's strongly connected components over parent → child edges;
+ * every component with more than one person contains at least one cycle.
+ *
+ * @param {Array<number>} personIds - Person IDs
+ * @param {Array<Object>} parentRows - parentOf relationship rows
+ * @returns {Array<Array<number>>} Sorted person IDs of each loop
+ */
+function findParentCycles(personIds, parentRows) {
+  const children = new Map(personIds.map((id) => [id, []]))
+  for (const row of parentRows) {
+    children.get(row.person1Id).push(row.person2Id)
+  }
+
+  const index = new Map()
+  const lowLink = new Map()
+  const onStack = new Set()
+  const stack = []
+  const cycles = []
+  let counter = 0
+
+  for (const start of personIds) {
+    if (index.has(start)) continue
+
+    // Iterative depth-first search: each frame is [personId, next child position]
+    const frames = [[start, 0]]
+    index.set(start, counter)
+    lowLink.set(start, counter++)
+    stack.push(start)
+    onStack.add(start)
+
+    while (frames.length > 0) {
+      const frame = frames[frames.length - 1]
+      const [id, position] = frame
+      const childIds = children.get(id)
+
+      if (position < childIds.length) {
+        frame[1]++
+        const childId = childIds[position]
+        if (!index.has(childId)) {
+          index.set(childId, counter)
+          lowLink.set(childId, counter++)
+          stack.push(childId)
+          onStack.add(childId)
+          frames.push([childId, 0])
+        } else if (onStack.has(childId)) {
+          lowLink.set(id, Math.min(lowLink.get(id), index.get(childId)))
+        }
+        continue
+      }
+
+      frames.pop()
+      if (frames.length > 0) {
+        const parentId = frames[frames.length - 1][0]
+        lowLink.set(parentId, Math.min(lowLink.get(parentId), lowLink.get(id)))
+      }
+
+      if (lowLink.get(id) === index.get(id)) {
+        const component = []
+        let memberId
+        do {
+          memberId = stack.pop()
+          onStack.delete(memberId)
+          component.push(memberId)
+        } while (memberId !== id)
+
+        if (component.length > 1) {
+          cycles.push(component.sort((a, b) => a - b))
+        }
+      }
+    }
+  }
+
+  return cycles
+}
+
+/**
+ * Scans people and relationships for data integrity issues
+ *
+ * Only relationships between people in the list are checked, so pass
+ * active people to ignore soft-deleted ones. Dates are compared at their
+ * common precision (see isClearlyLater), so "1950" vs "1950-06-01" is not
+ * an issue.
+ *
+ * Checks:
+ * - selfRelationship: a relationship links a person to themselves
+ * - deathBeforeBirth: a person's death date is before their birth date
+ * - parentYoungerThanChild: a parent was born after their child
+ * - multipleBiologicalParents: a child has more than one biological mother
+ *   (or father); adoptive and step parents may repeat
+ * - parentCycle: people are (indirectly) their own ancestors
+ *
+ * @param {Array} peopleRows - Person records
+ * @param {Array} relationshipRows - Relationship records (normalized database format)
+ * @returns {Array<{type: string, message: string, personIds: number[], relationshipIds: number[]}>}
+ *   Issues ordered by type (ISSUE_TYPES order), then by first person ID
+ */
+export function findTreeIssues(peopleRows, relationshipRows) {
+  const peopleById = new Map(peopleRows.map((person) => [person.id, person]))
+  const rows = relationshipRows.filter(
+    (row) => peopleById.has(row.person1Id) && peopleById.has(row.person2Id)
+  )
+  const issues = []
+
+  for (const row of rows) {
+    if (row.person1Id === row.person2Id) {
+      issues.push({
+        type: ISSUE_TYPES.selfRelationship,
+        message: `${describePerson(peopleById.get(row.person1Id))} has a ${row.type} relationship with themselves`,
+        personIds: [row.person1Id],
+        relationshipIds: [row.id]
+      })
+    }
+  }
+
+  for (const person of peopleRows) {
+    if (person.birthDate && person.deathDate && isClearlyLater(person.birthDate, person.deathDate)) {
+      issues.push({
+        type: ISSUE_TYPES.deathBeforeBirth,
+        message: `${describePerson(person)} died (${person.deathDate}) before they were born (${person.birthDate})`,
+        personIds: [person.id],
+        relationshipIds: []
+      })
+    }
+  }
+
+  const parentRows = rows.filter((row) => row.type === 'parentOf' && row.person1Id !== row.person2Id)
+
+  for (const row of parentRows) {
+    const parent = peopleById.get(row.person1Id)
+    const child = peopleById.get(row.person2Id)
+    if (parent.birthDate && child.birthDate && isClearlyLater(parent.birthDate, child.birthDate)) {
+      issues.push({
+        type: ISSUE_TYPES.parentYoungerThanChild,
+        message: `${describePerson(parent)} (born ${parent.birthDate}) is recorded as a parent of ` +
+          `${describePerson(child)} (born ${child.birthDate})`,
+        personIds: [parent.id, child.id],
+        relationshipIds: [row.id]
+      })
+    }
+  }
+
+  const biologicalParents = new Map()
+  for (const row of parentRows) {
+    const role = baseParentRole(row.parentRole)
+    if (effectiveRelationKind(row.parentRole, row.relationKind) !== 'biological' || !role) continue
+
+    const key = `${row.person2Id}:${role}`
+    if (!biologicalParents.has(key)) biologicalParents.set(key, [])
+    biologicalParents.get(key).push(row)
+  }
+
+  for (const group of biologicalParents.values()) {
+    if (group.length < 2) continue
+    const child = peopleById.get(group[0].person2Id)
+    const sorted = [...group].sort((a, b) => a.person1Id - b.person1Id)
+    issues.push({
+      type: ISSUE_TYPES.multipleBiologicalParents,
+      message: `${describePerson(child)} has ${group.length} biological ${baseParentRole(group[0].parentRole)}s: ` +
+        sorted.map((row) => describePerson(peopleById.get(row.person1Id))).join(', '),
+      personIds: [child.id, ...sorted.map((row) => row.person1Id)],
+      relationshipIds: sorted.map((row) => row.id)
+    })
+  }
+
+  for (const cycle of findParentCycles([...peopleById.keys()], parentRows)) {
+    const members = new Set(cycle)
+    issues.push({
+      type: ISSUE_TYPES.parentCycle,
+      message: `${cycle.map((id) => describePerson(peopleById.get(id))).join(', ')} are recorded as their own ancestors`,
+      personIds: cycle,
+      relationshipIds: parentRows
+        .filter((row) => members.has(row.person1Id) && members.has(row.person2Id))
+        .map((row) => row.id)
+        .sort((a, b) => a - b)
+    })
+  }
+
+  issues.sort((a, b) =>
+    ISSUE_ORDER.indexOf(a.type) - ISSUE_ORDER.indexOf(b.type) ||
+    a.personIds[0] - b.personIds[0]
+  )
+
+  return issues
+}
@@ -0,0 +1,62 @@
+/**
+ * Unit tests for Tree Validation Module
+ */
+
+import { describe, it, expect } from 'vitest'
+import { findTreeIssues, ISSUE_TYPES } from './treeValidation.js'
+
+function person(id, birthDate = null, deathDate = null) {
+  return { id, firstName: `P${id}`, lastName: 'Test', birthDate, deathDate }
+}
+
+function parentOf(id, person1Id, person2Id, parentRole, relationKind = null) {
+  return { id, person1Id, person2Id, type: 'parentOf', parentRole, relationKind }
+}
+
+describe('findTreeIssues', () => {
+  it('should return no issues for consistent data', () => {
+    const people = [person(1, '1900', '1970'), person(2, '1930-05-01')]
+
+    expect(findTreeIssues(people, [parentOf(1, 1, 2, 'father')])).toEqual([])
+  })
+
+  it('should compare dates at their common precision', () => {
+    expect(findTreeIssues([person(1, '1950-06-01', '1950')], [])).toEqual([])
+    expect(findTreeIssues([person(1, '1950-06-01', '1950-05-31')], [])[0].type).toBe(ISSUE_TYPES.deathBeforeBirth)
+  })
+
+  it('should allow adoptive and step parents alongside a biological one', () => {
+    const people = [person(1), person(2), person(3), person(4)]
+    const relationships = [
+      parentOf(1, 1, 4, 'mother'),
+      parentOf(2, 2, 4, 'mother', 'adoptive'),
+      parentOf(3, 3, 4, 'stepMother')
+    ]
+
+    expect(findTreeIssues(people, relationships)).toEqual([])
+  })
+
+  it('should report each loop once with the edges inside it', () => {
+    // 1 -> 2 -> 3 -> 1 is a loop; 3 -> 4 leaves it; 5 -> 6 -> 5 is another
+    const people = [1, 2, 3, 4, 5, 6].map((id) => person(id))
+    const relationships = [
+      parentOf(1, 1, 2, 'father'),
+      parentOf(2, 2, 3, 'father'),
+      parentOf(3, 3, 1, 'father'),
+      parentOf(4, 3, 4, 'father'),
+      parentOf(5, 5, 6, 'mother'),
+      parentOf(6, 6, 5, 'mother')
+    ]
+
+    const cycles = findTreeIssues(people, relationships).filter((issue) => issue.type === ISSUE_TYPES.parentCycle)
+
+    expect(cycles.map(({ personIds, relationshipIds }) => ({ personIds, relationshipIds }))).toEqual([
+      { personIds: [1, 2, 3], relationshipIds: [1, 2, 3] },
+      { personIds: [5, 6], relationshipIds: [5, 6] }
+    ])
+  })
+
+  it('should ignore relationships with people outside the list', () => {
+    expect(findTreeIssues([person(1, '1990')], [parentOf(1, 1, 2, 'father')])).toEqual([])
+  })
+})
@@ -0,0 +1,139 @@
+/**
+ * Upcoming Events Module
+ *
+ * Finds yearly recurring dates (birthdays and wedding anniversaries) falling
+ * within the next N days, for "this week" style widgets. All calculations
+ * use UTC calendar days so results do not shift with the server's time zone.
+ */
+
+import { isPersonLiving } from './personHelpers.js'
+
+const DAY_MS = 24 * 60 * 60 * 1000
+
+/**
+ * Splits a YYYY-MM-DD date into numeric parts
+ *
+ * @param {string|null} date - Stored date
+ * @returns {{year: number, month: number, day: number}|null}
+ *   Parts, or null for missing or partial dates (no month or day, or "00" parts)
+ */
+export function parseFullDate(date) {
+  const match = /^(\d{4})-(\d{2})-(\d{2})$/.exec(date || '')
+  if (!match) return null
+
+  const [year, month, day] = match.slice(1).map(Number)
+  if (month < 1 || month > 12 || day < 1 || day > 31) return null
+
+  return { year, month, day }
+}
+
+/**
+ * Finds the next occurrence of a month/day on or after a reference day
+ *
+ * February 29 falls on February 28 in non-leap years.
+ *
+ * @param {number} month - Month (1-12)
+ * @param {number} day - Day of month
+ * @param {Date} today - Reference date (only its UTC calendar day is used)
+ * @returns {{date: Date, year: number, daysUntil: number}} Next occurrence at UTC midnight
+ */
+export function nextOccurrence(month, day, today) {
+  const start = Date.UTC(today.getUTCFullYear(), today.getUTCMonth(), today.getUTCDate())
+
+  const occurrenceIn = (year) => {
+    const lastDay = new Date(Date.UTC(year, month, 0)).getUTCDate()
+    return Date.UTC(year, month - 1, Math.min(day, lastDay))
+  }
+
+  let year = today.getUTCFullYear()
+  let occurrence = occurrenceIn(year)
+  if (occurrence < start) {
+    year++
+    occurrence = occurrenceIn(year)
+  }
+
+  return { date: new Date(occurrence), year, daysUntil: Math.round((occurrence - start) / DAY_MS) }
+}
+
+/**
+ * Finds living people whose birthday falls within the next `withinDays` days
+ *
+ * People without a full birth date (missing, or lacking month or day) are skipped.
+ *
+ * @param {Array} people - Person records (database or API format)
+ * @param {Date} today - Reference date
+ * @param {number} withinDays - Window length in days (0 = today only)
+ * @returns {Array<{person: Object, date: string, daysUntil: number, turningAge: number}>}
+ *   Ordered by days until the birthday, then person ID; date is YYYY-MM-DD
+ */
+export function findUpcomingBirthdays(people, today, withinDays) {
+  const upcoming = []
+
+  for (const person of people) {
+    const birth = parseFullDate(person.birthDate)
+    if (!birth || !isPersonLiving(person, today)) continue
+
+    const next = nextOccurrence(birth.month, birth.day, today)
+    if (next.daysUntil > withinDays) continue
+
+    upcoming.push({
+      person,
+      date: next.date.toISOString().slice(0, 10),
+      daysUntil: next.daysUntil,
+      turningAge: next.year - birth.year
+    })
+  }
+
+  upcoming.sort((a, b) => a.daysUntil - b.daysUntil || a.person.id - b.person.id)
+
+  return upcoming
+}
+
+/**
+ * Finds current marriages whose anniversary falls within the next `withinDays` days
+ *
+ * Only spouse relationships with a full start date (marriage date) and no
+ * end date count, and both spouses must be living. A couple stored in both
+ * directions is reported once.
+ *
+ * @param {Array} relationships - Relationship records (spouse rows are used)
+ * @param {Map<number, Object>} peopleById - Person records by ID (missing people are skipped)
+ * @param {Date} today - Reference date
+ * @param {number} withinDays - Window length in days (0 = today only)
+ * @returns {Array<{relationshipId: number, spouses: [Object, Object], marriageDate: string, date: string, daysUntil: number, years: number}>}
+ *   Ordered by days until the anniversary, then relationship ID; date is YYYY-MM-DD
+ */
+export function findUpcomingAnniversaries(relationships, peopleById, today, withinDays) {
+  const upcoming = []
+  const seenPairs = new Set()
+
+  for (const relationship of relationships) {
+    if (relationship.type !== 'spouse' || relationship.endDate) continue
+
+    const married = parseFullDate(relationship.startDate)
+    if (!married) continue
+
+    const spouses = [peopleById.get(relationship.person1Id), peopleById.get(relationship.person2Id)]
+    if (spouses.some((spouse) => !spouse || !isPersonLiving(spouse, today))) continue
+
+    const pairKey = [relationship.person1Id, relationship.person2Id].sort((a, b) => a - b).join('-')
+    if (seenPairs.has(pairKey)) continue
+
+    const next = nextOccurrence(married.month, married.day, today)
+    if (next.daysUntil > withinDays) continue
+
+    seenPairs.add(pairKey)
+    upcoming.push({
+      relationshipId: relationship.id,
+      spouses,
+      marriageDate: relationship.startDate,
+      date: next.date.toISOString().slice(0, 10),
+      daysUntil: next.daysUntil,
+      years: next.year - married.year
+    })
+  }
+
+  upcoming.sort((a, b) => a.daysUntil - b.daysUntil || a.relationshipId - b.relationshipId)
+
+  return upcoming
+}
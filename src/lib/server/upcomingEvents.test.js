@@ -0,0 +1,107 @@
+/**
+ * Unit tests for Upcoming Events Module
+ */
+
+import { describe, it, expect } from 'vitest'
+import {
+  parseFullDate,
+  nextOccurrence,
+  findUpcomingBirthdays,
+  findUpcomingAnniversaries
+} from './upcomingEvents.js'
+
+describe('parseFullDate', () => {
+  it('should parse full dates and reject partial ones', () => {
+    expect(parseFullDate('1950-01-15')).toEqual({ year: 1950, month: 1, day: 15 })
+    expect(parseFullDate('1950')).toBeNull()
+    expect(parseFullDate('1950-01')).toBeNull()
+    expect(parseFullDate('1950-00-00')).toBeNull()
+    expect(parseFullDate(null)).toBeNull()
+  })
+})
+
+describe('nextOccurrence', () => {
+  it('should roll over to next year once the date has passed', () => {
+    const next = nextOccurrence(1, 3, new Date('2025-12-28T15:00:00Z'))
+
+    expect(next.date.toISOString().slice(0, 10)).toBe('2026-01-03')
+    expect(next.daysUntil).toBe(6)
+  })
+
+  it('should place February 29 on February 28 in non-leap years', () => {
+    const next = nextOccurrence(2, 29, new Date('2025-02-01T00:00:00Z'))
+
+    expect(next.date.toISOString().slice(0, 10)).toBe('2025-02-28')
+  })
+})
+
+describe('findUpcomingBirthdays', () => {
+  const today = new Date('2025-12-28T09:00:00Z')
+
+  it('should include birthdays across the year boundary with the age turned', () => {
+    const people = [
+      { id: 1, firstName: 'NewYear', birthDate: '1990-01-01', deathDate: null },
+      { id: 2, firstName: 'Today', birthDate: '2000-12-28', deathDate: null },
+      { id: 3, firstName: 'TooLate', birthDate: '1990-02-15', deathDate: null },
+      { id: 4, firstName: 'Passed', birthDate: '1990-12-27', deathDate: null }
+    ]
+
+    const upcoming = findUpcomingBirthdays(people, today, 30)
+
+    expect(upcoming.map(({ person, date, daysUntil, turningAge }) => [person.id, date, daysUntil, turningAge])).toEqual([
+      [2, '2025-12-28', 0, 25],
+      [1, '2026-01-01', 4, 36]
+    ])
+  })
+
+  it('should skip deceased people and partial or missing birth dates', () => {
+    const people = [
+      { id: 1, birthDate: '1990-12-30', deathDate: '2020-01-01' },
+      { id: 2, birthDate: '1990', deathDate: null },
+      { id: 3, birthDate: null, deathDate: null },
+      { id: 4, birthDate: '1850-12-30', deathDate: null }
+    ]
+
+    expect(findUpcomingBirthdays(people, today, 30)).toEqual([])
+  })
+})
+
+describe('findUpcomingAnniversaries', () => {
+  const today = new Date('2025-06-01T09:00:00Z')
+  const peopleById = new Map([
+    [1, { id: 1, birthDate: '1960-01-01', deathDate: null }],
+    [2, { id: 2, birthDate: '1962-01-01', deathDate: null }],
+    [3, { id: 3, birthDate: '1930-01-01', deathDate: '2010-01-01' }]
+  ])
+  const spouse = (id, person1Id, person2Id, startDate, endDate = null) =>
+    ({ id, type: 'spouse', person1Id, person2Id, startDate, endDate })
+
+  it('should report a current marriage once with the years married', () => {
+    const upcoming = findUpcomingAnniversaries(
+      [spouse(1, 1, 2, '1985-06-15'), spouse(2, 2, 1, '1985-06-15')],
+      peopleById,
+      today,
+      30
+    )
+
+    expect(upcoming).toHaveLength(1)
+    expect(upcoming[0]).toMatchObject({ relationshipId: 1, date: '2025-06-15', daysUntil: 14, years: 40 })
+    expect(upcoming[0].spouses.map((person) => person.id)).toEqual([1, 2])
+  })
+
+  it('should skip ended marriages, deceased spouses, and missing or out-of-window dates', () => {
+    const upcoming = findUpcomingAnniversaries(
+      [
+        spouse(1, 1, 2, '1985-06-15', '1995-01-01'),
+        spouse(2, 1, 3, '1950-06-10'),
+        spouse(3, 1, 2, null),
+        spouse(4, 1, 2, '1985-09-01')
+      ],
+      peopleById,
+      today,
+      30
+    )
+
+    expect(upcoming).toEqual([])
+  })
+})
@@ -0,0 +1,95 @@
+/**
+ * Integration Tests for Parent Direction Repair API
+ *
+ * Tests POST /api/admin/fix-parent-directions endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { POST } from '../../../../routes/api/admin/fix-parent-directions/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('POST /api/admin/fix-parent-directions', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender, birth_date)
+      VALUES (?, ?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'Father', 'Smith', 'male', '1950-04-01')
+    insertPerson.run(2, 'Son', 'Smith', 'male', '1980-07-15')
+    insertPerson.run(3, 'Mother', 'Smith', 'female', '1952-02-02')
+    insertPerson.run(4, 'Undated', 'Smith', 'female', null)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const insertParent = (id, parentId, childId, role) =>
+    sqlite.prepare(`
+      INSERT INTO relationships (id, person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, ?, 'parentOf', ?)
+    `).run(id, parentId, childId, role)
+
+  const selectParents = () =>
+    sqlite.prepare('SELECT id, person1_id, person2_id, parent_role FROM relationships ORDER BY id').all()
+
+  it('should reverse an edge whose parent was born after the child', async () => {
+    // Son recorded as the father of Father
+    insertParent(1, 2, 1, 'father')
+    insertParent(2, 3, 2, 'mother')
+
+    const response = await POST(createMockEvent(db))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toEqual({
+      fixed: [{ relationshipId: 1, parentId: 1, childId: 2, parentRole: 'father' }],
+      skipped: []
+    })
+    expect(selectParents()).toEqual([
+      { id: 1, person1_id: 1, person2_id: 2, parent_role: 'father' },
+      { id: 2, person1_id: 3, person2_id: 2, parent_role: 'mother' }
+    ])
+  })
+
+  it('should set the role from the new parent\'s gender', async () => {
+    // Son recorded as the father of Mother
+    insertParent(1, 2, 3, 'father')
+
+    const data = await (await POST(createMockEvent(db))).json()
+
+    expect(data.fixed).toEqual([{ relationshipId: 1, parentId: 3, childId: 2, parentRole: 'mother' }])
+  })
+
+  it('should skip a reversal that would give the child a second father', async () => {
+    sqlite.prepare("INSERT INTO people (id, first_name, last_name, gender, birth_date) VALUES (5, 'OtherDad', 'Smith', 'male', '1949-01-01')").run()
+    insertParent(1, 5, 2, 'father')
+    insertParent(2, 2, 1, 'father')
+
+    const data = await (await POST(createMockEvent(db))).json()
+
+    expect(data).toEqual({ fixed: [], skipped: [{ relationshipId: 2, reason: 'parentRoleConflict' }] })
+    expect(selectParents()[1]).toEqual({ id: 2, person1_id: 2, person2_id: 1, parent_role: 'father' })
+  })
+
+  it('should leave edges alone when a birth date is missing or the order is correct', async () => {
+    insertParent(1, 1, 2, 'father')
+    insertParent(2, 4, 2, 'mother')
+
+    const data = await (await POST(createMockEvent(db))).json()
+
+    expect(data).toEqual({ fixed: [], skipped: [] })
+  })
+})
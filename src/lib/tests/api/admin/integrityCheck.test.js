@@ -0,0 +1,80 @@
+/**
+ * Integration Tests for Integrity Check API
+ *
+ * Tests POST /api/admin/integrity-check endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { POST } from '../../../../routes/api/admin/integrity-check/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('POST /api/admin/integrity-check', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    sqlite.prepare("INSERT INTO people (id, first_name, last_name) VALUES (1, 'John', 'Smith'), (2, 'Mary', 'Smith')").run()
+    sqlite.prepare("INSERT INTO relationships (id, person1_id, person2_id, type) VALUES (1, 1, 2, 'spouse')").run()
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const check = (query = '') =>
+    POST(createMockEvent(db, { url: new URL(`http://localhost/api/admin/integrity-check${query}`) }))
+
+  // Writes a relationship to a person who does not exist, bypassing enforcement
+  const seedDanglingRelationship = () => {
+    sqlite.pragma('foreign_keys = OFF')
+    sqlite.prepare("INSERT INTO relationships (id, person1_id, person2_id, type, parent_role) VALUES (2, 99, 1, 'parentOf', 'father')").run()
+    sqlite.pragma('foreign_keys = ON')
+  }
+
+  it('should report "ok" for a clean database', async () => {
+    const response = await check()
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toEqual({
+      ok: true,
+      integrityCheck: ['ok'],
+      foreignKeyViolations: [],
+      repairedRelationships: 0
+    })
+  })
+
+  it('should report a dangling relationship from foreign_key_check', async () => {
+    seedDanglingRelationship()
+
+    const data = await (await check()).json()
+
+    expect(data.ok).toBe(false)
+    expect(data.integrityCheck).toEqual(['ok'])
+    expect(data.foreignKeyViolations).toEqual([
+      expect.objectContaining({ table: 'relationships', rowid: 2, parent: 'people' })
+    ])
+    expect(data.repairedRelationships).toBe(0)
+    expect(sqlite.prepare('SELECT COUNT(*) AS count FROM relationships').get().count).toBe(2)
+  })
+
+  it('should delete dangling relationships when repair=true', async () => {
+    seedDanglingRelationship()
+
+    const data = await (await check('?repair=true')).json()
+
+    expect(data.ok).toBe(true)
+    expect(data.foreignKeyViolations).toEqual([])
+    expect(data.repairedRelationships).toBe(1)
+    expect(sqlite.prepare('SELECT id FROM relationships').all()).toEqual([{ id: 1 }])
+  })
+})
@@ -0,0 +1,102 @@
+/**
+ * Integration Tests for Audit Log API
+ *
+ * Tests GET /api/audit and the audit entries written by people and
+ * relationship handlers
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../routes/api/audit/+server.js'
+import { POST as createPerson } from '../../../../routes/api/people/+server.js'
+import { PUT as updatePerson, DELETE as deletePerson } from '../../../../routes/api/people/[id]/+server.js'
+import { POST as createRelationship } from '../../../../routes/api/relationships/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/audit', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const jsonRequest = (body) => ({ json: async () => body })
+
+  const getAudit = (query = '') =>
+    GET(createMockEvent(db, { url: new URL(`http://localhost/api/audit${query}`) }))
+
+  const post = async (handler, body) => (await handler(createMockEvent(db, { request: jsonRequest(body) }))).json()
+
+  it('should record a matching entry when a person is created', async () => {
+    const person = await post(createPerson, { firstName: 'John', lastName: 'Smith', birthDate: '1950-01-01' })
+
+    const response = await getAudit()
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.entries).toEqual([
+      {
+        id: 1,
+        entityType: 'person',
+        entityId: person.id,
+        action: 'create',
+        payload: { after: person },
+        createdAt: expect.any(String)
+      }
+    ])
+  })
+
+  it('should record updates, deletes, and relationship writes newest first', async () => {
+    const john = await post(createPerson, { firstName: 'John', lastName: 'Smith' })
+    const jane = await post(createPerson, { firstName: 'Jane', lastName: 'Smith' })
+    await post(createRelationship, { person1Id: john.id, person2Id: jane.id, type: 'spouse' })
+
+    await updatePerson(createMockEvent(db, {
+      params: { id: String(john.id) },
+      request: jsonRequest({ firstName: 'Johnny', lastName: 'Smith' })
+    }))
+    await deletePerson(createMockEvent(db, { params: { id: String(jane.id) } }))
+
+    const { entries } = await (await getAudit()).json()
+
+    expect(entries.map(({ entityType, entityId, action }) => [entityType, entityId, action])).toEqual([
+      ['person', jane.id, 'delete'],
+      ['person', john.id, 'update'],
+      ['relationship', 1, 'create'],
+      ['person', jane.id, 'create'],
+      ['person', john.id, 'create']
+    ])
+    expect(entries[1].payload.before.firstName).toBe('John')
+    expect(entries[1].payload.after.firstName).toBe('Johnny')
+    expect(entries[0].payload.before.firstName).toBe('Jane')
+  })
+
+  it('should not record rejected writes', async () => {
+    const response = await createPerson(createMockEvent(db, { request: jsonRequest({ lastName: 'Smith' }) }))
+
+    expect(response.status).toBe(400)
+    expect((await (await getAudit()).json()).entries).toEqual([])
+  })
+
+  it('should apply the limit and reject an invalid one', async () => {
+    await post(createPerson, { firstName: 'A', lastName: 'One' })
+    await post(createPerson, { firstName: 'B', lastName: 'Two' })
+
+    const { entries } = await (await getAudit('?limit=1')).json()
+    expect(entries.map((entry) => entry.payload.after.firstName)).toEqual(['B'])
+
+    expect((await getAudit('?limit=0')).status).toBe(400)
+    expect((await getAudit('?limit=abc')).status).toBe(400)
+  })
+})
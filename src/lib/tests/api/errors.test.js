@@ -0,0 +1,42 @@
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../routes/api/people/[id]/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+/**
+ * Test suite for structured error responses
+ *
+ * Handlers report errors as JSON { error, status } rather than plain text,
+ * keeping their original status codes.
+ */
+describe('Structured JSON errors', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+    await setupTestDatabase(sqlite, db)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  it('should return a JSON body for a 404', async () => {
+    const response = await GET(createMockEvent(db, { params: { id: '999' } }))
+
+    expect(response.status).toBe(404)
+    expect(response.headers.get('content-type')).toContain('application/json')
+    expect(await response.json()).toEqual({ error: 'Person not found', status: 404 })
+  })
+
+  it('should return a JSON body for a 400', async () => {
+    const response = await GET(createMockEvent(db, { params: { id: 'abc' } }))
+
+    expect(response.status).toBe(400)
+    expect(response.headers.get('content-type')).toContain('application/json')
+    expect(await response.json()).toEqual({ error: 'Invalid ID', status: 400 })
+  })
+})
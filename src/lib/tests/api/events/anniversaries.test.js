@@ -0,0 +1,82 @@
+/**
+ * Integration Tests for Upcoming Anniversaries API
+ *
+ * Tests GET /api/events/anniversaries endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../routes/api/events/anniversaries/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/events/anniversaries', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, birth_date, death_date)
+      VALUES (?, ?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'John', 'Smith', '1960-01-01', null)
+    insertPerson.run(2, 'Jane', 'Smith', '1962-01-01', null)
+    insertPerson.run(3, 'Ann', 'Lee', '1970-01-01', null)
+    insertPerson.run(4, 'Bob', 'Lee', '1968-01-01', null)
+    insertPerson.run(5, 'Old', 'Gray', '1930-01-01', '2015-01-01')
+    insertPerson.run(6, 'Widow', 'Gray', '1935-01-01', null)
+
+    const insertSpouse = sqlite.prepare(`
+      INSERT INTO relationships (id, person1_id, person2_id, type, start_date, end_date)
+      VALUES (?, ?, ?, 'spouse', ?, ?)
+    `)
+    insertSpouse.run(1, 1, 2, '1985-06-15', null)
+    insertSpouse.run(2, 3, 4, '1995-06-10', '2005-03-01')
+    insertSpouse.run(3, 5, 6, '1955-06-20', null)
+
+    // Today is June 1, 2025
+    vi.useFakeTimers({ toFake: ['Date'] })
+    vi.setSystemTime(new Date('2025-06-01T12:00:00Z'))
+  })
+
+  afterEach(() => {
+    vi.useRealTimers()
+    sqlite.close()
+  })
+
+  const getAnniversaries = (query = '') =>
+    GET(createMockEvent(db, { url: new URL(`http://localhost/api/events/anniversaries${query}`) }))
+
+  it('should return a current marriage whose anniversary falls in the window', async () => {
+    const response = await getAnniversaries('?within=30')
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.anniversaries).toHaveLength(1)
+    expect(data.anniversaries[0]).toMatchObject({
+      relationshipId: 1,
+      marriageDate: '1985-06-15',
+      date: '2025-06-15',
+      daysUntil: 14,
+      years: 40
+    })
+    expect(data.anniversaries[0].spouses.map((person) => person.firstName)).toEqual(['John', 'Jane'])
+  })
+
+  it('should exclude anniversaries outside the window', async () => {
+    const data = await (await getAnniversaries('?within=7')).json()
+
+    expect(data.anniversaries).toEqual([])
+  })
+
+  it('should return 400 for an invalid window', async () => {
+    expect((await getAnniversaries('?within=-5')).status).toBe(400)
+  })
+})
@@ -0,0 +1,73 @@
+/**
+ * Integration Tests for Upcoming Birthdays API
+ *
+ * Tests GET /api/events/birthdays endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../routes/api/events/birthdays/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/events/birthdays', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, birth_date, death_date)
+      VALUES (?, ?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'January', 'Doe', '1990-01-05', null)
+    insertPerson.run(2, 'December', 'Doe', '1980-12-30', null)
+    insertPerson.run(3, 'March', 'Doe', '1970-03-01', null)
+    insertPerson.run(4, 'Deceased', 'Doe', '1940-01-02', '2001-06-01')
+    insertPerson.run(5, 'Undated', 'Doe', null, null)
+
+    // Today is December 28, 2025
+    vi.useFakeTimers({ toFake: ['Date'] })
+    vi.setSystemTime(new Date('2025-12-28T12:00:00Z'))
+  })
+
+  afterEach(() => {
+    vi.useRealTimers()
+    sqlite.close()
+  })
+
+  const getBirthdays = (query = '') =>
+    GET(createMockEvent(db, { url: new URL(`http://localhost/api/events/birthdays${query}`) }))
+
+  it('should include January birthdays when the window wraps the year', async () => {
+    const response = await getBirthdays('?within=14')
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.within).toBe(14)
+    expect(data.birthdays.map(({ person, date, daysUntil, turningAge }) => [person.id, date, daysUntil, turningAge]))
+      .toEqual([
+        [2, '2025-12-30', 2, 45],
+        [1, '2026-01-05', 8, 36]
+      ])
+  })
+
+  it('should default to a 30 day window', async () => {
+    const data = await (await getBirthdays()).json()
+
+    expect(data.within).toBe(30)
+    expect(data.birthdays.map((birthday) => birthday.person.id)).toEqual([2, 1])
+  })
+
+  it('should return 400 for an invalid window', async () => {
+    expect((await getBirthdays('?within=-1')).status).toBe(400)
+    expect((await getBirthdays('?within=abc')).status).toBe(400)
+    expect((await getBirthdays('?within=400')).status).toBe(400)
+  })
+})
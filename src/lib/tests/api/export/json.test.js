@@ -0,0 +1,95 @@
+/**
+ * Integration Tests for JSON Export API
+ *
+ * Tests GET /api/export/json endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../routes/api/export/json/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/export/json', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, birth_date, gender, birth_place)
+      VALUES (?, ?, ?, ?, ?, ?)
+    `)
+    insertPerson.run(2, 'Mary', 'Smith', '1905-03-10', 'female', null)
+    insertPerson.run(1, 'John', 'Smith', '1900-01-15', 'male', 'Boston')
+    insertPerson.run(3, 'Junior', 'Smith', null, 'male', null)
+    insertPerson.run(4, 'Deleted', 'Person', null, null, null)
+    sqlite.prepare("UPDATE people SET deleted_at = '2024-01-01 00:00:00' WHERE id = 4").run()
+
+    const insertRelationship = sqlite.prepare(`
+      INSERT INTO relationships (id, person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, ?, ?, ?)
+    `)
+    insertRelationship.run(3, 2, 3, 'parentOf', 'mother')
+    insertRelationship.run(1, 1, 2, 'spouse', null)
+    insertRelationship.run(2, 1, 3, 'parentOf', 'father')
+    insertRelationship.run(4, 4, 3, 'parentOf', null)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const exportJson = (query = '') =>
+    GET(createMockEvent(db, { url: new URL(`http://localhost/api/export/json${query}`) }))
+
+  it('should export people and relationships with a timestamp by default', async () => {
+    const response = await exportJson()
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(typeof data.exportedAt).toBe('string')
+    expect(data.people.map((person) => person.id)).toEqual([1, 2, 3])
+    expect(data.relationships.map((relationship) => relationship.id)).toEqual([1, 2, 3])
+  })
+
+  it('should produce byte-identical output for two successive stable exports', async () => {
+    const first = await (await exportJson('?stable=true')).text()
+    const second = await (await exportJson('?stable=true')).text()
+
+    expect(second).toBe(first)
+  })
+
+  it('should order records by ID, sort keys, and omit the timestamp when stable', async () => {
+    const response = await exportJson('?stable=true')
+    const text = await response.text()
+    const data = JSON.parse(text)
+
+    expect(response.headers.get('Content-Type')).toBe('application/json')
+    expect(Object.keys(data)).toEqual(['people', 'relationships'])
+    expect(data.people.map((person) => person.id)).toEqual([1, 2, 3])
+    expect(data.relationships.map((relationship) => relationship.id)).toEqual([1, 2, 3])
+
+    for (const record of [...data.people, ...data.relationships]) {
+      const keys = Object.keys(record)
+      expect(keys).toEqual([...keys].sort())
+    }
+
+    expect(text.endsWith('}\n')).toBe(true)
+  })
+
+  it('should only change the affected lines when one record changes', async () => {
+    const before = (await (await exportJson('?stable=true')).text()).split('\n')
+    sqlite.prepare("UPDATE people SET birth_date = '1906-03-10' WHERE id = 2").run()
+    const after = (await (await exportJson('?stable=true')).text()).split('\n')
+
+    expect(after).toHaveLength(before.length)
+    expect(after.filter((line, index) => line !== before[index])).toEqual(['      "birthDate": "1906-03-10",'])
+  })
+})
@@ -0,0 +1,92 @@
+/**
+ * Integration Tests for People CSV Export API
+ *
+ * Tests GET /api/export/people.csv endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../routes/api/export/people.csv/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+import { parseCsv } from '$lib/server/csv.js'
+
+describe('GET /api/export/people.csv', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const insertPerson = (id, firstName, lastName, birthDate = null, deathDate = null, gender = null) => {
+    sqlite
+      .prepare(`
+        INSERT INTO people (id, first_name, last_name, birth_date, death_date, gender)
+        VALUES (?, ?, ?, ?, ?, ?)
+      `)
+      .run(id, firstName, lastName, birthDate, deathDate, gender)
+  }
+
+  it('should return a CSV attachment', async () => {
+    const response = await GET(createMockEvent(db))
+
+    expect(response.status).toBe(200)
+    expect(response.headers.get('Content-Type')).toBe('text/csv')
+    expect(response.headers.get('Content-Disposition')).toBe('attachment; filename="people.csv"')
+  })
+
+  it('should export a header row and one row per person that parse back to the stored values', async () => {
+    insertPerson(1, 'John', 'Smith', '1900-01-15', '1980-06-01', 'male')
+    insertPerson(2, 'Mary "Molly"', 'Smith, Jr', '1905-03-10', null, 'female')
+    insertPerson(3, 'Pat', 'O\'Brien')
+
+    const response = await GET(createMockEvent(db))
+    const rows = parseCsv(await response.text())
+
+    expect(rows).toEqual([
+      ['id', 'firstName', 'lastName', 'birthDate', 'deathDate', 'gender'],
+      ['1', 'John', 'Smith', '1900-01-15', '1980-06-01', 'male'],
+      ['2', 'Mary "Molly"', 'Smith, Jr', '1905-03-10', '', 'female'],
+      ['3', 'Pat', 'O\'Brien', '', '', '']
+    ])
+  })
+
+  it('should export only the header row when there are no people', async () => {
+    const response = await GET(createMockEvent(db))
+
+    expect(await response.text()).toBe('id,firstName,lastName,birthDate,deathDate,gender\n')
+  })
+
+  it('should exclude soft-deleted people', async () => {
+    insertPerson(1, 'John', 'Smith')
+    insertPerson(2, 'Deleted', 'Person')
+    sqlite.prepare("UPDATE people SET deleted_at = '2024-01-01 00:00:00' WHERE id = 2").run()
+
+    const rows = parseCsv(await (await GET(createMockEvent(db))).text())
+
+    expect(rows.map((row) => row[0])).toEqual(['id', '1'])
+  })
+
+  it('should stream every row when the table spans several pages', async () => {
+    const insert = sqlite.prepare('INSERT INTO people (first_name, last_name) VALUES (?, ?)')
+    sqlite.transaction(() => {
+      for (let i = 1; i <= 1203; i++) insert.run(`Person${i}`, 'Bulk')
+    })()
+
+    const rows = parseCsv(await (await GET(createMockEvent(db))).text())
+
+    expect(rows).toHaveLength(1204)
+    expect(rows[1]).toEqual(['1', 'Person1', 'Bulk', '', '', ''])
+    expect(rows[1203]).toEqual(['1203', 'Person1203', 'Bulk', '', '', ''])
+  })
+})
@@ -0,0 +1,75 @@
+/**
+ * Integration Tests for DOT Export API
+ *
+ * Tests GET /api/export/tree.dot endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../routes/api/export/tree.dot/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/export/tree.dot', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, birth_date, death_date)
+      VALUES (?, ?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'John', 'Smith', '1900-01-15', '1980-06-01')
+    insertPerson.run(2, 'Mary "Molly"', 'Smith', '1905-03-10', null)
+    insertPerson.run(3, 'Junior', 'Smith', null, null)
+    insertPerson.run(4, 'Deleted', 'Person', null, null)
+    sqlite.prepare("UPDATE people SET deleted_at = '2024-01-01 00:00:00' WHERE id = 4").run()
+
+    const insertRelationship = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertRelationship.run(1, 2, 'spouse', null)
+    insertRelationship.run(1, 3, 'parentOf', 'father')
+    insertRelationship.run(2, 3, 'parentOf', 'mother')
+    insertRelationship.run(4, 3, 'parentOf', null)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  it('should return a DOT digraph with the Graphviz content type', async () => {
+    const response = await GET(createMockEvent(db))
+    const text = await response.text()
+
+    expect(response.status).toBe(200)
+    expect(response.headers.get('Content-Type')).toBe('text/vnd.graphviz')
+    expect(text.startsWith('digraph familytree {')).toBe(true)
+    expect(text.trimEnd().endsWith('}')).toBe(true)
+  })
+
+  it('should emit the expected node and edge lines for a small tree', async () => {
+    const lines = (await (await GET(createMockEvent(db))).text()).split('\n')
+
+    expect(lines).toContain('  p1 [label="John Smith\\n(1900-1980)"];')
+    expect(lines).toContain('  p2 [label="Mary \\"Molly\\" Smith\\n(1905-)"];')
+    expect(lines).toContain('  p3 [label="Junior Smith"];')
+    expect(lines).toContain('  p1 -> p3;')
+    expect(lines).toContain('  p2 -> p3;')
+    expect(lines).toContain('  p1 -> p2 [dir=none, style=dashed];')
+  })
+
+  it('should leave out soft-deleted people and their edges', async () => {
+    const text = await (await GET(createMockEvent(db))).text()
+
+    expect(text).not.toContain('p4')
+  })
+})
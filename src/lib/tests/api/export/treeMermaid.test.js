@@ -0,0 +1,85 @@
+/**
+ * Integration Tests for Mermaid Export API
+ *
+ * Tests GET /api/export/tree.mermaid endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../routes/api/export/tree.mermaid/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/export/tree.mermaid', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    // Grandpa(1) + Grandma(2) -> Dad(3) + Mom(4) -> Kid(5); Grandpa -> Aunt(6)
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, birth_date)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'Grandpa', 'Smith', '1900-01-01')
+    insertPerson.run(2, 'Grandma', 'Smith', null)
+    insertPerson.run(3, 'Dad', 'Smith', '1930-05-05')
+    insertPerson.run(4, 'Mom "Dot"', 'Jones', null)
+    insertPerson.run(5, 'Kid', 'Smith', null)
+    insertPerson.run(6, 'Aunt', 'Smith', null)
+
+    const insertRelationship = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertRelationship.run(1, 2, 'spouse', null)
+    insertRelationship.run(1, 3, 'parentOf', 'father')
+    insertRelationship.run(2, 3, 'parentOf', 'mother')
+    insertRelationship.run(1, 6, 'parentOf', 'father')
+    insertRelationship.run(3, 4, 'spouse', null)
+    insertRelationship.run(3, 5, 'parentOf', 'father')
+    insertRelationship.run(4, 5, 'parentOf', 'mother')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const exportMermaid = (query = '') =>
+    GET(createMockEvent(db, { url: new URL(`http://localhost/api/export/tree.mermaid${query}`) }))
+
+  it('should export the whole tree with sanitized labels', async () => {
+    const response = await exportMermaid()
+    const lines = (await response.text()).split('\n')
+
+    expect(response.status).toBe(200)
+    expect(lines[0]).toBe('graph TD')
+    expect(lines).toContain('  p1["Grandpa Smith (1900-)"]')
+    expect(lines).toContain('  p4["Mom #quot;Dot#quot; Jones"]')
+    expect(lines).toContain('  p1 -.- p2')
+    expect(lines).toContain('  p1 --> p3')
+  })
+
+  it('should export only the root person and their descendants with rootId', async () => {
+    const text = await (await exportMermaid('?rootId=3')).text()
+
+    expect(text).toBe([
+      'graph TD',
+      '  p3["Dad Smith (1930-)"]',
+      '  p5["Kid Smith"]',
+      '  p3 --> p5',
+      ''
+    ].join('\n'))
+  })
+
+  it('should return 400 for an invalid rootId and 404 for a missing root', async () => {
+    expect((await exportMermaid('?rootId=abc')).status).toBe(400)
+    expect((await exportMermaid('?rootId=999')).status).toBe(404)
+  })
+})
@@ -0,0 +1,87 @@
+/**
+ * Integration Tests for GEDCOM Import Merge Suggestions
+ *
+ * Tests POST /api/gedcom/import/[uploadId]?autoSuggestMerges=true
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { POST } from '../../../../routes/api/gedcom/import/[uploadId]/+server.js'
+import { storePreviewData, clearPreviewData } from '$lib/server/gedcomPreview.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('POST /api/gedcom/import/[uploadId]?autoSuggestMerges=true', () => {
+  const uploadId = 'merge-suggestions-upload'
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, birth_date)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'John', 'Smith', '1950-04-12')
+    insertPerson.run(2, 'Mary', 'Jones', '1952')
+    insertPerson.run(3, 'Mary', 'Jones', '1952-07-01')
+
+    // The file repeats John Smith and adds someone new
+    await storePreviewData(uploadId, {
+      individuals: [
+        { id: '@I1@', firstName: 'John', lastName: 'Smith', birthDate: '1950-04-12', sex: 'M' },
+        { id: '@I2@', firstName: 'Anna', lastName: 'Berg', birthDate: '1980-01-01', sex: 'F' }
+      ],
+      families: []
+    }, [])
+  })
+
+  afterEach(async () => {
+    await clearPreviewData(uploadId)
+    sqlite.close()
+  })
+
+  const importGedcom = (query = '') =>
+    POST(createMockEvent(db, {
+      params: { uploadId },
+      url: new URL(`http://localhost/api/gedcom/import/${uploadId}${query}`),
+      request: { json: async () => ({ importAll: true }) }
+    }))
+
+  it('should suggest merging an imported person with the existing duplicate', async () => {
+    const response = await importGedcom('?autoSuggestMerges=true')
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.imported.persons).toBe(2)
+
+    const [imported] = sqlite
+      .prepare("SELECT id FROM people WHERE first_name = 'John' AND import_batch = ?")
+      .all(data.batchId)
+
+    // Mary Jones (2, 3) duplicates existing data only and is not suggested
+    expect(data.mergeSuggestions).toEqual([
+      {
+        name: 'john smith',
+        personIds: [1, imported.id],
+        confidence: 100,
+        importedPersonIds: [imported.id],
+        existingPersonIds: [1]
+      }
+    ])
+  })
+
+  it('should omit suggestions unless requested', async () => {
+    const response = await importGedcom()
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).not.toHaveProperty('mergeSuggestions')
+  })
+})
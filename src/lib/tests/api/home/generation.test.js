@@ -0,0 +1,107 @@
+/**
+ * Integration Tests for Home Person Generation Label API
+ *
+ * Tests GET /api/home/[id]/people/[personId]/generation endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../routes/api/home/[id]/people/[personId]/generation/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/home/[id]/people/[personId]/generation', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    // GreatGrandpa(1) -> Grandpa(2), GreatUncle(3)
+    // Grandpa(2) -> Dad(4) -> Me(5)
+    // GreatUncle(3) -> FirstCousinOnceRemoved(6) -> SecondCousin(7)
+    // Stranger(8)
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name)
+      VALUES (?, ?, ?)
+    `)
+    insertPerson.run(1, 'GreatGrandpa', 'Smith')
+    insertPerson.run(2, 'Grandpa', 'Smith')
+    insertPerson.run(3, 'GreatUncle', 'Smith')
+    insertPerson.run(4, 'Dad', 'Smith')
+    insertPerson.run(5, 'Me', 'Smith')
+    insertPerson.run(6, 'Cousin', 'Smith')
+    insertPerson.run(7, 'SecondCousin', 'Smith')
+    insertPerson.run(8, 'Stranger', 'Jones')
+
+    const insertParent = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, 'parentOf', 'father')
+    `)
+    insertParent.run(1, 2)
+    insertParent.run(1, 3)
+    insertParent.run(2, 4)
+    insertParent.run(4, 5)
+    insertParent.run(3, 6)
+    insertParent.run(6, 7)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  function eventFor(homeId, personId) {
+    return createMockEvent(db, { params: { id: String(homeId), personId: String(personId) } })
+  }
+
+  it('should place a grandparent two generations up', async () => {
+    const response = await GET(eventFor(5, 2))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toEqual({
+      homeId: 5,
+      personId: 2,
+      connected: true,
+      generationGap: 2,
+      label: "your grandparent's generation"
+    })
+  })
+
+  it('should place a second cousin in the same generation', async () => {
+    const response = await GET(eventFor(5, 7))
+    const data = await response.json()
+
+    expect(data).toMatchObject({ generationGap: 0, label: 'your generation' })
+  })
+
+  it('should place a younger generation below the home person', async () => {
+    const response = await GET(eventFor(2, 7))
+    const data = await response.json()
+
+    expect(data).toMatchObject({ generationGap: -2, label: "your grandchild's generation" })
+  })
+
+  it('should report unconnected people', async () => {
+    const response = await GET(eventFor(5, 8))
+    const data = await response.json()
+
+    expect(data).toMatchObject({ connected: false, generationGap: null, label: null })
+  })
+
+  it('should return 400 for invalid IDs', async () => {
+    const response = await GET(eventFor('abc', 2))
+
+    expect(response.status).toBe(400)
+  })
+
+  it('should return 404 for unknown people', async () => {
+    expect((await GET(eventFor(999, 2))).status).toBe(404)
+    expect((await GET(eventFor(5, 999))).status).toBe(404)
+  })
+})
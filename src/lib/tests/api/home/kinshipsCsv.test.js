@@ -0,0 +1,75 @@
+/**
+ * Integration Tests for Kinships CSV Export API
+ *
+ * Tests GET /api/home/[id]/kinships.csv endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../routes/api/home/[id]/kinships.csv/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/home/[id]/kinships.csv', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'Grandma', 'Smith', 'female')
+    insertPerson.run(2, 'Dad', 'Smith', 'male')
+    insertPerson.run(3, 'Me', 'Smith', 'male')
+    insertPerson.run(4, 'Aunt', 'Smith, Jr', 'female')
+    insertPerson.run(5, 'Stranger', 'Jones', null)
+
+    const insertParent = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, 'parentOf', ?)
+    `)
+    insertParent.run(1, 2, 'mother')
+    insertParent.run(1, 4, 'mother')
+    insertParent.run(2, 3, 'father')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  it('should export the kinship of everyone to the home person', async () => {
+    const response = await GET(createMockEvent(db, { params: { id: '3' } }))
+    const text = await response.text()
+
+    expect(response.status).toBe(200)
+    expect(response.headers.get('Content-Type')).toBe('text/csv')
+
+    expect(text.trim().split('\n')).toEqual([
+      'personId,name,relationship,degree',
+      '2,Dad Smith,father,1',
+      '1,Grandma Smith,paternal grandmother,2',
+      '4,"Aunt Smith, Jr",aunt,3',
+      '5,Stranger Jones,no known relationship,'
+    ])
+  })
+
+  it('should return 404 when the home person does not exist', async () => {
+    const response = await GET(createMockEvent(db, { params: { id: '999' } }))
+
+    expect(response.status).toBe(404)
+  })
+
+  it('should return 400 for invalid ID format', async () => {
+    const response = await GET(createMockEvent(db, { params: { id: 'abc' } }))
+
+    expect(response.status).toBe(400)
+  })
+})
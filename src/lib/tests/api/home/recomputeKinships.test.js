@@ -0,0 +1,127 @@
+/**
+ * Integration Tests for Kinship Cache API
+ *
+ * Tests POST /api/home/[id]/recompute-kinships and cached reads through
+ * GET /api/home/[id]/kinships.csv
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { POST } from '../../../../routes/api/home/[id]/recompute-kinships/+server.js'
+import { GET as getKinshipsCsv } from '../../../../routes/api/home/[id]/kinships.csv/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('POST /api/home/[id]/recompute-kinships', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'Grandma', 'Smith', 'female')
+    insertPerson.run(2, 'Dad', 'Smith', 'male')
+    insertPerson.run(3, 'Me', 'Smith', 'male')
+    insertPerson.run(4, 'Stranger', 'Jones', null)
+
+    const insertParent = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, 'parentOf', ?)
+    `)
+    insertParent.run(1, 2, 'mother')
+    insertParent.run(2, 3, 'father')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const recompute = (id) => POST(createMockEvent(db, { params: { id: String(id) } }))
+
+  const readCsv = async (id) =>
+    (await (await getKinshipsCsv(createMockEvent(db, { params: { id: String(id) } }))).text()).trim().split('\n')
+
+  const cachedRows = () =>
+    sqlite.prepare('SELECT home_id, person_id, label, degree FROM kinship_cache ORDER BY person_id').all()
+
+  it('should populate the cache and return how many were computed', async () => {
+    const response = await recompute(3)
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toEqual({ homeId: 3, computed: 3 })
+    expect(cachedRows()).toEqual([
+      { home_id: 3, person_id: 1, label: 'paternal grandmother', degree: 2 },
+      { home_id: 3, person_id: 2, label: 'father', degree: 1 },
+      { home_id: 3, person_id: 4, label: 'no known relationship', degree: null }
+    ])
+  })
+
+  it('should serve reads from the cache', async () => {
+    await recompute(3)
+    // Edit the cache directly; only a cached read can return this label
+    sqlite.prepare("UPDATE kinship_cache SET label = 'cached father' WHERE person_id = 2").run()
+
+    expect(await readCsv(3)).toEqual([
+      'personId,name,relationship,degree',
+      '2,Dad Smith,cached father,1',
+      '1,Grandma Smith,paternal grandmother,2',
+      '4,Stranger Jones,no known relationship,'
+    ])
+  })
+
+  it('should keep entries for other home people when recomputing', async () => {
+    await recompute(1)
+    await recompute(3)
+    await recompute(3)
+
+    const counts = sqlite.prepare('SELECT home_id, COUNT(*) AS count FROM kinship_cache GROUP BY home_id').all()
+    expect(counts).toEqual([{ home_id: 1, count: 3 }, { home_id: 3, count: 3 }])
+  })
+
+  it('should clear the cache when people or relationships change', async () => {
+    const mutations = [
+      "INSERT INTO relationships (person1_id, person2_id, type) VALUES (3, 4, 'spouse')",
+      "UPDATE relationships SET parent_role = 'mother' WHERE person1_id = 2",
+      'DELETE FROM relationships WHERE person1_id = 1',
+      "INSERT INTO people (first_name) VALUES ('New')",
+      "UPDATE people SET gender = 'female' WHERE id = 2",
+      'UPDATE people SET deleted_at = CURRENT_TIMESTAMP WHERE id = 4',
+      'DELETE FROM people WHERE id = 1'
+    ]
+
+    for (const mutation of mutations) {
+      await recompute(3)
+      expect(cachedRows().length).toBeGreaterThan(0)
+
+      sqlite.prepare(mutation).run()
+
+      expect(cachedRows()).toEqual([])
+    }
+  })
+
+  it('should keep the cache when only a name changes', async () => {
+    await recompute(3)
+    sqlite.prepare("UPDATE people SET first_name = 'Papa' WHERE id = 2").run()
+
+    expect(cachedRows()).toHaveLength(3)
+    expect(await readCsv(3)).toContain('2,Papa Smith,father,1')
+  })
+
+  it('should return 404 when the home person does not exist', async () => {
+    expect((await recompute(999)).status).toBe(404)
+  })
+
+  it('should return 400 for invalid ID format', async () => {
+    expect((await recompute('abc')).status).toBe(400)
+  })
+})
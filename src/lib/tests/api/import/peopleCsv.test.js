@@ -0,0 +1,106 @@
+/**
+ * Integration Tests for People CSV Import API
+ *
+ * Tests POST /api/import/people.csv endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { promises as fs } from 'fs'
+import path from 'path'
+import { POST } from '../../../../routes/api/import/people.csv/+server.js'
+import { GET as exportPeopleCsv } from '../../../../routes/api/export/people.csv/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+const FIXTURE = path.join(process.cwd(), 'src/test/fixtures/csv/people-with-malformed-row.csv')
+
+describe('POST /api/import/people.csv', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  function importCsv(text) {
+    return POST(createMockEvent(db, { request: { text: async () => text } }))
+  }
+
+  const selectPeople = () =>
+    sqlite
+      .prepare('SELECT first_name, last_name, birth_date, death_date, gender FROM people ORDER BY id')
+      .all()
+
+  it('should import valid rows and report the line of the malformed row', async () => {
+    const response = await importCsv(await fs.readFile(FIXTURE, 'utf8'))
+    const data = await response.json()
+
+    expect(response.status).toBe(201)
+    expect(data.imported).toBe(3)
+    expect(data.rejected).toHaveLength(1)
+    expect(data.rejected[0].line).toBe(4)
+    expect(data.rejected[0].error).toContain('birthDate')
+
+    expect(selectPeople()).toEqual([
+      { first_name: 'John', last_name: 'Smith', birth_date: '1900-01-15', death_date: '1980-06-01', gender: 'male' },
+      { first_name: 'Mary "Molly"', last_name: 'Smith, Jr', birth_date: '1905-03-10', death_date: null, gender: 'female' },
+      { first_name: 'Anne', last_name: 'Smith', birth_date: '1932-07-04', death_date: null, gender: null }
+    ])
+  })
+
+  it('should import a file without a header row', async () => {
+    const response = await importCsv('1,John,Smith,,,male\n2,Mary,Smith,,,female\n')
+    const data = await response.json()
+
+    expect(response.status).toBe(201)
+    expect(data).toEqual({ batchId: expect.any(String), imported: 2, rejected: [] })
+  })
+
+  it('should assign new IDs rather than using the id column', async () => {
+    sqlite.prepare("INSERT INTO people (id, first_name, last_name) VALUES (1, 'Existing', 'Person')").run()
+
+    await importCsv('1,John,Smith,,,\n')
+
+    expect(sqlite.prepare('SELECT id, first_name FROM people ORDER BY id').all()).toEqual([
+      { id: 1, first_name: 'Existing' },
+      { id: 2, first_name: 'John' }
+    ])
+  })
+
+  it('should return 400 and insert nothing when no row is valid', async () => {
+    const response = await importCsv('id,firstName,lastName,birthDate,deathDate,gender\n1,,Smith,,,\n')
+    const data = await response.json()
+
+    expect(response.status).toBe(400)
+    expect(data.imported).toBe(0)
+    expect(data.rejected.map((row) => row.line)).toEqual([2])
+    expect(selectPeople()).toEqual([])
+  })
+
+  it('should round-trip the CSV export', async () => {
+    sqlite.prepare(`
+      INSERT INTO people (first_name, last_name, birth_date, gender)
+      VALUES ('Pat', 'O''Brien, "Jr"', '1950-05-05', 'other')
+    `).run()
+
+    const exported = await (await exportPeopleCsv(createMockEvent(db))).text()
+    sqlite.prepare('DELETE FROM people').run()
+
+    const data = await (await importCsv(exported)).json()
+
+    expect(data).toEqual({ batchId: expect.any(String), imported: 1, rejected: [] })
+    expect(selectPeople()).toEqual([
+      { first_name: 'Pat', last_name: 'O\'Brien, "Jr"', birth_date: '1950-05-05', death_date: null, gender: 'other' }
+    ])
+  })
+})
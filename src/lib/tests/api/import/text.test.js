@@ -0,0 +1,99 @@
+/**
+ * Integration Tests for Text Outline Import API
+ *
+ * Tests POST /api/import/text endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { POST } from '../../../../routes/api/import/text/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('POST /api/import/text', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  function importText(text) {
+    return POST(createMockEvent(db, { request: { text: async () => text } }))
+  }
+
+  it('should create people and parent links from a two-level outline', async () => {
+    const response = await importText([
+      'John Smith (M)',
+      '\tMary',
+      '\tRobert Jones',
+      '',
+      'Ellen Brown (F)',
+      '    Peter'
+    ].join('\n'))
+    const data = await response.json()
+
+    expect(response.status).toBe(201)
+    expect(data.peopleCreated).toBe(5)
+    expect(data.relationshipsCreated).toBe(3)
+    expect(data.tree).toMatchObject([
+      {
+        firstName: 'John',
+        lastName: 'Smith',
+        line: 1,
+        children: [
+          { firstName: 'Mary', lastName: 'Smith', line: 2, children: [] },
+          { firstName: 'Robert', lastName: 'Jones', line: 3, children: [] }
+        ]
+      },
+      {
+        firstName: 'Ellen',
+        lastName: 'Brown',
+        line: 5,
+        children: [{ firstName: 'Peter', lastName: 'Brown', line: 6, children: [] }]
+      }
+    ])
+
+    const john = data.tree[0]
+    const links = sqlite.prepare(`
+      SELECT person1_id, person2_id, type, parent_role FROM relationships ORDER BY id
+    `).all()
+    expect(links).toEqual([
+      { person1_id: john.id, person2_id: john.children[0].id, type: 'parentOf', parent_role: 'father' },
+      { person1_id: john.id, person2_id: john.children[1].id, type: 'parentOf', parent_role: 'father' },
+      { person1_id: data.tree[1].id, person2_id: data.tree[1].children[0].id, type: 'parentOf', parent_role: 'mother' }
+    ])
+    expect(sqlite.prepare('SELECT gender FROM people WHERE id = ?').get(john.id).gender).toBe('male')
+  })
+
+  it('should leave the parent role empty when the parent has no gender marker', async () => {
+    await importText('Sam Taylor\n  Alex')
+
+    const link = sqlite.prepare('SELECT parent_role FROM relationships').get()
+    expect(link.parent_role).toBeNull()
+  })
+
+  it('should reject a top-level person without a last name and create nothing', async () => {
+    const response = await importText('John Smith\n  Mary\nOrphan')
+    const data = await response.json()
+
+    expect(response.status).toBe(400)
+    expect(data.line).toBe(3)
+    expect(sqlite.prepare('SELECT COUNT(*) AS count FROM people').get().count).toBe(0)
+  })
+
+  it('should reject an empty outline', async () => {
+    const response = await importText('\n# only a comment\n')
+
+    expect(response.status).toBe(400)
+  })
+})
@@ -0,0 +1,131 @@
+/**
+ * Integration Tests for Tree Backup Export/Import API
+ *
+ * Tests GET /api/export/tree and POST /api/import/tree endpoints
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET as exportTree } from '../../../../routes/api/export/tree/+server.js'
+import { POST as importTree } from '../../../../routes/api/import/tree/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('Tree backup export and import', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, birth_date, gender, birth_place)
+      VALUES (?, ?, ?, ?, ?, ?)
+    `)
+    insertPerson.run(5, 'John', 'Smith', '1900-01-15', 'male', 'Boston')
+    insertPerson.run(8, 'Mary', 'Jones', '1905-03-10', 'female', null)
+    insertPerson.run(12, 'Junior', 'Smith', '1930-07-04', 'male', null)
+
+    const insertRelationship = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role, relation_kind)
+      VALUES (?, ?, ?, ?, ?)
+    `)
+    insertRelationship.run(5, 8, 'spouse', null, null)
+    insertRelationship.run(5, 12, 'parentOf', 'father', null)
+    insertRelationship.run(8, 12, 'parentOf', 'mother', 'adoptive')
+    sqlite.prepare("UPDATE relationships SET sort_order = 2 WHERE type = 'parentOf' AND person1_id = 5").run()
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const postTree = (body) =>
+    importTree(createMockEvent(db, { request: { json: async () => body } }))
+
+  // The tree described by names rather than IDs, so it can be compared across re-imports
+  const describeGraph = () => ({
+    people: sqlite
+      .prepare('SELECT first_name, last_name, birth_date, gender, birth_place FROM people ORDER BY first_name')
+      .all(),
+    relationships: sqlite
+      .prepare(`
+        SELECT p1.first_name AS person1, p2.first_name AS person2, r.type, r.parent_role, r.relation_kind, r.sort_order
+        FROM relationships r
+        JOIN people p1 ON p1.id = r.person1_id
+        JOIN people p2 ON p2.id = r.person2_id
+        ORDER BY person1, person2, r.type
+      `)
+      .all()
+  })
+
+  it('should export a versioned document as an attachment', async () => {
+    const response = await exportTree(createMockEvent(db))
+    const document = JSON.parse(await response.text())
+
+    expect(response.status).toBe(200)
+    expect(response.headers.get('Content-Disposition')).toMatch(/^attachment; filename="familytree_\d{8}\.json"$/)
+    expect(document.version).toBe(1)
+    expect(typeof document.exportedAt).toBe('string')
+    expect(document.people).toHaveLength(3)
+    expect(document.relationships).toHaveLength(3)
+  })
+
+  it('should round-trip: export, wipe, re-import, and reproduce the same graph', async () => {
+    // Year-only date as stored by the GEDCOM importer
+    sqlite.prepare("UPDATE people SET birth_date = '1930' WHERE id = 12").run()
+    const original = describeGraph()
+    const document = JSON.parse(await (await exportTree(createMockEvent(db))).text())
+
+    sqlite.exec('DELETE FROM relationships; DELETE FROM people;')
+
+    const response = await postTree(document)
+    const data = await response.json()
+
+    expect(response.status).toBe(201)
+    expect(data.peopleImported).toBe(3)
+    expect(data.relationshipsImported).toBe(3)
+    expect(describeGraph()).toEqual(original)
+  })
+
+  it('should remap IDs when importing into a database that already has data', async () => {
+    const document = JSON.parse(await (await exportTree(createMockEvent(db))).text())
+
+    const data = await (await postTree(document)).json()
+
+    expect(Object.keys(data.idMap)).toEqual(['5', '8', '12'])
+    expect(Object.values(data.idMap).every((id) => ![5, 8, 12].includes(id))).toBe(true)
+
+    const juniorId = data.idMap['12']
+    const parents = sqlite
+      .prepare("SELECT person1_id FROM relationships WHERE person2_id = ? AND type = 'parentOf' ORDER BY person1_id")
+      .all(juniorId)
+      .map((row) => row.person1_id)
+    expect(parents).toEqual([data.idMap['5'], data.idMap['8']])
+    expect(sqlite.prepare('SELECT COUNT(*) AS count FROM people').get().count).toBe(6)
+  })
+
+  it('should reject an invalid document without writing anything', async () => {
+    const document = JSON.parse(await (await exportTree(createMockEvent(db))).text())
+    document.relationships.push({ person1Id: 5, person2Id: 999, type: 'spouse' })
+
+    const response = await postTree(document)
+
+    expect(response.status).toBe(400)
+    expect((await response.json()).error).toContain('relationships[3]')
+    expect(sqlite.prepare('SELECT COUNT(*) AS count FROM people').get().count).toBe(3)
+  })
+
+  it('should reject invalid JSON', async () => {
+    const response = await importTree(
+      createMockEvent(db, { request: { json: async () => { throw new SyntaxError('bad') } } })
+    )
+
+    expect(response.status).toBe(400)
+  })
+})
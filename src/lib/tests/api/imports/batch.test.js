@@ -0,0 +1,87 @@
+/**
+ * Integration Tests for Import Batches API
+ *
+ * Tests GET and DELETE /api/imports/{batchId}
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET, DELETE } from '../../../../routes/api/imports/[batchId]/+server.js'
+import { POST as importText } from '../../../../routes/api/import/text/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('/api/imports/[batchId]', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    // A person who existed before the import
+    sqlite.prepare("INSERT INTO people (id, first_name, last_name) VALUES (1, 'Existing', 'Person')").run()
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  async function importOutline(text) {
+    const response = await importText(createMockEvent(db, { request: { text: async () => text } }))
+    return response.json()
+  }
+
+  const batchEvent = (batchId) => createMockEvent(db, { params: { batchId } })
+
+  it('should list the people and relationships created by an import', async () => {
+    const { batchId } = await importOutline('John Smith (M)\n  Jane\n  Jack\n')
+
+    const response = await GET(batchEvent(batchId))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.batchId).toBe(batchId)
+    expect(data.people.map((person) => person.firstName)).toEqual(['John', 'Jane', 'Jack'])
+    expect(data.relationships).toHaveLength(2)
+    expect(data.relationships.every((relationship) => relationship.type === 'father')).toBe(true)
+  })
+
+  it('should roll back everything an import created and keep other data', async () => {
+    const first = await importOutline('Ann Lee (F)\n  Bob\n')
+    const second = await importOutline('Carl Diaz\n')
+
+    // A relationship added after the import that points at an imported person
+    const [ann] = sqlite.prepare("SELECT id FROM people WHERE first_name = 'Ann'").all()
+    sqlite
+      .prepare("INSERT INTO relationships (person1_id, person2_id, type) VALUES (1, ?, 'spouse')")
+      .run(ann.id)
+
+    const response = await DELETE(batchEvent(first.batchId))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toEqual({ batchId: first.batchId, peopleDeleted: 2, relationshipsDeleted: 2 })
+    expect(sqlite.prepare('SELECT first_name FROM people ORDER BY id').all()).toEqual([
+      { first_name: 'Existing' },
+      { first_name: 'Carl' }
+    ])
+    expect(sqlite.prepare('SELECT COUNT(*) AS count FROM relationships').get().count).toBe(0)
+
+    // The other import is still listed
+    expect((await GET(batchEvent(second.batchId))).status).toBe(200)
+  })
+
+  it('should return 404 for an unknown or already rolled back batch', async () => {
+    const { batchId } = await importOutline('Solo Person\n')
+
+    expect((await DELETE(batchEvent(batchId))).status).toBe(200)
+    expect((await GET(batchEvent(batchId))).status).toBe(404)
+    expect((await DELETE(batchEvent(batchId))).status).toBe(404)
+    expect((await GET(batchEvent('no-such-batch'))).status).toBe(404)
+  })
+})
@@ -0,0 +1,157 @@
+/**
+ * Integration Tests for Approximate Birth Date Filtering
+ *
+ * Tests GET /api/people?approximate=true|false and the birthDateQualifier field
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET, POST } from '../../../../routes/api/people/+server.js'
+import { PUT, PATCH } from '../../../../routes/api/people/[id]/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/people?approximate=', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (first_name, last_name, birth_date, birth_date_qualifier)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertPerson.run('Exact', 'Doe', '1980-01-01', null)
+    insertPerson.run('About', 'Doe', '1850-01-01', 'about')
+    insertPerson.run('Before', 'Doe', '1820-06-01', 'before')
+    insertPerson.run('Unknown', 'Doe', null, null)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  function eventFor(query) {
+    return createMockEvent(db, { url: new URL(`http://localhost/api/people${query}`) })
+  }
+
+  it('should return only people with approximate birth dates', async () => {
+    const response = await GET(eventFor('?approximate=true'))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.map((p) => p.firstName)).toEqual(['About', 'Before'])
+    expect(data.map((p) => p.birthDateQualifier)).toEqual(['about', 'before'])
+  })
+
+  it('should return only people without a qualifier when approximate=false', async () => {
+    const response = await GET(eventFor('?approximate=false'))
+    const data = await response.json()
+
+    expect(data.map((p) => p.firstName)).toEqual(['Exact', 'Unknown'])
+  })
+
+  it('should combine with field projection', async () => {
+    const response = await GET(eventFor('?approximate=true&fields=id,birthDateQualifier'))
+    const data = await response.json()
+
+    expect(data).toEqual([
+      { id: 2, birthDateQualifier: 'about' },
+      { id: 3, birthDateQualifier: 'before' }
+    ])
+  })
+
+  it('should exclude soft-deleted people', async () => {
+    sqlite.prepare('UPDATE people SET deleted_at = CURRENT_TIMESTAMP WHERE id = 2').run()
+
+    const response = await GET(eventFor('?approximate=true'))
+    const data = await response.json()
+
+    expect(data.map((p) => p.id)).toEqual([3])
+  })
+
+  it('should return 400 for an invalid approximate value', async () => {
+    const response = await GET(eventFor('?approximate=maybe'))
+
+    expect(response.status).toBe(400)
+  })
+
+  describe('POST /api/people with birthDateQualifier', () => {
+    const create = (body) => POST(createMockEvent(db, { request: { json: async () => body } }))
+
+    it('should store the qualifier', async () => {
+      const response = await create({
+        firstName: 'Ann',
+        lastName: 'Lee',
+        birthDate: '1900-01-01',
+        birthDateQualifier: 'after'
+      })
+      const data = await response.json()
+
+      expect(response.status).toBe(201)
+      expect(data.birthDateQualifier).toBe('after')
+    })
+
+    it('should reject an unknown qualifier', async () => {
+      const response = await create({
+        firstName: 'Ann',
+        lastName: 'Lee',
+        birthDate: '1900-01-01',
+        birthDateQualifier: 'circa'
+      })
+
+      expect(response.status).toBe(400)
+    })
+
+    it('should reject a qualifier without a birth date', async () => {
+      const response = await create({ firstName: 'Ann', lastName: 'Lee', birthDateQualifier: 'about' })
+
+      expect(response.status).toBe(400)
+    })
+  })
+
+  describe('updating with an empty birthDateQualifier', () => {
+    const update = (handler, body) =>
+      handler(createMockEvent(db, { params: { id: '2' }, request: { json: async () => body } }))
+
+    it('should store null when PUT sends an empty qualifier', async () => {
+      const response = await update(PUT, {
+        firstName: 'About',
+        lastName: 'Doe',
+        birthDate: '1850-01-01',
+        birthDateQualifier: ''
+      })
+      const data = await response.json()
+
+      expect(response.status).toBe(200)
+      expect(data.birthDateQualifier).toBeNull()
+      const row = sqlite.prepare('SELECT birth_date_qualifier FROM people WHERE id = 2').get()
+      expect(row.birth_date_qualifier).toBeNull()
+    })
+
+    it('should store null when PATCH sends an empty qualifier', async () => {
+      const response = await update(PATCH, { birthDateQualifier: '' })
+      const data = await response.json()
+
+      expect(response.status).toBe(200)
+      expect(data.birthDateQualifier).toBeNull()
+      const row = sqlite.prepare('SELECT birth_date_qualifier FROM people WHERE id = 2').get()
+      expect(row.birth_date_qualifier).toBeNull()
+    })
+
+    it('should drop the person from approximate=true after clearing', async () => {
+      await update(PATCH, { birthDateQualifier: '' })
+
+      const response = await GET(eventFor('?approximate=true'))
+      const data = await response.json()
+
+      expect(data.map((p) => p.id)).toEqual([3])
+    })
+  })
+})
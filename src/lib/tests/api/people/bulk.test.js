@@ -0,0 +1,96 @@
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { POST } from '../../../../routes/api/people/bulk/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+/**
+ * Test suite for POST /api/people/bulk
+ *
+ * All entries are validated up front and inserted in one transaction;
+ * a single invalid entry rejects the whole batch.
+ */
+describe('POST /api/people/bulk', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+    await setupTestDatabase(sqlite, db)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const postBulk = async (body) => {
+    const event = createMockEvent(db, {
+      request: { json: async () => body }
+    })
+    return POST(event)
+  }
+
+  const countPeople = () => sqlite.prepare('SELECT COUNT(*) AS count FROM people').get().count
+
+  it('should create every person and return them in request order with IDs', async () => {
+    const response = await postBulk([
+      { firstName: 'William', lastName: 'Hart', birthDate: '1841-03-02', gender: 'Male' },
+      { firstName: 'Mary', lastName: 'Hart', birthPlace: '  Ohio  ' },
+      { firstName: 'Thomas', lastName: 'Hart' }
+    ])
+    const data = await response.json()
+
+    expect(response.status).toBe(201)
+    expect(data.map((person) => person.firstName)).toEqual(['William', 'Mary', 'Thomas'])
+    expect(data.every((person) => Number.isInteger(person.id))).toBe(true)
+    expect(data[0].id).toBeLessThan(data[1].id)
+    expect(data[1].id).toBeLessThan(data[2].id)
+    expect(data[0].gender).toBe('male')
+    expect(data[1].birthPlace).toBe('Ohio')
+    expect(countPeople()).toBe(3)
+  })
+
+  it('should reject the whole batch and report the first invalid entry', async () => {
+    const response = await postBulk([
+      { firstName: 'William', lastName: 'Hart' },
+      { firstName: 'Mary', lastName: 'Hart', birthDate: '1850-13-45' },
+      { firstName: '', lastName: 'Hart' }
+    ])
+    const data = await response.json()
+
+    expect(response.status).toBe(400)
+    expect(data.index).toBe(1)
+    expect(data.error).toContain('birthDate')
+    expect(countPeople()).toBe(0)
+  })
+
+  it('should reject non-object entries with their index', async () => {
+    const response = await postBulk([{ firstName: 'William', lastName: 'Hart' }, 'Mary Hart'])
+    const data = await response.json()
+
+    expect(response.status).toBe(400)
+    expect(data.index).toBe(1)
+  })
+
+  it('should reject an empty or non-array body', async () => {
+    expect((await postBulk([])).status).toBe(400)
+    expect((await postBulk({ firstName: 'William', lastName: 'Hart' })).status).toBe(400)
+  })
+
+  it('should roll back every insert when the database fails mid-batch', async () => {
+    sqlite.exec(`
+      CREATE TRIGGER reject_fail BEFORE INSERT ON people
+      WHEN NEW.first_name = 'Fail'
+      BEGIN SELECT RAISE(ABORT, 'rejected'); END
+    `)
+
+    const response = await postBulk([
+      { firstName: 'William', lastName: 'Hart' },
+      { firstName: 'Fail', lastName: 'Hart' }
+    ])
+
+    expect(response.status).toBe(500)
+    expect(countPeople()).toBe(0)
+  })
+})
@@ -0,0 +1,92 @@
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../routes/api/people/by-marriage-count/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+/**
+ * Test suite for GET /api/people/by-marriage-count
+ *
+ * Fixture:
+ *   Henry(1) married Anne(2), Jane(3) and Catherine(4)
+ *   Catherine(4) also married Thomas(5)
+ *   Mary(6) married Philip(7) (stored in both directions)
+ */
+describe('GET /api/people/by-marriage-count', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare('INSERT INTO people (id, first_name, last_name) VALUES (?, ?, ?)')
+    insertPerson.run(1, 'Henry', 'Tudor')
+    insertPerson.run(2, 'Anne', 'Boleyn')
+    insertPerson.run(3, 'Jane', 'Seymour')
+    insertPerson.run(4, 'Catherine', 'Parr')
+    insertPerson.run(5, 'Thomas', 'Seymour')
+    insertPerson.run(6, 'Mary', 'Tudor')
+    insertPerson.run(7, 'Philip', 'Habsburg')
+
+    const insertSpouse = sqlite.prepare(
+      "INSERT INTO relationships (person1_id, person2_id, type) VALUES (?, ?, 'spouse')"
+    )
+    insertSpouse.run(1, 2)
+    insertSpouse.run(3, 1)
+    insertSpouse.run(1, 4)
+    insertSpouse.run(5, 4)
+    insertSpouse.run(6, 7)
+    insertSpouse.run(7, 6)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const getByMarriageCount = async (query = '') => {
+    const event = createMockEvent(db, {
+      url: new URL(`http://localhost/api/people/by-marriage-count${query}`)
+    })
+    return GET(event)
+  }
+
+  it('should return people married at least twice by default, counting edges in both directions', async () => {
+    const response = await getByMarriageCount()
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.map(({ id, marriageCount, spouseIds }) => ({ id, marriageCount, spouseIds }))).toEqual([
+      { id: 1, marriageCount: 3, spouseIds: [2, 3, 4] },
+      { id: 4, marriageCount: 2, spouseIds: [1, 5] }
+    ])
+    expect(data[0].firstName).toBe('Henry')
+  })
+
+  it('should exclude a once-married person even when the marriage is stored in both directions', async () => {
+    const data = await (await getByMarriageCount('?min=2')).json()
+
+    expect(data.map((person) => person.id)).not.toContain(6)
+    expect(data.map((person) => person.id)).not.toContain(7)
+  })
+
+  it('should honour a custom minimum', async () => {
+    const data = await (await getByMarriageCount('?min=3')).json()
+
+    expect(data.map((person) => person.id)).toEqual([1])
+  })
+
+  it('should not count spouses who have been soft-deleted', async () => {
+    sqlite.prepare("UPDATE people SET deleted_at = '2024-01-01 00:00:00' WHERE id = 5").run()
+
+    const data = await (await getByMarriageCount()).json()
+
+    expect(data.map((person) => person.id)).toEqual([1])
+  })
+
+  it('should reject an invalid min', async () => {
+    expect((await getByMarriageCount('?min=0')).status).toBe(400)
+    expect((await getByMarriageCount('?min=abc')).status).toBe(400)
+  })
+})
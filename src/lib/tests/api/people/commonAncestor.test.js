@@ -0,0 +1,99 @@
+/**
+ * Integration Tests for Group Common Ancestor API
+ *
+ * Tests POST /api/people/common-ancestor endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { POST } from '../../../../routes/api/people/common-ancestor/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('POST /api/people/common-ancestor', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name)
+      VALUES (?, ?, ?)
+    `)
+    insertPerson.run(1, 'Grandpa', 'Smith')
+    insertPerson.run(2, 'Grandma', 'Smith')
+    insertPerson.run(3, 'Aunt', 'Smith')
+    insertPerson.run(4, 'Dad', 'Smith')
+    insertPerson.run(5, 'Cousin', 'Jones')
+    insertPerson.run(6, 'Me', 'Smith')
+    insertPerson.run(7, 'Sister', 'Smith')
+    insertPerson.run(8, 'Stranger', 'Brown')
+
+    const insertParent = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, 'parentOf', ?)
+    `)
+    insertParent.run(1, 3, 'father')
+    insertParent.run(2, 3, 'mother')
+    insertParent.run(1, 4, 'father')
+    insertParent.run(2, 4, 'mother')
+    insertParent.run(3, 5, 'mother')
+    insertParent.run(4, 6, 'father')
+    insertParent.run(4, 7, 'father')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  function eventFor(body) {
+    return createMockEvent(db, { request: { json: async () => body } })
+  }
+
+  it('should return the grandparents shared by three grandchildren', async () => {
+    const response = await POST(eventFor({ ids: [5, 6, 7] }))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.personIds).toEqual([5, 6, 7])
+    expect(data.commonAncestor.person.id).toBe(1)
+    expect(data.commonAncestor.distances).toEqual([
+      { personId: 5, distance: 2 },
+      { personId: 6, distance: 2 },
+      { personId: 7, distance: 2 }
+    ])
+    expect(data.mostRecentCommonAncestors.map((a) => a.person.id)).toEqual([1, 2])
+  })
+
+  it('should return null when the group shares no ancestor', async () => {
+    const response = await POST(eventFor({ ids: [5, 6, 8] }))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.commonAncestor).toBeNull()
+    expect(data.mostRecentCommonAncestors).toEqual([])
+  })
+
+  it('should return 400 for fewer than three distinct people', async () => {
+    const response = await POST(eventFor({ ids: [5, 6, 6] }))
+
+    expect(response.status).toBe(400)
+  })
+
+  it('should return 400 when ids is missing or invalid', async () => {
+    expect((await POST(eventFor({}))).status).toBe(400)
+    expect((await POST(eventFor({ ids: [5, 'x', 7] }))).status).toBe(400)
+  })
+
+  it('should return 404 when a person does not exist', async () => {
+    const response = await POST(eventFor({ ids: [5, 6, 999] }))
+
+    expect(response.status).toBe(404)
+  })
+})
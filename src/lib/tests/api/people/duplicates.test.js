@@ -166,4 +166,24 @@ describe('GET /api/people/duplicates', () => {
     expect(response.status).toBe(200)
     expect(data).toHaveLength(2) // Limited to 2 results
   })
+  it('should group likely duplicates when groups=true', async () => {
+    sqlite.prepare(`
+      INSERT INTO people (first_name, last_name, birth_date)
+      VALUES (?, ?, ?), (?, ?, ?), (?, ?, ?)
+    `).run(
+      'Mary', 'Jones', '1901-03-09',
+      'mary', 'Jones ', '1901',
+      'Martha', 'Jones', '1901-03-09'
+    )
+
+    const event = {
+      ...createMockEvent(db),
+      url: new URL('http://localhost/api/people/duplicates?groups=true')
+    }
+    const response = await GET(event)
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toEqual([{ name: 'mary jones', personIds: [1, 2], confidence: 90 }])
+  })
 })
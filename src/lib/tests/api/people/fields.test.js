@@ -0,0 +1,81 @@
+/**
+ * Integration Tests for People Field Projection
+ *
+ * Tests GET /api/people?fields=... minimal projections for mobile clients
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../routes/api/people/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/people?fields=', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    sqlite.prepare(`
+      INSERT INTO people (first_name, last_name, birth_date, gender, nickname)
+      VALUES (?, ?, ?, ?, ?)
+    `).run('John', 'Doe', '1980-01-01', 'male', 'Johnny')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  function eventFor(query) {
+    return createMockEvent(db, { url: new URL(`http://localhost/api/people${query}`) })
+  }
+
+  it('should return only the requested fields', async () => {
+    const response = await GET(eventFor('?fields=id,firstName,lastName'))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toEqual([{ id: 1, firstName: 'John', lastName: 'Doe' }])
+  })
+
+  it('should format createdAt as RFC3339 when requested', async () => {
+    const response = await GET(eventFor('?fields=id,createdAt'))
+    const data = await response.json()
+
+    expect(Object.keys(data[0])).toEqual(['id', 'createdAt'])
+    expect(data[0].createdAt).toMatch(/^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z$/)
+  })
+
+  it('should tolerate whitespace and duplicate field names', async () => {
+    const response = await GET(eventFor('?fields=id,%20nickname,id'))
+    const data = await response.json()
+
+    expect(data).toEqual([{ id: 1, nickname: 'Johnny' }])
+  })
+
+  it('should reject non-whitelisted fields with 400', async () => {
+    const response = await GET(eventFor('?fields=id,password'))
+
+    expect(response.status).toBe(400)
+    expect((await response.json()).error).toContain('password')
+  })
+
+  it('should reject an empty fields list with 400', async () => {
+    const response = await GET(eventFor('?fields='))
+
+    expect(response.status).toBe(400)
+  })
+
+  it('should return full records when fields is omitted', async () => {
+    const response = await GET(eventFor(''))
+    const data = await response.json()
+
+    expect(data[0]).toMatchObject({ id: 1, firstName: 'John', birthDate: '1980-01-01', gender: 'male' })
+  })
+})
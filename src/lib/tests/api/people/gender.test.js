@@ -0,0 +1,93 @@
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { POST } from '../../../../routes/api/people/+server.js'
+import { PUT } from '../../../../routes/api/people/[id]/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+/**
+ * Test suite for gender validation in Person API
+ *
+ * Allowed values are male, female, other, unknown, and empty for
+ * unspecified; input is case-insensitive and stored lowercase.
+ */
+describe('Person gender validation', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+    await setupTestDatabase(sqlite, db)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const createPerson = async (body) => {
+    const event = createMockEvent(db, {
+      request: { json: async () => body }
+    })
+    return POST(event)
+  }
+
+  const updatePerson = async (id, body) => {
+    const event = createMockEvent(db, {
+      params: { id: String(id) },
+      request: { json: async () => body }
+    })
+    return PUT(event)
+  }
+
+  it.each([['male'], ['female'], ['other'], ['unknown']])('should accept gender "%s"', async (gender) => {
+    const response = await createPerson({ firstName: 'Pat', lastName: 'Doe', gender })
+    const data = await response.json()
+
+    expect(response.status).toBe(201)
+    expect(data.gender).toBe(gender)
+  })
+
+  it('should store an empty gender as unspecified (null)', async () => {
+    const response = await createPerson({ firstName: 'Pat', lastName: 'Doe', gender: '' })
+    const data = await response.json()
+
+    expect(response.status).toBe(201)
+    expect(data.gender).toBeNull()
+  })
+
+  it('should reject a gender outside the allowed set', async () => {
+    const response = await createPerson({ firstName: 'Pat', lastName: 'Doe', gender: 'robot' })
+
+    expect(response.status).toBe(400)
+    expect((await response.json()).error).toContain('gender must be one of')
+  })
+
+  it('should normalize case on create', async () => {
+    const response = await createPerson({ firstName: 'Pat', lastName: 'Doe', gender: 'Female' })
+    const data = await response.json()
+
+    expect(response.status).toBe(201)
+    expect(data.gender).toBe('female')
+  })
+
+  it('should normalize case on update', async () => {
+    const created = await (await createPerson({ firstName: 'Pat', lastName: 'Doe' })).json()
+
+    const response = await updatePerson(created.id, { firstName: 'Pat', lastName: 'Doe', gender: 'Male' })
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.gender).toBe('male')
+    expect(sqlite.prepare('SELECT gender FROM people WHERE id = ?').get(created.id).gender).toBe('male')
+  })
+
+  it('should reject an invalid gender on update', async () => {
+    const created = await (await createPerson({ firstName: 'Pat', lastName: 'Doe', gender: 'male' })).json()
+
+    const response = await updatePerson(created.id, { firstName: 'Pat', lastName: 'Doe', gender: 'x' })
+
+    expect(response.status).toBe(400)
+    expect(sqlite.prepare('SELECT gender FROM people WHERE id = ?').get(created.id).gender).toBe('male')
+  })
+})
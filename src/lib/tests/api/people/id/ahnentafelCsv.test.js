@@ -0,0 +1,92 @@
+/**
+ * Integration Tests for Ahnentafel CSV Export API
+ *
+ * Tests GET /api/people/[id]/ahnentafel.csv endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../../routes/api/people/[id]/ahnentafel.csv/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+import { parseCsvRecords } from '$lib/server/csv.js'
+
+describe('GET /api/people/[id]/ahnentafel.csv', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender, birth_date, death_date)
+      VALUES (?, ?, ?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'Me', 'Smith', 'male', '1990-04-01', null)
+    insertPerson.run(2, 'Dad', 'Smith', 'male', '1960-02-14', null)
+    insertPerson.run(3, 'Mom', 'O\'Brien, Walsh', 'female', '1962-07-30', null)
+    insertPerson.run(4, 'Grandma', 'O\'Brien', 'female', '1930-01-01', '2001-09-12')
+    insertPerson.run(5, 'Son', 'Smith', 'male', '2020-05-05', null)
+
+    const insertParent = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, 'parentOf', ?)
+    `)
+    insertParent.run(2, 1, 'father')
+    insertParent.run(3, 1, 'mother')
+    insertParent.run(4, 3, 'mother')
+    insertParent.run(1, 5, 'father')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  it('should export ancestors with Ahnentafel numbers', async () => {
+    const response = await GET(createMockEvent(db, { params: { id: '1' } }))
+    const text = await response.text()
+
+    expect(response.status).toBe(200)
+    expect(response.headers.get('Content-Type')).toBe('text/csv')
+    expect(response.headers.get('Content-Disposition')).toBe('attachment; filename="ahnentafel_1.csv"')
+
+    const records = parseCsvRecords(text).map((record) => record.fields)
+    expect(records).toEqual([
+      ['ahnentafel', 'name', 'birthDate', 'deathDate'],
+      ['1', 'Me Smith', '1990-04-01', ''],
+      ['2', 'Dad Smith', '1960-02-14', ''],
+      ['3', 'Mom O\'Brien, Walsh', '1962-07-30', ''],
+      ['7', 'Grandma O\'Brien', '1930-01-01', '2001-09-12']
+    ])
+  })
+
+  it('should put the father on row 2', async () => {
+    const text = await (await GET(createMockEvent(db, { params: { id: '1' } }))).text()
+    const [, , fatherRow] = parseCsvRecords(text)
+
+    expect(fatherRow.fields.slice(0, 2)).toEqual(['2', 'Dad Smith'])
+  })
+
+  it('should not include descendants', async () => {
+    const text = await (await GET(createMockEvent(db, { params: { id: '1' } }))).text()
+
+    expect(text).not.toContain('Son Smith')
+  })
+
+  it('should return 404 for a missing person', async () => {
+    const response = await GET(createMockEvent(db, { params: { id: '999' } }))
+
+    expect(response.status).toBe(404)
+  })
+
+  it('should return 400 for an invalid ID', async () => {
+    const response = await GET(createMockEvent(db, { params: { id: 'abc' } }))
+
+    expect(response.status).toBe(400)
+  })
+})
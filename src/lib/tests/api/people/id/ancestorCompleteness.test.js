@@ -0,0 +1,91 @@
+/**
+ * Integration Tests for Ancestor Completeness API
+ *
+ * Tests GET /api/people/[id]/ancestor-completeness endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../../routes/api/people/[id]/ancestor-completeness/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/people/[id]/ancestor-completeness', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    // Me(1) <- Dad(2), Mom(3); Dad <- Grandpa(4), Grandma(5); Mom <- Nana(6); Grandpa <- GreatGrandpa(7)
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name)
+      VALUES (?, ?, ?)
+    `)
+    insertPerson.run(1, 'Me', 'Smith')
+    insertPerson.run(2, 'Dad', 'Smith')
+    insertPerson.run(3, 'Mom', 'Jones')
+    insertPerson.run(4, 'Grandpa', 'Smith')
+    insertPerson.run(5, 'Grandma', 'Brown')
+    insertPerson.run(6, 'Nana', 'Jones')
+    insertPerson.run(7, 'GreatGrandpa', 'Smith')
+
+    const insertParent = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, 'parentOf', ?)
+    `)
+    insertParent.run(2, 1, 'father')
+    insertParent.run(3, 1, 'mother')
+    insertParent.run(4, 2, 'father')
+    insertParent.run(5, 2, 'mother')
+    insertParent.run(6, 3, 'mother')
+    insertParent.run(7, 4, 'father')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const getCompleteness = (id, query = '') =>
+    GET(createMockEvent(db, {
+      params: { id: String(id) },
+      url: new URL(`http://localhost/api/people/${id}/ancestor-completeness${query}`)
+    }))
+
+  it('should report expected and found ancestors per generation', async () => {
+    const response = await getCompleteness(1, '?generations=3')
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.personId).toBe(1)
+    expect(data.generations).toBe(3)
+    expect(data.levels).toEqual([
+      { generation: 1, expected: 2, found: 2, percent: 100 },
+      { generation: 2, expected: 4, found: 3, percent: 75 },
+      { generation: 3, expected: 8, found: 1, percent: 12.5 }
+    ])
+  })
+
+  it('should default to five generations', async () => {
+    const data = await (await getCompleteness(1)).json()
+
+    expect(data.levels).toHaveLength(5)
+    expect(data.levels[4]).toEqual({ generation: 5, expected: 32, found: 0, percent: 0 })
+  })
+
+  it('should return 400 for invalid generations', async () => {
+    expect((await getCompleteness(1, '?generations=0')).status).toBe(400)
+    expect((await getCompleteness(1, '?generations=11')).status).toBe(400)
+    expect((await getCompleteness(1, '?generations=two')).status).toBe(400)
+  })
+
+  it('should return 400 for an invalid ID and 404 for a missing person', async () => {
+    expect((await getCompleteness('abc')).status).toBe(400)
+    expect((await getCompleteness(999)).status).toBe(404)
+  })
+})
@@ -0,0 +1,104 @@
+/**
+ * Integration Tests for Ancestor Contributions API
+ *
+ * Tests GET /api/people/[id]/ancestor-contributions endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../../routes/api/people/[id]/ancestor-contributions/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/people/[id]/ancestor-contributions', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    // Dad(2) and Mom(3) are first cousins: their fathers Uncle(4) and Father(5)
+    // are brothers, sons of Founder(6) + Foundress(7). Kid(1) is their child.
+    // Adopter(8) adopted Dad(2).
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender)
+      VALUES (?, ?, ?, ?)
+    `)
+    const names = ['Kid', 'Dad', 'Mom', 'Uncle', 'Father', 'Founder', 'Foundress', 'Adopter']
+    const genders = ['male', 'male', 'female', 'male', 'male', 'male', 'female', 'male']
+    names.forEach((name, index) => insertPerson.run(index + 1, name, 'Smith', genders[index]))
+
+    const insertParent = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role, relation_kind)
+      VALUES (?, ?, 'parentOf', ?, ?)
+    `)
+    insertParent.run(2, 1, 'father', null)
+    insertParent.run(3, 1, 'mother', null)
+    insertParent.run(4, 2, 'father', null)
+    insertParent.run(5, 3, 'father', null)
+    insertParent.run(6, 4, 'father', null)
+    insertParent.run(7, 4, 'mother', null)
+    insertParent.run(6, 5, 'father', null)
+    insertParent.run(7, 5, 'mother', null)
+    insertParent.run(8, 2, 'adoptiveFather', 'adoptive')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const getContributions = (id, query = '') =>
+    GET(createMockEvent(db, {
+      params: { id: String(id) },
+      url: new URL(`http://localhost/api/people/${id}/ancestor-contributions${query}`)
+    }))
+
+  const byName = (data) =>
+    Object.fromEntries(data.ancestors.map(({ person, ...entry }) => [person.firstName, entry]))
+
+  it('should give a collapsed ancestor more than others of their generation', async () => {
+    const response = await getContributions(1, '?kinship=blood')
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.personId).toBe(1)
+
+    const contributions = byName(data)
+    expect(contributions.Dad).toEqual({ contribution: 0.5, generation: 1, paths: 1 })
+    expect(contributions.Uncle).toEqual({ contribution: 0.25, generation: 2, paths: 1 })
+    // Great-grandparents normally contribute 1/8; Founder is reached through both parents
+    expect(contributions.Founder).toEqual({ contribution: 0.25, generation: 3, paths: 2 })
+    expect(contributions.Foundress).toEqual({ contribution: 0.25, generation: 3, paths: 2 })
+    expect(contributions.Adopter).toBeUndefined()
+  })
+
+  it('should order ancestors by contribution', async () => {
+    const data = await (await getContributions(1, '?kinship=blood')).json()
+
+    expect(data.ancestors.map(({ person }) => person.firstName)).toEqual(
+      ['Dad', 'Mom', 'Uncle', 'Father', 'Founder', 'Foundress']
+    )
+  })
+
+  it('should count adoptive parents in legal mode', async () => {
+    const data = await (await getContributions(1)).json()
+
+    expect(byName(data).Adopter).toEqual({ contribution: 0.25, generation: 2, paths: 1 })
+  })
+
+  it('should return an empty list for a person without parents', async () => {
+    const data = await (await getContributions(6)).json()
+
+    expect(data.ancestors).toEqual([])
+  })
+
+  it('should return 400 for an invalid ID and 404 for a missing person', async () => {
+    expect((await getContributions('abc')).status).toBe(400)
+    expect((await getContributions(999)).status).toBe(404)
+  })
+})
@@ -0,0 +1,104 @@
+/**
+ * Integration Tests for Child Ordering API
+ *
+ * Tests GET /api/people/[id]/children and POST /api/people/[id]/children/reorder
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../../routes/api/people/[id]/children/+server.js'
+import { POST } from '../../../../../routes/api/people/[id]/children/reorder/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('Child ordering', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, birth_date)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'Parent', 'Smith', '1900-01-01')
+    insertPerson.run(2, 'Eldest', 'Smith', '1925-01-01')
+    insertPerson.run(3, 'Middle', 'Smith', '1927-01-01')
+    insertPerson.run(4, 'Youngest', 'Smith', '1930-01-01')
+    insertPerson.run(5, 'Unrelated', 'Jones', null)
+
+    const insertParent = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, 'parentOf', 'father')
+    `)
+    insertParent.run(1, 3)
+    insertParent.run(1, 4)
+    insertParent.run(1, 2)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const getChildren = (id) => GET(createMockEvent(db, { params: { id: String(id) } }))
+
+  const reorder = (id, body) =>
+    POST(createMockEvent(db, { params: { id: String(id) }, request: { json: async () => body } }))
+
+  const childNames = async (id) => (await (await getChildren(id)).json()).map((child) => child.firstName)
+
+  it('should list children by birth date before any reordering', async () => {
+    expect(await childNames(1)).toEqual(['Eldest', 'Middle', 'Youngest'])
+  })
+
+  it('should reorder three children and read them back in the new order', async () => {
+    const response = await reorder(1, { childIds: [4, 2, 3] })
+
+    expect(response.status).toBe(200)
+    expect(await response.json()).toEqual({ parentId: 1, childIds: [4, 2, 3] })
+
+    const children = await (await getChildren(1)).json()
+    expect(children.map((child) => child.firstName)).toEqual(['Youngest', 'Eldest', 'Middle'])
+    expect(children.map((child) => child.sortOrder)).toEqual([0, 1, 2])
+  })
+
+  it('should store the order on the parentOf relationships', async () => {
+    await reorder(1, { childIds: [3, 4, 2] })
+
+    const rows = sqlite
+      .prepare('SELECT person2_id, sort_order FROM relationships WHERE person1_id = 1 ORDER BY sort_order')
+      .all()
+    expect(rows).toEqual([
+      { person2_id: 3, sort_order: 0 },
+      { person2_id: 4, sort_order: 1 },
+      { person2_id: 2, sort_order: 2 }
+    ])
+  })
+
+  it('should reject lists that are not exactly the current children', async () => {
+    const missing = await reorder(1, { childIds: [4, 2] })
+    const extra = await reorder(1, { childIds: [4, 2, 3, 5] })
+    const repeated = await reorder(1, { childIds: [4, 2, 2] })
+
+    expect(missing.status).toBe(400)
+    expect(extra.status).toBe(400)
+    expect(repeated.status).toBe(400)
+    expect(await childNames(1)).toEqual(['Eldest', 'Middle', 'Youngest'])
+  })
+
+  it('should reject a malformed body', async () => {
+    expect((await reorder(1, { childIds: 'abc' })).status).toBe(400)
+    expect((await reorder(1, [4, 2, 3])).status).toBe(400)
+  })
+
+  it('should return 404 for a missing parent', async () => {
+    expect((await reorder(999, { childIds: [] })).status).toBe(404)
+    expect((await getChildren(999)).status).toBe(404)
+  })
+})
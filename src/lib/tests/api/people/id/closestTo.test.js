@@ -0,0 +1,95 @@
+/**
+ * Integration Tests for Closest Relative API
+ *
+ * Tests POST /api/people/[id]/closest-to endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { POST } from '../../../../../routes/api/people/[id]/closest-to/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('POST /api/people/[id]/closest-to', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    // Grandpa(1) -> Dad(2), Uncle(3)
+    // Dad(2) -> Me(4), Sister(5)
+    // Uncle(3) -> Cousin(6)
+    // Stranger(7)
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'Grandpa', 'Smith', 'male')
+    insertPerson.run(2, 'Dad', 'Smith', 'male')
+    insertPerson.run(3, 'Uncle', 'Smith', 'male')
+    insertPerson.run(4, 'Me', 'Smith', 'male')
+    insertPerson.run(5, 'Sister', 'Smith', 'female')
+    insertPerson.run(6, 'Cousin', 'Smith', 'female')
+    insertPerson.run(7, 'Stranger', 'Doe', null)
+
+    const insertParent = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, 'parentOf', 'father')
+    `)
+    insertParent.run(1, 2)
+    insertParent.run(1, 3)
+    insertParent.run(2, 4)
+    insertParent.run(2, 5)
+    insertParent.run(3, 6)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const closestTo = (id, body) =>
+    POST(createMockEvent(db, {
+      params: { id: String(id) },
+      url: new URL(`http://localhost/api/people/${id}/closest-to`),
+      request: { json: async () => body }
+    }))
+
+  it('should return a sibling over a cousin', async () => {
+    const response = await closestTo(4, { ids: [6, 7, 5] })
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.closest.person.id).toBe(5)
+    expect(data.closest.label).toBe('sister')
+    expect(data.candidates.map((entry) => [entry.person.id, entry.label, entry.degree])).toEqual([
+      [5, 'sister', 2],
+      [6, 'first cousin', 4],
+      [7, 'no known relationship', null]
+    ])
+  })
+
+  it('should return null when no candidate is related', async () => {
+    const response = await closestTo(4, { ids: [7] })
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.closest).toBeNull()
+  })
+
+  it('should return 400 for a missing or invalid ids list', async () => {
+    expect((await closestTo(4, {})).status).toBe(400)
+    expect((await closestTo(4, { ids: [] })).status).toBe(400)
+    expect((await closestTo(4, { ids: ['abc'] })).status).toBe(400)
+  })
+
+  it('should return 404 when the person or a candidate does not exist', async () => {
+    expect((await closestTo(999, { ids: [5] })).status).toBe(404)
+    expect((await closestTo(4, { ids: [5, 999] })).status).toBe(404)
+  })
+})
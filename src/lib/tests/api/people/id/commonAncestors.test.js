@@ -0,0 +1,105 @@
+/**
+ * Integration Tests for Common Ancestors API
+ *
+ * Tests GET /api/people/[id]/common-ancestors/[otherId] endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../../routes/api/people/[id]/common-ancestors/[otherId]/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/people/[id]/common-ancestors/[otherId]', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    // Two first cousins sharing grandparents:
+    //   Grandpa(1) + Grandma(2)
+    //     ├── Uncle(3) ── Cousin(5)
+    //     └── Dad(4)   ── Me(6)
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'George', 'Smith', 'male')
+    insertPerson.run(2, 'Martha', 'Smith', 'female')
+    insertPerson.run(3, 'Uncle', 'Smith', 'male')
+    insertPerson.run(4, 'Dad', 'Smith', 'male')
+    insertPerson.run(5, 'Cousin', 'Smith', 'female')
+    insertPerson.run(6, 'Me', 'Smith', 'male')
+    insertPerson.run(7, 'Stranger', 'Jones', 'male')
+
+    const insertRelationship = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, 'parentOf', ?)
+    `)
+    insertRelationship.run(1, 3, 'father')
+    insertRelationship.run(2, 3, 'mother')
+    insertRelationship.run(1, 4, 'father')
+    insertRelationship.run(2, 4, 'mother')
+    insertRelationship.run(3, 5, 'father')
+    insertRelationship.run(4, 6, 'father')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  it('should return shared grandparents for first cousins', async () => {
+    const event = createMockEvent(db, { params: { id: '5', otherId: '6' } })
+
+    const response = await GET(event)
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.person1Id).toBe(5)
+    expect(data.person2Id).toBe(6)
+    expect(data.commonAncestors).toHaveLength(2)
+    expect(data.commonAncestors.map((a) => a.person.firstName)).toEqual(['George', 'Martha'])
+
+    for (const ancestor of data.commonAncestors) {
+      expect(ancestor.distance1).toBe(2)
+      expect(ancestor.distance2).toBe(2)
+      expect(ancestor.combinedDistance).toBe(4)
+      expect(ancestor.isMostRecent).toBe(true)
+    }
+
+    expect(data.mostRecentCommonAncestors).toHaveLength(2)
+  })
+
+  it('should return empty lists for unrelated people', async () => {
+    const event = createMockEvent(db, { params: { id: '6', otherId: '7' } })
+
+    const response = await GET(event)
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.commonAncestors).toEqual([])
+    expect(data.mostRecentCommonAncestors).toEqual([])
+  })
+
+  it('should return 404 when a person does not exist', async () => {
+    const event = createMockEvent(db, { params: { id: '6', otherId: '999' } })
+
+    const response = await GET(event)
+
+    expect(response.status).toBe(404)
+  })
+
+  it('should return 400 for invalid ID format', async () => {
+    const event = createMockEvent(db, { params: { id: 'abc', otherId: '6' } })
+
+    const response = await GET(event)
+
+    expect(response.status).toBe(400)
+  })
+})
@@ -0,0 +1,100 @@
+/**
+ * Integration Tests for Person Connections API
+ *
+ * Tests GET /api/people/[id]/connections endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../../routes/api/people/[id]/connections/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/people/[id]/connections', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    // Grandma(1) -> Mom(2); Mom(2) + Husband(3) -> Son(4), Daughter(5); StepKid(6)
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender, birth_date)
+      VALUES (?, ?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'Grandma', 'Smith', 'female', '1920-01-01')
+    insertPerson.run(2, 'Mom', 'Smith', 'female', '1950-01-01')
+    insertPerson.run(3, 'Husband', 'Jones', 'male', '1948-01-01')
+    insertPerson.run(4, 'Son', 'Jones', 'male', '1980-01-01')
+    insertPerson.run(5, 'Daughter', 'Jones', 'female', '1975-01-01')
+    insertPerson.run(6, 'StepKid', 'Brown', null, '1970-01-01')
+
+    const insertRelationship = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertRelationship.run(1, 2, 'parentOf', 'mother')
+    insertRelationship.run(2, 3, 'spouse', null)
+    insertRelationship.run(3, 2, 'spouse', null)
+    insertRelationship.run(2, 4, 'parentOf', 'mother')
+    insertRelationship.run(2, 5, 'parentOf', 'mother')
+    insertRelationship.run(2, 6, 'parentOf', 'stepMother')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const getConnections = (id) => GET(createMockEvent(db, { params: { id: String(id) } }))
+
+  const summarize = (data) =>
+    data.connections.map((connection) => [connection.person.id, connection.relation, connection.label])
+
+  it('should label each direct connection from the person\'s perspective', async () => {
+    const response = await getConnections(2)
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.personId).toBe(2)
+    expect(summarize(data)).toEqual([
+      [1, 'parent', 'mother'],
+      [3, 'spouse', 'husband'],
+      [6, 'child', 'stepchild'],
+      [5, 'child', 'daughter'],
+      [4, 'child', 'son']
+    ])
+  })
+
+  it('should label a child as son or daughter by the child\'s gender', async () => {
+    sqlite.prepare("UPDATE people SET gender = 'female' WHERE id = 4").run()
+
+    const data = await (await getConnections(2)).json()
+    const child = data.connections.find((connection) => connection.person.id === 4)
+
+    expect(child.label).toBe('daughter')
+  })
+
+  it('should label parents from the child\'s side', async () => {
+    const data = await (await getConnections(6)).json()
+
+    expect(summarize(data)).toEqual([[2, 'parent', 'stepmother']])
+  })
+
+  it('should exclude soft-deleted people', async () => {
+    sqlite.prepare('UPDATE people SET deleted_at = CURRENT_TIMESTAMP WHERE id = 1').run()
+
+    const data = await (await getConnections(2)).json()
+
+    expect(data.connections.map((connection) => connection.person.id)).not.toContain(1)
+  })
+
+  it('should return 400 for an invalid ID and 404 for a missing person', async () => {
+    expect((await getConnections('abc')).status).toBe(400)
+    expect((await getConnections(999)).status).toBe(404)
+  })
+})
@@ -0,0 +1,90 @@
+/**
+ * Integration Tests for Relative Counts API
+ *
+ * Tests GET /api/people/[id]/counts endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../../routes/api/people/[id]/counts/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/people/[id]/counts', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    // Grandpa(1) + Grandma(2) -> Dad(3), Aunt(4)
+    // Dad(3) + Mom(5) -> Me(6), Brother(7); Mom(5) -> AdoptedSister(8) (adoptive)
+    // Me(6) -> Kid(9) -> Grandkid(10)
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name)
+      VALUES (?, ?, ?)
+    `)
+    const names = ['Grandpa', 'Grandma', 'Dad', 'Aunt', 'Mom', 'Me', 'Brother', 'AdoptedSister', 'Kid', 'Grandkid']
+    names.forEach((name, index) => insertPerson.run(index + 1, name, 'Smith'))
+
+    const insertParent = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role, relation_kind)
+      VALUES (?, ?, 'parentOf', ?, ?)
+    `)
+    insertParent.run(1, 3, 'father', null)
+    insertParent.run(2, 3, 'mother', null)
+    insertParent.run(1, 4, 'father', null)
+    insertParent.run(2, 4, 'mother', null)
+    insertParent.run(3, 6, 'father', null)
+    insertParent.run(5, 6, 'mother', null)
+    insertParent.run(3, 7, 'father', null)
+    insertParent.run(5, 7, 'mother', null)
+    insertParent.run(5, 8, 'mother', 'adoptive')
+    insertParent.run(6, 9, 'father', null)
+    insertParent.run(9, 10, 'father', null)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  function eventFor(id, query = '') {
+    return createMockEvent(db, {
+      params: { id: String(id) },
+      url: new URL(`http://localhost/api/people/${id}/counts${query}`)
+    })
+  }
+
+  it('should count ancestors, descendants, and siblings', async () => {
+    const response = await GET(eventFor(6))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toEqual({ personId: 6, ancestors: 4, descendants: 2, siblings: 2 })
+  })
+
+  it('should count each descendant once', async () => {
+    const data = await (await GET(eventFor(1))).json()
+
+    expect(data).toMatchObject({ ancestors: 0, descendants: 6, siblings: 0 })
+  })
+
+  it('should leave out adoptive links for kinship=blood', async () => {
+    const data = await (await GET(eventFor(6, '?kinship=blood'))).json()
+
+    expect(data.siblings).toBe(1)
+  })
+
+  it('should return 404 for a missing person', async () => {
+    expect((await GET(eventFor(999))).status).toBe(404)
+  })
+
+  it('should return 400 for an invalid ID', async () => {
+    expect((await GET(eventFor('abc'))).status).toBe(400)
+  })
+})
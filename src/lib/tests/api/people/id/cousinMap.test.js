@@ -0,0 +1,119 @@
+/**
+ * Integration Tests for Cousin Map API
+ *
+ * Tests GET /api/people/[id]/cousin-map endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../../routes/api/people/[id]/cousin-map/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/people/[id]/cousin-map', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    // Grandpa(1) -> Dad(2), Aunt(3), AdoptedUncle(4, adoptive)
+    // Dad(2) -> Me(5), Brother(6)
+    // Aunt(3) -> Cousin(7) -> CousinDaughter(8)
+    // AdoptedUncle(4) -> AdoptedCousin(9)
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'Grandpa', 'Smith', 'male')
+    insertPerson.run(2, 'Dad', 'Smith', 'male')
+    insertPerson.run(3, 'Aunt', 'Smith', 'female')
+    insertPerson.run(4, 'Uncle', 'Smith', 'male')
+    insertPerson.run(5, 'Me', 'Smith', 'female')
+    insertPerson.run(6, 'Brother', 'Smith', 'male')
+    insertPerson.run(7, 'Cousin', 'Jones', 'male')
+    insertPerson.run(8, 'CousinDaughter', 'Jones', 'female')
+    insertPerson.run(9, 'AdoptedCousin', 'Smith', 'female')
+
+    const insertParent = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role, relation_kind)
+      VALUES (?, ?, 'parentOf', ?, ?)
+    `)
+    insertParent.run(1, 2, 'father', null)
+    insertParent.run(1, 3, 'father', null)
+    insertParent.run(1, 4, 'father', 'adoptive')
+    insertParent.run(2, 5, 'father', null)
+    insertParent.run(2, 6, 'father', null)
+    insertParent.run(3, 7, 'mother', null)
+    insertParent.run(7, 8, 'father', null)
+    insertParent.run(4, 9, 'father', null)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const getCousinMap = (id, query = '') =>
+    GET(createMockEvent(db, {
+      params: { id: String(id) },
+      url: new URL(`http://localhost/api/people/${id}/cousin-map${query}`)
+    }))
+
+  const summarize = (data) =>
+    data.groups.map((group) => ({
+      label: group.label,
+      ids: group.cousins.map((cousin) => cousin.person.id)
+    }))
+
+  it('should categorize first cousins and first cousins once removed', async () => {
+    const response = await getCousinMap(5)
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.personId).toBe(5)
+    expect(data.total).toBe(3)
+    expect(data.auntsUncles.map((person) => person.id)).toEqual([3, 4])
+    expect(data.groups.map(({ degree, removed }) => ({ degree, removed }))).toEqual([
+      { degree: 1, removed: 0 },
+      { degree: 1, removed: 1 }
+    ])
+    expect(summarize(data)).toEqual([
+      { label: 'first cousin', ids: [7, 9] },
+      { label: 'first cousin once removed', ids: [8] }
+    ])
+    expect(data.groups[0].cousins[0]).toMatchObject({ person: { firstName: 'Cousin' }, auntUncleIds: [3] })
+  })
+
+  it('should not list siblings as cousins', async () => {
+    const data = await (await getCousinMap(5)).json()
+    const ids = data.groups.flatMap((group) => group.cousins.map((cousin) => cousin.person.id))
+
+    expect(ids).not.toContain(6)
+  })
+
+  it('should ignore adoptive lines with kinship=blood', async () => {
+    const data = await (await getCousinMap(5, '?kinship=blood')).json()
+
+    expect(data.auntsUncles.map((person) => person.id)).toEqual([3])
+    expect(summarize(data)).toEqual([
+      { label: 'first cousin', ids: [7] },
+      { label: 'first cousin once removed', ids: [8] }
+    ])
+  })
+
+  it('should return an empty map for a person without aunts or uncles', async () => {
+    const data = await (await getCousinMap(1)).json()
+
+    expect(data).toEqual({ personId: 1, total: 0, auntsUncles: [], groups: [] })
+  })
+
+  it('should return 400 for an invalid ID and 404 for a missing person', async () => {
+    expect((await getCousinMap('abc')).status).toBe(400)
+    expect((await getCousinMap(999)).status).toBe(404)
+  })
+})
@@ -0,0 +1,99 @@
+/**
+ * Integration Tests for Descendant Growth API
+ *
+ * Tests GET /api/people/[id]/descendant-growth endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../../routes/api/people/[id]/descendant-growth/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/people/[id]/descendant-growth', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, birth_date)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'Patriarch', 'Smith', '1880-01-01')
+    insertPerson.run(2, 'Son', 'Smith', '1910-05-01')
+    insertPerson.run(3, 'Daughter', 'Smith', '1914-01-01')
+    insertPerson.run(4, 'Grandson', 'Smith', '1941-01-01')
+    insertPerson.run(5, 'Granddaughter', 'Smith', null)
+    insertPerson.run(6, 'Grandchild', 'Smith', '1948-01-01')
+    insertPerson.run(7, 'Great-grandchild', 'Smith', '1972-01-01')
+    insertPerson.run(8, 'Unrelated', 'Jones', '1930-01-01')
+
+    const insertParent = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, 'parentOf', ?)
+    `)
+    insertParent.run(1, 2, 'father')
+    insertParent.run(1, 3, 'father')
+    insertParent.run(2, 4, 'father')
+    insertParent.run(2, 5, 'father')
+    insertParent.run(3, 6, 'mother')
+    insertParent.run(4, 7, 'father')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  it('should return cumulative descendant counts per birth decade', async () => {
+    const response = await GET(createMockEvent(db, { params: { id: '1' } }))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toEqual({
+      personId: 1,
+      total: 6,
+      undated: 1,
+      decades: [
+        { decade: 1910, born: 2, cumulative: 2 },
+        { decade: 1920, born: 0, cumulative: 2 },
+        { decade: 1930, born: 0, cumulative: 2 },
+        { decade: 1940, born: 2, cumulative: 4 },
+        { decade: 1950, born: 0, cumulative: 4 },
+        { decade: 1960, born: 0, cumulative: 4 },
+        { decade: 1970, born: 1, cumulative: 5 }
+      ]
+    })
+  })
+
+  it('should only count the subtree below the person', async () => {
+    const data = await (await GET(createMockEvent(db, { params: { id: '3' } }))).json()
+
+    expect(data.total).toBe(1)
+    expect(data.decades).toEqual([{ decade: 1940, born: 1, cumulative: 1 }])
+  })
+
+  it('should return empty decades for a person without descendants', async () => {
+    const data = await (await GET(createMockEvent(db, { params: { id: '8' } }))).json()
+
+    expect(data).toEqual({ personId: 8, total: 0, undated: 0, decades: [] })
+  })
+
+  it('should return 404 for a missing person', async () => {
+    const response = await GET(createMockEvent(db, { params: { id: '999' } }))
+
+    expect(response.status).toBe(404)
+  })
+
+  it('should return 400 for an invalid ID', async () => {
+    const response = await GET(createMockEvent(db, { params: { id: 'abc' } }))
+
+    expect(response.status).toBe(400)
+  })
+})
@@ -0,0 +1,87 @@
+/**
+ * Integration Tests for Descendant Summary API
+ *
+ * Tests GET /api/people/[id]/descendant-summary endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../../routes/api/people/[id]/descendant-summary/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/people/[id]/descendant-summary', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, birth_date, death_date)
+      VALUES (?, ?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'Patriarch', 'Smith', '1880-01-01', '1950-01-01')
+    insertPerson.run(2, 'Son', 'Smith', '1910-01-01', '1990-01-01')
+    insertPerson.run(3, 'Daughter', 'Smith', '1850-01-01', null) // presumed deceased by age
+    insertPerson.run(4, 'Grandson', 'Smith', '1950-01-01', null)
+    insertPerson.run(5, 'Granddaughter', 'Smith', null, null)
+    insertPerson.run(6, 'Grandchild', 'Smith', '1940-01-01', '2000-01-01')
+    insertPerson.run(7, 'Unrelated', 'Jones', '1960-01-01', null)
+
+    const insertParent = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, 'parentOf', ?)
+    `)
+    insertParent.run(1, 2, 'father')
+    insertParent.run(1, 3, 'father')
+    insertParent.run(2, 4, 'father')
+    insertParent.run(2, 5, 'father')
+    insertParent.run(3, 6, 'mother')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  it('should split descendants into living and deceased per generation', async () => {
+    const response = await GET(createMockEvent(db, { params: { id: '1' } }))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toEqual({
+      personId: 1,
+      total: 5,
+      living: 2,
+      deceased: 3,
+      generations: [
+        { generation: 1, total: 2, living: 0, deceased: 2 },
+        { generation: 2, total: 3, living: 2, deceased: 1 }
+      ]
+    })
+  })
+
+  it('should return zero counts for a person without descendants', async () => {
+    const response = await GET(createMockEvent(db, { params: { id: '7' } }))
+    const data = await response.json()
+
+    expect(data).toEqual({ personId: 7, total: 0, living: 0, deceased: 0, generations: [] })
+  })
+
+  it('should return 404 when the person does not exist', async () => {
+    const response = await GET(createMockEvent(db, { params: { id: '999' } }))
+
+    expect(response.status).toBe(404)
+  })
+
+  it('should return 400 for invalid ID format', async () => {
+    const response = await GET(createMockEvent(db, { params: { id: 'abc' } }))
+
+    expect(response.status).toBe(400)
+  })
+})
@@ -0,0 +1,98 @@
+/**
+ * Integration Tests for Extended Affinity API
+ *
+ * Tests GET /api/people/[id]/extended-affinity endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../../routes/api/people/[id]/extended-affinity/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/people/[id]/extended-affinity', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    // Me(1) is married to Wife(2); Wife(2) was previously married to Ex(3)
+    // and to Earlier(4); Ex(3) later married Other(5)
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'Me', 'Smith', 'male')
+    insertPerson.run(2, 'Wife', 'Smith', 'female')
+    insertPerson.run(3, 'Ex', 'Jones', 'male')
+    insertPerson.run(4, 'Earlier', 'Brown', null)
+    insertPerson.run(5, 'Other', 'Jones', 'female')
+
+    const insertSpouse = sqlite.prepare(`
+      INSERT INTO relationships (id, person1_id, person2_id, type, start_date, end_date)
+      VALUES (?, ?, ?, 'spouse', ?, ?)
+    `)
+    insertSpouse.run(1, 1, 2, '2010-05-01', null)
+    insertSpouse.run(2, 2, 1, '2010-05-01', null)
+    insertSpouse.run(3, 3, 2, '2000-06-01', '2008-01-15')
+    insertSpouse.run(4, 2, 3, '2000-06-01', '2008-01-15')
+    insertSpouse.run(5, 4, 2, '1995-01-01', '1998')
+    insertSpouse.run(6, 3, 5, '2009-01-01', null)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const getAffinity = (id) => GET(createMockEvent(db, { params: { id: String(id) } }))
+
+  const summarize = (data) =>
+    data.formerSpouses.map((entry) => [entry.person.id, entry.label, entry.via.id, entry.endDate])
+
+  it('should list the former spouses of the person\'s current spouse', async () => {
+    const response = await getAffinity(1)
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.personId).toBe(1)
+    expect(summarize(data)).toEqual([
+      [4, 'wife\'s former spouse', 2, '1998'],
+      [3, 'wife\'s former husband', 2, '2008-01-15']
+    ])
+    expect(data.formerSpouses[1]).toMatchObject({ relationshipId: 3, startDate: '2000-06-01' })
+  })
+
+  it('should label from the new spouse\'s side of a blended family', async () => {
+    // Other(5) is married to Ex(3), who was previously married to Wife(2)
+    const data = await (await getAffinity(5)).json()
+
+    expect(summarize(data)).toEqual([[2, 'husband\'s former wife', 3, '2008-01-15']])
+  })
+
+  it('should return nothing without a current marriage to someone with exes', async () => {
+    // Earlier(4) has no current marriage
+    expect((await (await getAffinity(4)).json()).formerSpouses).toEqual([])
+
+    // Wife(2) is Me(1)'s only current spouse and Me(1) has no ended marriages
+    expect((await (await getAffinity(2)).json()).formerSpouses).toEqual([])
+  })
+
+  it('should exclude soft-deleted people', async () => {
+    sqlite.prepare('UPDATE people SET deleted_at = CURRENT_TIMESTAMP WHERE id = 3').run()
+
+    const data = await (await getAffinity(1)).json()
+
+    expect(data.formerSpouses.map((entry) => entry.person.id)).toEqual([4])
+  })
+
+  it('should return 400 for an invalid ID and 404 for a missing person', async () => {
+    expect((await getAffinity('abc')).status).toBe(400)
+    expect((await getAffinity(999)).status).toBe(404)
+  })
+})
@@ -0,0 +1,88 @@
+/**
+ * Integration Tests for Generations API
+ *
+ * Tests GET /api/people/[id]/generations endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../../routes/api/people/[id]/generations/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/people/[id]/generations', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    // Grandpa(1) + Grandma(2) -> Dad(3) + Mom(4) -> Kid(5), Kid2(6); Stranger(7)
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'Grandpa', 'Smith', 'male')
+    insertPerson.run(2, 'Grandma', 'Smith', 'female')
+    insertPerson.run(3, 'Dad', 'Smith', 'male')
+    insertPerson.run(4, 'Mom', 'Jones', 'female')
+    insertPerson.run(5, 'Kid', 'Smith', 'male')
+    insertPerson.run(6, 'Kid2', 'Smith', 'female')
+    insertPerson.run(7, 'Stranger', 'Doe', null)
+
+    const insertRelationship = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertRelationship.run(1, 2, 'spouse', null)
+    insertRelationship.run(1, 3, 'parentOf', 'father')
+    insertRelationship.run(2, 3, 'parentOf', 'mother')
+    insertRelationship.run(3, 4, 'spouse', null)
+    insertRelationship.run(3, 5, 'parentOf', 'father')
+    insertRelationship.run(3, 6, 'parentOf', 'father')
+    insertRelationship.run(4, 5, 'parentOf', 'mother')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const getGenerations = (id) =>
+    GET(createMockEvent(db, {
+      params: { id: String(id) },
+      url: new URL(`http://localhost/api/people/${id}/generations`)
+    }))
+
+  it('should number a three-generation family relative to the root', async () => {
+    const response = await getGenerations(3)
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toEqual({
+      personId: 3,
+      generations: { 1: -1, 2: -1, 3: 0, 4: 0, 5: 1, 6: 1 }
+    })
+  })
+
+  it('should number from the youngest generation upward', async () => {
+    const data = await (await getGenerations(6)).json()
+
+    expect(data.generations).toEqual({ 1: -2, 2: -2, 3: -1, 4: -1, 5: 0, 6: 0 })
+  })
+
+  it('should return only the root for a disconnected person', async () => {
+    const data = await (await getGenerations(7)).json()
+
+    expect(data.generations).toEqual({ 7: 0 })
+  })
+
+  it('should return 400 for an invalid ID and 404 for a missing person', async () => {
+    expect((await getGenerations('abc')).status).toBe(400)
+    expect((await getGenerations(999)).status).toBe(404)
+  })
+})
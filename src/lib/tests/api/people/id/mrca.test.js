@@ -0,0 +1,88 @@
+/**
+ * Integration Tests for Most Recent Common Ancestor API
+ *
+ * Tests GET /api/people/[id]/mrca/[otherId] endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../../routes/api/people/[id]/mrca/[otherId]/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/people/[id]/mrca/[otherId]', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    // Grandpa(1) -> Dad(2), Uncle(3); Dad(2) -> Me(4); Uncle(3) -> Cousin(5); Stranger(6)
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name)
+      VALUES (?, ?, ?)
+    `)
+    insertPerson.run(1, 'Grandpa', 'Smith')
+    insertPerson.run(2, 'Dad', 'Smith')
+    insertPerson.run(3, 'Uncle', 'Smith')
+    insertPerson.run(4, 'Me', 'Smith')
+    insertPerson.run(5, 'Cousin', 'Smith')
+    insertPerson.run(6, 'Stranger', 'Jones')
+
+    const insertParent = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, 'parentOf', 'father')
+    `)
+    insertParent.run(1, 2)
+    insertParent.run(1, 3)
+    insertParent.run(2, 4)
+    insertParent.run(3, 5)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  function eventFor(id, otherId) {
+    return createMockEvent(db, {
+      params: { id: String(id), otherId: String(otherId) },
+      url: new URL(`http://localhost/api/people/${id}/mrca/${otherId}`)
+    })
+  }
+
+  it('should return the shared grandparent of cousins', async () => {
+    const response = await GET(eventFor(4, 5))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toMatchObject({ person1Id: 4, person2Id: 5, distance1: 2, distance2: 2 })
+    expect(data.ancestor).toMatchObject({ id: 1, firstName: 'Grandpa' })
+  })
+
+  it('should return the parent for a parent/child pair', async () => {
+    const data = await (await GET(eventFor(4, 2))).json()
+
+    expect(data.ancestor.id).toBe(2)
+    expect(data.distance1).toBe(1)
+    expect(data.distance2).toBe(0)
+  })
+
+  it('should return a null ancestor for unrelated people', async () => {
+    const data = await (await GET(eventFor(4, 6))).json()
+
+    expect(data).toEqual({ person1Id: 4, person2Id: 6, ancestor: null, distance1: null, distance2: null })
+  })
+
+  it('should return 404 for a missing person', async () => {
+    expect((await GET(eventFor(4, 999))).status).toBe(404)
+  })
+
+  it('should return 400 for an invalid ID', async () => {
+    expect((await GET(eventFor('abc', 4))).status).toBe(400)
+  })
+})
@@ -0,0 +1,86 @@
+/**
+ * Integration Tests for Namesakes In Line API
+ *
+ * Tests GET /api/people/[id]/namesakes-in-line endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../../routes/api/people/[id]/namesakes-in-line/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/people/[id]/namesakes-in-line', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    // William(1) -> Henry(2) -> William(3) -> Henry(4) -> William(5)
+    // Henry(2) -> Uncle William(6) (a sibling line, not the direct line of 3)
+    const insertPerson = sqlite.prepare('INSERT INTO people (id, first_name, last_name) VALUES (?, ?, ?)')
+    insertPerson.run(1, 'William', 'Hale')
+    insertPerson.run(2, 'Henry', 'Hale')
+    insertPerson.run(3, 'William', 'Hale')
+    insertPerson.run(4, 'Henry', 'Hale')
+    insertPerson.run(5, 'William', 'Hale')
+    insertPerson.run(6, 'William', 'Hale')
+
+    const insertParent = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, 'parentOf', 'father')
+    `)
+    insertParent.run(1, 2)
+    insertParent.run(2, 3)
+    insertParent.run(3, 4)
+    insertParent.run(4, 5)
+    insertParent.run(2, 6)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const getNamesakes = (id) =>
+    GET(createMockEvent(db, {
+      params: { id: String(id) },
+      url: new URL(`http://localhost/api/people/${id}/namesakes-in-line`)
+    }))
+
+  const summarize = (entries) => entries.map((entry) => [entry.person.id, entry.generation])
+
+  it('should find a name repeated every other generation in both directions', async () => {
+    const response = await getNamesakes(3)
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.firstName).toBe('William')
+    expect(summarize(data.ancestors)).toEqual([[1, 2]])
+    expect(summarize(data.descendants)).toEqual([[5, 2]])
+  })
+
+  it('should not include namesakes outside the direct line', async () => {
+    const data = await (await getNamesakes(5)).json()
+
+    expect(summarize(data.ancestors)).toEqual([[3, 2], [1, 4]])
+    expect(data.descendants).toEqual([])
+  })
+
+  it('should follow a side branch up to the shared ancestor', async () => {
+    const data = await (await getNamesakes(6)).json()
+
+    expect(summarize(data.ancestors)).toEqual([[1, 2]])
+    expect(data.descendants).toEqual([])
+  })
+
+  it('should return 400 for an invalid ID and 404 for a missing person', async () => {
+    expect((await getNamesakes('abc')).status).toBe(400)
+    expect((await getNamesakes(999)).status).toBe(404)
+  })
+})
@@ -0,0 +1,100 @@
+/**
+ * Integration Tests for Next Research Suggestion API
+ *
+ * Tests GET /api/people/[id]/next-research endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../../routes/api/people/[id]/next-research/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/people/[id]/next-research', () => {
+  let db
+  let sqlite
+  let insertPerson
+  let insertParent
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, birth_date, gender)
+      VALUES (?, ?, ?, ?, ?)
+    `)
+    insertParent = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, 'parentOf', ?)
+    `)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  it('should suggest finding the missing father', async () => {
+    insertPerson.run(1, 'Alice', 'Smith', '1990-01-01', 'female')
+    insertPerson.run(2, 'Mary', 'Smith', '1960-01-01', 'female')
+    insertParent.run(2, 1, 'mother')
+
+    const response = await GET(createMockEvent(db, { params: { id: '1' } }))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.personId).toBe(1)
+    expect(data.suggestion).toMatchObject({
+      type: 'missingParent',
+      personId: 1,
+      task: 'Find father of Alice Smith'
+    })
+    expect(data.suggestion.rationale).toContain('father')
+  })
+
+  it('should suggest a missing birth date once both parents are known', async () => {
+    insertPerson.run(1, 'Alice', 'Smith', null, 'female')
+    insertPerson.run(2, 'Mary', 'Smith', '1960-01-01', 'female')
+    insertPerson.run(3, 'Tom', 'Smith', '1958-01-01', 'male')
+    insertParent.run(2, 1, 'mother')
+    insertParent.run(3, 1, 'father')
+
+    const response = await GET(createMockEvent(db, { params: { id: '1' } }))
+    const data = await response.json()
+
+    expect(data.suggestion).toMatchObject({
+      type: 'missingBirthDate',
+      task: 'Add birth date for Alice Smith'
+    })
+  })
+
+  it('should move on to the nearest ancestor gap when the person is complete', async () => {
+    insertPerson.run(1, 'Alice', 'Smith', '1990-01-01', 'female')
+    insertPerson.run(2, 'Mary', 'Smith', '1960-01-01', 'female')
+    insertPerson.run(3, 'Tom', 'Smith', '1958-01-01', 'male')
+    insertParent.run(2, 1, 'mother')
+    insertParent.run(3, 1, 'father')
+
+    const response = await GET(createMockEvent(db, { params: { id: '1' } }))
+    const data = await response.json()
+
+    expect(data.suggestion.type).toBe('missingAncestorParent')
+    expect(data.suggestion.generation).toBe(1)
+  })
+
+  it('should return 404 when person does not exist', async () => {
+    const response = await GET(createMockEvent(db, { params: { id: '999' } }))
+
+    expect(response.status).toBe(404)
+  })
+
+  it('should return 400 for invalid ID format', async () => {
+    const response = await GET(createMockEvent(db, { params: { id: 'abc' } }))
+
+    expect(response.status).toBe(400)
+  })
+})
@@ -0,0 +1,107 @@
+/**
+ * Integration Tests for Pedigree Gaps API
+ *
+ * Tests GET /api/people/[id]/pedigree-gaps endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../../routes/api/people/[id]/pedigree-gaps/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/people/[id]/pedigree-gaps', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    // Partial pedigree by Ahnentafel slot:
+    // 1 Alice, 2 Bob, 3 Carol, 4 Dan (Bob's father), 7 Eve (Carol's mother), 8 Frank (Dan's father)
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'Alice', 'Smith', 'female')
+    insertPerson.run(2, 'Bob', 'Smith', 'male')
+    insertPerson.run(3, 'Carol', 'Jones', 'female')
+    insertPerson.run(4, 'Dan', 'Smith', 'male')
+    insertPerson.run(5, 'Eve', 'Brown', 'female')
+    insertPerson.run(6, 'Frank', 'Smith', 'male')
+
+    const insertParent = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, 'parentOf', ?)
+    `)
+    insertParent.run(2, 1, 'father')
+    insertParent.run(3, 1, 'mother')
+    insertParent.run(4, 2, 'father')
+    insertParent.run(5, 3, 'mother')
+    insertParent.run(6, 4, 'father')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  function eventFor(id, query = '') {
+    return createMockEvent(db, {
+      params: { id: String(id) },
+      url: new URL(`http://localhost/api/people/${id}/pedigree-gaps${query}`)
+    })
+  }
+
+  it('should list the empty Ahnentafel slots per generation', async () => {
+    const response = await GET(eventFor(1))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.personId).toBe(1)
+    expect(data.generations).toBe(4)
+    expect(data.levels).toEqual([
+      { generation: 1, expected: 2, known: 2, missing: [] },
+      { generation: 2, expected: 4, known: 2, missing: [5, 6] },
+      { generation: 3, expected: 8, known: 1, missing: [9, 10, 11, 12, 13, 14, 15] }
+    ])
+    expect(data.totalMissing).toBe(9)
+  })
+
+  it('should extend to the requested number of generations', async () => {
+    const response = await GET(eventFor(1, '?generations=5'))
+    const data = await response.json()
+
+    expect(data.levels).toHaveLength(4)
+    expect(data.levels[3]).toMatchObject({ generation: 4, expected: 16, known: 0 })
+  })
+
+  it('should report every slot missing for a person without parents', async () => {
+    const response = await GET(eventFor(6, '?generations=2'))
+    const data = await response.json()
+
+    expect(data.levels).toEqual([{ generation: 1, expected: 2, known: 0, missing: [2, 3] }])
+  })
+
+  it('should return 400 for invalid generations', async () => {
+    expect((await GET(eventFor(1, '?generations=1'))).status).toBe(400)
+    expect((await GET(eventFor(1, '?generations=abc'))).status).toBe(400)
+    expect((await GET(eventFor(1, '?generations=11'))).status).toBe(400)
+  })
+
+  it('should return 400 for invalid ID', async () => {
+    const response = await GET(eventFor('abc'))
+
+    expect(response.status).toBe(400)
+  })
+
+  it('should return 404 for unknown person', async () => {
+    const response = await GET(eventFor(999))
+
+    expect(response.status).toBe(404)
+  })
+})
@@ -0,0 +1,115 @@
+/**
+ * Integration Tests for Pedigree Skeleton API
+ *
+ * Tests POST /api/people/[id]/pedigree-skeleton endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { POST } from '../../../../../routes/api/people/[id]/pedigree-skeleton/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('POST /api/people/[id]/pedigree-skeleton', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    // Me(1) has a known father Dad(2); everything else is unknown
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'Me', 'Smith', 'male')
+    insertPerson.run(2, 'Dad', 'Smith', 'male')
+
+    sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (2, 1, 'parentOf', 'father')
+    `).run()
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  function eventFor(id, generations) {
+    const query = generations === undefined ? '' : `?generations=${generations}`
+    return createMockEvent(db, {
+      params: { id: String(id) },
+      url: new URL(`http://localhost/api/people/${id}/pedigree-skeleton${query}`)
+    })
+  }
+
+  // Maps child ID => { father, mother } from stored parent relationships
+  function parentsByChild() {
+    const rows = sqlite.prepare(`
+      SELECT person1_id AS parentId, person2_id AS childId, parent_role AS role
+      FROM relationships WHERE type = 'parentOf'
+    `).all()
+
+    const parents = new Map()
+    for (const row of rows) {
+      parents.set(row.childId, { ...parents.get(row.childId), [row.role]: row.parentId })
+    }
+    return parents
+  }
+
+  it('should create the missing parent for a 2-generation skeleton', async () => {
+    const response = await POST(eventFor(1, 2))
+    const data = await response.json()
+
+    expect(response.status).toBe(201)
+    expect(data.personId).toBe(1)
+    expect(data.generations).toBe(2)
+    expect(data.created).toHaveLength(1)
+
+    const [{ ahnentafel, person }] = data.created
+    expect(ahnentafel).toBe(3)
+    expect(person).toMatchObject({ firstName: 'Unknown', lastName: 'Mother of Me Smith', gender: 'female' })
+    expect(parentsByChild().get(1)).toEqual({ father: 2, mother: person.id })
+  })
+
+  it('should link each placeholder to the person in its child slot', async () => {
+    const data = await (await POST(eventFor(1, 3))).json()
+    const byNumber = new Map(data.created.map((entry) => [entry.ahnentafel, entry.person]))
+
+    expect([...byNumber.keys()]).toEqual([3, 4, 5, 6, 7])
+
+    const parents = parentsByChild()
+    // Slots 4 and 5 are the father and mother of slot 2 (the known Dad)
+    expect(parents.get(2)).toEqual({ father: byNumber.get(4).id, mother: byNumber.get(5).id })
+    // Slots 6 and 7 are the father and mother of slot 3 (the new placeholder mother)
+    expect(parents.get(byNumber.get(3).id)).toEqual({ father: byNumber.get(6).id, mother: byNumber.get(7).id })
+
+    expect(byNumber.get(4)).toMatchObject({ lastName: 'Father of Dad Smith', gender: 'male' })
+    expect(byNumber.get(7)).toMatchObject({ lastName: 'Mother of Unknown Mother of Me Smith', gender: 'female' })
+  })
+
+  it('should create nothing when the pedigree is already complete', async () => {
+    await POST(eventFor(1, 2))
+    const data = await (await POST(eventFor(1, 2))).json()
+
+    expect(data.created).toEqual([])
+  })
+
+  it('should reject generations outside 2 to 5', async () => {
+    expect((await POST(eventFor(1, 1))).status).toBe(400)
+    expect((await POST(eventFor(1, 6))).status).toBe(400)
+  })
+
+  it('should return 404 for a missing person', async () => {
+    expect((await POST(eventFor(999))).status).toBe(404)
+  })
+
+  it('should return 400 for an invalid ID', async () => {
+    expect((await POST(eventFor('abc'))).status).toBe(400)
+  })
+})
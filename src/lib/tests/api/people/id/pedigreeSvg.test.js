@@ -0,0 +1,91 @@
+/**
+ * Integration Tests for Pedigree Chart SVG API
+ *
+ * Tests GET /api/people/[id]/pedigree.svg endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../../routes/api/people/[id]/pedigree.svg/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/people/[id]/pedigree.svg', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender, birth_date)
+      VALUES (?, ?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'Alice', 'Smith', 'female', '1990-05-01')
+    insertPerson.run(2, 'Bob', 'Smith', 'male', null)
+    insertPerson.run(3, 'Carol', 'Jones', 'female', null)
+    insertPerson.run(4, 'Dan', 'Smith', 'male', null)
+    insertPerson.run(5, 'Eve', 'Brown', 'female', null)
+    insertPerson.run(6, 'Frank', 'Smith', 'male', null)
+    insertPerson.run(7, 'Gus', 'Smith', 'male', null)
+
+    const insertParent = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, 'parentOf', ?)
+    `)
+    insertParent.run(2, 1, 'father')
+    insertParent.run(3, 1, 'mother')
+    insertParent.run(4, 2, 'father')
+    insertParent.run(5, 3, 'mother')
+    insertParent.run(6, 4, 'father')
+    insertParent.run(7, 6, 'father') // 5th generation, beyond the default chart
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  function eventFor(id, query = '') {
+    return createMockEvent(db, {
+      params: { id: String(id) },
+      url: new URL(`http://localhost/api/people/${id}/pedigree.svg${query}`)
+    })
+  }
+
+  it('should render the subject and one box per ancestor within 4 generations', async () => {
+    const response = await GET(eventFor(1))
+    const svg = await response.text()
+
+    expect(response.status).toBe(200)
+    expect(response.headers.get('Content-Type')).toBe('image/svg+xml')
+    expect(svg).toContain('Alice Smith')
+    expect(svg).not.toContain('Gus Smith')
+    // Subject + 5 ancestors
+    expect(svg.match(/class="person"/g)).toHaveLength(6)
+  })
+
+  it('should include a fifth generation when requested', async () => {
+    const response = await GET(eventFor(1, '?generations=5'))
+    const svg = await response.text()
+
+    expect(svg).toContain('Gus Smith')
+    expect(svg.match(/class="person"/g)).toHaveLength(7)
+  })
+
+  it('should return 400 for unsupported generations', async () => {
+    const response = await GET(eventFor(1, '?generations=9'))
+
+    expect(response.status).toBe(400)
+  })
+
+  it('should return 404 when the person does not exist', async () => {
+    const response = await GET(eventFor(999))
+
+    expect(response.status).toBe(404)
+  })
+})
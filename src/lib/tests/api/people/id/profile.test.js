@@ -0,0 +1,92 @@
+/**
+ * Integration Tests for Person Profile API
+ *
+ * Tests GET /api/people/[id]/profile endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../../routes/api/people/[id]/profile/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/people/[id]/profile', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender, birth_date, death_date, photo_url, nickname, birth_surname)
+      VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'Margaret', 'Smith', 'female', '1900-03-15', '1975-01-10', 'https://example.com/maggie.jpg', 'Maggie', 'Jones')
+    insertPerson.run(2, 'Robert', 'Smith', 'male', '1898-07-01', '1960-02-02', null, null, null)
+    insertPerson.run(3, 'Alice', 'Smith', 'female', '1925-05-05', '2001-01-01', null, null, null)
+    insertPerson.run(4, 'Mary', 'Jones', 'female', '1870-01-01', '1940-01-01', null, null, null)
+    insertPerson.run(5, 'Plain', 'Person', null, null, null, null, null, null)
+
+    const insertRelationship = sqlite.prepare(`
+      INSERT INTO relationships (id, person1_id, person2_id, type, parent_role, start_date)
+      VALUES (?, ?, ?, ?, ?, ?)
+    `)
+    insertRelationship.run(1, 1, 2, 'spouse', null, '1922-06-01')
+    insertRelationship.run(2, 1, 3, 'parentOf', 'mother', null)
+    insertRelationship.run(3, 4, 1, 'parentOf', 'mother', null)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const getProfile = (id) => GET(createMockEvent(db, { params: { id: String(id) } }))
+
+  it('should return every profile section for a richly populated person', async () => {
+    const response = await getProfile(1)
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(Object.keys(data).sort()).toEqual(['age', 'aliases', 'living', 'person', 'photos', 'relationships'])
+
+    expect(data.person).toMatchObject({ id: 1, firstName: 'Margaret', nickname: 'Maggie' })
+    expect(data.living).toBe(false)
+    expect(data.age).toBe(74)
+    expect(data.photos).toEqual([{ url: 'https://example.com/maggie.jpg', primary: true }])
+    expect(data.aliases).toEqual([
+      { type: 'nickname', value: 'Maggie' },
+      { type: 'birthSurname', value: 'Jones' }
+    ])
+    expect(data.relationships.map(({ id, type, parentRole, startDate, relatedPerson }) =>
+      ({ id, type, parentRole, startDate, relatedName: relatedPerson.firstName })
+    )).toEqual([
+      { id: 1, type: 'spouse', parentRole: null, startDate: '1922-06-01', relatedName: 'Robert' },
+      { id: 2, type: 'mother', parentRole: 'mother', startDate: null, relatedName: 'Alice' },
+      { id: 3, type: 'mother', parentRole: 'mother', startDate: null, relatedName: 'Mary' }
+    ])
+  })
+
+  it('should return empty sections for a sparsely populated person', async () => {
+    const data = await (await getProfile(5)).json()
+
+    expect(data).toMatchObject({ living: true, age: null, relationships: [], photos: [], aliases: [] })
+  })
+
+  it('should exclude relationships with soft-deleted people', async () => {
+    sqlite.prepare('UPDATE people SET deleted_at = CURRENT_TIMESTAMP WHERE id = 2').run()
+
+    const data = await (await getProfile(1)).json()
+
+    expect(data.relationships.map((relationship) => relationship.id)).toEqual([2, 3])
+  })
+
+  it('should return 400 for an invalid ID and 404 for a missing person', async () => {
+    expect((await getProfile('abc')).status).toBe(400)
+    expect((await getProfile(999)).status).toBe(404)
+  })
+})
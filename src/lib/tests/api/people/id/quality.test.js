@@ -0,0 +1,80 @@
+/**
+ * Integration Tests for Person Quality API
+ *
+ * Tests GET /api/people/[id]/quality endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../../routes/api/people/[id]/quality/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/people/[id]/quality', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender, birth_date, death_date)
+      VALUES (?, ?, ?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'Father', 'Walsh', 'male', null, null)
+    insertPerson.run(2, 'Mother', 'Walsh', 'female', null, null)
+    insertPerson.run(3, 'Documented', 'Walsh', 'female', '1901-04-12', '1975-09-30')
+    insertPerson.run(4, 'Bare', 'Walsh', null, null, null)
+
+    const insertParent = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, 'parentOf', ?)
+    `)
+    insertParent.run(1, 3, 'father')
+    insertParent.run(2, 3, 'mother')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  it('should grade a fully documented person A', async () => {
+    const response = await GET(createMockEvent(db, { params: { id: '3' } }))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toEqual({
+      personId: 3,
+      indicators: {
+        hasBirthDate: true,
+        hasDeathDateOrPresumedLiving: true,
+        hasBothParents: true,
+        hasGender: true
+      },
+      passed: 4,
+      total: 4,
+      grade: 'A'
+    })
+  })
+
+  it('should grade a bare person F', async () => {
+    const data = await (await GET(createMockEvent(db, { params: { id: '4' } }))).json()
+
+    expect(data.passed).toBe(0)
+    expect(data.grade).toBe('F')
+    expect(Object.values(data.indicators).every((value) => value === false)).toBe(true)
+  })
+
+  it('should return 404 for a missing person', async () => {
+    expect((await GET(createMockEvent(db, { params: { id: '999' } }))).status).toBe(404)
+  })
+
+  it('should return 400 for an invalid ID', async () => {
+    expect((await GET(createMockEvent(db, { params: { id: 'abc' } }))).status).toBe(400)
+  })
+})
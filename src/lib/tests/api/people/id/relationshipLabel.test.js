@@ -0,0 +1,168 @@
+/**
+ * Integration Tests for Relationship Label API
+ *
+ * Tests GET /api/people/[id]/relationship-label/[otherId] endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../../routes/api/people/[id]/relationship-label/[otherId]/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/people/[id]/relationship-label/[otherId]', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'Grandma', 'Smith', 'female')
+    insertPerson.run(2, 'Dad', 'Smith', 'male')
+    insertPerson.run(3, 'Me', 'Smith', 'male')
+    insertPerson.run(4, 'Stranger', 'Jones', null)
+    insertPerson.run(5, 'Adopted', 'Smith', 'male')
+
+    const insertParent = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, 'parentOf', ?)
+    `)
+    insertParent.run(1, 2, 'mother')
+    insertParent.run(2, 3, 'father')
+
+    sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role, relation_kind)
+      VALUES (2, 5, 'parentOf', 'father', 'adoptive')
+    `).run()
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  it('should label a paternal grandmother', async () => {
+    const response = await GET(createMockEvent(db, { params: { id: '3', otherId: '1' } }))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toMatchObject({
+      person1Id: 3,
+      person2Id: 1,
+      label: 'paternal grandmother',
+      related: true
+    })
+    expect(data.relationship).toMatchObject({ type: 'grandparent', up: 2, down: 0, spouseHops: 0 })
+  })
+
+  it('should return "no known relationship" when disconnected', async () => {
+    const response = await GET(createMockEvent(db, { params: { id: '3', otherId: '4' } }))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.label).toBe('no known relationship')
+    expect(data.related).toBe(false)
+    expect(data.relationship).toBeNull()
+  })
+
+  it('should return 404 when a person does not exist', async () => {
+    const response = await GET(createMockEvent(db, { params: { id: '3', otherId: '999' } }))
+
+    expect(response.status).toBe(404)
+  })
+
+  it('should return 400 for invalid ID format', async () => {
+    const response = await GET(createMockEvent(db, { params: { id: '3', otherId: 'x' } }))
+
+    expect(response.status).toBe(400)
+  })
+
+  describe('kinship mode', () => {
+    const labelFor = (id, otherId, kinship) => {
+      const url = new URL(`http://localhost/api/people/${id}/relationship-label/${otherId}`)
+      if (kinship) {
+        url.searchParams.set('kinship', kinship)
+      }
+      return GET(createMockEvent(db, { params: { id: String(id), otherId: String(otherId) }, url }))
+    }
+
+    it('should count adoptive parent edges by default (legal)', async () => {
+      const response = await labelFor(2, 5)
+      const data = await response.json()
+
+      expect(response.status).toBe(200)
+      expect(data.label).toBe('son')
+    })
+
+    it('should ignore adoptive parent edges when kinship=blood', async () => {
+      const legal = await (await labelFor(2, 5, 'legal')).json()
+      const blood = await (await labelFor(2, 5, 'blood')).json()
+
+      expect(legal.label).toBe('son')
+      expect(blood.label).toBe('no known relationship')
+      expect(blood.related).toBe(false)
+    })
+
+    it('should keep biological edges in blood mode', async () => {
+      const data = await (await labelFor(3, 1, 'blood')).json()
+
+      expect(data.label).toBe('paternal grandmother')
+    })
+
+    it('should return 400 for an invalid kinship value', async () => {
+      const response = await labelFor(2, 5, 'step')
+
+      expect(response.status).toBe(400)
+    })
+  })
+
+  describe('language', () => {
+    beforeEach(() => {
+      // Grandma(1) -> Uncle(6) -> Cousin(7)
+      sqlite.prepare(`
+        INSERT INTO people (id, first_name, last_name, gender)
+        VALUES (6, 'Uncle', 'Smith', 'male'), (7, 'Cousin', 'Smith', 'male')
+      `).run()
+      sqlite.prepare(`
+        INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+        VALUES (1, 6, 'parentOf', 'mother'), (6, 7, 'parentOf', 'father')
+      `).run()
+    })
+
+    const labelIn = (id, otherId, lang) => {
+      const url = new URL(`http://localhost/api/people/${id}/relationship-label/${otherId}`)
+      url.searchParams.set('lang', lang)
+      return GET(createMockEvent(db, { params: { id: String(id), otherId: String(otherId) }, url }))
+    }
+
+    it('should return "primo" for a first cousin when lang=es', async () => {
+      const response = await labelIn(3, 7, 'es')
+      const data = await response.json()
+
+      expect(response.status).toBe(200)
+      expect(data.lang).toBe('es')
+      expect(data.label).toBe('primo')
+      expect(data.relationship).toMatchObject({ type: 'cousin', degree: 1, removed: 0 })
+    })
+
+    it('should translate gendered terms', async () => {
+      expect((await (await labelIn(3, 1, 'es')).json()).label).toBe('abuela paterna')
+      expect((await (await labelIn(3, 4, 'es')).json()).label).toBe('sin relación conocida')
+    })
+
+    it('should fall back to English for unsupported languages', async () => {
+      const data = await (await labelIn(3, 7, 'xx')).json()
+
+      expect(data.lang).toBe('en')
+      expect(data.label).toBe('first cousin')
+    })
+  })
+})
@@ -0,0 +1,87 @@
+/**
+ * Integration Tests for Person Relationships API
+ *
+ * Tests GET /api/people/[id]/relationships endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../../routes/api/people/[id]/relationships/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/people/[id]/relationships', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    // John(1) + Jane(2) -> Kid(3); Loner(4); Unrelated pair Ann(5) + Bob(6)
+    const insertPerson = sqlite.prepare('INSERT INTO people (id, first_name, last_name) VALUES (?, ?, ?)')
+    insertPerson.run(1, 'John', 'Smith')
+    insertPerson.run(2, 'Jane', 'Smith')
+    insertPerson.run(3, 'Kid', 'Smith')
+    insertPerson.run(4, 'Loner', 'Doe')
+    insertPerson.run(5, 'Ann', 'Lee')
+    insertPerson.run(6, 'Bob', 'Lee')
+
+    const insertRelationship = sqlite.prepare(`
+      INSERT INTO relationships (id, person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, ?, ?, ?)
+    `)
+    insertRelationship.run(1, 1, 2, 'spouse', null)
+    insertRelationship.run(2, 1, 3, 'parentOf', 'father')
+    insertRelationship.run(3, 2, 3, 'parentOf', 'mother')
+    insertRelationship.run(4, 5, 6, 'spouse', null)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const getRelationships = (id) => GET(createMockEvent(db, { params: { id: String(id) } }))
+
+  it('should return relationships where the person is a parent and a spouse', async () => {
+    const response = await getRelationships(1)
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.map(({ id, person1Id, person2Id, type, parentRole }) => ({ id, person1Id, person2Id, type, parentRole })))
+      .toEqual([
+        { id: 1, person1Id: 1, person2Id: 2, type: 'spouse', parentRole: null },
+        { id: 2, person1Id: 1, person2Id: 3, type: 'father', parentRole: 'father' }
+      ])
+  })
+
+  it('should include relationships where the person is person2', async () => {
+    const data = await (await getRelationships(3)).json()
+
+    expect(data.map((relationship) => relationship.id)).toEqual([2, 3])
+  })
+
+  it('should return an empty array for a person with no relationships', async () => {
+    const response = await getRelationships(4)
+
+    expect(response.status).toBe(200)
+    expect(await response.json()).toEqual([])
+  })
+
+  it('should exclude relationships involving soft-deleted people', async () => {
+    sqlite.prepare('UPDATE people SET deleted_at = CURRENT_TIMESTAMP WHERE id = 2').run()
+
+    const data = await (await getRelationships(1)).json()
+
+    expect(data.map((relationship) => relationship.id)).toEqual([2])
+  })
+
+  it('should return 400 for an invalid ID and 404 for a missing person', async () => {
+    expect((await getRelationships('abc')).status).toBe(400)
+    expect((await getRelationships(999)).status).toBe(404)
+  })
+})
@@ -0,0 +1,152 @@
+/**
+ * Integration Tests for Placeholder Resolution API
+ *
+ * Tests POST /api/people/[id]/resolve-to/[realId] endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { POST } from '../../../../../routes/api/people/[id]/resolve-to/[realId]/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('POST /api/people/[id]/resolve-to/[realId]', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender, birth_place)
+      VALUES (?, ?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'Unknown', 'Father', 'male', 'Boston')
+    insertPerson.run(2, 'Mary', 'Smith', 'female', null)
+    insertPerson.run(3, 'Child', 'Smith', null, null)
+    insertPerson.run(4, 'Sibling', 'Smith', null, null)
+    insertPerson.run(5, 'John', 'Smith', null, null) // Newly added real father
+
+    const insertRelationship = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertRelationship.run(1, 2, 'spouse', null)
+    insertRelationship.run(1, 3, 'parentOf', 'father')
+    insertRelationship.run(2, 3, 'parentOf', 'mother')
+    insertRelationship.run(1, 4, 'parentOf', 'father')
+    // The real father was already linked to one child
+    insertRelationship.run(5, 4, 'parentOf', 'father')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  function eventFor(id, realId) {
+    return createMockEvent(db, { params: { id: String(id), realId: String(realId) } })
+  }
+
+  it('should move a placeholder father\'s relationships to the real person', async () => {
+    const response = await POST(eventFor(1, 5))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toMatchObject({
+      success: true,
+      placeholderId: 1,
+      realId: 5,
+      relationshipsTransferred: 2,
+      relationshipsSkipped: 1
+    })
+
+    const rels = sqlite.prepare(`
+      SELECT person1_id, person2_id, type, parent_role FROM relationships
+      WHERE person1_id = 5 OR person2_id = 5
+      ORDER BY person2_id, type
+    `).all()
+
+    expect(rels).toEqual([
+      { person1_id: 5, person2_id: 2, type: 'spouse', parent_role: null },
+      { person1_id: 5, person2_id: 3, type: 'parentOf', parent_role: 'father' },
+      { person1_id: 5, person2_id: 4, type: 'parentOf', parent_role: 'father' }
+    ])
+  })
+
+  it('should keep marriage and divorce dates of moved spouse links', async () => {
+    sqlite.prepare(`
+      UPDATE relationships SET start_date = '1950-06-10', end_date = '1962-01-31'
+      WHERE person1_id = 1 AND person2_id = 2 AND type = 'spouse'
+    `).run()
+
+    await POST(eventFor(1, 5))
+
+    const marriage = sqlite.prepare(`
+      SELECT start_date, end_date FROM relationships
+      WHERE person1_id = 5 AND person2_id = 2 AND type = 'spouse'
+    `).get()
+    expect(marriage).toEqual({ start_date: '1950-06-10', end_date: '1962-01-31' })
+  })
+
+  it('should keep the child order of moved parent links', async () => {
+    sqlite.prepare('UPDATE relationships SET sort_order = 1 WHERE person1_id = 1 AND person2_id = 3').run()
+
+    await POST(eventFor(1, 5))
+
+    const link = sqlite.prepare('SELECT sort_order FROM relationships WHERE person1_id = 5 AND person2_id = 3').get()
+    expect(link.sort_order).toBe(1)
+  })
+
+  it('should skip a moved parent link that would create a cycle', async () => {
+    // Resolving the placeholder father to his own grandchild
+    sqlite.prepare("INSERT INTO people (id, first_name, last_name) VALUES (6, 'Grandchild', 'Smith')").run()
+    sqlite.prepare("INSERT INTO relationships (person1_id, person2_id, type, parent_role) VALUES (3, 6, 'parentOf', 'father')").run()
+
+    const response = await POST(eventFor(1, 6))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    // Only the marriage moves; Sibling keeps John as father
+    expect(data.relationshipsTransferred).toBe(1)
+    expect(data.relationshipsSkipped).toBe(2)
+    expect(sqlite.prepare(
+      "SELECT COUNT(*) AS n FROM relationships WHERE person1_id = 6 AND person2_id = 3 AND type = 'parentOf'"
+    ).get().n).toBe(0)
+  })
+
+  it('should delete the placeholder and its old edges', async () => {
+    await POST(eventFor(1, 5))
+
+    expect(sqlite.prepare('SELECT COUNT(*) AS n FROM people WHERE id = 1').get().n).toBe(0)
+    expect(sqlite.prepare(
+      'SELECT COUNT(*) AS n FROM relationships WHERE person1_id = 1 OR person2_id = 1'
+    ).get().n).toBe(0)
+  })
+
+  it('should fill only metadata the real person is missing', async () => {
+    sqlite.prepare("UPDATE people SET gender = 'male' WHERE id = 5").run()
+    sqlite.prepare("UPDATE people SET gender = 'other' WHERE id = 1").run()
+
+    const response = await POST(eventFor(1, 5))
+    const data = await response.json()
+
+    expect(data.filledFields).toEqual(['birthPlace'])
+    expect(data.person).toMatchObject({ id: 5, firstName: 'John', gender: 'male', birthPlace: 'Boston' })
+  })
+
+  it('should return 404 when either person does not exist', async () => {
+    expect((await POST(eventFor(999, 5))).status).toBe(404)
+    expect((await POST(eventFor(1, 999))).status).toBe(404)
+  })
+
+  it('should return 400 when resolving a person to themselves', async () => {
+    const response = await POST(eventFor(5, 5))
+
+    expect(response.status).toBe(400)
+  })
+})
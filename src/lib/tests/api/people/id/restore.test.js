@@ -0,0 +1,112 @@
+/**
+ * Integration Tests for Person Soft Delete and Restore
+ *
+ * Tests DELETE /api/people/[id] soft-delete behavior and
+ * POST /api/people/[id]/restore
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET as getPeople } from '../../../../../routes/api/people/+server.js'
+import { GET as getPerson, DELETE as deletePerson } from '../../../../../routes/api/people/[id]/+server.js'
+import { POST as restorePerson } from '../../../../../routes/api/people/[id]/restore/+server.js'
+import { GET as getRelationships } from '../../../../../routes/api/relationships/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('Person soft delete and restore', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    sqlite.prepare(`
+      INSERT INTO people (first_name, last_name)
+      VALUES (?, ?)
+    `).run('John', 'Doe')
+
+    sqlite.prepare(`
+      INSERT INTO people (first_name, last_name)
+      VALUES (?, ?)
+    `).run('Jane', 'Doe')
+
+    sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type)
+      VALUES (?, ?, ?)
+    `).run(1, 2, 'spouse')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  it('should hide a deleted person from the people list', async () => {
+    await deletePerson(createMockEvent(db, { params: { id: '1' } }))
+
+    const response = await getPeople(createMockEvent(db))
+    const data = await response.json()
+
+    expect(data).toHaveLength(1)
+    expect(data[0].firstName).toBe('Jane')
+  })
+
+  it('should return 404 when getting a deleted person', async () => {
+    await deletePerson(createMockEvent(db, { params: { id: '1' } }))
+
+    const response = await getPerson(createMockEvent(db, { params: { id: '1' } }))
+
+    expect(response.status).toBe(404)
+  })
+
+  it('should hide relationships of a deleted person', async () => {
+    await deletePerson(createMockEvent(db, { params: { id: '1' } }))
+
+    const response = await getRelationships(createMockEvent(db))
+    const data = await response.json()
+
+    expect(data).toEqual([])
+  })
+
+  it('should restore a deleted person and their relationships', async () => {
+    await deletePerson(createMockEvent(db, { params: { id: '1' } }))
+
+    const response = await restorePerson(createMockEvent(db, { params: { id: '1' } }))
+    const restored = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(restored).toMatchObject({ id: 1, firstName: 'John', lastName: 'Doe' })
+
+    const peopleResponse = await getPeople(createMockEvent(db))
+    expect(await peopleResponse.json()).toHaveLength(2)
+
+    const personResponse = await getPerson(createMockEvent(db, { params: { id: '1' } }))
+    expect(personResponse.status).toBe(200)
+
+    const relationshipsResponse = await getRelationships(createMockEvent(db))
+    expect(await relationshipsResponse.json()).toHaveLength(1)
+  })
+
+  it('should return 400 when restoring a person that is not deleted', async () => {
+    const response = await restorePerson(createMockEvent(db, { params: { id: '1' } }))
+
+    expect(response.status).toBe(400)
+  })
+
+  it('should return 404 when restoring a nonexistent person', async () => {
+    const response = await restorePerson(createMockEvent(db, { params: { id: '999' } }))
+
+    expect(response.status).toBe(404)
+  })
+
+  it('should return 400 for invalid ID format', async () => {
+    const response = await restorePerson(createMockEvent(db, { params: { id: 'abc' } }))
+
+    expect(response.status).toBe(400)
+  })
+})
@@ -454,9 +454,9 @@ describe('DELETE /api/people/[id]', () => {
     // Assert
     expect(response.status).toBe(204)
 
-    // Verify person was actually deleted
+    // Verify person was soft-deleted (row kept with deleted_at set)
     const person = sqlite.prepare('SELECT * FROM people WHERE id = ?').get(1)
-    expect(person).toBeUndefined()
+    expect(person.deleted_at).not.toBeNull()
   })
 
   it('should not return a body for 204 response', async () => {
@@ -515,7 +515,7 @@ describe('DELETE /api/people/[id]', () => {
     expect(response.status).toBe(400)
   })
 
-  it('should cascade delete relationships when person is deleted', async () => {
+  it('should retain relationships when person is soft-deleted', async () => {
     // Arrange: Create two people and a relationship
     sqlite.prepare(`
       INSERT INTO people (first_name, last_name)
@@ -541,9 +541,24 @@ describe('DELETE /api/people/[id]', () => {
     // Assert
     expect(response.status).toBe(204)
 
-    // Verify relationship was cascade deleted
+    // Verify relationship is retained so the person can be restored
     const relationships = sqlite.prepare('SELECT * FROM relationships').all()
-    expect(relationships).toHaveLength(0)
+    expect(relationships).toHaveLength(1)
+  })
+
+  it('should return 404 when deleting an already deleted person', async () => {
+    // Arrange
+    sqlite.prepare(`
+      INSERT INTO people (first_name, last_name, deleted_at)
+      VALUES (?, ?, CURRENT_TIMESTAMP)
+    `).run('John', 'Doe')
+
+    // Act
+    const event = createMockEvent(db, { params: { id: '1' } })
+    const response = await DELETE(event)
+
+    // Assert
+    expect(response.status).toBe(404)
   })
 
   it('should return 500 on database error', async () => {
@@ -0,0 +1,81 @@
+/**
+ * Integration Tests for Person Timeline API
+ *
+ * Tests GET /api/people/[id]/timeline endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../../routes/api/people/[id]/timeline/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/people/[id]/timeline', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender, birth_date, death_date, birth_place)
+      VALUES (?, ?, ?, ?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'John', 'Walsh', 'male', '1925-03-02', '1990-11-20', 'Cork')
+    insertPerson.run(2, 'Mary', 'Byrne', 'female', '1927-07-04', null, null)
+    insertPerson.run(3, 'Pat', 'Walsh', 'male', '1952-08-15', null, null)
+    insertPerson.run(4, 'Ann', 'Walsh', 'female', '1955-02-01', null, null)
+    insertPerson.run(5, 'Undated', 'Walsh', 'male', null, null, null)
+    insertPerson.run(6, 'Step', 'Byrne', 'female', '1948-05-05', null, null)
+
+    const insertRelationship = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role, start_date, relation_kind)
+      VALUES (?, ?, ?, ?, ?, ?)
+    `)
+    insertRelationship.run(1, 2, 'spouse', null, '1950-06-10', null)
+    insertRelationship.run(1, 3, 'parentOf', 'father', null, null)
+    insertRelationship.run(1, 4, 'parentOf', 'father', null, null)
+    insertRelationship.run(1, 5, 'parentOf', 'father', null, null)
+    insertRelationship.run(1, 6, 'parentOf', 'father', null, 'step')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  it('should list birth, marriage, children\'s births, and death in order', async () => {
+    const response = await GET(createMockEvent(db, { params: { id: '1' } }))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toEqual({
+      personId: 1,
+      events: [
+        { date: '1925-03-02', type: 'birth', description: 'Born in Cork', personId: null },
+        { date: '1950-06-10', type: 'marriage', description: 'Married Mary Byrne', personId: 2 },
+        { date: '1952-08-15', type: 'childBirth', description: 'Son Pat Walsh born', personId: 3 },
+        { date: '1955-02-01', type: 'childBirth', description: 'Daughter Ann Walsh born', personId: 4 },
+        { date: '1990-11-20', type: 'death', description: 'Died', personId: null }
+      ]
+    })
+  })
+
+  it('should show the marriage on the spouse\'s timeline too', async () => {
+    const data = await (await GET(createMockEvent(db, { params: { id: '2' } }))).json()
+
+    expect(data.events.map((event) => event.description)).toEqual(['Born', 'Married John Walsh'])
+  })
+
+  it('should return 404 for a missing person', async () => {
+    expect((await GET(createMockEvent(db, { params: { id: '999' } }))).status).toBe(404)
+  })
+
+  it('should return 400 for an invalid ID', async () => {
+    expect((await GET(createMockEvent(db, { params: { id: 'abc' } }))).status).toBe(400)
+  })
+})
@@ -40,7 +40,7 @@ describe('POST /api/people/merge/preview', () => {
     const response = await POST(event)
 
     expect(response.status).toBe(400)
-    const text = await response.text()
+    const { error: text } = await response.json()
     expect(text).toContain('sourceId and targetId are required')
   })
 
@@ -56,7 +56,7 @@ describe('POST /api/people/merge/preview', () => {
     const response = await POST(event)
 
     expect(response.status).toBe(404)
-    const text = await response.text()
+    const { error: text } = await response.json()
     expect(text).toContain('Source person not found')
   })
 
@@ -78,7 +78,7 @@ describe('POST /api/people/merge/preview', () => {
     const response = await POST(event)
 
     expect(response.status).toBe(404)
-    const text = await response.text()
+    const { error: text } = await response.json()
     expect(text).toContain('Target person not found')
   })
 
@@ -0,0 +1,121 @@
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET, POST } from '../../../../routes/api/people/+server.js'
+import { GET as GET_ONE, PUT } from '../../../../routes/api/people/[id]/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+/**
+ * Test suite for middleName, maidenName, and suffix in Person API
+ *
+ * Tests the name part fields in:
+ * - POST /api/people (create)
+ * - GET /api/people and GET /api/people/[id] (read back)
+ * - PUT /api/people/[id] (update)
+ */
+describe('Person name parts (middleName, maidenName, suffix)', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+    await setupTestDatabase(sqlite, db)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const createPerson = async (body) => {
+    const event = createMockEvent(db, {
+      request: { json: async () => body }
+    })
+    return POST(event)
+  }
+
+  it('should create a person with a maiden name and read it back intact', async () => {
+    const createResponse = await createPerson({
+      firstName: 'Mary',
+      middleName: 'Anne',
+      lastName: 'Smith',
+      maidenName: "O'Brien",
+      suffix: 'Jr.'
+    })
+    const created = await createResponse.json()
+
+    expect(createResponse.status).toBe(201)
+    expect(created).toMatchObject({
+      middleName: 'Anne',
+      maidenName: "O'Brien",
+      suffix: 'Jr.'
+    })
+
+    const response = await GET_ONE(createMockEvent(db, { params: { id: String(created.id) } }))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.maidenName).toBe("O'Brien")
+    expect(data.middleName).toBe('Anne')
+    expect(data.suffix).toBe('Jr.')
+  })
+
+  it('should return null name parts for existing rows without them', async () => {
+    sqlite.prepare(`
+      INSERT INTO people (first_name, last_name)
+      VALUES (?, ?)
+    `).run('John', 'Doe')
+
+    const response = await GET(createMockEvent(db))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data[0]).toMatchObject({
+      firstName: 'John',
+      middleName: null,
+      maidenName: null,
+      suffix: null
+    })
+  })
+
+  it('should not require any name part', async () => {
+    const response = await createPerson({ firstName: 'Jane', lastName: 'Doe' })
+    const data = await response.json()
+
+    expect(response.status).toBe(201)
+    expect(data.middleName).toBeNull()
+    expect(data.maidenName).toBeNull()
+    expect(data.suffix).toBeNull()
+  })
+
+  it('should update name parts only when provided', async () => {
+    sqlite.prepare(`
+      INSERT INTO people (first_name, last_name, maiden_name, suffix)
+      VALUES (?, ?, ?, ?)
+    `).run('Robert', 'King', 'Queen', 'III')
+
+    const response = await PUT(createMockEvent(db, {
+      params: { id: '1' },
+      request: { json: async () => ({ firstName: 'Robert', lastName: 'King', middleName: 'Lee' }) }
+    }))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.middleName).toBe('Lee')
+    expect(data.maidenName).toBe('Queen')
+    expect(data.suffix).toBe('III')
+  })
+
+  it('should accept an ordinal suffix such as 3rd', async () => {
+    const response = await createPerson({ firstName: 'Bob', lastName: 'Doe', suffix: '3rd' })
+
+    expect(response.status).toBe(201)
+    expect((await response.json()).suffix).toBe('3rd')
+  })
+
+  it('should reject a suffix with invalid characters', async () => {
+    const response = await createPerson({ firstName: 'Bob', lastName: 'Doe', suffix: '#3' })
+
+    expect(response.status).toBe(400)
+  })
+})
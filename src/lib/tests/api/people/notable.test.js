@@ -0,0 +1,142 @@
+/**
+ * Integration Tests for Notable People
+ *
+ * Tests the notable flag on POST/PUT /api/people, GET /api/notable, and
+ * GET /api/people/[id]/notable-connections
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { POST } from '../../../../routes/api/people/+server.js'
+import { PUT } from '../../../../routes/api/people/[id]/+server.js'
+import { GET as getNotable } from '../../../../routes/api/notable/+server.js'
+import { GET as getNotableConnections } from '../../../../routes/api/people/[id]/notable-connections/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('Notable people', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+    await setupTestDatabase(sqlite, db)
+
+    // King(1) -> Prince(2) -> Grandson(3) -> Kid(4); Poet(5) is notable but unrelated
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender, notable)
+      VALUES (?, ?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'King', 'Royal', 'male', 1)
+    insertPerson.run(2, 'Prince', 'Royal', 'male', 0)
+    insertPerson.run(3, 'Grandson', 'Royal', 'male', 0)
+    insertPerson.run(4, 'Kid', 'Royal', 'female', 0)
+    insertPerson.run(5, 'Poet', 'Bard', 'female', 1)
+
+    const insertParent = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, 'parentOf', 'father')
+    `)
+    insertParent.run(1, 2)
+    insertParent.run(2, 3)
+    insertParent.run(3, 4)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  describe('notable field', () => {
+    it('should default to false on create', async () => {
+      const response = await POST(createMockEvent(db, {
+        request: { json: async () => ({ firstName: 'New', lastName: 'Person' }) }
+      }))
+
+      expect(response.status).toBe(201)
+      expect((await response.json()).notable).toBe(false)
+    })
+
+    it('should be settable on create and update', async () => {
+      const created = await (await POST(createMockEvent(db, {
+        request: { json: async () => ({ firstName: 'Famous', lastName: 'Person', notable: true }) }
+      }))).json()
+      expect(created.notable).toBe(true)
+
+      const response = await PUT(createMockEvent(db, {
+        params: { id: String(created.id) },
+        request: { json: async () => ({ firstName: 'Famous', lastName: 'Person', notable: false }) }
+      }))
+      expect((await response.json()).notable).toBe(false)
+    })
+
+    it('should reject non-boolean values', async () => {
+      const response = await POST(createMockEvent(db, {
+        request: { json: async () => ({ firstName: 'New', lastName: 'Person', notable: 'yes' }) }
+      }))
+
+      expect(response.status).toBe(400)
+      expect((await response.json()).error).toBe('notable must be a boolean')
+    })
+  })
+
+  describe('GET /api/notable', () => {
+    it('should list notable people by name', async () => {
+      const response = await getNotable(createMockEvent(db))
+
+      expect(response.status).toBe(200)
+      const data = await response.json()
+      expect(data.map((person) => person.firstName)).toEqual(['Poet', 'King'])
+      expect(data.every((person) => person.notable)).toBe(true)
+    })
+
+    it('should exclude soft-deleted people', async () => {
+      sqlite.prepare(`UPDATE people SET deleted_at = CURRENT_TIMESTAMP WHERE id = 5`).run()
+
+      const data = await (await getNotable(createMockEvent(db))).json()
+
+      expect(data.map((person) => person.id)).toEqual([1])
+    })
+  })
+
+  describe('GET /api/people/[id]/notable-connections', () => {
+    const getConnections = (id) =>
+      getNotableConnections(createMockEvent(db, {
+        params: { id: String(id) },
+        url: new URL(`http://localhost/api/people/${id}/notable-connections`)
+      }))
+
+    it('should return the path to a notable ancestor', async () => {
+      const response = await getConnections(4)
+
+      expect(response.status).toBe(200)
+      const data = await response.json()
+      expect(data.personId).toBe(4)
+      expect(data.connections).toHaveLength(1)
+
+      const [connection] = data.connections
+      expect(connection.person.id).toBe(1)
+      expect(connection.label).toBe('paternal great-grandfather')
+      expect(connection.generations).toBe(3)
+      expect(connection.path.map((person) => person.id)).toEqual([4, 3, 2, 1])
+    })
+
+    it('should return no connections when no ancestor is notable', async () => {
+      const data = await (await getConnections(1)).json()
+
+      expect(data.connections).toEqual([])
+    })
+
+    it('should return 404 for a missing person', async () => {
+      const response = await getConnections(999)
+
+      expect(response.status).toBe(404)
+    })
+
+    it('should return 400 for an invalid ID', async () => {
+      const response = await getConnections('abc')
+
+      expect(response.status).toBe(400)
+    })
+  })
+})
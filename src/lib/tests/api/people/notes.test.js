@@ -0,0 +1,74 @@
+/**
+ * Integration Tests for Person Notes
+ *
+ * Tests the notes field on POST/PUT/PATCH /api/people
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { POST } from '../../../../routes/api/people/+server.js'
+import { PUT, PATCH } from '../../../../routes/api/people/[id]/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('Person notes', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+    await setupTestDatabase(sqlite, db)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  async function createPerson(body) {
+    return POST(createMockEvent(db, { request: { json: async () => body } }))
+  }
+
+  it('should store trimmed notes and keep line breaks', async () => {
+    const response = await createPerson({ firstName: 'John', lastName: 'Walsh', notes: '  Farmer\nServed in the navy  ' })
+    const data = await response.json()
+
+    expect(response.status).toBe(201)
+    expect(data.notes).toBe('Farmer\nServed in the navy')
+  })
+
+  it('should default to null', async () => {
+    const data = await (await createPerson({ firstName: 'John', lastName: 'Walsh' })).json()
+
+    expect(data.notes).toBeNull()
+  })
+
+  it('should clear notes given as blank on update', async () => {
+    const created = await (await createPerson({ firstName: 'John', lastName: 'Walsh', notes: 'Farmer' })).json()
+
+    const response = await PUT(createMockEvent(db, {
+      params: { id: String(created.id) },
+      request: { json: async () => ({ firstName: 'John', lastName: 'Walsh', notes: '   ' }) }
+    }))
+
+    expect((await response.json()).notes).toBeNull()
+  })
+
+  it('should update only notes with PATCH', async () => {
+    const created = await (await createPerson({ firstName: 'John', lastName: 'Walsh' })).json()
+
+    const response = await PATCH(createMockEvent(db, {
+      params: { id: String(created.id) },
+      request: { json: async () => ({ notes: 'Emigrated in 1848' }) }
+    }))
+
+    expect(await response.json()).toMatchObject({ firstName: 'John', notes: 'Emigrated in 1848' })
+  })
+
+  it('should reject non-string notes', async () => {
+    const response = await createPerson({ firstName: 'John', lastName: 'Walsh', notes: 42 })
+
+    expect(response.status).toBe(400)
+    expect((await response.json()).error).toBe('notes must be a string')
+  })
+})
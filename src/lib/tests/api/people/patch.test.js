@@ -0,0 +1,156 @@
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { PATCH } from '../../../../routes/api/people/[id]/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+/**
+ * Test suite for PATCH /api/people/[id]
+ *
+ * Only fields present in the body change; explicit nulls clear a field and
+ * omitted fields keep their values.
+ */
+describe('PATCH /api/people/[id]', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+    await setupTestDatabase(sqlite, db)
+
+    sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, birth_date, birth_date_qualifier, gender, nickname, birth_place)
+      VALUES (1, 'John', 'Smith', '1900-01-01', 'about', 'male', 'Jack', 'Boston')
+    `).run()
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const patchPerson = (id, body) =>
+    PATCH(createMockEvent(db, {
+      params: { id: String(id) },
+      request: { json: async () => body }
+    }))
+
+  const storedPerson = () => sqlite.prepare('SELECT * FROM people WHERE id = 1').get()
+
+  it('should update only the death date and leave the name untouched', async () => {
+    const response = await patchPerson(1, { deathDate: '1970-05-05' })
+
+    expect(response.status).toBe(200)
+    const data = await response.json()
+    expect(data).toMatchObject({
+      id: 1,
+      firstName: 'John',
+      lastName: 'Smith',
+      birthDate: '1900-01-01',
+      birthDateQualifier: 'about',
+      deathDate: '1970-05-05',
+      gender: 'male',
+      nickname: 'Jack',
+      birthPlace: 'Boston'
+    })
+
+    const stored = storedPerson()
+    expect(stored.first_name).toBe('John')
+    expect(stored.last_name).toBe('Smith')
+    expect(stored.death_date).toBe('1970-05-05')
+  })
+
+  it('should clear a field sent as null', async () => {
+    const response = await patchPerson(1, { nickname: null })
+
+    expect(response.status).toBe(200)
+    expect((await response.json()).nickname).toBe(null)
+    expect(storedPerson().first_name).toBe('John')
+  })
+
+  it('should clear the birth date qualifier along with the birth date', async () => {
+    const data = await (await patchPerson(1, { birthDate: null })).json()
+
+    expect(data.birthDate).toBe(null)
+    expect(data.birthDateQualifier).toBe(null)
+  })
+
+  it('should validate against the stored values', async () => {
+    const response = await patchPerson(1, { deathDate: '1899-12-31' })
+
+    expect(response.status).toBe(400)
+    expect((await response.json()).error).toBe('deathDate cannot be before birthDate')
+    expect(storedPerson().death_date).toBe(null)
+  })
+
+  it('should patch a GEDCOM-imported person with a year-only birth date', async () => {
+    sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, birth_date, death_date, photo_url)
+      VALUES (2, 'Ada', 'Gray', '1950', '2001-03', 'photos/ada.jpg')
+    `).run()
+
+    const response = await patchPerson(2, { notes: 'x' })
+
+    expect(response.status).toBe(200)
+    expect(await response.json()).toMatchObject({ notes: 'x', birthDate: '1950', deathDate: '2001-03' })
+  })
+
+  it('should still validate the changed fields of an imported person', async () => {
+    sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, birth_date)
+      VALUES (2, 'Ada', 'Gray', '1950')
+    `).run()
+
+    const response = await patchPerson(2, { deathDate: '2001-3-1' })
+
+    expect(response.status).toBe(400)
+    expect((await response.json()).error).toContain('deathDate must be in YYYY-MM-DD format')
+  })
+
+  it('should not allow required names to be cleared', async () => {
+    const response = await patchPerson(1, { firstName: null })
+
+    expect(response.status).toBe(400)
+    expect(storedPerson().first_name).toBe('John')
+  })
+
+  it('should normalize gender and places like PUT', async () => {
+    const data = await (await patchPerson(1, { gender: 'Female', deathPlace: '  Salem ' })).json()
+
+    expect(data.gender).toBe('female')
+    expect(data.deathPlace).toBe('Salem')
+  })
+
+  it('should reject unknown fields', async () => {
+    const response = await patchPerson(1, { firstName: 'Jim', id: 5 })
+
+    expect(response.status).toBe(400)
+    expect((await response.json()).error).toBe('Unknown field(s): id')
+    expect(storedPerson().first_name).toBe('John')
+  })
+
+  it('should reject a body that is not an object', async () => {
+    const response = await patchPerson(1, ['deathDate'])
+
+    expect(response.status).toBe(400)
+  })
+
+  it('should return the unchanged person for an empty body', async () => {
+    const response = await patchPerson(1, {})
+
+    expect(response.status).toBe(200)
+    expect((await response.json()).firstName).toBe('John')
+  })
+
+  it('should return 404 for a missing person', async () => {
+    const response = await patchPerson(999, { deathDate: '1970-05-05' })
+
+    expect(response.status).toBe(404)
+  })
+
+  it('should return 400 for an invalid ID', async () => {
+    const response = await patchPerson('abc', { deathDate: '1970-05-05' })
+
+    expect(response.status).toBe(400)
+  })
+})
@@ -203,7 +203,7 @@ describe('Story #77: Photo URL Support', () => {
 
       // Assert
       expect(response.status).toBe(400)
-      const errorText = await response.text()
+      const { error: errorText } = await response.json()
       expect(errorText).toContain('photoUrl must be a string')
     })
 
@@ -212,8 +212,7 @@ describe('Story #77: Photo URL Support', () => {
         'https://example.com/photo.jpg',
         'http://example.com/photo.png',
         'https://cdn.cloudinary.com/v1/image/upload/photo.webp',
-        'https://graph.facebook.com/v12.0/123456789/picture',
-        'data:image/png;base64,iVBORw0KGgoAAAANS...' // data URL
+        'https://graph.facebook.com/v12.0/123456789/picture'
       ]
 
       for (const photoUrl of testCases) {
@@ -237,6 +236,31 @@ describe('Story #77: Photo URL Support', () => {
         expect(data.photoUrl).toBe(photoUrl)
       }
     })
+
+    it('should reject URLs that are not http or https', async () => {
+      const testCases = [
+        'ftp://example.com/photo.jpg',
+        'javascript:alert(1)',
+        'data:image/png;base64,iVBORw0KGgoAAAANS...',
+        'example.com/photo.jpg',
+        '/photos/john.jpg'
+      ]
+
+      for (const photoUrl of testCases) {
+        const request = {
+          json: async () => ({ firstName: 'Test', lastName: 'User', photoUrl })
+        }
+
+        // Act
+        const event = createMockEvent(db, { request })
+        const response = await POST(event)
+
+        // Assert
+        expect(response.status).toBe(400)
+        const { error: errorText } = await response.json()
+        expect(errorText).toBe('photoUrl must be a valid http or https URL')
+      }
+    })
   })
 
   describe('GET /api/people/[id] - retrieving person with photoUrl', () => {
@@ -393,8 +417,29 @@ describe('Story #77: Photo URL Support', () => {
 
       // Assert
       expect(response.status).toBe(400)
-      const errorText = await response.text()
+      const { error: errorText } = await response.json()
       expect(errorText).toContain('photoUrl must be a string')
     })
+
+    it('should reject an update with an invalid URL scheme', async () => {
+      // Arrange
+      sqlite.prepare(`
+        INSERT INTO people (first_name, last_name, photo_url)
+        VALUES (?, ?, ?)
+      `).run('John', 'Doe', 'https://example.com/photo.jpg')
+
+      const request = {
+        json: async () => ({ firstName: 'John', lastName: 'Doe', photoUrl: 'file:///etc/passwd' })
+      }
+
+      // Act
+      const event = createMockEvent(db, { params: { id: '1' }, request })
+      const response = await PUT(event)
+
+      // Assert
+      expect(response.status).toBe(400)
+      expect(sqlite.prepare('SELECT photo_url FROM people WHERE id = 1').get().photo_url)
+        .toBe('https://example.com/photo.jpg')
+    })
   })
 })
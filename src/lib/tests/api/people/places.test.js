@@ -0,0 +1,84 @@
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { POST } from '../../../../routes/api/people/+server.js'
+import { GET as GET_ONE, PUT } from '../../../../routes/api/people/[id]/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+/**
+ * Test suite for birthPlace and deathPlace in Person API
+ *
+ * Places are optional, trimmed on input, and omitted from
+ * responses when not recorded.
+ */
+describe('Person places (birthPlace, deathPlace)', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+    await setupTestDatabase(sqlite, db)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const createPerson = (body) => POST(createMockEvent(db, {
+    request: { json: async () => body }
+  }))
+
+  it('should round-trip both places with surrounding whitespace trimmed', async () => {
+    const createResponse = await createPerson({
+      firstName: 'Ada',
+      lastName: 'Lovelace',
+      birthPlace: '  London, England ',
+      deathPlace: 'Marylebone, London\t'
+    })
+    const created = await createResponse.json()
+
+    expect(createResponse.status).toBe(201)
+    expect(created.birthPlace).toBe('London, England')
+    expect(created.deathPlace).toBe('Marylebone, London')
+
+    const response = await GET_ONE(createMockEvent(db, { params: { id: String(created.id) } }))
+    const data = await response.json()
+
+    expect(data.birthPlace).toBe('London, England')
+    expect(data.deathPlace).toBe('Marylebone, London')
+  })
+
+  it('should omit missing places from the JSON response', async () => {
+    const response = await createPerson({ firstName: 'John', lastName: 'Doe', birthPlace: '   ' })
+    const data = await response.json()
+
+    expect(response.status).toBe(201)
+    expect(data).not.toHaveProperty('birthPlace')
+    expect(data).not.toHaveProperty('deathPlace')
+    expect(sqlite.prepare('SELECT birth_place FROM people WHERE id = ?').get(data.id).birth_place).toBeNull()
+  })
+
+  it('should update places only when provided', async () => {
+    sqlite.prepare(`
+      INSERT INTO people (first_name, last_name, birth_place, death_place)
+      VALUES (?, ?, ?, ?)
+    `).run('Jane', 'Doe', 'Paris', 'Rome')
+
+    const response = await PUT(createMockEvent(db, {
+      params: { id: '1' },
+      request: { json: async () => ({ firstName: 'Jane', lastName: 'Doe', deathPlace: ' Milan ' }) }
+    }))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.birthPlace).toBe('Paris')
+    expect(data.deathPlace).toBe('Milan')
+  })
+
+  it('should reject a non-string place', async () => {
+    const response = await createPerson({ firstName: 'John', lastName: 'Doe', birthPlace: 42 })
+
+    expect(response.status).toBe(400)
+  })
+})
@@ -369,7 +369,7 @@ describe('POST /api/people - Edge Cases', () => {
     expect(data.gender).toBe('male')
   })
 
-  it('should reject uppercase gender values (enforce lowercase)', async () => {
+  it('should normalize gender case on input', async () => {
     const request = {
       json: async () => ({
         firstName: 'John',
@@ -379,8 +379,10 @@ describe('POST /api/people - Edge Cases', () => {
     }
 
     const response = await POST(createMockEvent(db, { request }))
+    const data = await response.json()
 
-    expect(response.status).toBe(400)
+    expect(response.status).toBe(201)
+    expect(data.gender).toBe('male')
   })
 
   it('should reject invalid gender values', async () => {
@@ -398,7 +400,7 @@ describe('POST /api/people - Edge Cases', () => {
   })
 
   it('should accept all valid gender values', async () => {
-    const genders = ['female', 'male', 'other', 'unspecified']
+    const genders = ['female', 'male', 'other', 'unknown', 'unspecified']
 
     for (const gender of genders) {
       const request = {
@@ -0,0 +1,71 @@
+/**
+ * Integration Tests for Living/Deceased Filtering
+ *
+ * Tests GET /api/people?status=living|deceased
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../routes/api/people/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/people?status=', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (first_name, last_name, birth_date, death_date, birth_date_qualifier)
+      VALUES (?, ?, ?, ?, ?)
+    `)
+    insertPerson.run('Living', 'Doe', '1980-01-01', null, null)
+    insertPerson.run('Deceased', 'Doe', '1850-01-01', '1920-03-04', 'about')
+    insertPerson.run('Undated', 'Doe', null, null, null)
+    insertPerson.run('Departed', 'Doe', '1900-01-01', '1970-05-06', null)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  function eventFor(query) {
+    return createMockEvent(db, { url: new URL(`http://localhost/api/people${query}`) })
+  }
+
+  it('should return only people without a death date, including undated people', async () => {
+    const response = await GET(eventFor('?status=living'))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.map((p) => p.firstName)).toEqual(['Living', 'Undated'])
+  })
+
+  it('should return only people with a death date', async () => {
+    const response = await GET(eventFor('?status=deceased'))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.map((p) => p.firstName)).toEqual(['Deceased', 'Departed'])
+  })
+
+  it('should combine with other filters and field projection', async () => {
+    const response = await GET(eventFor('?status=deceased&approximate=false&fields=id,firstName'))
+    const data = await response.json()
+
+    expect(data).toEqual([{ id: 4, firstName: 'Departed' }])
+  })
+
+  it('should return 400 for an invalid status value', async () => {
+    const response = await GET(eventFor('?status=unknown'))
+
+    expect(response.status).toBe(400)
+  })
+})
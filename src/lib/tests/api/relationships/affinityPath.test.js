@@ -0,0 +1,106 @@
+/**
+ * Integration Tests for Affinity Path API
+ *
+ * Tests GET /api/relationships/affinity-path endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../routes/api/relationships/affinity-path/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/relationships/affinity-path', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    // Dad(1) -> Me(2), Sister(3); Sister + BrotherInLaw(4)
+    // HisFather(5) -> BrotherInLaw(4), HisSister(6); Stranger(7)
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'Dad', 'Smith', 'male')
+    insertPerson.run(2, 'Me', 'Smith', 'male')
+    insertPerson.run(3, 'Sister', 'Smith', 'female')
+    insertPerson.run(4, 'BrotherInLaw', 'Jones', 'male')
+    insertPerson.run(5, 'HisFather', 'Jones', 'male')
+    insertPerson.run(6, 'HisSister', 'Jones', 'female')
+    insertPerson.run(7, 'Stranger', 'Brown', null)
+
+    const insertRelationship = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertRelationship.run(1, 2, 'parentOf', 'father')
+    insertRelationship.run(1, 3, 'parentOf', 'father')
+    insertRelationship.run(3, 4, 'spouse', null)
+    insertRelationship.run(5, 4, 'parentOf', 'father')
+    insertRelationship.run(5, 6, 'parentOf', 'father')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  function eventFor(query) {
+    return createMockEvent(db, {
+      url: new URL(`http://localhost/api/relationships/affinity-path${query}`)
+    })
+  }
+
+  it('should describe a brother-in-law\'s sister as a chain through the marriage', async () => {
+    const response = await GET(eventFor('?a=2&b=6'))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toMatchObject({
+      a: 2,
+      b: 6,
+      connected: true,
+      bloodRelated: false,
+      label: "sister's husband's sister",
+      marriages: 1,
+      path: [2, 1, 3, 4, 5, 6]
+    })
+    expect(data.links).toEqual([
+      { fromId: 2, toId: 3, label: 'sister' },
+      { fromId: 3, toId: 4, label: 'husband' },
+      { fromId: 4, toId: 6, label: 'sister' }
+    ])
+  })
+
+  it('should use the kinship label for blood relatives', async () => {
+    const response = await GET(eventFor('?a=2&b=3'))
+    const data = await response.json()
+
+    expect(data).toMatchObject({ connected: true, bloodRelated: true, label: 'sister', marriages: 0 })
+  })
+
+  it('should return connected false when no path exists', async () => {
+    const response = await GET(eventFor('?a=2&b=7'))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toMatchObject({ connected: false, bloodRelated: false, path: [], links: [] })
+  })
+
+  it('should return 400 for missing or identical IDs', async () => {
+    expect((await GET(eventFor('?a=2'))).status).toBe(400)
+    expect((await GET(eventFor('?a=2&b=2'))).status).toBe(400)
+  })
+
+  it('should return 404 for unknown person', async () => {
+    const response = await GET(eventFor('?a=2&b=999'))
+
+    expect(response.status).toBe(404)
+  })
+})
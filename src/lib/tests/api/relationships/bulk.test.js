@@ -0,0 +1,105 @@
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { POST } from '../../../../routes/api/relationships/bulk/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+/**
+ * Test suite for POST /api/relationships/bulk
+ *
+ * Entries run through the normal relationship rules in one transaction;
+ * the first rejected entry rolls back the whole batch.
+ */
+describe('POST /api/relationships/bulk', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare('INSERT INTO people (id, first_name, last_name) VALUES (?, ?, ?)')
+    insertPerson.run(1, 'William', 'Hart')
+    insertPerson.run(2, 'Mary', 'Hart')
+    insertPerson.run(3, 'Thomas', 'Hart')
+    insertPerson.run(4, 'Ann', 'Walsh')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const postBulk = async (body) => {
+    const event = createMockEvent(db, {
+      request: { json: async () => body }
+    })
+    return POST(event)
+  }
+
+  const countRelationships = () => sqlite.prepare('SELECT COUNT(*) AS count FROM relationships').get().count
+
+  it('should create every relationship and return them in request order', async () => {
+    const response = await postBulk([
+      { person1Id: 1, person2Id: 2, type: 'spouse', startDate: '1865-04-10' },
+      { person1Id: 1, person2Id: 3, type: 'father' },
+      { person1Id: 2, person2Id: 3, type: 'parentOf', parentRole: 'mother' }
+    ])
+    const data = await response.json()
+
+    expect(response.status).toBe(201)
+    expect(data.map((relationship) => relationship.type)).toEqual(['spouse', 'father', 'mother'])
+    expect(data[0].startDate).toBe('1865-04-10')
+    expect(data[2].parentRole).toBe('mother')
+    expect(data.every((relationship) => Number.isInteger(relationship.id))).toBe(true)
+    expect(countRelationships()).toBe(3)
+
+    const stored = sqlite.prepare('SELECT type, parent_role FROM relationships WHERE person2_id = 3 ORDER BY id').all()
+    expect(stored).toEqual([
+      { type: 'parentOf', parent_role: 'father' },
+      { type: 'parentOf', parent_role: 'mother' }
+    ])
+  })
+
+  it('should roll back the whole batch when an entry gives a child a second mother', async () => {
+    const response = await postBulk([
+      { person1Id: 1, person2Id: 2, type: 'spouse' },
+      { person1Id: 2, person2Id: 3, type: 'mother' },
+      { person1Id: 4, person2Id: 3, type: 'mother' }
+    ])
+    const data = await response.json()
+
+    expect(response.status).toBe(400)
+    expect(data).toEqual({ index: 2, error: 'Person already has a mother', status: 400 })
+    expect(countRelationships()).toBe(0)
+  })
+
+  it('should report a missing person with 404 and its index', async () => {
+    const response = await postBulk([
+      { person1Id: 1, person2Id: 3, type: 'father' },
+      { person1Id: 99, person2Id: 3, type: 'mother' }
+    ])
+    const data = await response.json()
+
+    expect(response.status).toBe(404)
+    expect(data).toEqual({ index: 1, error: 'person1 not found', status: 404 })
+    expect(countRelationships()).toBe(0)
+  })
+
+  it('should reject an invalid entry before writing anything', async () => {
+    const response = await postBulk([
+      { person1Id: 1, person2Id: 3, type: 'father' },
+      { person1Id: 2, person2Id: 3, type: 'cousin' }
+    ])
+    const data = await response.json()
+
+    expect(response.status).toBe(400)
+    expect(data.index).toBe(1)
+    expect(countRelationships()).toBe(0)
+  })
+
+  it('should reject an empty or non-array body', async () => {
+    expect((await postBulk([])).status).toBe(400)
+    expect((await postBulk({ person1Id: 1, person2Id: 2, type: 'spouse' })).status).toBe(400)
+  })
+})
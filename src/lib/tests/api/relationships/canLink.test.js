@@ -0,0 +1,113 @@
+/**
+ * Integration Tests for Relationship Link Check API
+ *
+ * Tests GET /api/relationships/can-link endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../routes/api/relationships/can-link/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/relationships/can-link', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name)
+      VALUES (?, ?, ?)
+    `)
+    insertPerson.run(1, 'Grandpa', 'Smith')
+    insertPerson.run(2, 'Dad', 'Smith')
+    insertPerson.run(3, 'Me', 'Smith')
+    insertPerson.run(4, 'Other', 'Jones')
+    insertPerson.run(5, 'Wife', 'Jones')
+
+    const insertRelationship = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertRelationship.run(1, 2, 'parentOf', 'father')
+    insertRelationship.run(2, 3, 'parentOf', 'father')
+    insertRelationship.run(2, 5, 'spouse', null)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  async function check(query) {
+    const response = await GET(createMockEvent(db, {
+      url: new URL(`http://localhost/api/relationships/can-link?${query}`)
+    }))
+    return { status: response.status, data: response.status === 200 ? await response.json() : null }
+  }
+
+  it('should allow a valid link', async () => {
+    const { status, data } = await check('person1=5&person2=3&type=mother')
+
+    expect(status).toBe(200)
+    expect(data).toEqual({ allowed: true })
+  })
+
+  it('should reject linking a person to themselves', async () => {
+    const { data } = await check('person1=3&person2=3&type=spouse')
+
+    expect(data).toMatchObject({ allowed: false, reason: 'self' })
+  })
+
+  it('should reject a missing person', async () => {
+    const { data } = await check('person1=3&person2=999&type=spouse')
+
+    expect(data).toMatchObject({ allowed: false, reason: 'missingPerson' })
+  })
+
+  it('should reject a soft-deleted person as missing', async () => {
+    sqlite.prepare('UPDATE people SET deleted_at = CURRENT_TIMESTAMP WHERE id = 4').run()
+
+    const { data } = await check('person1=3&person2=4&type=spouse')
+
+    expect(data).toMatchObject({ allowed: false, reason: 'missingPerson' })
+  })
+
+  it('should reject a duplicate relationship', async () => {
+    const { data } = await check('person1=2&person2=5&type=spouse')
+
+    expect(data).toMatchObject({ allowed: false, reason: 'duplicate' })
+  })
+
+  it('should reject a parent-role conflict', async () => {
+    const { data } = await check('person1=4&person2=3&type=father')
+
+    expect(data).toMatchObject({ allowed: false, reason: 'parentRoleConflict' })
+    expect(data.message).toBe('Person already has a father')
+  })
+
+  it('should reject a link that would create a cycle', async () => {
+    // Me cannot become the mother of my own grandfather
+    const { data } = await check('person1=3&person2=1&type=mother')
+
+    expect(data).toMatchObject({ allowed: false, reason: 'cycle' })
+  })
+
+  it('should not insert anything', async () => {
+    await check('person1=5&person2=3&type=mother')
+
+    expect(sqlite.prepare('SELECT COUNT(*) AS n FROM relationships').get().n).toBe(3)
+  })
+
+  it('should return 400 for invalid parameters', async () => {
+    expect((await check('person1=x&person2=3&type=spouse')).status).toBe(400)
+    expect((await check('person1=1&person2=3&type=cousin')).status).toBe(400)
+    expect((await check('person1=1&person2=3&type=parentOf')).status).toBe(400)
+  })
+})
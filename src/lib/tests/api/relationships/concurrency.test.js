@@ -0,0 +1,90 @@
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { POST } from '../../../../routes/api/relationships/+server.js'
+import { PUT } from '../../../../routes/api/relationships/[id]/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+/**
+ * Test suite for concurrent relationship creation
+ * Validation and insert must be atomic so parent-role and duplicate
+ * invariants hold when requests arrive at the same time
+ */
+describe('Concurrent relationship creation', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'Child', 'Person', 'male')
+    insertPerson.run(2, 'Mother', 'Person', 'female')
+    insertPerson.run(3, 'OtherMother', 'Person', 'female')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  function postRelationship(body) {
+    const request = { json: async () => body }
+    return POST(createMockEvent(db, { request }))
+  }
+
+  function countMothers() {
+    return sqlite.prepare(
+      "SELECT COUNT(*) AS count FROM relationships WHERE person2_id = 1 AND parent_role = 'mother'"
+    ).get().count
+  }
+
+  it('should let exactly one of two identical mother assignments succeed', async () => {
+    const body = { person1Id: 2, person2Id: 1, type: 'mother' }
+
+    const responses = await Promise.all([postRelationship(body), postRelationship(body)])
+    const statuses = responses.map((response) => response.status).sort()
+
+    expect(statuses).toEqual([201, 400])
+    expect(countMothers()).toBe(1)
+  })
+
+  it('should let exactly one of two competing mothers succeed', async () => {
+    const responses = await Promise.all([
+      postRelationship({ person1Id: 2, person2Id: 1, type: 'mother' }),
+      postRelationship({ person1Id: 3, person2Id: 1, type: 'mother' })
+    ])
+    const rejected = responses.find((response) => response.status === 400)
+
+    expect(responses.filter((response) => response.status === 201)).toHaveLength(1)
+    expect((await rejected.json()).error).toBe('Person already has a mother')
+    expect(countMothers()).toBe(1)
+  })
+
+  it('should let exactly one of two updates that add a mother succeed', async () => {
+    const insertSpouse = sqlite.prepare(`
+      INSERT INTO relationships (id, person1_id, person2_id, type)
+      VALUES (?, ?, ?, 'spouse')
+    `)
+    insertSpouse.run(10, 2, 3)
+    insertSpouse.run(11, 3, 2)
+
+    const putRelationship = (id, body) => PUT(createMockEvent(db, {
+      params: { id: String(id) },
+      request: { json: async () => body }
+    }))
+
+    const responses = await Promise.all([
+      putRelationship(10, { person1Id: 2, person2Id: 1, type: 'mother' }),
+      putRelationship(11, { person1Id: 3, person2Id: 1, type: 'mother' })
+    ])
+
+    expect(responses.map((response) => response.status).sort()).toEqual([200, 400])
+    expect(countMothers()).toBe(1)
+  })
+})
@@ -0,0 +1,91 @@
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { POST } from '../../../../routes/api/relationships/+server.js'
+import { PUT, PATCH } from '../../../../routes/api/relationships/[id]/+server.js'
+import { GET as canLink } from '../../../../routes/api/relationships/can-link/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+/**
+ * Test suite for parent cycle prevention
+ *
+ * Creating or updating a parent link must never make someone their own
+ * ancestor, and can-link must agree with what the write endpoints accept.
+ */
+describe('Parent cycle prevention', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    await setupTestDatabase(sqlite, db)
+
+    // Grandpa(1) -> Dad(2) -> Me(3); Wife(4) is unrelated
+    const insertPerson = sqlite.prepare('INSERT INTO people (id, first_name, last_name) VALUES (?, ?, ?)')
+    insertPerson.run(1, 'Grandpa', 'Smith')
+    insertPerson.run(2, 'Dad', 'Smith')
+    insertPerson.run(3, 'Me', 'Smith')
+    insertPerson.run(4, 'Wife', 'Jones')
+
+    const insertRelationship = sqlite.prepare(`
+      INSERT INTO relationships (id, person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, ?, ?, ?)
+    `)
+    insertRelationship.run(1, 1, 2, 'parentOf', 'father')
+    insertRelationship.run(2, 2, 3, 'parentOf', 'father')
+    insertRelationship.run(3, 3, 4, 'spouse', null)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  function postRelationship(body) {
+    return POST(createMockEvent(db, { request: { json: async () => body } }))
+  }
+
+  async function checkCanLink(query) {
+    const response = await canLink(createMockEvent(db, {
+      url: new URL(`http://localhost/api/relationships/can-link?${query}`)
+    }))
+    return response.json()
+  }
+
+  it('should reject creating a link that makes a grandchild the parent of their grandparent', async () => {
+    const response = await postRelationship({ person1Id: 3, person2Id: 1, type: 'mother' })
+
+    expect(response.status).toBe(400)
+    expect((await response.json()).error).toBe('A person cannot be the parent of their own ancestor')
+    expect(await checkCanLink('person1=3&person2=1&type=mother')).toMatchObject({ allowed: false, reason: 'cycle' })
+  })
+
+  it('should reject an update that closes a loop', async () => {
+    // Turn the spouse link into "Me is the father of Grandpa"
+    const response = await PUT(createMockEvent(db, {
+      params: { id: '3' },
+      request: { json: async () => ({ person1Id: 3, person2Id: 1, type: 'father' }) }
+    }))
+
+    expect(response.status).toBe(400)
+    expect((await response.json()).error).toBe('A person cannot be the parent of their own ancestor')
+  })
+
+  it('should ignore the link being updated when looking for a loop', async () => {
+    // Reversing Dad -> Me is only a loop through the link that is being replaced
+    const response = await PATCH(createMockEvent(db, {
+      params: { id: '2' },
+      request: { json: async () => ({ person1Id: 3, person2Id: 2, type: 'mother' }) }
+    }))
+
+    expect(response.status).toBe(200)
+  })
+
+  it('should agree with can-link on allowed links', async () => {
+    expect(await checkCanLink('person1=4&person2=3&type=mother')).toEqual({ allowed: true })
+
+    const response = await postRelationship({ person1Id: 4, person2Id: 3, type: 'mother' })
+    expect(response.status).toBe(201)
+  })
+})
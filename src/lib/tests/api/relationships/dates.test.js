@@ -0,0 +1,111 @@
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET as listRelationships, POST } from '../../../../routes/api/relationships/+server.js'
+import { GET as getRelationship, PUT } from '../../../../routes/api/relationships/[id]/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+/**
+ * Test suite for relationship start and end dates
+ * For spouse relationships these are the marriage and divorce/separation dates
+ */
+describe('Relationship startDate and endDate', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name)
+      VALUES (?, ?, ?)
+    `)
+    insertPerson.run(1, 'John', 'Doe')
+    insertPerson.run(2, 'Jane', 'Doe')
+    insertPerson.run(3, 'Junior', 'Doe')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  function postRelationship(body) {
+    return POST(createMockEvent(db, { request: { json: async () => body } }))
+  }
+
+  function putRelationship(id, body) {
+    return PUT(createMockEvent(db, { params: { id: String(id) }, request: { json: async () => body } }))
+  }
+
+  it('should create a spouse relationship with marriage and divorce dates', async () => {
+    const response = await postRelationship({
+      person1Id: 1,
+      person2Id: 2,
+      type: 'spouse',
+      startDate: '1950-06-10',
+      endDate: '1972-03-01'
+    })
+    const data = await response.json()
+
+    expect(response.status).toBe(201)
+    expect(data.startDate).toBe('1950-06-10')
+    expect(data.endDate).toBe('1972-03-01')
+
+    const row = sqlite.prepare('SELECT start_date, end_date FROM relationships WHERE id = ?').get(data.id)
+    expect(row).toEqual({ start_date: '1950-06-10', end_date: '1972-03-01' })
+  })
+
+  it('should return the dates from get and list', async () => {
+    const created = await (await postRelationship({
+      person1Id: 1, person2Id: 2, type: 'spouse', startDate: '1950-06-10', endDate: '1972-03-01'
+    })).json()
+
+    const single = await (await getRelationship(createMockEvent(db, { params: { id: String(created.id) } }))).json()
+    const list = await (await listRelationships(createMockEvent(db))).json()
+
+    expect(single).toMatchObject({ startDate: '1950-06-10', endDate: '1972-03-01' })
+    expect(list[0]).toMatchObject({ startDate: '1950-06-10', endDate: '1972-03-01' })
+  })
+
+  it('should default missing dates to null', async () => {
+    const data = await (await postRelationship({ person1Id: 1, person2Id: 3, type: 'father' })).json()
+
+    expect(data.startDate).toBeNull()
+    expect(data.endDate).toBeNull()
+  })
+
+  it('should update the dates', async () => {
+    const created = await (await postRelationship({
+      person1Id: 1, person2Id: 2, type: 'spouse', startDate: '1950-06-10'
+    })).json()
+
+    const response = await putRelationship(created.id, {
+      person1Id: 1, person2Id: 2, type: 'spouse', startDate: '1950-06-10', endDate: '1980-01-01'
+    })
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toMatchObject({ startDate: '1950-06-10', endDate: '1980-01-01' })
+  })
+
+  it('should reject an end date before the start date', async () => {
+    const response = await postRelationship({
+      person1Id: 1, person2Id: 2, type: 'spouse', startDate: '1972-03-01', endDate: '1950-06-10'
+    })
+
+    expect(response.status).toBe(400)
+    expect((await response.json()).error).toBe('endDate cannot be before startDate')
+  })
+
+  it('should reject malformed dates', async () => {
+    const response = await postRelationship({
+      person1Id: 1, person2Id: 2, type: 'spouse', startDate: '1950-02-30'
+    })
+
+    expect(response.status).toBe(400)
+    expect((await response.json()).error).toContain('startDate')
+  })
+})
@@ -0,0 +1,88 @@
+/**
+ * Integration Tests for Relationship Provenance API
+ *
+ * Tests GET /api/relationships/[id]/provenance endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../../routes/api/relationships/[id]/provenance/+server.js'
+import { POST as createRelationship } from '../../../../../routes/api/relationships/+server.js'
+import { PUT, DELETE } from '../../../../../routes/api/relationships/[id]/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/relationships/[id]/provenance', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    sqlite.prepare("INSERT INTO people (id, first_name, last_name) VALUES (1, 'John', 'Smith'), (2, 'Mary', 'Smith')").run()
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const jsonRequest = (body) => ({ json: async () => body })
+  const getProvenance = (id) => GET(createMockEvent(db, { params: { id: String(id) } }))
+
+  async function createSpouses() {
+    const response = await createRelationship(createMockEvent(db, {
+      request: jsonRequest({ person1Id: 1, person2Id: 2, type: 'spouse' })
+    }))
+    return response.json()
+  }
+
+  it('should return the relationship with its history oldest first', async () => {
+    const created = await createSpouses()
+    await PUT(createMockEvent(db, {
+      params: { id: String(created.id) },
+      request: jsonRequest({ person1Id: 1, person2Id: 2, type: 'spouse', startDate: '1990-06-01' })
+    }))
+
+    const response = await getProvenance(created.id)
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.relationship).toMatchObject({ id: created.id, startDate: '1990-06-01' })
+    expect(data.importBatch).toBeNull()
+    expect(data.history.map((entry) => entry.action)).toEqual(['create', 'update'])
+    expect(data.history[0].payload.after).toMatchObject({ person1Id: 1, person2Id: 2, type: 'spouse' })
+    expect(data.history[1].payload.before.startDate).toBeNull()
+    expect(data.history[1].payload.after.startDate).toBe('1990-06-01')
+  })
+
+  it('should report the import that created a relationship', async () => {
+    sqlite.prepare("INSERT INTO relationships (id, person1_id, person2_id, type, import_batch) VALUES (5, 1, 2, 'spouse', 'batch-1')").run()
+
+    const data = await (await getProvenance(5)).json()
+
+    expect(data.importBatch).toBe('batch-1')
+    expect(data.history).toEqual([])
+  })
+
+  it('should keep the history of a deleted relationship', async () => {
+    const created = await createSpouses()
+    await DELETE(createMockEvent(db, { params: { id: String(created.id) } }))
+
+    const response = await getProvenance(created.id)
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.relationship).toBeNull()
+    expect(data.history.map((entry) => entry.action)).toEqual(['create', 'delete'])
+  })
+
+  it('should return 400 for an invalid ID and 404 for an unknown relationship', async () => {
+    expect((await getProvenance('abc')).status).toBe(400)
+    expect((await getProvenance(999)).status).toBe(404)
+  })
+})
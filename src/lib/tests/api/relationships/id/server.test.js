@@ -97,7 +97,7 @@ describe('GET /api/relationships/[id]', () => {
 
     // Assert
     expect(response.status).toBe(404)
-    const errorText = await response.text()
+    const { error: errorText } = await response.json()
     expect(errorText).toBe('Relationship not found')
   })
 
@@ -107,7 +107,7 @@ describe('GET /api/relationships/[id]', () => {
 
     // Assert
     expect(response.status).toBe(400)
-    const errorText = await response.text()
+    const { error: errorText } = await response.json()
     expect(errorText).toBe('Invalid ID')
   })
 
@@ -118,7 +118,7 @@ describe('GET /api/relationships/[id]', () => {
 
     // Assert
     expect(response.status).toBe(400)
-    const errorText = await response.text()
+    const { error: errorText } = await response.json()
     expect(errorText).toBe('Invalid ID')
   })
 
@@ -276,7 +276,7 @@ describe('PUT /api/relationships/[id]', () => {
 
       // Assert
       expect(response.status).toBe(400)
-      const errorText = await response.text()
+      const { error: errorText } = await response.json()
       expect(errorText).toContain('already has a mother')
     })
 
@@ -308,7 +308,7 @@ describe('PUT /api/relationships/[id]', () => {
 
       // Assert
       expect(response.status).toBe(400)
-      const errorText = await response.text()
+      const { error: errorText } = await response.json()
       expect(errorText).toContain('already has a father')
     })
 
@@ -365,7 +365,7 @@ describe('PUT /api/relationships/[id]', () => {
 
       // Assert
       expect(response.status).toBe(400)
-      const errorText = await response.text()
+      const { error: errorText } = await response.json()
       expect(errorText).toContain('already exists')
     })
   })
@@ -393,7 +393,7 @@ describe('PUT /api/relationships/[id]', () => {
 
       // Assert
       expect(response.status).toBe(400)
-      const errorText = await response.text()
+      const { error: errorText } = await response.json()
       expect(errorText).toContain('Invalid relationship type')
     })
   })
@@ -420,7 +420,7 @@ describe('PUT /api/relationships/[id]', () => {
 
       // Assert
       expect(response.status).toBe(400)
-      const errorText = await response.text()
+      const { error: errorText } = await response.json()
       expect(errorText).toContain('person1Id is required')
     })
 
@@ -441,7 +441,7 @@ describe('PUT /api/relationships/[id]', () => {
 
       // Assert
       expect(response.status).toBe(404)
-      const errorText = await response.text()
+      const { error: errorText } = await response.json()
       expect(errorText).toBe('Relationship not found')
     })
 
@@ -462,7 +462,7 @@ describe('PUT /api/relationships/[id]', () => {
 
       // Assert
       expect(response.status).toBe(400)
-      const errorText = await response.text()
+      const { error: errorText } = await response.json()
       expect(errorText).toBe('Invalid ID')
     })
 
@@ -484,7 +484,7 @@ describe('PUT /api/relationships/[id]', () => {
 
       // Assert
       expect(response.status).toBe(400)
-      const errorText = await response.text()
+      const { error: errorText } = await response.json()
       expect(errorText).toBe('Invalid JSON')
     })
   })
@@ -592,7 +592,7 @@ describe('DELETE /api/relationships/[id]', () => {
 
     // Assert
     expect(response.status).toBe(404)
-    const errorText = await response.text()
+    const { error: errorText } = await response.json()
     expect(errorText).toBe('Relationship not found')
   })
 
@@ -602,7 +602,7 @@ describe('DELETE /api/relationships/[id]', () => {
 
     // Assert
     expect(response.status).toBe(400)
-    const errorText = await response.text()
+    const { error: errorText } = await response.json()
     expect(errorText).toBe('Invalid ID')
   })
 
@@ -613,7 +613,7 @@ describe('DELETE /api/relationships/[id]', () => {
 
     // Assert
     expect(response.status).toBe(400)
-    const errorText = await response.text()
+    const { error: errorText } = await response.json()
     expect(errorText).toBe('Invalid ID')
   })
 
@@ -0,0 +1,83 @@
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { POST } from '../../../../routes/api/relationships/+server.js'
+import { PUT } from '../../../../routes/api/relationships/[id]/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+/**
+ * Test suite for relationships referencing people who do not exist
+ * Both create and update return 404 naming the missing side
+ */
+describe('Relationships with missing people', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name)
+      VALUES (?, ?, ?)
+    `)
+    insertPerson.run(1, 'John', 'Doe')
+    insertPerson.run(2, 'Jane', 'Doe')
+    insertPerson.run(3, 'Gone', 'Doe')
+    sqlite.prepare('UPDATE people SET deleted_at = CURRENT_TIMESTAMP WHERE id = 3').run()
+
+    sqlite.prepare(`
+      INSERT INTO relationships (id, person1_id, person2_id, type)
+      VALUES (1, 1, 2, 'spouse')
+    `).run()
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  function postRelationship(body) {
+    return POST(createMockEvent(db, { request: { json: async () => body } }))
+  }
+
+  function putRelationship(id, body) {
+    return PUT(createMockEvent(db, { params: { id: String(id) }, request: { json: async () => body } }))
+  }
+
+  function countRelationships() {
+    return sqlite.prepare('SELECT COUNT(*) AS count FROM relationships').get().count
+  }
+
+  it('should return 404 when person1 does not exist on create', async () => {
+    const response = await postRelationship({ person1Id: 9999, person2Id: 2, type: 'mother' })
+
+    expect(response.status).toBe(404)
+    expect((await response.json()).error).toBe('person1 not found')
+    expect(countRelationships()).toBe(1)
+  })
+
+  it('should return 404 when person2 does not exist on create', async () => {
+    const response = await postRelationship({ person1Id: 1, person2Id: 9999, type: 'spouse' })
+
+    expect(response.status).toBe(404)
+    expect((await response.json()).error).toBe('person2 not found')
+    expect(countRelationships()).toBe(1)
+  })
+
+  it('should treat a soft-deleted person as not found', async () => {
+    const response = await postRelationship({ person1Id: 3, person2Id: 2, type: 'father' })
+
+    expect(response.status).toBe(404)
+    expect((await response.json()).error).toBe('person1 not found')
+  })
+
+  it('should return 404 when updating to a bogus person', async () => {
+    const response = await putRelationship(1, { person1Id: 1, person2Id: 9999, type: 'spouse' })
+
+    expect(response.status).toBe(404)
+    expect((await response.json()).error).toBe('person2 not found')
+    expect(sqlite.prepare('SELECT person2_id FROM relationships WHERE id = 1').get().person2_id).toBe(2)
+  })
+})
@@ -0,0 +1,115 @@
+/**
+ * Integration Tests for Missing Parent Role API
+ *
+ * Tests GET and POST /api/relationships/missing-role
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET, POST } from '../../../../routes/api/relationships/missing-role/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('/api/relationships/missing-role', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'Dad', 'Smith', 'male')
+    insertPerson.run(2, 'Mom', 'Smith', 'female')
+    insertPerson.run(3, 'Kid', 'Smith', 'female')
+    insertPerson.run(4, 'Other', 'Jones', null)
+    insertPerson.run(5, 'Gone', 'Jones', 'male')
+    sqlite.prepare(`UPDATE people SET deleted_at = CURRENT_TIMESTAMP WHERE id = 5`).run()
+
+    const insertRelationship = sqlite.prepare(`
+      INSERT INTO relationships (id, person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, ?, ?, ?)
+    `)
+    insertRelationship.run(1, 1, 3, 'parentOf', null) // roleless
+    insertRelationship.run(2, 2, 3, 'parentOf', 'mother')
+    insertRelationship.run(3, 1, 2, 'spouse', null)
+    insertRelationship.run(4, 4, 2, 'parentOf', null) // roleless, parent gender unknown
+    insertRelationship.run(5, 5, 4, 'parentOf', null) // involves a soft-deleted person
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const assignRole = (body) =>
+    POST(createMockEvent(db, { request: { json: async () => body } }))
+
+  describe('GET', () => {
+    it('should list parentOf relationships without a parent role', async () => {
+      const response = await GET(createMockEvent(db))
+
+      expect(response.status).toBe(200)
+      const data = await response.json()
+      expect(data.count).toBe(2)
+      expect(data.relationships.map((entry) => entry.relationship.id)).toEqual([1, 4])
+
+      const [first, second] = data.relationships
+      expect(first.relationship).toMatchObject({ person1Id: 1, person2Id: 3, type: 'parentOf', parentRole: null })
+      expect(first.parent.firstName).toBe('Dad')
+      expect(first.child.firstName).toBe('Kid')
+      expect(first.suggestedRole).toBe('father')
+      expect(second.suggestedRole).toBe(null)
+    })
+
+    it('should return an empty list when every parent has a role', async () => {
+      sqlite.prepare(`UPDATE relationships SET parent_role = 'father' WHERE type = 'parentOf' AND parent_role IS NULL`).run()
+
+      const data = await (await GET(createMockEvent(db))).json()
+
+      expect(data).toEqual({ count: 0, relationships: [] })
+    })
+  })
+
+  describe('POST', () => {
+    it('should assign the parent role', async () => {
+      const response = await assignRole({ relationshipId: 1, parentRole: 'father' })
+
+      expect(response.status).toBe(200)
+      expect(await response.json()).toMatchObject({ id: 1, type: 'father', parentRole: 'father' })
+      expect(sqlite.prepare('SELECT parent_role FROM relationships WHERE id = 1').get().parent_role).toBe('father')
+
+      const data = await (await GET(createMockEvent(db))).json()
+      expect(data.relationships.map((entry) => entry.relationship.id)).toEqual([4])
+    })
+
+    it('should reject a role the child already has', async () => {
+      const response = await assignRole({ relationshipId: 1, parentRole: 'mother' })
+
+      expect(response.status).toBe(400)
+      expect((await response.json()).error).toBe('Person already has a mother')
+    })
+
+    it('should reject relationships that already have a role or are not parent links', async () => {
+      expect((await assignRole({ relationshipId: 2, parentRole: 'father' })).status).toBe(400)
+      expect((await assignRole({ relationshipId: 3, parentRole: 'father' })).status).toBe(400)
+    })
+
+    it('should validate the body', async () => {
+      expect((await assignRole({ relationshipId: 1, parentRole: 'uncle' })).status).toBe(400)
+      expect((await assignRole({ relationshipId: 'abc', parentRole: 'father' })).status).toBe(400)
+    })
+
+    it('should return 404 for a missing relationship', async () => {
+      const response = await assignRole({ relationshipId: 999, parentRole: 'father' })
+
+      expect(response.status).toBe(404)
+    })
+  })
+})
@@ -0,0 +1,63 @@
+/**
+ * Integration Tests for Orphaned Relationships API
+ *
+ * Tests GET and DELETE /api/relationships/orphaned
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET, DELETE } from '../../../../routes/api/relationships/orphaned/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('/api/relationships/orphaned', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    sqlite.prepare("INSERT INTO people (id, first_name, last_name) VALUES (1, 'John', 'Smith'), (2, 'Mary', 'Smith')").run()
+    sqlite.prepare('UPDATE people SET deleted_at = CURRENT_TIMESTAMP WHERE id = 2').run()
+    sqlite.prepare("INSERT INTO relationships (id, person1_id, person2_id, type) VALUES (1, 1, 2, 'spouse')").run()
+
+    // Orphans written with enforcement off, as an old database might contain
+    sqlite.pragma('foreign_keys = OFF')
+    sqlite.prepare("INSERT INTO relationships (id, person1_id, person2_id, type, parent_role) VALUES (2, 99, 1, 'parentOf', 'father')").run()
+    sqlite.prepare("INSERT INTO relationships (id, person1_id, person2_id, type) VALUES (3, 1, 98, 'spouse')").run()
+    sqlite.pragma('foreign_keys = ON')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  it('should list relationships referencing missing people', async () => {
+    const response = await GET(createMockEvent(db))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.count).toBe(2)
+    expect(data.relationships.map(({ id, person1Id, person2Id }) => [id, person1Id, person2Id])).toEqual([
+      [2, 99, 1],
+      [3, 1, 98]
+    ])
+  })
+
+  it('should delete orphaned relationships and keep the rest', async () => {
+    const response = await DELETE(createMockEvent(db))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toEqual({ deleted: 2 })
+    expect(sqlite.prepare('SELECT id FROM relationships').all()).toEqual([{ id: 1 }])
+
+    const after = await (await GET(createMockEvent(db))).json()
+    expect(after).toEqual({ count: 0, relationships: [] })
+  })
+})
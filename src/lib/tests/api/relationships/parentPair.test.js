@@ -0,0 +1,107 @@
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { POST } from '../../../../routes/api/relationships/+server.js'
+import { PUT } from '../../../../routes/api/relationships/[id]/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+/**
+ * Test suite for parent pair uniqueness
+ * A child's mother and father must be distinct people
+ */
+describe('Parent pair uniqueness', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    await setupTestDatabase(sqlite, db)
+
+    // Insert test people
+    sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender)
+      VALUES (?, ?, ?, ?)
+    `).run(1, 'Child', 'Person', 'male')
+
+    sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender)
+      VALUES (?, ?, ?, ?)
+    `).run(2, 'Parent', 'Person', 'female')
+
+    sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender)
+      VALUES (?, ?, ?, ?)
+    `).run(3, 'Other', 'Person', 'male')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  function postRelationship(body) {
+    const request = { json: async () => body }
+    return POST(createMockEvent(db, { request }))
+  }
+
+  it('should reject setting the same person as both mother and father on create', async () => {
+    const response1 = await postRelationship({ person1Id: 2, person2Id: 1, type: 'mother' })
+    expect(response1.status).toBe(201)
+
+    const response2 = await postRelationship({ person1Id: 2, person2Id: 1, type: 'father' })
+    const data = await response2.json()
+
+    expect(response2.status).toBe(400)
+    expect(data.error).toBe('The same person cannot be both mother and father of a child')
+
+    // Only the original mother relationship should exist
+    const rows = sqlite.prepare(
+      "SELECT * FROM relationships WHERE person2_id = 1 AND type = 'parentOf'"
+    ).all()
+    expect(rows).toHaveLength(1)
+    expect(rows[0].parent_role).toBe('mother')
+  })
+
+  it('should reject the same conflict when using parentOf with an explicit parentRole', async () => {
+    await postRelationship({ person1Id: 2, person2Id: 1, type: 'parentOf', parentRole: 'father' })
+
+    const response = await postRelationship({ person1Id: 2, person2Id: 1, type: 'parentOf', parentRole: 'mother' })
+
+    expect(response.status).toBe(400)
+  })
+
+  it('should still allow distinct mother and father', async () => {
+    const response1 = await postRelationship({ person1Id: 2, person2Id: 1, type: 'mother' })
+    const response2 = await postRelationship({ person1Id: 3, person2Id: 1, type: 'father' })
+
+    expect(response1.status).toBe(201)
+    expect(response2.status).toBe(201)
+  })
+
+  it('should reject updating a father relationship to point at the existing mother', async () => {
+    await postRelationship({ person1Id: 2, person2Id: 1, type: 'mother' })
+    const created = await postRelationship({ person1Id: 3, person2Id: 1, type: 'father' })
+    const father = await created.json()
+
+    const request = {
+      json: async () => ({ person1Id: 2, person2Id: 1, type: 'father' })
+    }
+    const response = await PUT(createMockEvent(db, { params: { id: String(father.id) }, request }))
+
+    expect(response.status).toBe(400)
+    expect((await response.json()).error).toBe('The same person cannot be both mother and father of a child')
+  })
+
+  it('should allow changing the role of an existing parent relationship', async () => {
+    const created = await postRelationship({ person1Id: 2, person2Id: 1, type: 'mother' })
+    const mother = await created.json()
+
+    const request = {
+      json: async () => ({ person1Id: 2, person2Id: 1, type: 'father' })
+    }
+    const response = await PUT(createMockEvent(db, { params: { id: String(mother.id) }, request }))
+
+    expect(response.status).toBe(200)
+  })
+})
@@ -0,0 +1,141 @@
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { POST } from '../../../../routes/api/relationships/+server.js'
+import { PUT } from '../../../../routes/api/relationships/[id]/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+/**
+ * Test suite for adoptive and step parent roles
+ * They are stored as parentOf rows; only biological roles are limited to one per child
+ */
+describe('Adoptive and step parent roles', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'Birth', 'Mother', 'female')
+    insertPerson.run(2, 'Adoptive', 'Mother', 'female')
+    insertPerson.run(3, 'Child', 'Doe', 'male')
+    insertPerson.run(4, 'Step', 'Father', 'male')
+    insertPerson.run(5, 'Other', 'Mother', 'female')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  function postRelationship(body) {
+    return POST(createMockEvent(db, { request: { json: async () => body } }))
+  }
+
+  function putRelationship(id, body) {
+    return PUT(createMockEvent(db, { params: { id: String(id) }, request: { json: async () => body } }))
+  }
+
+  it('should allow a child to have a biological mother and an adoptive mother', async () => {
+    const birth = await postRelationship({ person1Id: 1, person2Id: 3, type: 'mother' })
+    const adoptive = await postRelationship({ person1Id: 2, person2Id: 3, type: 'adoptiveMother' })
+    const data = await adoptive.json()
+
+    expect(birth.status).toBe(201)
+    expect(adoptive.status).toBe(201)
+    expect(data).toMatchObject({ type: 'adoptiveMother', parentRole: 'adoptiveMother', relationKind: 'adoptive' })
+
+    const rows = sqlite
+      .prepare('SELECT person1_id, type, parent_role, relation_kind FROM relationships WHERE person2_id = 3 ORDER BY person1_id')
+      .all()
+    expect(rows).toEqual([
+      { person1_id: 1, type: 'parentOf', parent_role: 'mother', relation_kind: null },
+      { person1_id: 2, type: 'parentOf', parent_role: 'adoptiveMother', relation_kind: 'adoptive' }
+    ])
+  })
+
+  it('should still reject a second biological mother', async () => {
+    await postRelationship({ person1Id: 1, person2Id: 3, type: 'mother' })
+    await postRelationship({ person1Id: 2, person2Id: 3, type: 'adoptiveMother' })
+
+    const response = await postRelationship({ person1Id: 5, person2Id: 3, type: 'mother' })
+
+    expect(response.status).toBe(400)
+    expect((await response.json()).error).toBe('Person already has a mother')
+  })
+
+  it('should accept step roles via parentOf with parentRole', async () => {
+    const response = await postRelationship({ person1Id: 4, person2Id: 3, type: 'parentOf', parentRole: 'stepFather' })
+    const data = await response.json()
+
+    expect(response.status).toBe(201)
+    expect(data).toMatchObject({ type: 'stepFather', parentRole: 'stepFather', relationKind: 'step' })
+  })
+
+  it('should allow changing an adoptive link to a step role while a biological mother exists', async () => {
+    await postRelationship({ person1Id: 1, person2Id: 3, type: 'mother' })
+    const created = await (await postRelationship({ person1Id: 2, person2Id: 3, type: 'adoptiveMother' })).json()
+
+    const response = await putRelationship(created.id, { person1Id: 2, person2Id: 3, type: 'stepMother' })
+
+    expect(response.status).toBe(200)
+    expect(await response.json()).toMatchObject({ type: 'stepMother', relationKind: 'step' })
+  })
+
+  it('should reject unknown parent roles', async () => {
+    const response = await postRelationship({ person1Id: 4, person2Id: 3, type: 'parentOf', parentRole: 'godfather' })
+
+    expect(response.status).toBe(400)
+    expect((await response.json()).error).toContain('parentRole must be one of')
+  })
+
+  it('should not count a mother with relationKind adoptive as the biological mother', async () => {
+    const adoptive = await postRelationship({ person1Id: 2, person2Id: 3, type: 'mother', relationKind: 'adoptive' })
+    const birth = await postRelationship({ person1Id: 1, person2Id: 3, type: 'mother' })
+
+    expect(adoptive.status).toBe(201)
+    expect(birth.status).toBe(201)
+  })
+
+  it('should count a mother with relationKind biological against the biological mother slot', async () => {
+    await postRelationship({ person1Id: 1, person2Id: 3, type: 'mother', relationKind: 'biological' })
+
+    const response = await postRelationship({ person1Id: 5, person2Id: 3, type: 'mother' })
+
+    expect(response.status).toBe(400)
+    expect((await response.json()).error).toBe('Person already has a mother')
+  })
+
+  it('should reject a relationKind that contradicts the parent role', async () => {
+    const response = await postRelationship({
+      person1Id: 2,
+      person2Id: 3,
+      type: 'adoptiveMother',
+      relationKind: 'biological'
+    })
+
+    expect(response.status).toBe(400)
+    expect((await response.json()).error).toBe('relationKind "biological" does not match parent role "adoptiveMother"')
+  })
+
+  it('should let an update turn an adoptive mother into the biological one only when the slot is free', async () => {
+    await postRelationship({ person1Id: 1, person2Id: 3, type: 'mother' })
+    const created = await (await postRelationship({
+      person1Id: 2,
+      person2Id: 3,
+      type: 'mother',
+      relationKind: 'adoptive'
+    })).json()
+
+    const response = await putRelationship(created.id, { person1Id: 2, person2Id: 3, type: 'mother' })
+
+    expect(response.status).toBe(400)
+    expect((await response.json()).error).toBe('Person already has a mother')
+  })
+})
@@ -0,0 +1,164 @@
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { POST } from '../../../../routes/api/relationships/+server.js'
+import { PATCH } from '../../../../routes/api/relationships/[id]/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+/**
+ * Test suite for PATCH /api/relationships/[id]
+ * Only the given fields change; everything else is left as it was
+ */
+describe('PATCH /api/relationships/[id]', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'John', 'Doe', 'male')
+    insertPerson.run(2, 'Jane', 'Doe', 'female')
+    insertPerson.run(3, 'Junior', 'Doe', 'male')
+    insertPerson.run(4, 'Mary', 'Smith', 'female')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  async function postRelationship(body) {
+    const response = await POST(createMockEvent(db, { request: { json: async () => body } }))
+    return response.json()
+  }
+
+  function patchRelationship(id, body) {
+    return PATCH(createMockEvent(db, { params: { id: String(id) }, request: { json: async () => body } }))
+  }
+
+  it('should update only the start date of a spouse relationship', async () => {
+    const created = await postRelationship({
+      person1Id: 1,
+      person2Id: 2,
+      type: 'spouse',
+      startDate: '1950-06-10',
+      endDate: '1972-03-01'
+    })
+
+    const response = await patchRelationship(created.id, { startDate: '1951-06-10' })
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toMatchObject({
+      id: created.id,
+      person1Id: 1,
+      person2Id: 2,
+      type: 'spouse',
+      startDate: '1951-06-10',
+      endDate: '1972-03-01'
+    })
+
+    const row = sqlite.prepare('SELECT person1_id, person2_id, type, start_date, end_date FROM relationships WHERE id = ?').get(created.id)
+    expect(row).toEqual({ person1_id: 1, person2_id: 2, type: 'spouse', start_date: '1951-06-10', end_date: '1972-03-01' })
+  })
+
+  it('should clear a date patched to null', async () => {
+    const created = await postRelationship({ person1Id: 1, person2Id: 2, type: 'spouse', endDate: '1972-03-01' })
+
+    const response = await patchRelationship(created.id, { endDate: null })
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.endDate).toBeNull()
+  })
+
+  it('should keep the parent role when only the parent changes', async () => {
+    const created = await postRelationship({ person1Id: 2, person2Id: 3, type: 'mother' })
+
+    const response = await patchRelationship(created.id, { person1Id: 4 })
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toMatchObject({ person1Id: 4, person2Id: 3, type: 'mother', parentRole: 'mother' })
+  })
+
+  it('should reject a patch that gives the child a second mother', async () => {
+    await postRelationship({ person1Id: 2, person2Id: 3, type: 'mother' })
+    const other = await postRelationship({ person1Id: 4, person2Id: 1, type: 'mother' })
+
+    const response = await patchRelationship(other.id, { person2Id: 3 })
+
+    expect(response.status).toBe(400)
+
+    const row = sqlite.prepare('SELECT person2_id FROM relationships WHERE id = ?').get(other.id)
+    expect(row.person2_id).toBe(1)
+  })
+
+  it('should reject a patch that duplicates another relationship', async () => {
+    await postRelationship({ person1Id: 1, person2Id: 2, type: 'spouse' })
+    const other = await postRelationship({ person1Id: 1, person2Id: 4, type: 'spouse' })
+
+    const response = await patchRelationship(other.id, { person2Id: 2 })
+
+    expect(response.status).toBe(400)
+  })
+
+  it('should change only the dates of an older parentOf row without a parentRole', async () => {
+    const { lastInsertRowid } = sqlite
+      .prepare("INSERT INTO relationships (person1_id, person2_id, type) VALUES (1, 3, 'parentOf')")
+      .run()
+
+    const response = await patchRelationship(lastInsertRowid, { startDate: '1960-01-01' })
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toMatchObject({ type: 'parentOf', parentRole: null, startDate: '1960-01-01' })
+    const stored = sqlite.prepare('SELECT type, parent_role FROM relationships WHERE id = ?').get(lastInsertRowid)
+    expect(stored).toEqual({ type: 'parentOf', parent_role: null })
+  })
+
+  it('should check date order against the stored date on a date-only patch', async () => {
+    const created = await postRelationship({ person1Id: 1, person2Id: 2, type: 'spouse', startDate: '1950-06-10' })
+
+    const response = await patchRelationship(created.id, { endDate: '1949-01-01' })
+
+    expect(response.status).toBe(400)
+    expect((await response.json()).error).toBe('endDate cannot be before startDate')
+  })
+
+  it('should reject unknown fields', async () => {
+    const created = await postRelationship({ person1Id: 1, person2Id: 2, type: 'spouse' })
+
+    const response = await patchRelationship(created.id, { weddingVenue: 'Chapel' })
+    const data = await response.json()
+
+    expect(response.status).toBe(400)
+    expect(data.error).toContain('weddingVenue')
+  })
+
+  it('should reject an invalid start date', async () => {
+    const created = await postRelationship({ person1Id: 1, person2Id: 2, type: 'spouse' })
+
+    const response = await patchRelationship(created.id, { startDate: 'June 1950' })
+
+    expect(response.status).toBe(400)
+  })
+
+  it('should return 404 for a missing relationship', async () => {
+    const response = await patchRelationship(999, { startDate: '1950-06-10' })
+
+    expect(response.status).toBe(404)
+  })
+
+  it('should return 400 for an invalid ID', async () => {
+    const response = await patchRelationship('abc', { startDate: '1950-06-10' })
+
+    expect(response.status).toBe(400)
+  })
+})
@@ -0,0 +1,126 @@
+/**
+ * Integration Tests for Relationship Path API
+ *
+ * Tests GET /api/relationships/path endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../routes/api/relationships/path/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/relationships/path', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    // Dad(1) + Mom(2) -> Me(3), Sister(4)
+    // Mom(2) -> HalfBrother(5); Stranger(6)
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'Dad', 'Smith', 'male')
+    insertPerson.run(2, 'Mom', 'Smith', 'female')
+    insertPerson.run(3, 'Me', 'Smith', 'male')
+    insertPerson.run(4, 'Sister', 'Smith', 'female')
+    insertPerson.run(5, 'HalfBrother', 'Jones', 'male')
+    insertPerson.run(6, 'Stranger', 'Brown', null)
+
+    const insertRelationship = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertRelationship.run(1, 3, 'parentOf', 'father')
+    insertRelationship.run(2, 3, 'parentOf', 'mother')
+    insertRelationship.run(1, 4, 'parentOf', 'father')
+    insertRelationship.run(2, 4, 'parentOf', 'mother')
+    insertRelationship.run(2, 5, 'parentOf', 'mother')
+    insertRelationship.run(1, 2, 'spouse', null)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  function eventFor(query) {
+    return createMockEvent(db, {
+      url: new URL(`http://localhost/api/relationships/path${query}`)
+    })
+  }
+
+  it('should return the shortest blood path', async () => {
+    const response = await GET(eventFor('?from=3&to=4'))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toEqual({
+      from: 3,
+      to: 4,
+      exclude: null,
+      connected: true,
+      label: 'sister',
+      up: 1,
+      down: 1,
+      path: [3, 1, 4]
+    })
+  })
+
+  it('should find an alternate path when a shared parent is excluded', async () => {
+    const response = await GET(eventFor('?from=3&to=4&exclude=1'))
+    const data = await response.json()
+
+    expect(data.connected).toBe(true)
+    expect(data.exclude).toBe(1)
+    expect(data.path).toEqual([3, 2, 4])
+  })
+
+  it('should report no connection when the only path runs through the excluded person', async () => {
+    const response = await GET(eventFor('?from=3&to=5&exclude=2'))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.connected).toBe(false)
+    expect(data.label).toBe('no known relationship')
+    expect(data.path).toEqual([])
+  })
+
+  it('should not treat a marriage as a blood connection', async () => {
+    const response = await GET(eventFor('?from=1&to=2'))
+    const data = await response.json()
+
+    expect(data.connected).toBe(false)
+  })
+
+  it('should return 400 when exclude is one of the endpoints', async () => {
+    const response = await GET(eventFor('?from=3&to=4&exclude=3'))
+
+    expect(response.status).toBe(400)
+  })
+
+  it('should return 400 for an invalid exclude ID', async () => {
+    const response = await GET(eventFor('?from=3&to=4&exclude=abc'))
+
+    expect(response.status).toBe(400)
+  })
+
+  it('should return 400 when from or to is missing', async () => {
+    const response = await GET(eventFor('?from=3'))
+
+    expect(response.status).toBe(400)
+  })
+
+  it('should return 404 when a person does not exist', async () => {
+    const response = await GET(eventFor('?from=3&to=999'))
+
+    expect(response.status).toBe(404)
+  })
+})
@@ -0,0 +1,82 @@
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { POST } from '../../../../routes/api/relationships/+server.js'
+import { PUT } from '../../../../routes/api/relationships/[id]/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+/**
+ * Test suite for self-referential relationships
+ * A person cannot be their own parent or spouse, on create or update
+ */
+describe('Self-referential relationships', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'Parent', 'Person', 'female')
+    insertPerson.run(2, 'Child', 'Person', 'male')
+
+    sqlite.prepare(`
+      INSERT INTO relationships (id, person1_id, person2_id, type, parent_role)
+      VALUES (1, 1, 2, 'parentOf', 'mother')
+    `).run()
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  function postRelationship(body) {
+    return POST(createMockEvent(db, { request: { json: async () => body } }))
+  }
+
+  function putRelationship(id, body) {
+    return PUT(createMockEvent(db, { params: { id: String(id) }, request: { json: async () => body } }))
+  }
+
+  function countRelationships() {
+    return sqlite.prepare('SELECT COUNT(*) AS count FROM relationships').get().count
+  }
+
+  it('should reject creating a self parentOf relationship', async () => {
+    const response = await postRelationship({ person1Id: 1, person2Id: 1, type: 'parentOf', parentRole: 'mother' })
+
+    expect(response.status).toBe(400)
+    expect((await response.json()).error).toBe('A person cannot have a relationship with themselves')
+    expect(countRelationships()).toBe(1)
+  })
+
+  it('should reject creating a self spouse relationship', async () => {
+    const response = await postRelationship({ person1Id: 2, person2Id: 2, type: 'spouse' })
+
+    expect(response.status).toBe(400)
+    expect((await response.json()).error).toBe('A person cannot have a relationship with themselves')
+    expect(countRelationships()).toBe(1)
+  })
+
+  it('should reject updating a relationship into a self parentOf', async () => {
+    const response = await putRelationship(1, { person1Id: 2, person2Id: 2, type: 'parentOf', parentRole: 'father' })
+
+    expect(response.status).toBe(400)
+    expect((await response.json()).error).toBe('A person cannot have a relationship with themselves')
+    expect(sqlite.prepare('SELECT person1_id, person2_id FROM relationships WHERE id = 1').get())
+      .toEqual({ person1_id: 1, person2_id: 2 })
+  })
+
+  it('should reject updating a relationship into a self spouse', async () => {
+    const response = await putRelationship(1, { person1Id: 1, person2Id: 1, type: 'spouse' })
+
+    expect(response.status).toBe(400)
+    expect((await response.json()).error).toBe('A person cannot have a relationship with themselves')
+  })
+})
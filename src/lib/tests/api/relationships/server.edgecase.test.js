@@ -229,7 +229,7 @@ describe('POST /api/relationships - Edge Cases', () => {
 
     expect(response.status).toBe(400)
     const data = await response.json()
-    expect(data.error).toContain('cannot be related to themselves')
+    expect(data.error).toBe('A person cannot have a relationship with themselves')
   })
 
   it('should prevent person from being their own spouse', async () => {
@@ -258,8 +258,8 @@ describe('POST /api/relationships - Edge Cases', () => {
 
     const response = await POST(createMockEvent(db, { request }))
 
-    // Returns 403 because person doesn't exist (ownership check fails before validation)
-    expect(response.status).toBe(400)
+    expect(response.status).toBe(404)
+    expect((await response.json()).error).toBe('person1 not found')
   })
 
   it('should reject relationship with non-existent relatedPersonId', async () => {
@@ -273,8 +273,8 @@ describe('POST /api/relationships - Edge Cases', () => {
 
     const response = await POST(createMockEvent(db, { request }))
 
-    // Returns 403 because person doesn't exist (ownership check fails before validation)
-    expect(response.status).toBe(400)
+    expect(response.status).toBe(404)
+    expect((await response.json()).error).toBe('person2 not found')
   })
 
   // Invalid Relationship Types
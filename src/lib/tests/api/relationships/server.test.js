@@ -308,7 +308,7 @@ describe('POST /api/relationships', () => {
 
       // Assert
       expect(response.status).toBe(400)
-      const errorText = await response.text()
+      const { error: errorText } = await response.json()
       expect(errorText).toContain('already has a mother')
     })
 
@@ -334,7 +334,7 @@ describe('POST /api/relationships', () => {
 
       // Assert
       expect(response.status).toBe(400)
-      const errorText = await response.text()
+      const { error: errorText } = await response.json()
       expect(errorText).toContain('already has a father')
     })
 
@@ -386,7 +386,7 @@ describe('POST /api/relationships', () => {
 
       // Assert
       expect(response.status).toBe(400)
-      const errorText = await response.text()
+      const { error: errorText } = await response.json()
       expect(errorText).toContain('already exists')
     })
 
@@ -440,7 +440,7 @@ describe('POST /api/relationships', () => {
 
       // Assert
       expect(response.status).toBe(400)
-      const errorText = await response.text()
+      const { error: errorText } = await response.json()
       expect(errorText).toContain('already exists')
     })
   })
@@ -463,7 +463,7 @@ describe('POST /api/relationships', () => {
 
       // Assert
       expect(response.status).toBe(400)
-      const errorText = await response.text()
+      const { error: errorText } = await response.json()
       expect(errorText).toContain('Invalid relationship type')
     })
 
@@ -483,7 +483,7 @@ describe('POST /api/relationships', () => {
 
       // Assert
       expect(response.status).toBe(400)
-      const errorText = await response.text()
+      const { error: errorText } = await response.json()
       expect(errorText).toContain('type is required')
     })
   })
@@ -505,7 +505,7 @@ describe('POST /api/relationships', () => {
 
       // Assert
       expect(response.status).toBe(400)
-      const errorText = await response.text()
+      const { error: errorText } = await response.json()
       expect(errorText).toContain('person1Id is required')
     })
 
@@ -525,7 +525,7 @@ describe('POST /api/relationships', () => {
 
       // Assert
       expect(response.status).toBe(400)
-      const errorText = await response.text()
+      const { error: errorText } = await response.json()
       expect(errorText).toContain('person2Id is required')
     })
 
@@ -542,7 +542,7 @@ describe('POST /api/relationships', () => {
 
       // Assert
       expect(response.status).toBe(400)
-      const errorText = await response.text()
+      const { error: errorText } = await response.json()
       expect(errorText).toBe('Invalid JSON')
     })
   })
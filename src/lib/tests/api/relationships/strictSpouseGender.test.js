@@ -0,0 +1,120 @@
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { POST } from '../../../../routes/api/relationships/+server.js'
+import { PUT } from '../../../../routes/api/relationships/[id]/+server.js'
+import { GET as canLink } from '../../../../routes/api/relationships/can-link/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+import { SAME_GENDER_SPOUSE_ERROR } from '$lib/server/relationshipHelpers.js'
+
+/**
+ * Test suite for STRICT_SPOUSE_GENDER
+ * Spouses may have any genders by default; strict mode rejects spouses
+ * with the same recorded gender, on create or update
+ */
+describe('Strict spouse gender', () => {
+  let db
+  let sqlite
+  let originalStrict
+
+  beforeEach(async () => {
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+    originalStrict = process.env.STRICT_SPOUSE_GENDER
+    delete process.env.STRICT_SPOUSE_GENDER
+
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'Alice', 'Smith', 'female')
+    insertPerson.run(2, 'Beth', 'Jones', 'female')
+    insertPerson.run(3, 'Carl', 'Brown', 'male')
+    insertPerson.run(4, 'Dana', 'White', null)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+    if (originalStrict === undefined) {
+      delete process.env.STRICT_SPOUSE_GENDER
+    } else {
+      process.env.STRICT_SPOUSE_GENDER = originalStrict
+    }
+  })
+
+  function postSpouse(person1Id, person2Id) {
+    return POST(createMockEvent(db, {
+      request: { json: async () => ({ person1Id, person2Id, type: 'spouse' }) }
+    }))
+  }
+
+  it('should allow same-gender spouses by default', async () => {
+    const response = await postSpouse(1, 2)
+
+    expect(response.status).toBe(201)
+  })
+
+  it('should allow same-gender spouses when explicitly disabled', async () => {
+    process.env.STRICT_SPOUSE_GENDER = 'false'
+
+    const response = await postSpouse(1, 2)
+
+    expect(response.status).toBe(201)
+  })
+
+  it('should reject same-gender spouses in strict mode', async () => {
+    process.env.STRICT_SPOUSE_GENDER = 'true'
+
+    const response = await postSpouse(1, 2)
+
+    expect(response.status).toBe(400)
+    expect((await response.json()).error).toBe(SAME_GENDER_SPOUSE_ERROR)
+    expect(sqlite.prepare('SELECT COUNT(*) AS count FROM relationships').get().count).toBe(0)
+  })
+
+  it('should allow different or unrecorded genders in strict mode', async () => {
+    process.env.STRICT_SPOUSE_GENDER = 'true'
+
+    expect((await postSpouse(1, 3)).status).toBe(201)
+    expect((await postSpouse(2, 4)).status).toBe(201)
+  })
+
+  it('should not affect parent relationships in strict mode', async () => {
+    process.env.STRICT_SPOUSE_GENDER = 'true'
+
+    const response = await POST(createMockEvent(db, {
+      request: { json: async () => ({ person1Id: 1, person2Id: 2, type: 'mother' }) }
+    }))
+
+    expect(response.status).toBe(201)
+  })
+
+  it('should reject updating a spouse relationship to same-gender spouses in strict mode', async () => {
+    sqlite.prepare(`
+      INSERT INTO relationships (id, person1_id, person2_id, type)
+      VALUES (1, 1, 3, 'spouse')
+    `).run()
+    process.env.STRICT_SPOUSE_GENDER = '1'
+
+    const response = await PUT(createMockEvent(db, {
+      params: { id: '1' },
+      request: { json: async () => ({ person1Id: 1, person2Id: 2, type: 'spouse' }) }
+    }))
+
+    expect(response.status).toBe(400)
+    expect((await response.json()).error).toBe(SAME_GENDER_SPOUSE_ERROR)
+  })
+
+  it('should report same-gender spouses as not linkable in strict mode', async () => {
+    const check = async () => (await canLink(createMockEvent(db, {
+      url: new URL('http://localhost/api/relationships/can-link?person1=1&person2=2&type=spouse')
+    }))).json()
+
+    expect(await check()).toEqual({ allowed: true })
+
+    process.env.STRICT_SPOUSE_GENDER = 'true'
+    expect(await check()).toMatchObject({ allowed: false, reason: 'sameGenderSpouse' })
+  })
+})
@@ -0,0 +1,88 @@
+/**
+ * Integration Tests for Person Search API
+ *
+ * Tests GET /api/search endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../routes/api/search/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/search', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, birth_place, notes, deleted_at)
+      VALUES (?, ?, ?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'Mary', 'Walsh', 'Cork, Ireland', null, null)
+    insertPerson.run(2, 'John', 'Walsh', 'Boston', 'Fought at Gettysburg with the 69th regiment', null)
+    insertPerson.run(3, 'Ann', 'Byrne', 'Dublin', null, null)
+    insertPerson.run(4, 'Deleted', 'Walsh', 'Cork', null, '2024-01-01 00:00:00')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  function search(query) {
+    return GET(createMockEvent(db, { url: new URL(`http://localhost/api/search${query}`) }))
+  }
+
+  it('should match on notes with a snippet', async () => {
+    const response = await search('?q=gettysburg')
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.count).toBe(1)
+    expect(data.results[0]).toMatchObject({
+      person: { id: 2, firstName: 'John' },
+      field: 'notes',
+      snippet: 'Fought at Gettysburg with the 69th regiment'
+    })
+  })
+
+  it('should match on birthplace and exclude soft-deleted people', async () => {
+    const response = await search('?q=cork')
+    const data = await response.json()
+
+    expect(data.results.map((result) => result.person.id)).toEqual([1])
+    expect(data.results[0]).toMatchObject({ field: 'birthPlace', snippet: 'Cork, Ireland' })
+  })
+
+  it('should order results by relevance', async () => {
+    const response = await search('?q=walsh%20boston')
+    const data = await response.json()
+
+    expect(data.results.map((result) => result.person.id)).toEqual([2])
+
+    const byName = await (await search('?q=walsh')).json()
+    expect(byName.results.map((result) => result.person.id)).toEqual([2, 1])
+    expect(byName.results[0].score).toBe(byName.results[1].score)
+  })
+
+  it('should apply limit after counting matches', async () => {
+    const response = await search('?q=walsh&limit=1')
+    const data = await response.json()
+
+    expect(data.count).toBe(2)
+    expect(data.results).toHaveLength(1)
+  })
+
+  it('should return 400 without a query', async () => {
+    expect((await search('?q=%20')).status).toBe(400)
+    expect((await search('')).status).toBe(400)
+  })
+
+  it('should return 400 for an invalid limit', async () => {
+    expect((await search('?q=walsh&limit=0')).status).toBe(400)
+  })
+})
@@ -0,0 +1,121 @@
+/**
+ * Integration Tests for Snapshot Diff API
+ *
+ * Tests GET /api/snapshots/diff endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { POST as CREATE } from '../../../../routes/api/snapshots/+server.js'
+import { GET } from '../../../../routes/api/snapshots/diff/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/snapshots/diff', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name)
+      VALUES (?, ?, ?)
+    `)
+    insertPerson.run(1, 'John', 'Smith')
+    insertPerson.run(2, 'Jane', 'Smith')
+    insertPerson.run(3, 'Old', 'Record')
+
+    const insertRelationship = sqlite.prepare(`
+      INSERT INTO relationships (id, person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, ?, ?, ?)
+    `)
+    insertRelationship.run(1, 1, 2, 'spouse', null)
+    insertRelationship.run(2, 3, 1, 'parentOf', 'father')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  async function snapshot(label) {
+    const response = await CREATE(createMockEvent(db, { request: { json: async () => ({ label }) } }))
+    return response.json()
+  }
+
+  function diff(query) {
+    return GET(createMockEvent(db, { url: new URL(`http://localhost/api/snapshots/diff${query}`) }))
+  }
+
+  it('should report people and relationships added, removed, and changed', async () => {
+    const before = await snapshot('Before edit')
+
+    sqlite.prepare("UPDATE people SET first_name = 'Johnny', birth_place = 'Boston' WHERE id = 1").run()
+    sqlite.prepare('DELETE FROM people WHERE id = 3').run()
+    sqlite.prepare("INSERT INTO people (id, first_name, last_name) VALUES (4, 'Baby', 'Smith')").run()
+    sqlite.prepare("INSERT INTO relationships (id, person1_id, person2_id, type, parent_role) VALUES (3, 2, 4, 'parentOf', 'mother')").run()
+
+    const after = await snapshot('After edit')
+
+    const response = await diff(`?from=${before.id}&to=${after.id}`)
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.from).toMatchObject({ id: before.id, label: 'Before edit' })
+    expect(data.to).toMatchObject({ id: after.id, label: 'After edit' })
+
+    expect(data.people.added.map((person) => person.id)).toEqual([4])
+    expect(data.people.removed.map((person) => person.id)).toEqual([3])
+    expect(data.people.changed).toEqual([
+      {
+        id: 1,
+        changes: {
+          firstName: { from: 'John', to: 'Johnny' },
+          birthPlace: { from: null, to: 'Boston' }
+        }
+      }
+    ])
+
+    expect(data.relationships.added).toMatchObject([{ id: 3, person1Id: 2, person2Id: 4, parentRole: 'mother' }])
+    expect(data.relationships.removed).toMatchObject([{ id: 2, person1Id: 3, person2Id: 1 }])
+    expect(data.relationships.changed).toEqual([])
+  })
+
+  it('should report a soft delete as a deletedAt change', async () => {
+    const before = await snapshot('Before')
+    sqlite.prepare("UPDATE people SET deleted_at = '2024-01-01 00:00:00' WHERE id = 2").run()
+    const after = await snapshot('After')
+
+    const data = await (await diff(`?from=${before.id}&to=${after.id}`)).json()
+
+    expect(data.people.changed).toEqual([
+      { id: 2, changes: { deletedAt: { from: null, to: '2024-01-01 00:00:00' } } }
+    ])
+  })
+
+  it('should return empty diffs for identical snapshots', async () => {
+    const first = await snapshot('One')
+    const second = await snapshot('Two')
+
+    const data = await (await diff(`?from=${first.id}&to=${second.id}`)).json()
+
+    expect(data.people).toEqual({ added: [], removed: [], changed: [] })
+    expect(data.relationships).toEqual({ added: [], removed: [], changed: [] })
+  })
+
+  it('should return 400 for missing or invalid IDs', async () => {
+    expect((await diff('?from=1')).status).toBe(400)
+    expect((await diff('?from=abc&to=1')).status).toBe(400)
+  })
+
+  it('should return 404 for unknown snapshots', async () => {
+    const first = await snapshot('One')
+
+    expect((await diff(`?from=${first.id}&to=999`)).status).toBe(404)
+  })
+})
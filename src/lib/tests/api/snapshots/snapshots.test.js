@@ -0,0 +1,149 @@
+/**
+ * Integration Tests for Snapshots API
+ *
+ * Tests:
+ * - POST /api/snapshots
+ * - GET /api/snapshots
+ * - GET /api/snapshots/[id]
+ * - POST /api/snapshots/[id]/restore
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET as LIST, POST as CREATE } from '../../../../routes/api/snapshots/+server.js'
+import { GET as GET_ONE } from '../../../../routes/api/snapshots/[id]/+server.js'
+import { POST as RESTORE } from '../../../../routes/api/snapshots/[id]/restore/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('Snapshots API', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, birth_place)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'John', 'Smith', 'Boston')
+    insertPerson.run(2, 'Jane', 'Smith', null)
+    insertPerson.run(3, 'Junior', 'Smith', null)
+
+    const insertRelationship = sqlite.prepare(`
+      INSERT INTO relationships (id, person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, ?, ?, ?)
+    `)
+    insertRelationship.run(1, 1, 2, 'spouse', null)
+    insertRelationship.run(2, 1, 3, 'parentOf', 'father')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  function createSnapshot(body) {
+    return CREATE(createMockEvent(db, { request: { json: async () => body } }))
+  }
+
+  function restore(id) {
+    return RESTORE(createMockEvent(db, { params: { id: String(id) } }))
+  }
+
+  it('should create a labeled snapshot of all people and relationships', async () => {
+    const response = await createSnapshot({ label: '  Before cleanup  ' })
+    const data = await response.json()
+
+    expect(response.status).toBe(201)
+    expect(data.label).toBe('Before cleanup')
+    expect(data.createdAt).toMatch(/^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z$/)
+    expect(data.people.map((person) => person.firstName)).toEqual(['John', 'Jane', 'Junior'])
+    expect(data.relationships).toHaveLength(2)
+  })
+
+  it('should list snapshots newest first with counts', async () => {
+    await createSnapshot({ label: 'First' })
+    sqlite.prepare("INSERT INTO people (first_name, last_name) VALUES ('New', 'Person')").run()
+    await createSnapshot({ label: 'Second' })
+
+    const response = await LIST(createMockEvent(db))
+    const data = await response.json()
+
+    expect(data.map(({ label, peopleCount, relationshipCount }) => ({ label, peopleCount, relationshipCount }))).toEqual([
+      { label: 'Second', peopleCount: 4, relationshipCount: 2 },
+      { label: 'First', peopleCount: 3, relationshipCount: 2 }
+    ])
+    expect(data[0]).not.toHaveProperty('people')
+  })
+
+  it('should retrieve a snapshot by ID', async () => {
+    const created = await (await createSnapshot({ label: 'Saved' })).json()
+
+    const response = await GET_ONE(createMockEvent(db, { params: { id: String(created.id) } }))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toEqual(created)
+  })
+
+  it('should restore the tree after it was mutated', async () => {
+    const created = await (await createSnapshot({ label: 'Good state' })).json()
+
+    // Mutate: edit a person, delete a person (cascades a relationship), soft-delete another, add one
+    sqlite.prepare("UPDATE people SET first_name = 'Johnny', birth_place = NULL WHERE id = 1").run()
+    sqlite.prepare('DELETE FROM people WHERE id = 3').run()
+    sqlite.prepare("UPDATE people SET deleted_at = CURRENT_TIMESTAMP WHERE id = 2").run()
+    sqlite.prepare("INSERT INTO people (id, first_name, last_name) VALUES (4, 'Intruder', 'Jones')").run()
+
+    const response = await restore(created.id)
+    const result = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(result).toEqual({ snapshotId: created.id, label: 'Good state', peopleRestored: 3, relationshipsRestored: 2 })
+
+    const peopleRows = sqlite.prepare('SELECT id, first_name, birth_place, deleted_at FROM people ORDER BY id').all()
+    expect(peopleRows).toEqual([
+      { id: 1, first_name: 'John', birth_place: 'Boston', deleted_at: null },
+      { id: 2, first_name: 'Jane', birth_place: null, deleted_at: null },
+      { id: 3, first_name: 'Junior', birth_place: null, deleted_at: null }
+    ])
+
+    const relationshipRows = sqlite.prepare('SELECT id, person1_id, person2_id, type FROM relationships ORDER BY id').all()
+    expect(relationshipRows).toEqual([
+      { id: 1, person1_id: 1, person2_id: 2, type: 'spouse' },
+      { id: 2, person1_id: 1, person2_id: 3, type: 'parentOf' }
+    ])
+  })
+
+  it('should keep snapshots after restoring', async () => {
+    const first = await (await createSnapshot({ label: 'First' })).json()
+    await createSnapshot({ label: 'Second' })
+
+    await restore(first.id)
+
+    const data = await (await LIST(createMockEvent(db))).json()
+    expect(data).toHaveLength(2)
+  })
+
+  it('should return 400 for a missing or blank label', async () => {
+    expect((await createSnapshot({})).status).toBe(400)
+    expect((await createSnapshot({ label: '   ' })).status).toBe(400)
+    expect((await createSnapshot({ label: 'x'.repeat(101) })).status).toBe(400)
+  })
+
+  it('should return 404 for unknown snapshot', async () => {
+    expect((await GET_ONE(createMockEvent(db, { params: { id: '999' } }))).status).toBe(404)
+    expect((await restore(999)).status).toBe(404)
+  })
+
+  it('should return 400 for invalid snapshot ID', async () => {
+    expect((await GET_ONE(createMockEvent(db, { params: { id: 'abc' } }))).status).toBe(400)
+    expect((await restore('abc')).status).toBe(400)
+  })
+})
@@ -0,0 +1,121 @@
+/**
+ * Integration Tests for Centrality Statistics API
+ *
+ * Tests GET /api/stats/centrality endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../routes/api/stats/centrality/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/stats/centrality', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  function insertPerson(firstName, lastName, gender = null) {
+    return sqlite.prepare(`
+      INSERT INTO people (first_name, last_name, gender)
+      VALUES (?, ?, ?)
+    `).run(firstName, lastName, gender).lastInsertRowid
+  }
+
+  function insertRelationship(person1Id, person2Id, type, parentRole = null) {
+    sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, ?, ?)
+    `).run(person1Id, person2Id, type, parentRole)
+  }
+
+  it('should return empty array when no people exist', async () => {
+    const event = createMockEvent(db, { url: new URL('http://localhost/api/stats/centrality') })
+
+    const response = await GET(event)
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toEqual([])
+  })
+
+  it('should rank a highly-connected person above a leaf', async () => {
+    // Hub: married, with two children and both parents recorded
+    const hub = insertPerson('Henry', 'Hub', 'male')
+    const wife = insertPerson('Wendy', 'Hub', 'female')
+    const child1 = insertPerson('Carl', 'Hub', 'male')
+    const child2 = insertPerson('Cora', 'Hub', 'female')
+    const father = insertPerson('Frank', 'Hub', 'male')
+
+    insertRelationship(hub, wife, 'spouse')
+    insertRelationship(hub, child1, 'parentOf', 'father')
+    insertRelationship(hub, child2, 'parentOf', 'father')
+    insertRelationship(father, hub, 'parentOf', 'father')
+
+    const event = createMockEvent(db, { url: new URL('http://localhost/api/stats/centrality') })
+
+    const response = await GET(event)
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toHaveLength(5)
+    expect(data[0].person.id).toBe(Number(hub))
+    expect(data[0].degree).toBe(4)
+    expect(data[0].score).toBe(1)
+
+    const leaf = data.find((entry) => entry.person.id === Number(child1))
+    expect(leaf.degree).toBe(1)
+    expect(data.indexOf(leaf)).toBeGreaterThan(0)
+  })
+
+  it('should count a person related by multiple edges only once', async () => {
+    const a = insertPerson('Alpha', 'Test')
+    const b = insertPerson('Beta', 'Test')
+
+    // Bidirectional spouse rows are allowed by the API
+    insertRelationship(a, b, 'spouse')
+    insertRelationship(b, a, 'spouse')
+
+    const event = createMockEvent(db, { url: new URL('http://localhost/api/stats/centrality') })
+
+    const response = await GET(event)
+    const data = await response.json()
+
+    expect(data.every((entry) => entry.degree === 1)).toBe(true)
+  })
+
+  it('should apply limit parameter', async () => {
+    const parent = insertPerson('Parent', 'Test')
+    const child = insertPerson('Child', 'Test')
+    insertPerson('Loner', 'Test')
+    insertRelationship(parent, child, 'parentOf', 'mother')
+
+    const event = createMockEvent(db, { url: new URL('http://localhost/api/stats/centrality?limit=1') })
+
+    const response = await GET(event)
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toHaveLength(1)
+  })
+
+  it('should return 400 for invalid limit', async () => {
+    const event = createMockEvent(db, { url: new URL('http://localhost/api/stats/centrality?limit=abc') })
+
+    const response = await GET(event)
+
+    expect(response.status).toBe(400)
+  })
+})
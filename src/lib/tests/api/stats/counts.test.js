@@ -0,0 +1,85 @@
+/**
+ * Integration Tests for Count Statistics API
+ *
+ * Tests GET /api/stats/counts endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../routes/api/stats/counts/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/stats/counts', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  function insertPerson(firstName, lastName) {
+    return sqlite.prepare(`
+      INSERT INTO people (first_name, last_name)
+      VALUES (?, ?)
+    `).run(firstName, lastName).lastInsertRowid
+  }
+
+  function insertRelationship(person1Id, person2Id, type, parentRole = null) {
+    sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, ?, ?)
+    `).run(person1Id, person2Id, type, parentRole)
+  }
+
+  it('should return zero counts for an empty database', async () => {
+    const response = await GET(createMockEvent(db))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toEqual({ people: 0, relationships: 0, parentOfCount: 0, spouseCount: 0 })
+  })
+
+  it('should count people and relationships by type', async () => {
+    const dad = insertPerson('John', 'Doe')
+    const mom = insertPerson('Jane', 'Doe')
+    const son = insertPerson('Jim', 'Doe')
+    const daughter = insertPerson('Jill', 'Doe')
+
+    insertRelationship(dad, mom, 'spouse')
+    insertRelationship(dad, son, 'parentOf', 'father')
+    insertRelationship(mom, son, 'parentOf', 'mother')
+    insertRelationship(dad, daughter, 'parentOf', 'father')
+
+    const response = await GET(createMockEvent(db))
+    const data = await response.json()
+
+    expect(data).toEqual({ people: 4, relationships: 4, parentOfCount: 3, spouseCount: 1 })
+  })
+
+  it('should exclude soft-deleted people and their relationships', async () => {
+    const dad = insertPerson('John', 'Doe')
+    const mom = insertPerson('Jane', 'Doe')
+    const son = insertPerson('Jim', 'Doe')
+
+    insertRelationship(dad, mom, 'spouse')
+    insertRelationship(dad, son, 'parentOf', 'father')
+    insertRelationship(mom, son, 'parentOf', 'mother')
+
+    sqlite.prepare('UPDATE people SET deleted_at = CURRENT_TIMESTAMP WHERE id = ?').run(dad)
+
+    const response = await GET(createMockEvent(db))
+    const data = await response.json()
+
+    expect(data).toEqual({ people: 2, relationships: 1, parentOfCount: 1, spouseCount: 0 })
+  })
+})
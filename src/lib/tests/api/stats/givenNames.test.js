@@ -0,0 +1,92 @@
+/**
+ * Integration Tests for Given Name Statistics API
+ *
+ * Tests GET /api/stats/given-names endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../routes/api/stats/given-names/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/stats/given-names', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (first_name, last_name, gender)
+      VALUES (?, ?, ?)
+    `)
+    insertPerson.run('Mary', 'Smith', 'female')
+    insertPerson.run('Mary', 'Jones', 'female')
+    insertPerson.run('Mary', 'Brown', 'female')
+    insertPerson.run('Anne', 'Smith', 'female')
+    insertPerson.run('Anne', 'Jones', 'female')
+    insertPerson.run('John', 'Smith', 'male')
+    insertPerson.run('John', 'Jones', 'male')
+    insertPerson.run('John', 'Brown', 'male')
+    insertPerson.run('John', 'Lee', 'male')
+    insertPerson.run('Alex', 'Lee', null)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const getGivenNames = (query = '') =>
+    GET(createMockEvent(db, { url: new URL(`http://localhost/api/stats/given-names${query}`) }))
+
+  it('should count first names across the whole tree, most common first', async () => {
+    const response = await getGivenNames()
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toEqual({
+      gender: null,
+      names: [
+        { firstName: 'John', count: 4 },
+        { firstName: 'Mary', count: 3 },
+        { firstName: 'Anne', count: 2 },
+        { firstName: 'Alex', count: 1 }
+      ]
+    })
+  })
+
+  it('should only count people of the requested gender', async () => {
+    const data = await (await getGivenNames('?gender=female')).json()
+
+    expect(data).toEqual({
+      gender: 'female',
+      names: [
+        { firstName: 'Mary', count: 3 },
+        { firstName: 'Anne', count: 2 }
+      ]
+    })
+  })
+
+  it('should respect the limit and exclude soft-deleted people', async () => {
+    sqlite.prepare("UPDATE people SET deleted_at = CURRENT_TIMESTAMP WHERE first_name = 'John' AND last_name != 'Lee'").run()
+
+    const data = await (await getGivenNames('?limit=2')).json()
+
+    expect(data.names).toEqual([
+      { firstName: 'Mary', count: 3 },
+      { firstName: 'Anne', count: 2 }
+    ])
+  })
+
+  it('should return 400 for an invalid gender or limit', async () => {
+    expect((await getGivenNames('?gender=robot')).status).toBe(400)
+    expect((await getGivenNames('?limit=0')).status).toBe(400)
+    expect((await getGivenNames('?limit=abc')).status).toBe(400)
+  })
+})
@@ -0,0 +1,72 @@
+/**
+ * Integration Tests for Longest Marriage Chain API
+ *
+ * Tests GET /api/stats/longest-marriage-chain endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../routes/api/stats/longest-marriage-chain/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/stats/longest-marriage-chain', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare('INSERT INTO people (id, first_name, last_name) VALUES (?, ?, ?)')
+    insertPerson.run(1, 'Alice', 'Ames')
+    insertPerson.run(2, 'Bert', 'Baker')
+    insertPerson.run(3, 'Cara', 'Cole')
+    insertPerson.run(4, 'Dan', 'Dunn')
+    insertPerson.run(5, 'Eve', 'Ellis')
+    insertPerson.run(6, 'Fred', 'Ford')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const insertSpouse = (person1Id, person2Id) =>
+    sqlite.prepare("INSERT INTO relationships (person1_id, person2_id, type) VALUES (?, ?, 'spouse')").run(person1Id, person2Id)
+
+  it('should return the chain formed by serial remarriages', async () => {
+    // Alice married Bert; Bert remarried Cara; Cara remarried Dan. Eve and Fred are a separate couple.
+    insertSpouse(1, 2)
+    insertSpouse(3, 2)
+    insertSpouse(3, 4)
+    insertSpouse(5, 6)
+
+    const response = await GET(createMockEvent(db))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.length).toBe(3)
+    expect(data.people.map((person) => person.firstName)).toEqual(['Alice', 'Bert', 'Cara', 'Dan'])
+  })
+
+  it('should ignore soft-deleted people', async () => {
+    insertSpouse(1, 2)
+    insertSpouse(2, 3)
+    insertSpouse(5, 6)
+    sqlite.prepare('UPDATE people SET deleted_at = CURRENT_TIMESTAMP WHERE id = 2').run()
+
+    const data = await (await GET(createMockEvent(db))).json()
+
+    expect(data.people.map((person) => person.id)).toEqual([5, 6])
+  })
+
+  it('should return an empty chain when nobody is married', async () => {
+    const data = await (await GET(createMockEvent(db))).json()
+
+    expect(data).toEqual({ length: 0, people: [] })
+  })
+})
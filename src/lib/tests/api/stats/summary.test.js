@@ -0,0 +1,75 @@
+/**
+ * Integration Tests for Summary Statistics API
+ *
+ * Tests GET /api/stats/summary endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../routes/api/stats/summary/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/stats/summary', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  function insertPerson(firstName, gender, birthDate, deathDate) {
+    sqlite.prepare(`
+      INSERT INTO people (first_name, last_name, gender, birth_date, death_date)
+      VALUES (?, ?, ?, ?, ?)
+    `).run(firstName, 'Test', gender, birthDate, deathDate)
+  }
+
+  it('should return zeros and nulls for an empty database', async () => {
+    const response = await GET(createMockEvent(db))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toEqual({
+      totalPeople: 0,
+      living: 0,
+      deceased: 0,
+      averageLifespanYears: null,
+      genders: {},
+      earliestBirthYear: null,
+      latestBirthYear: null
+    })
+  })
+
+  it('should compute counts, average lifespan, gender distribution, and birth year range', async () => {
+    insertPerson('Lived50', 'male', '1900-01-01', '1950-01-01')
+    insertPerson('Lived70', 'female', '1910-06-15', '1980-06-15')
+    insertPerson('PartialDeath', 'female', '1850', '1900')
+    insertPerson('Living', 'female', '1990-03-03', null)
+    insertPerson('Undated', null, null, null)
+    insertPerson('TooOld', 'male', '1801-01-01', null)
+    insertPerson('Deleted', 'male', '1700-01-01', null)
+    sqlite.prepare("UPDATE people SET deleted_at = CURRENT_TIMESTAMP WHERE first_name = 'Deleted'").run()
+
+    const data = await (await GET(createMockEvent(db))).json()
+
+    expect(data).toEqual({
+      totalPeople: 6,
+      living: 2,
+      deceased: 4,
+      averageLifespanYears: 60,
+      genders: { female: 3, male: 2, unspecified: 1 },
+      earliestBirthYear: 1801,
+      latestBirthYear: 1990
+    })
+  })
+})
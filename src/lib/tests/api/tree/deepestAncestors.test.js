@@ -0,0 +1,87 @@
+/**
+ * Integration Tests for Deepest Ancestors API
+ *
+ * Tests GET /api/tree/deepest-ancestors endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../routes/api/tree/deepest-ancestors/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/tree/deepest-ancestors', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    // GreatGrandpa(1) -> Grandpa(2) -> Dad(4) -> Kid(7)
+    // Grandma(3) -> Dad(4); Mom(5) -> Kid(7)
+    // AdoptiveGreat(6) -adoptive-> GreatGrandpa(1)
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender)
+      VALUES (?, ?, ?, ?)
+    `)
+    const names = ['GreatGrandpa', 'Grandpa', 'Grandma', 'Dad', 'Mom', 'AdoptiveGreat', 'Kid']
+    const genders = ['male', 'male', 'female', 'male', 'female', 'male', 'male']
+    names.forEach((name, index) => insertPerson.run(index + 1, name, 'Smith', genders[index]))
+
+    const insertParent = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role, relation_kind)
+      VALUES (?, ?, 'parentOf', ?, ?)
+    `)
+    insertParent.run(1, 2, 'father', null)
+    insertParent.run(2, 4, 'father', null)
+    insertParent.run(3, 4, 'mother', null)
+    insertParent.run(4, 7, 'father', null)
+    insertParent.run(5, 7, 'mother', null)
+    insertParent.run(6, 1, 'father', 'adoptive')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const getDeepest = (query = '') =>
+    GET(createMockEvent(db, { url: new URL(`http://localhost/api/tree/deepest-ancestors${query}`) }))
+
+  const summarize = (data) =>
+    data.ancestors.map(({ person, depth, descendant }) => [person.firstName, depth, descendant.firstName])
+
+  it('should return the ancestor at the top of the longest line', async () => {
+    const response = await getDeepest()
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.depth).toBe(4)
+    expect(summarize(data)).toEqual([['AdoptiveGreat', 4, 'Kid']])
+  })
+
+  it('should ignore adoptive edges when kinship=blood', async () => {
+    const data = await (await getDeepest('?kinship=blood')).json()
+
+    expect(data.depth).toBe(3)
+    expect(summarize(data)).toEqual([['GreatGrandpa', 3, 'Kid']])
+  })
+
+  it('should return every ancestor tied for the deepest line', async () => {
+    sqlite.prepare("DELETE FROM relationships WHERE relation_kind = 'adoptive'").run()
+    sqlite.prepare("INSERT INTO people (id, first_name, last_name) VALUES (8, 'OtherGreat', 'Jones')").run()
+    sqlite.prepare("INSERT INTO relationships (person1_id, person2_id, type, parent_role) VALUES (8, 3, 'parentOf', 'father')").run()
+
+    const data = await (await getDeepest()).json()
+
+    expect(summarize(data)).toEqual([['GreatGrandpa', 3, 'Kid'], ['OtherGreat', 3, 'Kid']])
+  })
+
+  it('should return 400 for an invalid kinship value', async () => {
+    expect((await getDeepest('?kinship=step')).status).toBe(400)
+  })
+})
@@ -0,0 +1,83 @@
+/**
+ * Integration Tests for Pinch Points API
+ *
+ * Tests GET /api/tree/pinch-points endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../routes/api/tree/pinch-points/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/tree/pinch-points', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    // Great-grandpa(1) -> Grandpa(2); Grandpa(2) + Grandma(3) -> Dad(4), Aunt(5)
+    // Dad(4) + Mom(6) -> Kid(7), Kid(8); Aunt(5) + Uncle(9) -> Cousin(10)
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender)
+      VALUES (?, ?, ?, ?)
+    `)
+    const names = ['GreatGrandpa', 'Grandpa', 'Grandma', 'Dad', 'Aunt', 'Mom', 'Kid', 'Kid2', 'Uncle', 'Cousin']
+    const genders = ['male', 'male', 'female', 'male', 'female', 'female', 'male', 'female', 'male', 'female']
+    names.forEach((name, index) => insertPerson.run(index + 1, name, 'Smith', genders[index]))
+
+    const insertParent = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, 'parentOf', ?)
+    `)
+    insertParent.run(1, 2, 'father')
+    insertParent.run(2, 4, 'father')
+    insertParent.run(3, 4, 'mother')
+    insertParent.run(2, 5, 'father')
+    insertParent.run(3, 5, 'mother')
+    insertParent.run(4, 7, 'father')
+    insertParent.run(6, 7, 'mother')
+    insertParent.run(4, 8, 'father')
+    insertParent.run(6, 8, 'mother')
+    insertParent.run(5, 10, 'mother')
+    insertParent.run(9, 10, 'father')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const getPinchPoints = (query = '') =>
+    GET(createMockEvent(db, { url: new URL(`http://localhost/api/tree/pinch-points${query}`) }))
+
+  it('should report the couple every descendant funnels through', async () => {
+    const response = await getPinchPoints()
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.threshold).toBe(1)
+    expect(data.leafCount).toBe(3)
+    expect(data.pinchPoints.map(({ person, leaves, coverage }) => [person.firstName, leaves, coverage])).toEqual([
+      ['Grandpa', 3, 1],
+      ['Grandma', 3, 1]
+    ])
+  })
+
+  it('should include branches covering most descendants at a lower threshold', async () => {
+    const data = await (await getPinchPoints('?threshold=0.6')).json()
+
+    expect(data.pinchPoints.map(({ person }) => person.firstName)).toEqual(['Grandpa', 'Grandma', 'Dad', 'Mom'])
+  })
+
+  it('should return 400 for an invalid threshold', async () => {
+    expect((await getPinchPoints('?threshold=0')).status).toBe(400)
+    expect((await getPinchPoints('?threshold=1.5')).status).toBe(400)
+    expect((await getPinchPoints('?threshold=most')).status).toBe(400)
+  })
+})
@@ -0,0 +1,110 @@
+/**
+ * Integration Tests for Relate Branches API
+ *
+ * Tests POST /api/tree/relate-branches endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { POST } from '../../../../routes/api/tree/relate-branches/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('POST /api/tree/relate-branches', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    // Smith line: Smith(1) -> Son(2); Jones line: Jones(3) -> Daughter(4)
+    // Son(2) + Daughter(4) married; Brown(5) -> BrownSon(6) is a separate line
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'Elder', 'Smith', 'male')
+    insertPerson.run(2, 'Son', 'Smith', 'male')
+    insertPerson.run(3, 'Elder', 'Jones', 'male')
+    insertPerson.run(4, 'Daughter', 'Jones', 'female')
+    insertPerson.run(5, 'Elder', 'Brown', 'male')
+    insertPerson.run(6, 'Son', 'Brown', 'male')
+
+    const insertRelationship = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertRelationship.run(1, 2, 'parentOf', 'father')
+    insertRelationship.run(3, 4, 'parentOf', 'father')
+    insertRelationship.run(5, 6, 'parentOf', 'father')
+    insertRelationship.run(2, 4, 'spouse', null)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  function relate(body) {
+    return POST(createMockEvent(db, {
+      request: { json: async () => body },
+      url: new URL('http://localhost/api/tree/relate-branches')
+    }))
+  }
+
+  it('should return the shared descendant of two lines joined by a marriage', async () => {
+    sqlite.prepare(`INSERT INTO people (id, first_name, last_name) VALUES (7, 'Grandchild', 'Smith')`).run()
+    sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (2, 7, 'parentOf', 'father'), (4, 7, 'parentOf', 'mother')
+    `).run()
+
+    const response = await relate({ rootIds: [1, 3] })
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data.merged).toBe(true)
+    expect(data.closest).toBeNull()
+    expect(data.sharedDescendants).toHaveLength(1)
+    expect(data.sharedDescendants[0]).toMatchObject({
+      person: { id: 7, firstName: 'Grandchild' },
+      generationsFromA: 2,
+      generationsFromB: 2
+    })
+  })
+
+  it('should return the closest members when the lines are only joined by marriage', async () => {
+    const response = await relate({ rootIds: [1, 3] })
+    const data = await response.json()
+
+    expect(data.merged).toBe(false)
+    expect(data.sharedDescendants).toEqual([])
+    expect(data.closest).toMatchObject({
+      from: { id: 2 },
+      to: { id: 4 },
+      steps: 1,
+      path: [2, 4],
+      label: 'wife'
+    })
+  })
+
+  it('should return no connection for unrelated lines', async () => {
+    const data = await (await relate({ rootIds: [1, 5] })).json()
+
+    expect(data).toMatchObject({ rootIds: [1, 5], merged: false, sharedDescendants: [], closest: null })
+  })
+
+  it('should return 400 for a body without two root IDs', async () => {
+    expect((await relate({ rootIds: [1] })).status).toBe(400)
+    expect((await relate({ rootIds: [1, 1] })).status).toBe(400)
+    expect((await relate({})).status).toBe(400)
+  })
+
+  it('should return 404 when a root does not exist', async () => {
+    expect((await relate({ rootIds: [1, 999] })).status).toBe(404)
+  })
+})
@@ -0,0 +1,83 @@
+/**
+ * Integration Tests for Tree Graph API
+ *
+ * Tests GET /api/tree endpoint, including MessagePack content negotiation
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../routes/api/tree/+server.js'
+import { decodeMsgpack } from '$lib/server/msgpack.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/tree', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    const insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, gender, birth_date, notes)
+      VALUES (?, ?, ?, ?, ?, ?)
+    `)
+    insertPerson.run(1, 'John', 'Doe', 'male', '1950-01-01', 'Born in Zürich')
+    insertPerson.run(2, 'Jane', 'Doe', 'female', null, null)
+    insertPerson.run(3, 'Baby', 'Doe', null, '1980-06-15', null)
+    insertPerson.run(4, 'Deleted', 'Doe', null, null, null)
+
+    const insertRelationship = sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, ?, ?)
+    `)
+    insertRelationship.run(1, 2, 'spouse', null)
+    insertRelationship.run(1, 3, 'parentOf', 'father')
+    insertRelationship.run(2, 3, 'parentOf', 'mother')
+    insertRelationship.run(4, 3, 'parentOf', 'father')
+    sqlite.prepare('UPDATE people SET deleted_at = CURRENT_TIMESTAMP WHERE id = 4').run()
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const getTree = (accept) =>
+    GET(createMockEvent(db, {
+      request: new Request('http://localhost/api/tree', { headers: accept ? { Accept: accept } : {} })
+    }))
+
+  it('should return nodes and edges as JSON by default', async () => {
+    const response = await getTree()
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(response.headers.get('Content-Type')).toContain('application/json')
+    expect(data.nodes.map((node) => node.id)).toEqual([1, 2, 3])
+    expect(data.edges).toHaveLength(3)
+  })
+
+  it('should return the same graph as MessagePack when requested', async () => {
+    const json = await (await getTree('application/json')).json()
+
+    const response = await getTree('application/msgpack')
+    const bytes = new Uint8Array(await response.arrayBuffer())
+
+    expect(response.status).toBe(200)
+    expect(response.headers.get('Content-Type')).toBe('application/msgpack')
+    expect(response.headers.get('Vary')).toBe('Accept')
+    expect(decodeMsgpack(bytes)).toEqual(json)
+    expect(bytes.length).toBeLessThan(JSON.stringify(json).length)
+  })
+
+  it('should fall back to JSON for other Accept values', async () => {
+    const response = await getTree('text/html, application/msgpack;q=0')
+
+    expect(response.headers.get('Content-Type')).toContain('application/json')
+  })
+})
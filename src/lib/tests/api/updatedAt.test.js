@@ -0,0 +1,104 @@
+/**
+ * Integration Tests for updatedAt timestamps
+ *
+ * Tests that people and relationships report when they last changed:
+ * updatedAt starts at createdAt, moves on update, and is untouched by reads
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { POST as createPerson } from '../../../routes/api/people/+server.js'
+import { GET as getPerson, PUT as updatePerson } from '../../../routes/api/people/[id]/+server.js'
+import { GET as getRelationship, PUT as updateRelationship } from '../../../routes/api/relationships/[id]/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+const OLD_TIMESTAMP = '2020-01-01 00:00:00'
+const OLD_RFC3339 = '2020-01-01T00:00:00Z'
+
+describe('updatedAt', () => {
+  let db
+  let sqlite
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    sqlite.prepare("INSERT INTO people (id, first_name, last_name) VALUES (1, 'John', 'Smith'), (2, 'Mary', 'Smith')").run()
+    sqlite.prepare("INSERT INTO relationships (id, person1_id, person2_id, type) VALUES (1, 1, 2, 'spouse')").run()
+
+    // Backdate both rows so a change within the same second is still visible
+    sqlite.prepare('UPDATE people SET created_at = ?, updated_at = ?').run(OLD_TIMESTAMP, OLD_TIMESTAMP)
+    sqlite.prepare('UPDATE relationships SET created_at = ?, updated_at = ?').run(OLD_TIMESTAMP, OLD_TIMESTAMP)
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const jsonRequest = (body) => ({ json: async () => body })
+
+  it('should start equal to createdAt for new rows', async () => {
+    const response = await createPerson(createMockEvent(db, {
+      request: jsonRequest({ firstName: 'Ann', lastName: 'Lee' })
+    }))
+    const data = await response.json()
+
+    expect(response.status).toBe(201)
+    expect(data.updatedAt).toMatch(/^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z$/)
+    expect(data.updatedAt).toBe(data.createdAt)
+
+    // Rows inserted with raw SQL get the same default
+    const row = sqlite.prepare("INSERT INTO people (first_name, last_name) VALUES ('Raw', 'Row') RETURNING id").get()
+    const raw = sqlite.prepare('SELECT created_at, updated_at FROM people WHERE id = ?').get(row.id)
+    expect(raw.updated_at).toBe(raw.created_at)
+  })
+
+  it('should change a person\'s updatedAt on update and not on read', async () => {
+    const before = await (await getPerson(createMockEvent(db, { params: { id: '1' } }))).json()
+    expect(before.updatedAt).toBe(OLD_RFC3339)
+
+    // A read leaves the timestamp alone
+    const reread = await (await getPerson(createMockEvent(db, { params: { id: '1' } }))).json()
+    expect(reread.updatedAt).toBe(OLD_RFC3339)
+
+    const response = await updatePerson(createMockEvent(db, {
+      params: { id: '1' },
+      request: jsonRequest({ firstName: 'Johnny', lastName: 'Smith' })
+    }))
+    const updated = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(updated.createdAt).toBe(OLD_RFC3339)
+    expect(updated.updatedAt).not.toBe(OLD_RFC3339)
+
+    const after = await (await getPerson(createMockEvent(db, { params: { id: '1' } }))).json()
+    expect(after.updatedAt).toBe(updated.updatedAt)
+
+    // The unrelated person is untouched
+    const other = await (await getPerson(createMockEvent(db, { params: { id: '2' } }))).json()
+    expect(other.updatedAt).toBe(OLD_RFC3339)
+  })
+
+  it('should change a relationship\'s updatedAt on update and not on read', async () => {
+    const before = await (await getRelationship(createMockEvent(db, { params: { id: '1' } }))).json()
+    expect(before.updatedAt).toBe(OLD_RFC3339)
+
+    const response = await updateRelationship(createMockEvent(db, {
+      params: { id: '1' },
+      request: jsonRequest({ person1Id: 1, person2Id: 2, type: 'spouse', startDate: '1990-06-01' })
+    }))
+    const updated = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(updated.createdAt).toBe(OLD_RFC3339)
+    expect(updated.updatedAt).not.toBe(OLD_RFC3339)
+
+    const after = await (await getRelationship(createMockEvent(db, { params: { id: '1' } }))).json()
+    expect(after.updatedAt).toBe(updated.updatedAt)
+  })
+})
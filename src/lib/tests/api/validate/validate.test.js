@@ -0,0 +1,143 @@
+/**
+ * Integration Tests for Tree Validation API
+ *
+ * Tests GET /api/validate endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from '../../../../routes/api/validate/+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /api/validate', () => {
+  let db
+  let sqlite
+  let insertPerson
+  let insertRelationship
+
+  beforeEach(async () => {
+    // Create in-memory database for testing
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+
+    // Setup test database
+    await setupTestDatabase(sqlite, db)
+
+    insertPerson = sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name, birth_date, death_date)
+      VALUES (?, ?, ?, ?, ?)
+    `)
+    insertRelationship = sqlite.prepare(`
+      INSERT INTO relationships (id, person1_id, person2_id, type, parent_role)
+      VALUES (?, ?, ?, ?, ?)
+    `)
+
+    // A consistent family that must not be reported
+    insertPerson.run(1, 'John', 'Smith', '1900-01-01', '1970-01-01')
+    insertPerson.run(2, 'Mary', 'Smith', '1905-01-01', null)
+    insertPerson.run(3, 'Jack', 'Smith', '1930-01-01', null)
+    insertRelationship.run(1, 1, 3, 'parentOf', 'father')
+    insertRelationship.run(2, 2, 3, 'parentOf', 'mother')
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const validate = async () => {
+    const response = await GET(createMockEvent(db))
+    expect(response.status).toBe(200)
+    return response.json()
+  }
+
+  const counts = () => ({
+    people: sqlite.prepare('SELECT COUNT(*) AS count FROM people').get().count,
+    relationships: sqlite.prepare('SELECT COUNT(*) AS count FROM relationships').get().count
+  })
+
+  it('should report no issues for a consistent tree', async () => {
+    expect(await validate()).toEqual({ issueCount: 0, issues: [] })
+  })
+
+  it('should report a person who died before they were born', async () => {
+    insertPerson.run(10, 'Early', 'Death', '1950-01-01', '1940-01-01')
+
+    const data = await validate()
+
+    expect(data.issues).toEqual([
+      {
+        type: 'deathBeforeBirth',
+        message: 'Early Death (#10) died (1940-01-01) before they were born (1950-01-01)',
+        personIds: [10],
+        relationshipIds: []
+      }
+    ])
+  })
+
+  it('should report a parent younger than their child', async () => {
+    insertPerson.run(10, 'Young', 'Parent', '1990-01-01', null)
+    insertRelationship.run(10, 10, 1, 'parentOf', 'father')
+
+    const data = await validate()
+
+    expect(data.issues).toEqual([
+      expect.objectContaining({ type: 'parentYoungerThanChild', personIds: [10, 1], relationshipIds: [10] })
+    ])
+  })
+
+  it('should report a child with two biological mothers', async () => {
+    insertPerson.run(10, 'Second', 'Mother', '1906-01-01', null)
+
+    // Bypass the API's parent-role check, as old imports could
+    insertRelationship.run(10, 10, 3, 'parentOf', 'mother')
+
+    const data = await validate()
+
+    expect(data.issues).toEqual([
+      expect.objectContaining({ type: 'multipleBiologicalParents', personIds: [3, 2, 10], relationshipIds: [2, 10] })
+    ])
+    expect(data.issues[0].message).toContain('2 biological mothers')
+  })
+
+  it('should report a parent cycle', async () => {
+    insertPerson.run(10, 'Loop', 'A', null, null)
+    insertPerson.run(11, 'Loop', 'B', null, null)
+    insertRelationship.run(10, 10, 11, 'parentOf', 'father')
+    insertRelationship.run(11, 11, 10, 'parentOf', 'father')
+
+    const data = await validate()
+
+    expect(data.issues).toEqual([
+      expect.objectContaining({ type: 'parentCycle', personIds: [10, 11], relationshipIds: [10, 11] })
+    ])
+  })
+
+  it('should report a self-relationship', async () => {
+    insertRelationship.run(10, 1, 1, 'spouse', null)
+
+    const data = await validate()
+
+    expect(data.issues).toEqual([
+      {
+        type: 'selfRelationship',
+        message: 'John Smith (#1) has a spouse relationship with themselves',
+        personIds: [1],
+        relationshipIds: [10]
+      }
+    ])
+  })
+
+  it('should skip soft-deleted people and leave the data unchanged', async () => {
+    insertPerson.run(10, 'Early', 'Death', '1950-01-01', '1940-01-01')
+    insertPerson.run(11, 'Young', 'Parent', '1990-01-01', null)
+    insertRelationship.run(10, 11, 1, 'parentOf', 'father')
+    sqlite.prepare('UPDATE people SET deleted_at = CURRENT_TIMESTAMP WHERE id = 11').run()
+    const before = counts()
+
+    const data = await validate()
+
+    expect(data.issues.map((issue) => issue.type)).toEqual(['deathBeforeBirth'])
+    expect(counts()).toEqual(before)
+  })
+})
@@ -0,0 +1,30 @@
+/**
+ * POST /api/admin/fix-parent-directions
+ * Repairs parentOf relationships stored the wrong way round
+ *
+ * A relationship is inverted when both people have birth dates and the
+ * stored parent was clearly born after the stored child. Each inverted
+ * relationship is reversed unless that would create a parent cycle, give the
+ * child a second biological mother or father, or the new parent's gender
+ * does not determine mother or father; those are reported as skipped.
+ *
+ * @returns {Response} JSON { fixed: [{ relationshipId, parentId, childId, parentRole }],
+ *   skipped: [{ relationshipId, reason }] }
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { fixParentDirections } from '$lib/server/parentDirectionRepair.js'
+import { internalError } from '$lib/server/errors.js'
+
+export async function POST({ locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    return json(fixParentDirections(database))
+  } catch (error) {
+    console.error('Error fixing parent directions:', error)
+    return internalError(error)
+  }
+}
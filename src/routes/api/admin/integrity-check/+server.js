@@ -0,0 +1,31 @@
+/**
+ * POST /api/admin/integrity-check
+ * Ops tool: runs SQLite's integrity_check and foreign_key_check and reports
+ * any problems found
+ *
+ * Query Parameters:
+ *   - repair: When "true", relationships referencing missing people are
+ *     deleted and the foreign key check is re-run
+ *
+ * @returns {Response} JSON { ok, integrityCheck, foreignKeyViolations, repairedRelationships }
+ *   integrityCheck is ["ok"] for a healthy database
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { runIntegrityCheck } from '$lib/server/integrityCheck.js'
+import { internalError } from '$lib/server/errors.js'
+
+export async function POST({ locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    const repair = url?.searchParams?.get('repair') === 'true'
+
+    return json(runIntegrityCheck(database, { repair }))
+  } catch (error) {
+    console.error('Error running integrity check:', error)
+    return internalError(error)
+  }
+}
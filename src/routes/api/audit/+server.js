@@ -0,0 +1,39 @@
+/**
+ * GET /api/audit
+ * Returns recent audit log entries, newest first
+ *
+ * Every create, update, delete, restore, and merge made through the people
+ * and relationships API writes an entry in the same transaction as the
+ * change (see $lib/server/auditLog.js for payload shapes).
+ *
+ * Query parameters:
+ * - limit: maximum number of entries to return (1-500, default 50)
+ *
+ * @returns {Response} JSON { entries: [{ id, entityType, entityId, action, payload, createdAt }] }
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { listAuditEntries } from '$lib/server/auditLog.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+const DEFAULT_LIMIT = 50
+const MAX_LIMIT = 500
+
+export async function GET({ locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    const limitParam = url?.searchParams?.get('limit')
+    const limit = limitParam ? Number(limitParam) : DEFAULT_LIMIT
+    if (!Number.isInteger(limit) || limit < 1 || limit > MAX_LIMIT) {
+      return jsonError(400, `Invalid limit parameter (must be 1-${MAX_LIMIT})`)
+    }
+
+    return json({ entries: listAuditEntries(database, limit) })
+  } catch (error) {
+    console.error('Error reading audit log:', error)
+    return internalError(error)
+  }
+}
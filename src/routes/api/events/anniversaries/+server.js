@@ -0,0 +1,64 @@
+/**
+ * GET /api/events/anniversaries
+ * Returns couples whose wedding anniversary falls within the next N days
+ *
+ * Anniversaries come from the start_date (marriage date) of spouse
+ * relationships. Marriages with an end_date, without a full marriage date,
+ * or where either spouse is soft-deleted or presumed deceased
+ * (see isPersonLiving) are skipped.
+ *
+ * Query parameters:
+ * - within: window length in days (0-366, default 30; 0 means today only)
+ *
+ * @returns {Response} JSON { within, anniversaries: [{ relationshipId, spouses: [person, person],
+ *   marriageDate, date, daysUntil, years }] } ordered soonest first; date is the
+ *   upcoming anniversary (YYYY-MM-DD)
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { people, relationships } from '$lib/db/schema.js'
+import { eq, isNull } from 'drizzle-orm'
+import { transformPersonToAPI } from '$lib/server/personHelpers.js'
+import { findUpcomingAnniversaries } from '$lib/server/upcomingEvents.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+const DEFAULT_WITHIN_DAYS = 30
+const MAX_WITHIN_DAYS = 366
+
+export async function GET({ locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    const withinParam = url?.searchParams?.get('within')
+    const within = withinParam ? Number(withinParam) : DEFAULT_WITHIN_DAYS
+    if (!Number.isInteger(within) || within < 0 || within > MAX_WITHIN_DAYS) {
+      return jsonError(400, `Invalid within parameter (must be 0-${MAX_WITHIN_DAYS})`)
+    }
+
+    const activePeople = await database
+      .select()
+      .from(people)
+      .where(isNull(people.deletedAt))
+
+    const spouseRelationships = await database
+      .select()
+      .from(relationships)
+      .where(eq(relationships.type, 'spouse'))
+
+    const peopleById = new Map(activePeople.map((person) => [person.id, person]))
+    const anniversaries = findUpcomingAnniversaries(spouseRelationships, peopleById, new Date(), within)
+
+    return json({
+      within,
+      anniversaries: anniversaries.map((anniversary) => ({
+        ...anniversary,
+        spouses: anniversary.spouses.map(transformPersonToAPI)
+      }))
+    })
+  } catch (error) {
+    console.error('Error finding upcoming anniversaries:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,57 @@
+/**
+ * GET /api/events/birthdays
+ * Returns living people whose birthday falls within the next N days
+ *
+ * Birthdays are matched on the month and day of birth_date, across the
+ * year boundary (late December windows include early January). People with
+ * no birth date, a partial birth date, or who are presumed deceased
+ * (see isPersonLiving) are skipped.
+ *
+ * Query parameters:
+ * - within: window length in days (0-366, default 30; 0 means today only)
+ *
+ * @returns {Response} JSON { within, birthdays: [{ person, date, daysUntil, turningAge }] }
+ *   ordered soonest first; date is the upcoming birthday (YYYY-MM-DD)
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { people } from '$lib/db/schema.js'
+import { isNull } from 'drizzle-orm'
+import { transformPersonToAPI } from '$lib/server/personHelpers.js'
+import { findUpcomingBirthdays } from '$lib/server/upcomingEvents.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+const DEFAULT_WITHIN_DAYS = 30
+const MAX_WITHIN_DAYS = 366
+
+export async function GET({ locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    const withinParam = url?.searchParams?.get('within')
+    const within = withinParam ? Number(withinParam) : DEFAULT_WITHIN_DAYS
+    if (!Number.isInteger(within) || within < 0 || within > MAX_WITHIN_DAYS) {
+      return jsonError(400, `Invalid within parameter (must be 0-${MAX_WITHIN_DAYS})`)
+    }
+
+    const allPeople = await database
+      .select()
+      .from(people)
+      .where(isNull(people.deletedAt))
+
+    const birthdays = findUpcomingBirthdays(allPeople, new Date(), within)
+
+    return json({
+      within,
+      birthdays: birthdays.map((birthday) => ({
+        ...birthday,
+        person: transformPersonToAPI(birthday.person)
+      }))
+    })
+  } catch (error) {
+    console.error('Error finding upcoming birthdays:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,41 @@
+/**
+ * GET /api/export/json
+ * Exports all people and relationships as one JSON document
+ *
+ * Query Parameters:
+ *   - stable: When "true", output is deterministic so two exports of
+ *     unchanged data are byte-identical (suitable for committing the tree
+ *     to version control): records ordered by ID, object keys sorted,
+ *     indented, and no exportedAt timestamp
+ *
+ * @returns {Response} JSON { exportedAt, people, relationships },
+ *   or { people, relationships } when stable
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { loadTree, stableStringify } from '$lib/server/treeExport.js'
+import { internalError } from '$lib/server/errors.js'
+
+export async function GET({ locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    const stable = url?.searchParams?.get('stable') === 'true'
+
+    const tree = await loadTree(database)
+
+    if (stable) {
+      return new Response(stableStringify(tree), {
+        status: 200,
+        headers: { 'Content-Type': 'application/json' }
+      })
+    }
+
+    return json({ exportedAt: new Date().toISOString(), ...tree })
+  } catch (error) {
+    console.error('Error exporting JSON:', error)
+    return internalError(error)
+  }
+}
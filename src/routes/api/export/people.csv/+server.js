@@ -0,0 +1,83 @@
+/**
+ * GET /api/export/people.csv
+ * Exports every (non-deleted) person as CSV
+ *
+ * Columns: id, firstName, lastName, birthDate, deathDate, gender
+ * (the layout accepted by POST /api/import/people.csv)
+ *
+ * Rows are streamed in ID order, one page at a time, so the whole table is
+ * never held in memory. Pages are fetched with keyset pagination rather than
+ * an open cursor, since better-sqlite3 blocks the connection while an
+ * iterator is active.
+ *
+ * @returns {Response} CSV file download (Content-Type: text/csv)
+ */
+
+import { db } from '$lib/db/client.js'
+import { people } from '$lib/db/schema.js'
+import { and, gt, isNull } from 'drizzle-orm'
+import { toCsvLine } from '$lib/server/csv.js'
+import { PEOPLE_CSV_COLUMNS } from '$lib/server/peopleCsv.js'
+import { internalError } from '$lib/server/errors.js'
+
+// Rows fetched per stream pull
+const PAGE_SIZE = 500
+
+export async function GET({ locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+    const encoder = new TextEncoder()
+    let lastId = 0
+
+    const stream = new ReadableStream({
+      start(controller) {
+        controller.enqueue(encoder.encode(toCsvLine(PEOPLE_CSV_COLUMNS)))
+      },
+      pull(controller) {
+        try {
+          const page = database
+            .select({
+              id: people.id,
+              firstName: people.firstName,
+              lastName: people.lastName,
+              birthDate: people.birthDate,
+              deathDate: people.deathDate,
+              gender: people.gender
+            })
+            .from(people)
+            .where(and(gt(people.id, lastId), isNull(people.deletedAt)))
+            .orderBy(people.id)
+            .limit(PAGE_SIZE)
+            .all()
+
+          if (page.length > 0) {
+            const chunk = page
+              .map((person) => toCsvLine(PEOPLE_CSV_COLUMNS.map((header) => person[header])))
+              .join('')
+            controller.enqueue(encoder.encode(chunk))
+            lastId = page[page.length - 1].id
+          }
+
+          if (page.length < PAGE_SIZE) {
+            controller.close()
+          }
+        } catch (error) {
+          console.error('Error streaming people CSV:', error)
+          controller.error(error)
+        }
+      }
+    })
+
+    return new Response(stream, {
+      status: 200,
+      headers: {
+        'Content-Type': 'text/csv',
+        'Content-Disposition': 'attachment; filename="people.csv"'
+      }
+    })
+  } catch (error) {
+    console.error('Error exporting people CSV:', error)
+    return internalError(error)
+  }
+}
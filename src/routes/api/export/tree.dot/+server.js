@@ -0,0 +1,45 @@
+/**
+ * GET /api/export/tree.dot
+ * Exports the family tree as a Graphviz DOT digraph
+ *
+ * Render with e.g. `dot -Tsvg tree.dot -o tree.svg`.
+ *
+ * @returns {Response} DOT file (Content-Type: text/vnd.graphviz)
+ */
+
+import { db } from '$lib/db/client.js'
+import { people, relationships } from '$lib/db/schema.js'
+import { isNull } from 'drizzle-orm'
+import { buildDotGraph } from '$lib/server/dotExporter.js'
+import { internalError } from '$lib/server/errors.js'
+
+export async function GET({ locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Fetch all people (excluding soft-deleted)
+    const allPeople = await database
+      .select()
+      .from(people)
+      .where(isNull(people.deletedAt))
+
+    // Fetch relationships between exported people only
+    const exportedIds = new Set(allPeople.map((p) => p.id))
+    const allRelationships = (await database
+      .select()
+      .from(relationships))
+      .filter((r) => exportedIds.has(r.person1Id) && exportedIds.has(r.person2Id))
+
+    return new Response(buildDotGraph(allPeople, allRelationships), {
+      status: 200,
+      headers: {
+        'Content-Type': 'text/vnd.graphviz',
+        'Content-Disposition': 'attachment; filename="familytree.dot"'
+      }
+    })
+  } catch (error) {
+    console.error('Error exporting DOT graph:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,59 @@
+/**
+ * GET /api/export/tree.mermaid
+ * Exports the family tree as a Mermaid `graph TD` definition
+ *
+ * Query parameters:
+ * - rootId: Only export this person and their descendants
+ *
+ * @returns {Response} Mermaid text (Content-Type: text/plain)
+ */
+
+import { db } from '$lib/db/client.js'
+import { people, relationships } from '$lib/db/schema.js'
+import { isNull } from 'drizzle-orm'
+import { buildFamilyGraph, getDescendants } from '$lib/server/familyGraph.js'
+import { buildMermaidGraph } from '$lib/server/mermaidExporter.js'
+import { parseId } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function GET({ locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    const rootParam = url?.searchParams?.get('rootId')
+    const rootId = rootParam ? parseId(rootParam) : null
+    if (rootParam && rootId === null) {
+      return jsonError(400, 'Invalid rootId')
+    }
+
+    // Fetch all people (excluding soft-deleted) and all relationships
+    let exportedPeople = await database
+      .select()
+      .from(people)
+      .where(isNull(people.deletedAt))
+
+    const allRelationships = await database
+      .select()
+      .from(relationships)
+
+    // Limit to the root person's descendants
+    if (rootId !== null) {
+      const graph = buildFamilyGraph(exportedPeople, allRelationships)
+      if (!graph.people.has(rootId)) {
+        return jsonError(404, 'Person not found')
+      }
+
+      const subtreeIds = new Set([rootId, ...getDescendants(graph, rootId).keys()])
+      exportedPeople = exportedPeople.filter((person) => subtreeIds.has(person.id))
+    }
+
+    return new Response(buildMermaidGraph(exportedPeople, allRelationships), {
+      status: 200,
+      headers: { 'Content-Type': 'text/plain; charset=utf-8' }
+    })
+  } catch (error) {
+    console.error('Error exporting Mermaid graph:', error)
+    return internalError(error)
+  }
+}
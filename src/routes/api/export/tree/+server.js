@@ -0,0 +1,43 @@
+/**
+ * GET /api/export/tree
+ * Exports the whole tree as a single JSON backup document
+ *
+ * The document can be restored with POST /api/import/tree.
+ *
+ * @returns {Response} JSON { version, exportedAt, people, relationships }
+ *   served as an attachment
+ */
+
+import { db } from '$lib/db/client.js'
+import { loadTree, TREE_EXPORT_VERSION } from '$lib/server/treeExport.js'
+import { internalError } from '$lib/server/errors.js'
+
+export async function GET({ locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    const tree = await loadTree(database)
+    const exportedAt = new Date().toISOString()
+
+    const document = {
+      version: TREE_EXPORT_VERSION,
+      exportedAt,
+      people: tree.people,
+      relationships: tree.relationships
+    }
+
+    const date = exportedAt.slice(0, 10).replace(/-/g, '')
+
+    return new Response(JSON.stringify(document), {
+      status: 200,
+      headers: {
+        'Content-Type': 'application/json',
+        'Content-Disposition': `attachment; filename="familytree_${date}.json"`
+      }
+    })
+  } catch (error) {
+    console.error('Error exporting tree:', error)
+    return internalError(error)
+  }
+}
@@ -11,6 +11,8 @@
 import { people, relationships } from '$lib/db/schema.js'
 import { buildGedcomFile } from '$lib/server/gedcomExporter.js'
 import { db } from '$lib/db/client.js'
+import { isNull } from 'drizzle-orm'
+import { jsonError, internalError } from '$lib/server/errors.js'
 
 /**
  * GET /api/gedcom/export
@@ -34,20 +36,21 @@ export async function GET(event) {
 
     // Validate format
     if (format !== '5.5.1' && format !== '7.0') {
-      return new Response('Invalid format parameter. Must be "5.5.1" or "7.0"', {
-        status: 400
-      })
+      return jsonError(400, 'Invalid format parameter. Must be "5.5.1" or "7.0"')
     }
 
-    // Fetch all people
+    // Fetch all people (excluding soft-deleted)
     const allPeople = await database
       .select()
       .from(people)
+      .where(isNull(people.deletedAt))
 
-    // Fetch all relationships
-    const allRelationships = await database
+    // Fetch relationships between exported people only
+    const exportedIds = new Set(allPeople.map((p) => p.id))
+    const allRelationships = (await database
       .select()
-      .from(relationships)
+      .from(relationships))
+      .filter((r) => exportedIds.has(r.person1Id) && exportedIds.has(r.person2Id))
 
     // Generate GEDCOM file
     const exportDate = new Date().toISOString().split('T')[0] // YYYY-MM-DD
@@ -71,6 +74,6 @@ export async function GET(event) {
     })
   } catch (error) {
     console.error('GET /api/gedcom/export error:', error)
-    return new Response('Internal Server Error', { status: 500 })
+    return internalError(error)
   }
 }
@@ -9,13 +9,15 @@
 import { json } from '@sveltejs/kit'
 import { db } from '$lib/db/client.js'
 import { people, relationships } from '$lib/db/schema.js'
-import { eq } from 'drizzle-orm'
+import { eq, isNull } from 'drizzle-orm'
 import { getPreviewData, getResolutionDecisions } from '$lib/server/gedcomPreview.js'
 import {
   prepareImportData,
   buildRelationshipsAfterInsertion,
   mapGedcomPersonToSchema
 } from '$lib/server/gedcomImporter.js'
+import { createImportBatchId, findImportMergeSuggestions } from '$lib/server/importBatches.js'
+import { jsonError } from '$lib/server/errors.js'
 
 /**
  * POST /api/gedcom/import/:uploadId
@@ -25,14 +27,25 @@ import {
  * - importAll: boolean - Import all individuals
  * - selectedIds: string[] (optional) - Specific GEDCOM IDs to import
  *
+ * Query parameters:
+ * - autoSuggestMerges: "true" to also return groups where imported persons
+ *   look like duplicates of existing persons, for the user to merge
+ *
  * Response:
  * - success: boolean
+ * - batchId: string - Import batch ID tagged on inserted persons and relationships
+ *   (merged updates to existing persons are not part of the batch)
  * - imported: { persons: number, relationships: number, updated: number }
+ * - mergeSuggestions: Array (only with autoSuggestMerges=true) - Duplicate groups
+ *   { name, personIds, confidence, importedPersonIds, existingPersonIds }
  * - errors: string[] (optional)
  */
-export async function POST({ request, params }) {
+export async function POST({ request, params, locals, url }) {
   const { uploadId } = params
 
+  // Use locals.db if provided (for testing), otherwise use singleton db
+  const database = locals?.db || db
+
   try {
     // Parse request body
     const body = await request.json()
@@ -41,10 +54,7 @@ export async function POST({ request, params }) {
     // Get preview data
     const previewData = await getPreviewData(uploadId)
     if (!previewData) {
-      return json(
-        { error: 'Preview data not found. Please upload and parse a GEDCOM file first.' },
-        { status: 404 }
-      )
+      return jsonError(404, 'Preview data not found. Please upload and parse a GEDCOM file first.')
     }
 
     // Get resolution decisions
@@ -56,10 +66,11 @@ export async function POST({ request, params }) {
       .map(d => d.existingPersonId)
 
     if (referencedPersonIds.length > 0) {
-      // Query database to get all person IDs
-      const allPersons = await db
+      // Query database to get all person IDs (excluding soft-deleted)
+      const allPersons = await database
         .select({ id: people.id })
         .from(people)
+        .where(isNull(people.deletedAt))
 
       const validPersonIds = new Set(allPersons.map(p => p.id))
 
@@ -93,12 +104,14 @@ export async function POST({ request, params }) {
     let personsUpdated = 0
     let relationshipsInserted = 0
 
+    const batchId = createImportBatchId()
+
     // Execute import in a transaction
     // Note: For better-sqlite3, the transaction callback must be synchronous
-    const transactionResult = db.transaction(() => {
+    const transactionResult = database.transaction(() => {
       // Step 1: Update existing persons (merges)
       for (const personUpdate of importData.personsToUpdate) {
-        db
+        database
           .update(people)
           .set(personUpdate.updates)
           .where(eq(people.id, personUpdate.personId))
@@ -117,9 +130,9 @@ export async function POST({ request, params }) {
         // Also check for 'id' field which may be used in some contexts
         const gedcomId = individual?.gedcomId || individual?._original?.gedcomId || individual?.id || individual?._original?.id
 
-        const insertedPerson = db
+        const insertedPerson = database
           .insert(people)
-          .values(personData)
+          .values({ ...personData, importBatch: batchId })
           .returning()
           .get()
 
@@ -140,7 +153,7 @@ export async function POST({ request, params }) {
       // Step 4: Insert relationships
       if (relationshipsToInsert.length > 0) {
         for (const relationship of relationshipsToInsert) {
-          db.insert(relationships).values(relationship).run()
+          database.insert(relationships).values({ ...relationship, importBatch: batchId }).run()
           relationshipsInserted++
         }
       }
@@ -157,15 +170,22 @@ export async function POST({ request, params }) {
     personsUpdated = transactionResult.personsUpdated
     relationshipsInserted = transactionResult.relationshipsInserted
 
-    // Return success response
-    return json({
+    const response = {
       success: true,
+      batchId,
       imported: {
         persons: personsInserted,
         updated: personsUpdated,
         relationships: relationshipsInserted
       }
-    })
+    }
+
+    if (url?.searchParams.get('autoSuggestMerges') === 'true') {
+      response.mergeSuggestions = await findImportMergeSuggestions(database, batchId)
+    }
+
+    // Return success response
+    return json(response)
   } catch (error) {
     console.error('Import error:', error)
 
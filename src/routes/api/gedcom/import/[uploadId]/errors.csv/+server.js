@@ -8,6 +8,7 @@
 
 import { getPreviewData } from '$lib/server/gedcomPreview.js'
 import { generateErrorLogCSV } from '$lib/server/gedcomErrorHandler.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
 
 /**
  * GET /api/gedcom/import/:uploadId/errors.csv
@@ -24,7 +25,7 @@ export async function GET({ params }) {
     const previewData = await getPreviewData(uploadId)
 
     if (!previewData) {
-      return new Response('Preview data not found', { status: 404 })
+      return jsonError(404, 'Preview data not found')
     }
 
     const errors = previewData.errors || []
@@ -53,6 +54,6 @@ export async function GET({ params }) {
     })
   } catch (error) {
     console.error('Error generating error log CSV:', error)
-    return new Response('Internal Server Error', { status: 500 })
+    return internalError(error)
   }
 }
@@ -19,6 +19,8 @@ import { storePreviewData } from '$lib/server/gedcomPreview.js'
 import { promises as fs } from 'fs'
 import { db } from '$lib/db/client.js'
 import { people } from '$lib/db/schema.js'
+import { isNull } from 'drizzle-orm'
+import { jsonError, internalError } from '$lib/server/errors.js'
 
 /**
  * POST /api/gedcom/parse/:uploadId
@@ -36,7 +38,7 @@ export async function POST({ params }) {
 
     if (!fileInfo.exists) {
       console.error('[GEDCOM Parse API] Upload not found:', uploadId)
-      return new Response('Upload not found', { status: 404 })
+      return jsonError(404, 'Upload not found')
     }
 
     // Read file content
@@ -47,16 +49,17 @@ export async function POST({ params }) {
 
     if (!parsed.success) {
       console.error('[GEDCOM Parse API] Parsing failed:', parsed.error)
-      return new Response(parsed.error, { status: 400 })
+      return jsonError(400, parsed.error)
     }
 
     // Extract statistics
     const statistics = extractStatistics(parsed)
 
-    // Get all existing people from database for duplicate detection
+    // Get all existing people from database for duplicate detection (excluding soft-deleted)
     const existingPeople = await db
       .select()
       .from(people)
+      .where(isNull(people.deletedAt))
 
     // Find duplicates
     const duplicates = findDuplicates(parsed.individuals, existingPeople)
@@ -92,6 +95,6 @@ export async function POST({ params }) {
     })
 
     console.error('[GEDCOM Parse API] Returning 500 Internal Server Error')
-    return new Response('Internal Server Error', { status: 500 })
+    return internalError(error)
   }
 }
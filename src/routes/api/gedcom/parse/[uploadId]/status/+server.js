@@ -14,6 +14,7 @@
 
 import { json } from '@sveltejs/kit'
 import { getTempFileInfo } from '$lib/server/gedcomStorage.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
 
 /**
  * GET /api/gedcom/parse/:uploadId/status
@@ -30,7 +31,7 @@ export async function GET({ params }) {
     const fileInfo = await getTempFileInfo(uploadId)
 
     if (!fileInfo.exists) {
-      return new Response('Upload not found', { status: 404 })
+      return jsonError(404, 'Upload not found')
     }
 
     // Return complete status
@@ -42,6 +43,6 @@ export async function GET({ params }) {
     })
   } catch (error) {
     console.error('Error getting parse status:', error)
-    return new Response('Internal Server Error', { status: 500 })
+    return internalError(error)
   }
 }
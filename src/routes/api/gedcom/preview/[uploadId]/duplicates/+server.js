@@ -9,6 +9,7 @@
 
 import { json } from '@sveltejs/kit'
 import { getPreviewData } from '$lib/server/gedcomPreview.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
 
 /**
  * Converts GEDCOM sex value to application gender value
@@ -112,7 +113,7 @@ export async function GET({ params }) {
     const previewData = await getPreviewData(uploadId)
 
     if (!previewData) {
-      return new Response('Preview data not found', { status: 404 })
+      return jsonError(404, 'Preview data not found')
     }
 
     // Format duplicates for comparison display
@@ -136,6 +137,6 @@ export async function GET({ params }) {
     return json({ duplicates: formattedDuplicates })
   } catch (error) {
     console.error('Error fetching duplicates:', error)
-    return new Response('Internal Server Error', { status: 500 })
+    return internalError(error)
   }
 }
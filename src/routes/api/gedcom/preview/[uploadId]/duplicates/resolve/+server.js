@@ -9,6 +9,7 @@
 
 import { json } from '@sveltejs/kit'
 import { saveResolutionDecisions } from '$lib/server/gedcomPreview.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
 
 /**
  * POST /api/gedcom/preview/:uploadId/duplicates/resolve
@@ -35,7 +36,7 @@ export async function POST({ request, params }) {
 
     // Validate request
     if (!decisions || !Array.isArray(decisions)) {
-      return new Response('Missing or invalid decisions array', { status: 400 })
+      return jsonError(400, 'Missing or invalid decisions array')
     }
 
     // Save resolution decisions
@@ -45,10 +46,10 @@ export async function POST({ request, params }) {
   } catch (error) {
     // Handle validation errors
     if (error.message.includes('Invalid resolution') || error.message.includes('not found')) {
-      return new Response(error.message, { status: error.message.includes('not found') ? 404 : 400 })
+      return jsonError(error.message.includes('not found') ? 404 : 400, error.message)
     }
 
     console.error('Error saving resolution decisions:', error)
-    return new Response('Internal Server Error', { status: 500 })
+    return internalError(error)
   }
 }
@@ -9,6 +9,7 @@
 
 import { json } from '@sveltejs/kit'
 import { getPreviewIndividuals } from '$lib/server/gedcomPreview.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
 
 /**
  * GET /api/gedcom/preview/:uploadId/individuals
@@ -46,12 +47,12 @@ export async function GET({ params, url }) {
     })
 
     if (!result) {
-      return new Response('Preview data not found', { status: 404 })
+      return jsonError(404, 'Preview data not found')
     }
 
     return json(result)
   } catch (error) {
     console.error('Error retrieving preview individuals:', error)
-    return new Response('Internal Server Error', { status: 500 })
+    return internalError(error)
   }
 }
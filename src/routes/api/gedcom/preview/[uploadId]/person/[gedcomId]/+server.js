@@ -9,6 +9,7 @@
 
 import { json } from '@sveltejs/kit'
 import { getPreviewPerson } from '$lib/server/gedcomPreview.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
 
 /**
  * GET /api/gedcom/preview/:uploadId/person/:gedcomId
@@ -25,12 +26,12 @@ export async function GET({ params }) {
     const result = await getPreviewPerson(uploadId, gedcomId)
 
     if (!result) {
-      return new Response('Person not found', { status: 404 })
+      return jsonError(404, 'Person not found')
     }
 
     return json(result)
   } catch (error) {
     console.error('Error retrieving preview person:', error)
-    return new Response('Internal Server Error', { status: 500 })
+    return internalError(error)
   }
 }
@@ -9,6 +9,7 @@
 
 import { json } from '@sveltejs/kit'
 import { getPreviewTree } from '$lib/server/gedcomPreview.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
 
 /**
  * GET /api/gedcom/preview/:uploadId/tree
@@ -25,12 +26,12 @@ export async function GET({ params }) {
     const result = await getPreviewTree(uploadId)
 
     if (!result) {
-      return new Response('Preview data not found', { status: 404 })
+      return jsonError(404, 'Preview data not found')
     }
 
     return json(result)
   } catch (error) {
     console.error('Error retrieving preview tree:', error)
-    return new Response('Internal Server Error', { status: 500 })
+    return internalError(error)
   }
 }
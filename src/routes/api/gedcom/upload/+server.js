@@ -18,6 +18,7 @@ import {
   saveUploadedFile,
   cleanupTempFile
 } from '$lib/server/gedcomStorage.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
 
 /**
  * POST /api/gedcom/upload
@@ -35,7 +36,7 @@ export async function POST({ request }) {
     try {
       formData = await request.formData()
     } catch (error) {
-      return new Response('Invalid form data', { status: 400 })
+      return jsonError(400, 'Invalid form data')
     }
 
     // Get uploaded file
@@ -43,21 +44,21 @@ export async function POST({ request }) {
 
     // Check if file exists and has File-like properties (name, size, arrayBuffer)
     if (!file || !file.name || typeof file.size !== 'number' || typeof file.arrayBuffer !== 'function') {
-      return new Response('No file provided', { status: 400 })
+      return jsonError(400, 'No file provided')
     }
 
     // Validate file type
     if (!validateFileType(file.name)) {
-      return new Response('Only .ged files are supported', { status: 400 })
+      return jsonError(400, 'Only .ged files are supported')
     }
 
     // Validate file size
     if (file.size === 0) {
-      return new Response('File is empty', { status: 400 })
+      return jsonError(400, 'File is empty')
     }
 
     if (!validateFileSize(file.size)) {
-      return new Response('File size exceeds 10MB limit', { status: 413 })
+      return jsonError(413, 'File size exceeds 10MB limit')
     }
 
     // Generate unique upload ID
@@ -72,7 +73,7 @@ export async function POST({ request }) {
     if (!saveResult.success) {
       // Clean up on error
       await cleanupTempFile(uploadId)
-      return new Response('Failed to save file', { status: 500 })
+      return jsonError(500, 'Failed to save file')
     }
 
     // Return success response with upload metadata
@@ -88,6 +89,6 @@ export async function POST({ request }) {
       await cleanupTempFile(uploadId)
     }
 
-    return new Response('Internal Server Error', { status: 500 })
+    return internalError(error)
   }
 }
@@ -0,0 +1,94 @@
+/**
+ * GET /api/home/[id]/kinships.csv
+ * Exports the kinship of everyone in the tree to the home person as CSV
+ *
+ * Columns: personId, name, relationship, degree
+ * - relationship: kinship label of the person relative to the home person
+ * - degree: steps along the kinship path (empty when unrelated)
+ *
+ * Served from the kinship cache when it has been filled for the home person
+ * (POST /api/home/[id]/recompute-kinships), otherwise computed live.
+ *
+ * @returns {Response} CSV file download (Content-Type: text/csv)
+ */
+
+import { db } from '$lib/db/client.js'
+import { loadFamilyGraph } from '$lib/server/familyGraph.js'
+import { describeKinshipToAll } from '$lib/server/kinship.js'
+import { getCachedKinships } from '$lib/server/kinshipCache.js'
+import { parseId } from '$lib/server/personHelpers.js'
+import { toCsv } from '$lib/server/csv.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+/**
+ * Builds CSV rows from the kinship cache
+ *
+ * @param {Object} database - Drizzle database instance
+ * @param {number} homeId - Home person ID
+ * @returns {Array|null} Rows, or null when nothing is cached
+ */
+function loadCachedRows(database, homeId) {
+  const cached = getCachedKinships(database, homeId)
+  if (!cached) return null
+
+  return cached.map((entry) => [
+    entry.personId,
+    [entry.firstName, entry.lastName].filter(Boolean).join(' '),
+    entry.label,
+    entry.degree
+  ])
+}
+
+/**
+ * Builds CSV rows by computing every kinship from the family graph
+ *
+ * @param {Object} database - Drizzle database instance
+ * @param {number} homeId - Home person ID
+ * @returns {Promise<Array|null>} Rows, or null when the home person does not exist
+ */
+async function computeRows(database, homeId) {
+  const graph = await loadFamilyGraph(database)
+
+  if (!graph.people.has(homeId)) return null
+
+  return describeKinshipToAll(graph, homeId).map((entry) => {
+    const person = graph.people.get(entry.personId)
+    return [
+      entry.personId,
+      [person.firstName, person.lastName].filter(Boolean).join(' '),
+      entry.label,
+      entry.degree
+    ]
+  })
+}
+
+export async function GET({ params, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const homeId = parseId(params.id)
+    if (homeId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    const rows = loadCachedRows(database, homeId) || await computeRows(database, homeId)
+    if (!rows) {
+      return jsonError(404, 'Person not found')
+    }
+
+    const csvContent = toCsv(['personId', 'name', 'relationship', 'degree'], rows)
+
+    return new Response(csvContent, {
+      status: 200,
+      headers: {
+        'Content-Type': 'text/csv',
+        'Content-Disposition': `attachment; filename="kinships_${homeId}.csv"`
+      }
+    })
+  } catch (error) {
+    console.error('Error exporting kinships CSV:', error)
+    return internalError(error)
+  }
+}
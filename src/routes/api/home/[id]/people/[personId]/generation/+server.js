@@ -0,0 +1,51 @@
+/**
+ * GET /api/home/[id]/people/[personId]/generation
+ * Describes which generation a person belongs to relative to the home person
+ *
+ * Works for people who are not directly related: generations are aligned
+ * along the connecting path (parent +1, child -1, marriage 0), so a second
+ * cousin lands in "your generation".
+ *
+ * @returns {Response} JSON { homeId, personId, connected, generationGap, label }
+ *   where generationGap is positive for older generations and null when unconnected
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { loadFamilyGraph } from '$lib/server/familyGraph.js'
+import { generationGap, formatGenerationLabel } from '$lib/server/kinship.js'
+import { parseId } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function GET({ params, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate IDs
+    const homeId = parseId(params.id)
+    const personId = parseId(params.personId)
+    if (homeId === null || personId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    const graph = await loadFamilyGraph(database)
+
+    if (!graph.people.has(homeId) || !graph.people.has(personId)) {
+      return jsonError(404, 'Person not found')
+    }
+
+    const gap = generationGap(graph, homeId, personId)
+
+    return json({
+      homeId,
+      personId,
+      connected: gap !== null,
+      generationGap: gap,
+      label: gap === null ? null : formatGenerationLabel(gap)
+    })
+  } catch (error) {
+    console.error('Error computing generation label:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,39 @@
+/**
+ * POST /api/home/[id]/recompute-kinships
+ * Precomputes and caches everyone's kinship to the home person
+ *
+ * Later reads of the home person's kinships (GET /api/home/[id]/kinships.csv)
+ * are served from the cache until any person or relationship changes, which
+ * clears it. Entries use legal kinship and English labels.
+ *
+ * @returns {Response} JSON { homeId, computed } where computed is the number of people cached
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { recomputeKinshipCache } from '$lib/server/kinshipCache.js'
+import { parseId } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function POST({ params, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const homeId = parseId(params.id)
+    if (homeId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    const computed = recomputeKinshipCache(database, homeId)
+    if (computed === null) {
+      return jsonError(404, 'Person not found')
+    }
+
+    return json({ homeId, computed })
+  } catch (error) {
+    console.error('Error recomputing kinships:', error)
+    return internalError(error)
+  }
+}
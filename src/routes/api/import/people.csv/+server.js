@@ -0,0 +1,57 @@
+/**
+ * POST /api/import/people.csv
+ * Imports people from a CSV file in the layout produced by GET /api/export/people.csv
+ *
+ * The request body is the CSV itself (text/csv) with columns
+ * id, firstName, lastName, birthDate, deathDate, gender. A header row is
+ * skipped when present. The id column is ignored and new IDs are assigned.
+ *
+ * Rows that fail validation are skipped and reported by line number; all
+ * valid rows are inserted in one transaction, tagged with a new import batch
+ * ID (see GET/DELETE /api/imports/{batchId}).
+ *
+ * @returns {Response} 201 JSON { batchId, imported, rejected: [{ line, error }] },
+ *   or 400 JSON with the same shape when no row could be imported
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { people } from '$lib/db/schema.js'
+import { buildPersonInsertValues } from '$lib/server/personHelpers.js'
+import { parsePeopleCsv, MAX_CSV_IMPORT_ROWS } from '$lib/server/peopleCsv.js'
+import { createImportBatchId } from '$lib/server/importBatches.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function POST({ request, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    const text = await request.text()
+
+    const { rows, rejected } = parsePeopleCsv(text)
+
+    if (rows.length + rejected.length > MAX_CSV_IMPORT_ROWS) {
+      return jsonError(400, `CSV must contain at most ${MAX_CSV_IMPORT_ROWS} rows`)
+    }
+
+    if (rows.length === 0) {
+      return json({ imported: 0, rejected }, { status: 400 })
+    }
+
+    const batchId = createImportBatchId()
+
+    database.transaction((tx) => {
+      for (const row of rows) {
+        tx.insert(people)
+          .values({ ...buildPersonInsertValues(row.data), importBatch: batchId })
+          .run()
+      }
+    })
+
+    return json({ batchId, imported: rows.length, rejected }, { status: 201 })
+  } catch (error) {
+    console.error('Error importing people CSV:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,99 @@
+/**
+ * POST /api/import/text
+ * Imports people from an indented plain-text outline
+ *
+ * The request body is the outline itself (text/plain): one person per line,
+ * children indented under their parent with tabs or spaces. A single-word
+ * name takes the last name of its parent; "(M)" or "(F)" after a name sets
+ * gender and whether the person is recorded as father or mother of the
+ * people nested under them. See textOutlineImporter.js for the full format.
+ *
+ * Everything is created in one transaction, tagged with a new import batch
+ * ID (see GET/DELETE /api/imports/{batchId}).
+ *
+ * @returns {Response} 201 JSON { batchId, peopleCreated, relationshipsCreated, tree } where tree is
+ *   [{ id, line, firstName, lastName, children: [...] }], or 400 JSON { line, error }
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { people, relationships } from '$lib/db/schema.js'
+import { parseTextOutline, parentRoleForGender } from '$lib/server/textOutlineImporter.js'
+import { createImportBatchId } from '$lib/server/importBatches.js'
+import { internalError } from '$lib/server/errors.js'
+
+export async function POST({ request, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    const text = await request.text()
+
+    const parsed = parseTextOutline(text)
+    if (!parsed.valid) {
+      return json({ line: parsed.line, error: parsed.error }, { status: 400 })
+    }
+
+    const { entries } = parsed
+    const batchId = createImportBatchId()
+
+    const result = database.transaction((tx) => {
+      const ids = []
+      let relationshipsCreated = 0
+
+      for (const entry of entries) {
+        const person = tx
+          .insert(people)
+          .values({
+            firstName: entry.firstName,
+            lastName: entry.lastName,
+            gender: entry.gender,
+            importBatch: batchId
+          })
+          .returning()
+          .get()
+        ids.push(person.id)
+
+        if (entry.parentIndex !== null) {
+          const parent = entries[entry.parentIndex]
+          tx.insert(relationships)
+            .values({
+              person1Id: ids[entry.parentIndex],
+              person2Id: person.id,
+              type: 'parentOf',
+              parentRole: parentRoleForGender(parent.gender),
+              importBatch: batchId
+            })
+            .run()
+          relationshipsCreated++
+        }
+      }
+
+      return { ids, relationshipsCreated }
+    })
+
+    // Rebuild the outline as a nested tree with the new IDs
+    const nodes = entries.map((entry, index) => ({
+      id: result.ids[index],
+      line: entry.line,
+      firstName: entry.firstName,
+      lastName: entry.lastName,
+      children: []
+    }))
+    const tree = []
+    entries.forEach((entry, index) => {
+      if (entry.parentIndex === null) tree.push(nodes[index])
+      else nodes[entry.parentIndex].children.push(nodes[index])
+    })
+
+    return json({
+      batchId,
+      peopleCreated: entries.length,
+      relationshipsCreated: result.relationshipsCreated,
+      tree
+    }, { status: 201 })
+  } catch (error) {
+    console.error('Error importing text outline:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,42 @@
+/**
+ * POST /api/import/tree
+ * Restores people and relationships from a GET /api/export/tree document
+ *
+ * Works on an empty or existing database: imported people get new IDs and
+ * relationships are remapped onto them, all in one transaction. The whole
+ * document is validated before anything is written.
+ *
+ * @returns {Response} 201 JSON { batchId, peopleImported, relationshipsImported, idMap },
+ *   or 400 JSON { error }
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { validateTreeDocument, importTree } from '$lib/server/treeExport.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function POST({ request, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    let document
+    try {
+      document = await request.json()
+    } catch (parseError) {
+      return jsonError(400, 'Invalid JSON')
+    }
+
+    const validation = validateTreeDocument(document)
+    if (!validation.valid) {
+      return jsonError(400, validation.error)
+    }
+
+    const result = importTree(database, document)
+
+    return json(result, { status: 201 })
+  } catch (error) {
+    console.error('Error importing tree:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,57 @@
+/**
+ * GET /api/imports/{batchId}
+ * Lists the people and relationships created by one import
+ *
+ * DELETE /api/imports/{batchId}
+ * Rolls back an import: deletes everything it created in one transaction
+ *
+ * Batch IDs are returned by the import endpoints (text outline, people CSV,
+ * tree backup, and GEDCOM). People an import only merged into are untouched.
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { getImportBatch, rollbackImportBatch } from '$lib/server/importBatches.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+/**
+ * @returns {Response} JSON { batchId, people, relationships }, or 404 when the
+ *   batch created nothing (or was already rolled back)
+ */
+export async function GET({ params, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    const batch = await getImportBatch(database, params.batchId)
+    if (!batch) {
+      return jsonError(404, 'Import batch not found')
+    }
+
+    return json(batch)
+  } catch (error) {
+    console.error('Error fetching import batch:', error)
+    return internalError(error)
+  }
+}
+
+/**
+ * @returns {Response} JSON { batchId, peopleDeleted, relationshipsDeleted }, or 404
+ *   when the batch created nothing (or was already rolled back)
+ */
+export async function DELETE({ params, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    const result = rollbackImportBatch(database, params.batchId)
+    if (!result) {
+      return jsonError(404, 'Import batch not found')
+    }
+
+    return json(result)
+  } catch (error) {
+    console.error('Error rolling back import batch:', error)
+    return internalError(error)
+  }
+}
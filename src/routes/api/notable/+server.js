@@ -0,0 +1,35 @@
+/**
+ * GET /api/notable
+ * Returns everyone marked as notable (such as historical figures), so
+ * clients can offer "am I related to someone famous" lookups
+ *
+ * People are ordered by last name, first name, and ID. Soft-deleted people
+ * are excluded.
+ *
+ * @returns {Response} JSON array of people
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { people } from '$lib/db/schema.js'
+import { and, asc, eq, isNull } from 'drizzle-orm'
+import { transformPeopleToAPI } from '$lib/server/personHelpers.js'
+import { internalError } from '$lib/server/errors.js'
+
+export async function GET({ locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    const notablePeople = await database
+      .select()
+      .from(people)
+      .where(and(eq(people.notable, true), isNull(people.deletedAt)))
+      .orderBy(asc(people.lastName), asc(people.firstName), asc(people.id))
+
+    return json(transformPeopleToAPI(notablePeople))
+  } catch (error) {
+    console.error('Error fetching notable people:', error)
+    return internalError(error)
+  }
+}
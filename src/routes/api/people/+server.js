@@ -1,23 +1,90 @@
 import { json } from '@sveltejs/kit'
 import { db } from '$lib/db/client.js'
 import { people } from '$lib/db/schema.js'
-import { transformPeopleToAPI, validatePersonData, transformPersonToAPI } from '$lib/server/personHelpers.js'
+import { and, isNull, isNotNull } from 'drizzle-orm'
+import {
+  transformPeopleToAPI,
+  validatePersonData,
+  transformPersonToAPI,
+  parseFieldsParam,
+  projectPersonToAPI,
+  buildPersonInsertValues
+} from '$lib/server/personHelpers.js'
+import { recordAudit, AUDIT_ENTITY_TYPES, AUDIT_ACTIONS } from '$lib/server/auditLog.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
 
 /**
  * GET /api/people
  * Returns all people from the database
+ * Soft-deleted people are excluded
+ *
+ * Query Parameters:
+ *   - fields: Comma-separated list of fields to return (e.g., "id,firstName,lastName")
+ *     Only the requested columns are selected, reducing payload for mobile clients.
+ *     Non-whitelisted field names are rejected with 400.
+ *   - approximate: "true" returns only people whose birth date carries an
+ *     about/before/after qualifier; "false" returns only exact birth dates
+ *   - status: "living" returns only people with no death date; "deceased"
+ *     returns only people with a death date
+ *
+ * Filters combine with each other and with fields.
  *
  * @returns {Response} JSON array of people
  */
-export async function GET({ locals }) {
+export async function GET({ locals, url }) {
   try {
     // Use locals.db if provided (for testing), otherwise use singleton db
     const database = locals?.db || db
 
-    // Query all people
+    // Build filter (soft-deleted people are always excluded)
+    const conditions = [isNull(people.deletedAt)]
+
+    const approximateParam = url?.searchParams?.get('approximate')
+    if (approximateParam !== null && approximateParam !== undefined) {
+      if (approximateParam !== 'true' && approximateParam !== 'false') {
+        return jsonError(400, 'approximate must be "true" or "false"')
+      }
+      conditions.push(
+        approximateParam === 'true'
+          ? isNotNull(people.birthDateQualifier)
+          : isNull(people.birthDateQualifier)
+      )
+    }
+
+    const statusParam = url?.searchParams?.get('status')
+    if (statusParam !== null && statusParam !== undefined) {
+      if (statusParam !== 'living' && statusParam !== 'deceased') {
+        return jsonError(400, 'status must be "living" or "deceased"')
+      }
+      conditions.push(
+        statusParam === 'living'
+          ? isNull(people.deathDate)
+          : isNotNull(people.deathDate)
+      )
+    }
+
+    // Minimal projection: select only the requested columns
+    const fieldsParam = url?.searchParams?.get('fields')
+    if (fieldsParam !== null && fieldsParam !== undefined) {
+      const projection = parseFieldsParam(fieldsParam)
+      if (!projection.valid) {
+        return jsonError(400, projection.error)
+      }
+
+      const columns = Object.fromEntries(projection.fields.map((field) => [field, people[field]]))
+      const rows = await database
+        .select(columns)
+        .from(people)
+        .where(and(...conditions))
+
+      return json(rows.map((row) => projectPersonToAPI(row, projection.fields)))
+    }
+
+    // Query all matching people
     const allPeople = await database
       .select()
       .from(people)
+      .where(and(...conditions))
 
     // Transform to API format
     const transformedPeople = transformPeopleToAPI(allPeople)
@@ -25,7 +92,7 @@ export async function GET({ locals }) {
     return json(transformedPeople)
   } catch (error) {
     console.error('Error fetching people:', error)
-    return new Response('Internal Server Error', { status: 500 })
+    return internalError(error)
   }
 }
 
@@ -45,42 +112,36 @@ export async function POST({ request, locals }) {
     let data
     try {
       data = await request.json()
-    } catch (jsonError) {
+    } catch (parseError) {
       // Handle JSON parsing errors
-      return new Response('Invalid JSON', { status: 400 })
+      return jsonError(400, 'Invalid JSON')
     }
 
     // Validate required fields
     const validation = validatePersonData(data)
     if (!validation.valid) {
-      return new Response(validation.error, { status: 400 })
+      return jsonError(400, validation.error)
     }
 
     // Insert person into database
     // Story #77: Now includes photoUrl
     // Issue #121: Now includes birthSurname and nickname
-    const result = await database
-      .insert(people)
-      .values({
-        firstName: data.firstName,
-        lastName: data.lastName,
-        birthDate: data.birthDate || null,
-        deathDate: data.deathDate || null,
-        gender: data.gender || null,
-        photoUrl: data.photoUrl || null,
-        birthSurname: data.birthSurname || null,
-        nickname: data.nickname || null
-      })
-      .returning()
-
-    const newPerson = result[0]
+    const transformedPerson = database.transaction((tx) => {
+      const newPerson = tx
+        .insert(people)
+        .values(buildPersonInsertValues(data))
+        .returning()
+        .get()
 
-    // Transform to API format
-    const transformedPerson = transformPersonToAPI(newPerson)
+      // Transform to API format
+      const created = transformPersonToAPI(newPerson)
+      recordAudit(tx, AUDIT_ENTITY_TYPES.person, created.id, AUDIT_ACTIONS.create, { after: created })
+      return created
+    })
 
     return json(transformedPerson, { status: 201 })
   } catch (error) {
     console.error('Error creating person:', error)
-    return new Response('Internal Server Error', { status: 500 })
+    return internalError(error)
   }
 }
@@ -1,12 +1,23 @@
 import { json } from '@sveltejs/kit'
 import { db } from '$lib/db/client.js'
 import { people } from '$lib/db/schema.js'
-import { eq } from 'drizzle-orm'
-import { parseId, transformPersonToAPI, validatePersonData } from '$lib/server/personHelpers.js'
+import { eq, and, isNull, sql } from 'drizzle-orm'
+import {
+  parseId,
+  transformPersonToAPI,
+  validatePersonData,
+  validatePersonPatch,
+  normalizeText,
+  normalizeGender,
+  EDITABLE_PERSON_FIELDS
+} from '$lib/server/personHelpers.js'
+import { recordAudit, AUDIT_ENTITY_TYPES, AUDIT_ACTIONS } from '$lib/server/auditLog.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
 
 /**
  * GET /api/people/[id]
  * Returns a single person by ID
+ * Soft-deleted people are treated as not found
  *
  * @param {Object} params - URL parameters containing id
  * @returns {Response} JSON of person or 404 if not found
@@ -19,19 +30,19 @@ export async function GET({ params, locals }) {
     // Validate ID
     const personId = parseId(params.id)
     if (personId === null) {
-      return new Response('Invalid ID', { status: 400 })
+      return jsonError(400, 'Invalid ID')
     }
 
     // Query person by ID
     const result = await database
       .select()
       .from(people)
-      .where(eq(people.id, personId))
+      .where(and(eq(people.id, personId), isNull(people.deletedAt)))
       .limit(1)
 
     // Check if person exists
     if (result.length === 0) {
-      return new Response('Person not found', { status: 404 })
+      return jsonError(404, 'Person not found')
     }
 
     const person = result[0]
@@ -42,7 +53,7 @@ export async function GET({ params, locals }) {
     return json(transformedPerson)
   } catch (error) {
     console.error('Error fetching person:', error)
-    return new Response('Internal Server Error', { status: 500 })
+    return internalError(error)
   }
 }
 
@@ -62,33 +73,33 @@ export async function PUT({ params, request, locals }) {
     // Validate ID
     const personId = parseId(params.id)
     if (personId === null) {
-      return new Response('Invalid ID', { status: 400 })
+      return jsonError(400, 'Invalid ID')
     }
 
     // Parse request body
     let data
     try {
       data = await request.json()
-    } catch (jsonError) {
+    } catch (parseError) {
       // Handle JSON parsing errors
-      return new Response('Invalid JSON', { status: 400 })
+      return jsonError(400, 'Invalid JSON')
     }
 
     // Validate required fields
     const validation = validatePersonData(data)
     if (!validation.valid) {
-      return new Response(validation.error, { status: 400 })
+      return jsonError(400, validation.error)
     }
 
     // Check if person exists
     const existing = await database
       .select()
       .from(people)
-      .where(eq(people.id, personId))
+      .where(and(eq(people.id, personId), isNull(people.deletedAt)))
       .limit(1)
 
     if (existing.length === 0) {
-      return new Response('Person not found', { status: 404 })
+      return jsonError(404, 'Person not found')
     }
 
     // Update person
@@ -99,7 +110,7 @@ export async function PUT({ params, request, locals }) {
       lastName: data.lastName,
       birthDate: data.birthDate !== undefined ? data.birthDate : null,
       deathDate: data.deathDate !== undefined ? data.deathDate : null,
-      gender: data.gender !== undefined ? data.gender : null
+      gender: normalizeGender(data.gender)
     }
 
     // Only update photoUrl if it's explicitly provided in the request
@@ -117,28 +128,188 @@ export async function PUT({ params, request, locals }) {
       updateData.nickname = data.nickname
     }
 
-    const result = await database
-      .update(people)
-      .set(updateData)
-      .where(eq(people.id, personId))
-      .returning()
+    // Only update middleName, maidenName, and suffix if explicitly provided
+    for (const field of ['middleName', 'maidenName', 'suffix']) {
+      if (data[field] !== undefined) {
+        updateData[field] = data[field]
+      }
+    }
 
-    const updatedPerson = result[0]
+    // Only update birthDateQualifier if explicitly provided (blank clears)
+    if (data.birthDateQualifier !== undefined) {
+      updateData.birthDateQualifier = data.birthDateQualifier || null
+    }
 
-    // Transform to API format
-    const transformedPerson = transformPersonToAPI(updatedPerson)
+    // Only update places if explicitly provided in the request (trimmed, blank clears)
+    for (const field of ['birthPlace', 'deathPlace']) {
+      if (data[field] !== undefined) {
+        updateData[field] = normalizeText(data[field])
+      }
+    }
+
+    // Only update notable if explicitly provided in the request
+    if (data.notable !== undefined && data.notable !== null) {
+      updateData.notable = data.notable
+    }
+
+    // Only update notes if explicitly provided in the request (trimmed, blank clears)
+    if (data.notes !== undefined) {
+      updateData.notes = normalizeText(data.notes)
+    }
+
+    // A qualifier is meaningless without a birth date
+    if (!updateData.birthDate) {
+      updateData.birthDateQualifier = null
+    }
+
+    const transformedPerson = database.transaction((tx) => {
+      const updatedPerson = tx
+        .update(people)
+        .set(updateData)
+        .where(eq(people.id, personId))
+        .returning()
+        .get()
+
+      // Transform to API format
+      const after = transformPersonToAPI(updatedPerson)
+      recordAudit(tx, AUDIT_ENTITY_TYPES.person, personId, AUDIT_ACTIONS.update, {
+        before: transformPersonToAPI(existing[0]),
+        after
+      })
+      return after
+    })
 
     return json(transformedPerson)
   } catch (error) {
     console.error('Error updating person:', error)
-    return new Response('Internal Server Error', { status: 500 })
+    return internalError(error)
+  }
+}
+
+/**
+ * PATCH /api/people/[id]
+ * Partially updates a person: only fields present in the body change
+ *
+ * Unlike PUT, omitted fields keep their current values; a field sent as
+ * null is cleared. Only the changed fields are validated (firstName and
+ * lastName still cannot be cleared), with cross-field rules such as
+ * death-before-birth checked against the stored values. Clearing birthDate
+ * also clears birthDateQualifier unless the body sets it. Unknown fields
+ * are rejected.
+ *
+ * @param {Object} params - URL parameters containing id
+ * @param {Request} request - HTTP request with the fields to change
+ * @returns {Response} JSON of updated person or error
+ */
+export async function PATCH({ params, request, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const personId = parseId(params.id)
+    if (personId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    // Parse request body
+    let data
+    try {
+      data = await request.json()
+    } catch (parseError) {
+      return jsonError(400, 'Invalid JSON')
+    }
+
+    if (data === null || typeof data !== 'object' || Array.isArray(data)) {
+      return jsonError(400, 'Request body must be a JSON object')
+    }
+
+    // Present keys are changed, absent keys are left alone
+    const changedFields = Object.keys(data)
+    const unknownFields = changedFields.filter((field) => !EDITABLE_PERSON_FIELDS.includes(field))
+    if (unknownFields.length > 0) {
+      return jsonError(400, `Unknown field(s): ${unknownFields.join(', ')}`)
+    }
+
+    // Check if person exists
+    const existing = await database
+      .select()
+      .from(people)
+      .where(and(eq(people.id, personId), isNull(people.deletedAt)))
+      .limit(1)
+
+    if (existing.length === 0) {
+      return jsonError(404, 'Person not found')
+    }
+
+    const updateData = {}
+    for (const field of changedFields) {
+      updateData[field] = data[field]
+    }
+
+    // A qualifier is meaningless without a birth date
+    if ('birthDate' in updateData && !updateData.birthDate && !('birthDateQualifier' in updateData)) {
+      updateData.birthDateQualifier = null
+    }
+
+    // Validate only the changed fields, so stored values from imports do not block edits
+    const validation = validatePersonPatch(existing[0], updateData)
+    if (!validation.valid) {
+      return jsonError(400, validation.error)
+    }
+
+    if ('gender' in updateData) {
+      updateData.gender = normalizeGender(updateData.gender)
+    }
+    for (const field of ['birthPlace', 'deathPlace']) {
+      if (field in updateData) {
+        updateData[field] = normalizeText(updateData[field])
+      }
+    }
+    if ('birthDateQualifier' in updateData) {
+      updateData.birthDateQualifier = updateData.birthDateQualifier || null
+    }
+    if (updateData.notable === null) {
+      updateData.notable = false
+    }
+    if ('notes' in updateData) {
+      updateData.notes = normalizeText(updateData.notes)
+    }
+
+    if (Object.keys(updateData).length === 0) {
+      return json(transformPersonToAPI(existing[0]))
+    }
+
+    const transformedPerson = database.transaction((tx) => {
+      const updatedPerson = tx
+        .update(people)
+        .set(updateData)
+        .where(eq(people.id, personId))
+        .returning()
+        .get()
+
+      // Transform to API format
+      const after = transformPersonToAPI(updatedPerson)
+      recordAudit(tx, AUDIT_ENTITY_TYPES.person, personId, AUDIT_ACTIONS.update, {
+        before: transformPersonToAPI(existing[0]),
+        after
+      })
+      return after
+    })
+
+    return json(transformedPerson)
+  } catch (error) {
+    console.error('Error patching person:', error)
+    return internalError(error)
   }
 }
 
 /**
  * DELETE /api/people/[id]
- * Deletes a person by ID
- * Cascade deletes relationships due to foreign key constraints
+ * Soft-deletes a person by ID
+ * Sets deleted_at instead of removing the row so the person can be restored
+ * via POST /api/people/[id]/restore. Relationships are kept but hidden while
+ * either person is deleted.
  *
  * @param {Object} params - URL parameters containing id
  * @returns {Response} 204 No Content on success, or error
@@ -151,29 +322,36 @@ export async function DELETE({ params, locals }) {
     // Validate ID
     const personId = parseId(params.id)
     if (personId === null) {
-      return new Response('Invalid ID', { status: 400 })
+      return jsonError(400, 'Invalid ID')
     }
 
     // Check if person exists
     const existing = await database
       .select()
       .from(people)
-      .where(eq(people.id, personId))
+      .where(and(eq(people.id, personId), isNull(people.deletedAt)))
       .limit(1)
 
     if (existing.length === 0) {
-      return new Response('Person not found', { status: 404 })
+      return jsonError(404, 'Person not found')
     }
 
-    // Delete person (relationships will cascade delete due to foreign key)
-    await database
-      .delete(people)
-      .where(eq(people.id, personId))
+    // Soft-delete person (relationships are retained for restore)
+    database.transaction((tx) => {
+      tx.update(people)
+        .set({ deletedAt: sql`CURRENT_TIMESTAMP` })
+        .where(eq(people.id, personId))
+        .run()
+
+      recordAudit(tx, AUDIT_ENTITY_TYPES.person, personId, AUDIT_ACTIONS.delete, {
+        before: transformPersonToAPI(existing[0])
+      })
+    })
 
     // Return 204 No Content (no body)
     return new Response(null, { status: 204 })
   } catch (error) {
     console.error('Error deleting person:', error)
-    return new Response('Internal Server Error', { status: 500 })
+    return internalError(error)
   }
 }
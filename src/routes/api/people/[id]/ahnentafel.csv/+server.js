@@ -0,0 +1,62 @@
+/**
+ * GET /api/people/[id]/ahnentafel.csv
+ * Exports a person's direct ancestors as CSV for spreadsheet pedigree work
+ *
+ * Columns: ahnentafel, name, birthDate, deathDate
+ * - ahnentafel: 1 = the person, 2n = father of n, 2n + 1 = mother of n
+ *
+ * Rows are in Ahnentafel order and cover every known ancestor. A person
+ * reached twice through pedigree collapse gets a row for each number.
+ *
+ * @returns {Response} CSV file download (Content-Type: text/csv)
+ */
+
+import { db } from '$lib/db/client.js'
+import { loadFamilyGraph } from '$lib/server/familyGraph.js'
+import { buildAhnentafel, MAX_AHNENTAFEL_GENERATIONS } from '$lib/server/pedigree.js'
+import { parseId } from '$lib/server/personHelpers.js'
+import { toCsv } from '$lib/server/csv.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function GET({ params, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const personId = parseId(params.id)
+    if (personId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    const graph = await loadFamilyGraph(database)
+
+    if (!graph.people.has(personId)) {
+      return jsonError(404, 'Person not found')
+    }
+
+    const slots = buildAhnentafel(graph, personId, MAX_AHNENTAFEL_GENERATIONS)
+    const rows = [...slots.entries()].map(([number, id]) => {
+      const person = graph.people.get(id)
+      return [
+        number,
+        [person.firstName, person.lastName].filter(Boolean).join(' '),
+        person.birthDate,
+        person.deathDate
+      ]
+    })
+
+    const csvContent = toCsv(['ahnentafel', 'name', 'birthDate', 'deathDate'], rows)
+
+    return new Response(csvContent, {
+      status: 200,
+      headers: {
+        'Content-Type': 'text/csv',
+        'Content-Disposition': `attachment; filename="ahnentafel_${personId}.csv"`
+      }
+    })
+  } catch (error) {
+    console.error('Error exporting Ahnentafel CSV:', error)
+    return internalError(error)
+  }
+}
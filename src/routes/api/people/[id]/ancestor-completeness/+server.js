@@ -0,0 +1,58 @@
+/**
+ * GET /api/people/[id]/ancestor-completeness
+ * Returns how many of the expected ancestors are known in each generation,
+ * for a research completeness bar chart
+ *
+ * Generation 1 is parents (expected 2), 2 is grandparents (expected 4), and so on.
+ *
+ * Query parameters:
+ * - generations: 1 to 10 generations above the person (default: 5)
+ *
+ * @returns {Response} JSON { personId, generations, levels: [{ generation, expected, found, percent }] }
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { loadFamilyGraph } from '$lib/server/familyGraph.js'
+import { computeAncestorCompleteness } from '$lib/server/pedigree.js'
+import { parseId } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+const DEFAULT_GENERATIONS = 5
+const MIN_GENERATIONS = 1
+const MAX_GENERATIONS = 10
+
+export async function GET({ params, locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const personId = parseId(params.id)
+    if (personId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    // Validate generations
+    const generationsParam = url?.searchParams?.get('generations')
+    const generations = generationsParam ? Number(generationsParam) : DEFAULT_GENERATIONS
+    if (!Number.isInteger(generations) || generations < MIN_GENERATIONS || generations > MAX_GENERATIONS) {
+      return jsonError(400, `generations must be an integer between ${MIN_GENERATIONS} and ${MAX_GENERATIONS}`)
+    }
+
+    const graph = await loadFamilyGraph(database)
+
+    if (!graph.people.has(personId)) {
+      return jsonError(404, 'Person not found')
+    }
+
+    return json({
+      personId,
+      generations,
+      levels: computeAncestorCompleteness(graph, personId, generations)
+    })
+  } catch (error) {
+    console.error('Error computing ancestor completeness:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,59 @@
+/**
+ * GET /api/people/[id]/ancestor-contributions
+ * Returns the share of the person's ancestry each ancestor theoretically contributes
+ *
+ * Each line to an ancestor contributes 1/2^generations (parents 1/2,
+ * grandparents 1/4, ...), summed over every line, so ancestors reached
+ * more than once through pedigree collapse contribute more than others of
+ * their generation. Use kinship=blood for genetic genealogy, since adoptive
+ * parents contribute no DNA.
+ *
+ * Query parameters:
+ * - kinship: "legal" (default) counts adoptive parent edges, "blood" ignores them
+ *
+ * @returns {Response} JSON { personId, ancestors: [{ person, contribution, generation, paths }] }
+ *   ordered by contribution (largest first); generation is the nearest one (1 = parent) and
+ *   paths counts the lines reaching the ancestor
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { loadFamilyGraph, parseKinshipMode } from '$lib/server/familyGraph.js'
+import { computeAncestorContributions } from '$lib/server/pedigree.js'
+import { parseId, transformPersonToAPI } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function GET({ params, locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const personId = parseId(params.id)
+    if (personId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    const kinshipMode = parseKinshipMode(url)
+    if (!kinshipMode.valid) {
+      return jsonError(400, kinshipMode.error)
+    }
+
+    const graph = await loadFamilyGraph(database, { kinship: kinshipMode.mode })
+
+    if (!graph.people.has(personId)) {
+      return jsonError(404, 'Person not found')
+    }
+
+    return json({
+      personId,
+      ancestors: computeAncestorContributions(graph, personId).map(({ personId: ancestorId, ...entry }) => ({
+        person: transformPersonToAPI(graph.people.get(ancestorId)),
+        ...entry
+      }))
+    })
+  } catch (error) {
+    console.error('Error computing ancestor contributions:', error)
+    return internalError(error)
+  }
+}
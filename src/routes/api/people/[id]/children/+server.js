@@ -0,0 +1,64 @@
+/**
+ * GET /api/people/[id]/children
+ * Returns a person's children in display order
+ *
+ * Children follow the user-defined order set via
+ * POST /api/people/[id]/children/reorder; children without a position come
+ * after the ordered ones, by birth date (unknown last) and then ID.
+ * Soft-deleted children are excluded.
+ *
+ * @returns {Response} JSON array of people, each with the relationship's sortOrder
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { people, relationships } from '$lib/db/schema.js'
+import { and, eq, isNull, sql } from 'drizzle-orm'
+import { parseId, transformPersonToAPI } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function GET({ params, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const parentId = parseId(params.id)
+    if (parentId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    const [parent] = await database
+      .select({ id: people.id })
+      .from(people)
+      .where(and(eq(people.id, parentId), isNull(people.deletedAt)))
+
+    if (!parent) {
+      return jsonError(404, 'Person not found')
+    }
+
+    const rows = await database
+      .select({ person: people, sortOrder: relationships.sortOrder })
+      .from(relationships)
+      .innerJoin(people, eq(people.id, relationships.person2Id))
+      .where(
+        and(
+          eq(relationships.person1Id, parentId),
+          eq(relationships.type, 'parentOf'),
+          isNull(people.deletedAt)
+        )
+      )
+      .orderBy(
+        sql`${relationships.sortOrder} IS NULL`,
+        relationships.sortOrder,
+        sql`${people.birthDate} IS NULL`,
+        people.birthDate,
+        people.id
+      )
+
+    return json(rows.map((row) => ({ ...transformPersonToAPI(row.person), sortOrder: row.sortOrder })))
+  } catch (error) {
+    console.error('Error fetching children:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,97 @@
+/**
+ * POST /api/people/[id]/children/reorder
+ * Sets the display order of a person's children
+ *
+ * Request body: { childIds: [number] } listing every current (non-deleted)
+ * child exactly once, in the desired order. Each child's parentOf
+ * relationship gets sort_order 0, 1, 2, ... in that order.
+ *
+ * @returns {Response} JSON { parentId, childIds } on success, 400 JSON { error }
+ *   when the list does not match the children, or 404 when the person is missing
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { people, relationships } from '$lib/db/schema.js'
+import { and, eq, isNull } from 'drizzle-orm'
+import { parseId } from '$lib/server/personHelpers.js'
+import { recordAudit, AUDIT_ENTITY_TYPES, AUDIT_ACTIONS } from '$lib/server/auditLog.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function POST({ params, request, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const parentId = parseId(params.id)
+    if (parentId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    let data
+    try {
+      data = await request.json()
+    } catch (parseError) {
+      return jsonError(400, 'Invalid JSON')
+    }
+
+    const childIds = data?.childIds
+    if (!Array.isArray(childIds) || !childIds.every((id) => Number.isInteger(id))) {
+      return jsonError(400, 'childIds must be an array of person IDs')
+    }
+
+    const [parent] = await database
+      .select({ id: people.id })
+      .from(people)
+      .where(and(eq(people.id, parentId), isNull(people.deletedAt)))
+
+    if (!parent) {
+      return jsonError(404, 'Person not found')
+    }
+
+    const current = await database
+      .select({ childId: relationships.person2Id })
+      .from(relationships)
+      .innerJoin(people, eq(people.id, relationships.person2Id))
+      .where(
+        and(
+          eq(relationships.person1Id, parentId),
+          eq(relationships.type, 'parentOf'),
+          isNull(people.deletedAt)
+        )
+      )
+    const currentIds = new Set(current.map((row) => row.childId))
+
+    const requested = new Set(childIds)
+    if (
+      requested.size !== childIds.length ||
+      requested.size !== currentIds.size ||
+      !childIds.every((id) => currentIds.has(id))
+    ) {
+      return jsonError(400, 'childIds must list each of the person\'s children exactly once')
+    }
+
+    database.transaction((tx) => {
+      childIds.forEach((childId, index) => {
+        tx.update(relationships)
+          .set({ sortOrder: index })
+          .where(
+            and(
+              eq(relationships.person1Id, parentId),
+              eq(relationships.person2Id, childId),
+              eq(relationships.type, 'parentOf')
+            )
+          )
+          .run()
+      })
+
+      recordAudit(tx, AUDIT_ENTITY_TYPES.person, parentId, AUDIT_ACTIONS.update, { after: { childIds } })
+    })
+
+    return json({ parentId, childIds })
+  } catch (error) {
+    console.error('Error reordering children:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,82 @@
+/**
+ * POST /api/people/[id]/closest-to
+ * Returns which of a set of candidates is most closely related to a person
+ *
+ * Answers "who among these is my closest match": candidates are ranked by
+ * kinship degree (generations up and down plus marriages crossed).
+ *
+ * Request body: { "ids": [2, 3, 4] } (at least one person ID)
+ *
+ * Query parameters:
+ * - kinship: "legal" (default) counts adoptive parent edges, "blood" ignores them
+ *
+ * @returns {Response} JSON { personId, closest: { person, label, degree } | null,
+ *   candidates: [{ person, label, degree }] } where candidates are ordered closest
+ *   first and closest is null when no candidate is related
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { loadFamilyGraph, parseKinshipMode } from '$lib/server/familyGraph.js'
+import { findClosestRelative } from '$lib/server/kinship.js'
+import { parseId, transformPersonToAPI } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function POST({ params, request, locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const personId = parseId(params.id)
+    if (personId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    const kinshipMode = parseKinshipMode(url)
+    if (!kinshipMode.valid) {
+      return jsonError(400, kinshipMode.error)
+    }
+
+    // Parse request body
+    let data
+    try {
+      data = await request.json()
+    } catch (parseError) {
+      return jsonError(400, 'Invalid JSON')
+    }
+
+    if (!data || !Array.isArray(data.ids) || data.ids.length === 0) {
+      return jsonError(400, 'ids must be a non-empty array of person IDs')
+    }
+
+    const parsedIds = data.ids.map((id) => parseId(id))
+    if (parsedIds.some((id) => id === null)) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    const graph = await loadFamilyGraph(database, { kinship: kinshipMode.mode })
+
+    const candidateIds = [...new Set(parsedIds)]
+    if (!graph.people.has(personId) || candidateIds.some((id) => !graph.people.has(id))) {
+      return jsonError(404, 'Person not found')
+    }
+
+    const { closest, ranking } = findClosestRelative(graph, personId, candidateIds)
+
+    const toEntry = (entry) => ({
+      person: transformPersonToAPI(graph.people.get(entry.personId)),
+      label: entry.label,
+      degree: entry.degree
+    })
+
+    return json({
+      personId,
+      closest: closest ? toEntry(closest) : null,
+      candidates: ranking.map(toEntry)
+    })
+  } catch (error) {
+    console.error('Error finding closest relative:', error)
+    return internalError(error)
+  }
+}
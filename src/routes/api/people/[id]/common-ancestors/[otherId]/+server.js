@@ -0,0 +1,62 @@
+/**
+ * GET /api/people/[id]/common-ancestors/[otherId]
+ * Returns the ancestors shared by two people
+ *
+ * Each common ancestor includes the generational distance from both people
+ * (1 = parent, 2 = grandparent, ...). The most recent common ancestor(s),
+ * those with the smallest combined distance, are flagged with isMostRecent.
+ *
+ * Query parameters:
+ * - kinship: "legal" (default) counts adoptive parent edges, "blood" ignores them
+ *
+ * @returns {Response} JSON { person1Id, person2Id, commonAncestors, mostRecentCommonAncestors }
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { loadFamilyGraph, findCommonAncestors, parseKinshipMode } from '$lib/server/familyGraph.js'
+import { parseId, transformPersonToAPI } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function GET({ params, locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate IDs
+    const person1Id = parseId(params.id)
+    const person2Id = parseId(params.otherId)
+    if (person1Id === null || person2Id === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    const kinshipMode = parseKinshipMode(url)
+    if (!kinshipMode.valid) {
+      return jsonError(400, kinshipMode.error)
+    }
+
+    const graph = await loadFamilyGraph(database, { kinship: kinshipMode.mode })
+
+    if (!graph.people.has(person1Id) || !graph.people.has(person2Id)) {
+      return jsonError(404, 'Person not found')
+    }
+
+    const commonAncestors = findCommonAncestors(graph, person1Id, person2Id).map((entry) => ({
+      person: transformPersonToAPI(graph.people.get(entry.ancestorId)),
+      distance1: entry.distance1,
+      distance2: entry.distance2,
+      combinedDistance: entry.combinedDistance,
+      isMostRecent: entry.isMostRecent
+    }))
+
+    return json({
+      person1Id,
+      person2Id,
+      commonAncestors,
+      mostRecentCommonAncestors: commonAncestors.filter((entry) => entry.isMostRecent)
+    })
+  } catch (error) {
+    console.error('Error finding common ancestors:', error)
+    return internalError(error)
+  }
+}
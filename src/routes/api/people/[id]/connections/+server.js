@@ -0,0 +1,92 @@
+/**
+ * GET /api/people/[id]/connections
+ * Returns everyone directly related to a person (parents, spouses, children)
+ * with a single label from that person's perspective
+ *
+ * Labels come from the relationship type, parent role, and the other
+ * person's gender: "mother", "stepfather", "adoptive son", "wife", "child".
+ * This is the data a profile's family sidebar needs. Connections are ordered
+ * parents, then spouses, then children, each by birth date (unknown last)
+ * and ID. Soft-deleted people are excluded.
+ *
+ * @returns {Response} JSON { personId, connections: [{ person, relation, label, relationshipId }] }
+ *   where relation is "parent", "spouse", or "child"
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { people, relationships } from '$lib/db/schema.js'
+import { and, eq, inArray, isNull, or } from 'drizzle-orm'
+import { describeConnection } from '$lib/server/kinship.js'
+import { parseId, transformPersonToAPI } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+const RELATION_ORDER = ['parent', 'spouse', 'child']
+
+export async function GET({ params, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const personId = parseId(params.id)
+    if (personId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    const [person] = await database
+      .select({ id: people.id })
+      .from(people)
+      .where(and(eq(people.id, personId), isNull(people.deletedAt)))
+
+    if (!person) {
+      return jsonError(404, 'Person not found')
+    }
+
+    const rows = await database
+      .select()
+      .from(relationships)
+      .where(or(eq(relationships.person1Id, personId), eq(relationships.person2Id, personId)))
+
+    const otherIds = [...new Set(rows.map((row) => (row.person1Id === personId ? row.person2Id : row.person1Id)))]
+    const others = new Map()
+    if (otherIds.length > 0) {
+      const otherPeople = await database
+        .select()
+        .from(people)
+        .where(and(inArray(people.id, otherIds), isNull(people.deletedAt)))
+      for (const other of otherPeople) others.set(other.id, other)
+    }
+
+    // Spouse rows may be stored in both directions; keep one connection per person and relation
+    const connections = new Map()
+    for (const row of rows) {
+      const other = others.get(row.person1Id === personId ? row.person2Id : row.person1Id)
+      if (!other) continue
+
+      const { relation, label } = describeConnection(row, personId, other.gender)
+      const key = `${relation}:${other.id}`
+      if (connections.has(key)) continue
+
+      connections.set(key, { person: other, relation, label, relationshipId: row.id })
+    }
+
+    const sorted = [...connections.values()].sort((a, b) =>
+      RELATION_ORDER.indexOf(a.relation) - RELATION_ORDER.indexOf(b.relation) ||
+      (a.person.birthDate === null) - (b.person.birthDate === null) ||
+      (a.person.birthDate || '').localeCompare(b.person.birthDate || '') ||
+      a.person.id - b.person.id
+    )
+
+    return json({
+      personId,
+      connections: sorted.map((connection) => ({
+        ...connection,
+        person: transformPersonToAPI(connection.person)
+      }))
+    })
+  } catch (error) {
+    console.error('Error fetching connections:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,51 @@
+/**
+ * GET /api/people/[id]/counts
+ * Returns how many ancestors, descendants, and siblings a person has,
+ * for tree summaries and spotting the most connected people
+ *
+ * People reached along several paths are counted once. Siblings include
+ * half siblings.
+ *
+ * Query parameters:
+ * - kinship: "legal" (default) counts adoptive parent edges, "blood" ignores them
+ *
+ * @returns {Response} JSON { personId, ancestors, descendants, siblings }
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { loadFamilyGraph, parseKinshipMode, countRelatives } from '$lib/server/familyGraph.js'
+import { parseId } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function GET({ params, locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const personId = parseId(params.id)
+    if (personId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    const kinshipMode = parseKinshipMode(url)
+    if (!kinshipMode.valid) {
+      return jsonError(400, kinshipMode.error)
+    }
+
+    const graph = await loadFamilyGraph(database, { kinship: kinshipMode.mode })
+
+    if (!graph.people.has(personId)) {
+      return jsonError(404, 'Person not found')
+    }
+
+    return json({
+      personId,
+      ...countRelatives(graph, personId)
+    })
+  } catch (error) {
+    console.error('Error counting relatives:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,64 @@
+/**
+ * GET /api/people/[id]/cousin-map
+ * Returns all of a person's cousins (descendants of their aunts and uncles)
+ * grouped by cousin degree and removal, in one response for a cousin-finder UI
+ *
+ * Query parameters:
+ * - kinship: "legal" (default) counts adoptive parent edges, "blood" ignores them
+ *
+ * @returns {Response} JSON { personId, total, auntsUncles: [person],
+ *   groups: [{ degree, removed, label, cousins: [{ person, auntUncleIds }] }] }
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { loadFamilyGraph, parseKinshipMode } from '$lib/server/familyGraph.js'
+import { buildCousinMap } from '$lib/server/kinship.js'
+import { parseId, transformPersonToAPI } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function GET({ params, locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const personId = parseId(params.id)
+    if (personId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    const kinshipMode = parseKinshipMode(url)
+    if (!kinshipMode.valid) {
+      return jsonError(400, kinshipMode.error)
+    }
+
+    const graph = await loadFamilyGraph(database, { kinship: kinshipMode.mode })
+
+    if (!graph.people.has(personId)) {
+      return jsonError(404, 'Person not found')
+    }
+
+    const cousinMap = buildCousinMap(graph, personId)
+
+    const groups = cousinMap.groups.map((group) => ({
+      degree: group.degree,
+      removed: group.removed,
+      label: group.label,
+      cousins: group.cousins.map((cousin) => ({
+        person: transformPersonToAPI(graph.people.get(cousin.personId)),
+        auntUncleIds: cousin.auntUncleIds
+      }))
+    }))
+
+    return json({
+      personId,
+      total: groups.reduce((sum, group) => sum + group.cousins.length, 0),
+      auntsUncles: cousinMap.auntUncleIds.map((id) => transformPersonToAPI(graph.people.get(id))),
+      groups
+    })
+  } catch (error) {
+    console.error('Error building cousin map:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,46 @@
+/**
+ * GET /api/people/[id]/descendant-growth
+ * Returns how a person's line of descendants grew over time
+ *
+ * Descendants are bucketed by birth decade; each decade carries the number
+ * born in it and the cumulative number born up to and including it. Decades
+ * without births between the first and last are included, so the series
+ * can be charted directly. Descendants without a birth year are only
+ * counted in undated.
+ *
+ * @returns {Response} JSON { personId, total, undated, decades: [{ decade, born, cumulative }] }
+ *   where decade is the decade's first year
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { loadFamilyGraph, computeDescendantGrowth } from '$lib/server/familyGraph.js'
+import { parseId } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function GET({ params, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const personId = parseId(params.id)
+    if (personId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    const graph = await loadFamilyGraph(database)
+
+    if (!graph.people.has(personId)) {
+      return jsonError(404, 'Person not found')
+    }
+
+    return json({
+      personId,
+      ...computeDescendantGrowth(graph, personId)
+    })
+  } catch (error) {
+    console.error('Error computing descendant growth:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,69 @@
+/**
+ * GET /api/people/[id]/descendant-summary
+ * Returns descendant counts split into living and deceased, per generation
+ *
+ * Generation 1 is children, 2 is grandchildren, and so on. Living status
+ * follows isPersonLiving (no death date and born within the maximum lifespan).
+ * Useful for reunion planning.
+ *
+ * @returns {Response} JSON { personId, total, living, deceased, generations: [{ generation, total, living, deceased }] }
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { loadFamilyGraph, getDescendants } from '$lib/server/familyGraph.js'
+import { parseId, isPersonLiving } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function GET({ params, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const personId = parseId(params.id)
+    if (personId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    const graph = await loadFamilyGraph(database)
+
+    if (!graph.people.has(personId)) {
+      return jsonError(404, 'Person not found')
+    }
+
+    const today = new Date()
+    const byGeneration = new Map()
+    let living = 0
+
+    for (const [descendantId, generation] of getDescendants(graph, personId)) {
+      if (!byGeneration.has(generation)) {
+        byGeneration.set(generation, { generation, total: 0, living: 0, deceased: 0 })
+      }
+
+      const entry = byGeneration.get(generation)
+      entry.total++
+
+      if (isPersonLiving(graph.people.get(descendantId), today)) {
+        entry.living++
+        living++
+      } else {
+        entry.deceased++
+      }
+    }
+
+    const generations = [...byGeneration.values()].sort((a, b) => a.generation - b.generation)
+    const total = generations.reduce((sum, entry) => sum + entry.total, 0)
+
+    return json({
+      personId,
+      total,
+      living,
+      deceased: total - living,
+      generations
+    })
+  } catch (error) {
+    console.error('Error computing descendant summary:', error)
+    return internalError(error)
+  }
+}
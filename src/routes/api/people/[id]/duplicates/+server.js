@@ -14,9 +14,10 @@
 import { json } from '@sveltejs/kit'
 import { db } from '$lib/db/client.js'
 import { people } from '$lib/db/schema.js'
-import { eq } from 'drizzle-orm'
+import { eq, and, isNull } from 'drizzle-orm'
 import { findDuplicatesForPerson } from '$lib/server/duplicateDetection.js'
 import { transformPeopleToAPI, transformPersonToAPI } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
 
 export async function GET({ locals, url, params }) {
   try {
@@ -26,7 +27,7 @@ export async function GET({ locals, url, params }) {
     // Parse person ID
     const personId = parseInt(params.id, 10)
     if (isNaN(personId)) {
-      return new Response('Invalid person ID', { status: 400 })
+      return jsonError(400, 'Invalid person ID')
     }
 
     // Parse query parameters
@@ -38,30 +39,31 @@ export async function GET({ locals, url, params }) {
 
     // Validate threshold
     if (isNaN(threshold) || threshold < 0 || threshold > 100) {
-      return new Response('Invalid threshold parameter (must be 0-100)', { status: 400 })
+      return jsonError(400, 'Invalid threshold parameter (must be 0-100)')
     }
 
     // Validate limit
     if (limit !== null && (isNaN(limit) || limit < 1)) {
-      return new Response('Invalid limit parameter (must be positive integer)', { status: 400 })
+      return jsonError(400, 'Invalid limit parameter (must be positive integer)')
     }
 
     // Find the target person
     const targetPersonResult = await database
       .select()
       .from(people)
-      .where(eq(people.id, personId))
+      .where(and(eq(people.id, personId), isNull(people.deletedAt)))
 
     if (targetPersonResult.length === 0) {
-      return new Response('Person not found', { status: 404 })
+      return jsonError(404, 'Person not found')
     }
 
     const targetPerson = targetPersonResult[0]
 
-    // Query all people
+    // Query all people (excluding soft-deleted)
     const allPeople = await database
       .select()
       .from(people)
+      .where(isNull(people.deletedAt))
 
     // Transform to API format
     const transformedPeople = transformPeopleToAPI(allPeople)
@@ -78,6 +80,6 @@ export async function GET({ locals, url, params }) {
     return json(duplicates)
   } catch (error) {
     console.error('Error finding duplicates for person:', error)
-    return new Response('Internal Server Error', { status: 500 })
+    return internalError(error)
   }
 }
@@ -0,0 +1,125 @@
+/**
+ * GET /api/people/[id]/extended-affinity
+ * Returns the former spouses of a person's current spouses, e.g. a wife's
+ * ex-husband, for navigating blended families
+ *
+ * A marriage is current when its spouse relationship has no end date and
+ * former when it has one; marriages without an end date are never treated
+ * as former. The person themselves is never listed, and soft-deleted people
+ * are excluded. A couple stored in both directions is listed once.
+ *
+ * @returns {Response} JSON { personId, formerSpouses: [{ person, label, via, relationshipId,
+ *   startDate, endDate }] } where via is the current spouse and the dates are the
+ *   former marriage's, ordered by current spouse ID, then end date, then person ID
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { people, relationships } from '$lib/db/schema.js'
+import { and, eq, inArray, isNotNull, isNull, or } from 'drizzle-orm'
+import { describeSpouseFormerSpouse } from '$lib/server/kinship.js'
+import { parseId, transformPersonToAPI } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function GET({ params, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const personId = parseId(params.id)
+    if (personId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    const [person] = await database
+      .select({ id: people.id })
+      .from(people)
+      .where(and(eq(people.id, personId), isNull(people.deletedAt)))
+
+    if (!person) {
+      return jsonError(404, 'Person not found')
+    }
+
+    const otherSide = (row, id) => (row.person1Id === id ? row.person2Id : row.person1Id)
+
+    const currentMarriages = await database
+      .select()
+      .from(relationships)
+      .where(
+        and(
+          eq(relationships.type, 'spouse'),
+          isNull(relationships.endDate),
+          or(eq(relationships.person1Id, personId), eq(relationships.person2Id, personId))
+        )
+      )
+    const spouseIds = [...new Set(currentMarriages.map((row) => otherSide(row, personId)))]
+
+    const formerMarriages = spouseIds.length === 0
+      ? []
+      : await database
+        .select()
+        .from(relationships)
+        .where(
+          and(
+            eq(relationships.type, 'spouse'),
+            isNotNull(relationships.endDate),
+            or(inArray(relationships.person1Id, spouseIds), inArray(relationships.person2Id, spouseIds))
+          )
+        )
+
+    const peopleIds = [...new Set([
+      ...spouseIds,
+      ...formerMarriages.flatMap((row) => [row.person1Id, row.person2Id])
+    ])]
+    const peopleById = new Map()
+    if (peopleIds.length > 0) {
+      const rows = await database
+        .select()
+        .from(people)
+        .where(and(inArray(people.id, peopleIds), isNull(people.deletedAt)))
+      for (const row of rows) peopleById.set(row.id, row)
+    }
+
+    const formerSpouses = new Map()
+    for (const spouseId of spouseIds) {
+      const spouse = peopleById.get(spouseId)
+      if (!spouse) continue
+
+      for (const row of formerMarriages) {
+        if (row.person1Id !== spouseId && row.person2Id !== spouseId) continue
+
+        const former = peopleById.get(otherSide(row, spouseId))
+        const key = `${spouseId}:${former?.id}`
+        if (!former || former.id === personId || formerSpouses.has(key)) continue
+
+        formerSpouses.set(key, {
+          person: former,
+          label: describeSpouseFormerSpouse(spouse.gender, former.gender),
+          via: spouse,
+          relationshipId: row.id,
+          startDate: row.startDate,
+          endDate: row.endDate
+        })
+      }
+    }
+
+    const sorted = [...formerSpouses.values()].sort((a, b) =>
+      a.via.id - b.via.id ||
+      a.endDate.localeCompare(b.endDate) ||
+      a.person.id - b.person.id
+    )
+
+    return json({
+      personId,
+      formerSpouses: sorted.map((entry) => ({
+        ...entry,
+        person: transformPersonToAPI(entry.person),
+        via: transformPersonToAPI(entry.via)
+      }))
+    })
+  } catch (error) {
+    console.error('Error fetching extended affinity:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,51 @@
+/**
+ * GET /api/people/[id]/generations
+ * Assigns every person connected to the root a generation number for
+ * laying out the tree by rank: 0 is the root, negative numbers are
+ * ancestors' generations, positive numbers descendants'. Spouses share
+ * the generation of the person they married. People with no connection
+ * to the root are omitted.
+ *
+ * Query parameters:
+ * - kinship: "legal" (default) counts adoptive parent edges, "blood" ignores them
+ *
+ * @returns {Response} JSON { personId, generations: { [personId]: generation } }
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { loadFamilyGraph, parseKinshipMode, assignGenerations } from '$lib/server/familyGraph.js'
+import { parseId } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function GET({ params, locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const personId = parseId(params.id)
+    if (personId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    const kinshipMode = parseKinshipMode(url)
+    if (!kinshipMode.valid) {
+      return jsonError(400, kinshipMode.error)
+    }
+
+    const graph = await loadFamilyGraph(database, { kinship: kinshipMode.mode })
+
+    if (!graph.people.has(personId)) {
+      return jsonError(404, 'Person not found')
+    }
+
+    return json({
+      personId,
+      generations: Object.fromEntries(assignGenerations(graph, personId))
+    })
+  } catch (error) {
+    console.error('Error assigning generations:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,60 @@
+/**
+ * GET /api/people/[id]/mrca/[otherId]
+ * Returns the most recent common ancestor of two people
+ *
+ * The ancestor is the one with the smallest combined generational distance.
+ * When one person descends from the other, the older person is returned
+ * with a distance of 0 from themselves. ancestor is null when the two are
+ * not related by blood.
+ *
+ * Query parameters:
+ * - kinship: "legal" (default) counts adoptive parent edges, "blood" ignores them
+ *
+ * @returns {Response} JSON { person1Id, person2Id, ancestor, distance1, distance2 }
+ *   where distance1 and distance2 are generations from each person to the ancestor (null when unrelated)
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { loadFamilyGraph, parseKinshipMode } from '$lib/server/familyGraph.js'
+import { findMostRecentCommonAncestor } from '$lib/server/kinship.js'
+import { parseId, transformPersonToAPI } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function GET({ params, locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate IDs
+    const person1Id = parseId(params.id)
+    const person2Id = parseId(params.otherId)
+    if (person1Id === null || person2Id === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    const kinshipMode = parseKinshipMode(url)
+    if (!kinshipMode.valid) {
+      return jsonError(400, kinshipMode.error)
+    }
+
+    const graph = await loadFamilyGraph(database, { kinship: kinshipMode.mode })
+
+    if (!graph.people.has(person1Id) || !graph.people.has(person2Id)) {
+      return jsonError(404, 'Person not found')
+    }
+
+    const mrca = findMostRecentCommonAncestor(graph, person1Id, person2Id)
+
+    return json({
+      person1Id,
+      person2Id,
+      ancestor: mrca ? transformPersonToAPI(graph.people.get(mrca.ancestorId)) : null,
+      distance1: mrca ? mrca.distance1 : null,
+      distance2: mrca ? mrca.distance2 : null
+    })
+  } catch (error) {
+    console.error('Error finding most recent common ancestor:', error)
+    return internalError(error)
+  }
+}
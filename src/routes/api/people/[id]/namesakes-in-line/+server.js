@@ -0,0 +1,57 @@
+/**
+ * GET /api/people/[id]/namesakes-in-line
+ * Returns ancestors and descendants in a person's direct line who share
+ * the person's first name, revealing naming traditions across generations
+ *
+ * Query parameters:
+ * - kinship: "legal" (default) counts adoptive parent edges, "blood" ignores them
+ *
+ * @returns {Response} JSON { personId, firstName, ancestors: [{ person, generation }],
+ *   descendants: [{ person, generation }] } ordered by generation
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { loadFamilyGraph, parseKinshipMode, findNamesakesInLine } from '$lib/server/familyGraph.js'
+import { parseId, transformPersonToAPI } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function GET({ params, locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const personId = parseId(params.id)
+    if (personId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    const kinshipMode = parseKinshipMode(url)
+    if (!kinshipMode.valid) {
+      return jsonError(400, kinshipMode.error)
+    }
+
+    const graph = await loadFamilyGraph(database, { kinship: kinshipMode.mode })
+
+    if (!graph.people.has(personId)) {
+      return jsonError(404, 'Person not found')
+    }
+
+    const namesakes = findNamesakesInLine(graph, personId)
+    const toEntry = (entry) => ({
+      person: transformPersonToAPI(graph.people.get(entry.personId)),
+      generation: entry.generation
+    })
+
+    return json({
+      personId,
+      firstName: graph.people.get(personId).firstName,
+      ancestors: namesakes.ancestors.map(toEntry),
+      descendants: namesakes.descendants.map(toEntry)
+    })
+  } catch (error) {
+    console.error('Error finding namesakes in line:', error)
+    return internalError(error)
+  }
+}
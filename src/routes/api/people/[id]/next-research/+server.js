@@ -0,0 +1,43 @@
+/**
+ * GET /api/people/[id]/next-research
+ * Returns a single prioritized research suggestion for a person
+ *
+ * Suggestions consider the person's own missing parents and fields first,
+ * then gaps among their ancestors (nearest generation first). Each suggestion
+ * includes a human-readable task and a rationale.
+ *
+ * @returns {Response} JSON { personId, suggestion } where suggestion is null when nothing is missing
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { loadFamilyGraph } from '$lib/server/familyGraph.js'
+import { suggestNextResearch } from '$lib/server/researchSuggestions.js'
+import { parseId } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function GET({ params, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const personId = parseId(params.id)
+    if (personId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    const graph = await loadFamilyGraph(database)
+
+    if (!graph.people.has(personId)) {
+      return jsonError(404, 'Person not found')
+    }
+
+    const suggestion = suggestNextResearch(graph, personId)
+
+    return json({ personId, suggestion })
+  } catch (error) {
+    console.error('Error computing next research task:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,59 @@
+/**
+ * GET /api/people/[id]/notable-connections
+ * Returns how a person descends from each of their notable ancestors
+ *
+ * Each connection is the shortest line from the person up to a notable
+ * ancestor, with the ancestor's kinship label from the person's perspective
+ * ("paternal great-grandfather"). Ancestors that are not notable, and notable people
+ * who are not ancestors, are not listed.
+ *
+ * Query parameters:
+ * - kinship: "legal" (default) counts adoptive parent edges, "blood" ignores them
+ *
+ * @returns {Response} JSON { personId, connections: [{ person, label, generations, path }] }
+ *   nearest ancestor first; path lists the people from the person up to the ancestor
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { findNotableAncestorPaths, loadFamilyGraph, parseKinshipMode } from '$lib/server/familyGraph.js'
+import { describeKinship } from '$lib/server/kinship.js'
+import { parseId, transformPersonToAPI } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function GET({ params, locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const personId = parseId(params.id)
+    if (personId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    const kinshipMode = parseKinshipMode(url)
+    if (!kinshipMode.valid) {
+      return jsonError(400, kinshipMode.error)
+    }
+
+    const graph = await loadFamilyGraph(database, { kinship: kinshipMode.mode })
+
+    if (!graph.people.has(personId)) {
+      return jsonError(404, 'Person not found')
+    }
+
+    return json({
+      personId,
+      connections: findNotableAncestorPaths(graph, personId).map(({ ancestorId, generations, path }) => ({
+        person: transformPersonToAPI(graph.people.get(ancestorId)),
+        label: describeKinship(graph, personId, ancestorId).label,
+        generations,
+        path: path.map((id) => transformPersonToAPI(graph.people.get(id)))
+      }))
+    })
+  } catch (error) {
+    console.error('Error finding notable connections:', error)
+    return internalError(error)
+  }
+}
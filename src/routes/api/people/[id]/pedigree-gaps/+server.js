@@ -0,0 +1,60 @@
+/**
+ * GET /api/people/[id]/pedigree-gaps
+ * Lists the missing ancestors at each pedigree level by Ahnentafel number
+ * (2 = father, 3 = mother, 4-7 = grandparents, 8-15 = great-grandparents, ...)
+ *
+ * Query parameters:
+ * - generations: 2 to 10, counting the subject (default: 4)
+ *
+ * @returns {Response} JSON { personId, generations, totalMissing, levels: [{ generation, expected, known, missing }] }
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { loadFamilyGraph } from '$lib/server/familyGraph.js'
+import { buildAhnentafel, findPedigreeGaps } from '$lib/server/pedigree.js'
+import { parseId } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+const DEFAULT_GENERATIONS = 4
+const MIN_GENERATIONS = 2
+const MAX_GENERATIONS = 10
+
+export async function GET({ params, locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const personId = parseId(params.id)
+    if (personId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    // Validate generations
+    const generationsParam = url?.searchParams?.get('generations')
+    const generations = generationsParam ? Number(generationsParam) : DEFAULT_GENERATIONS
+    if (!Number.isInteger(generations) || generations < MIN_GENERATIONS || generations > MAX_GENERATIONS) {
+      return jsonError(400, `generations must be an integer between ${MIN_GENERATIONS} and ${MAX_GENERATIONS}`)
+    }
+
+    const graph = await loadFamilyGraph(database)
+
+    if (!graph.people.has(personId)) {
+      return jsonError(404, 'Person not found')
+    }
+
+    const slots = buildAhnentafel(graph, personId, generations)
+    const levels = findPedigreeGaps(slots, generations)
+
+    return json({
+      personId,
+      generations,
+      totalMissing: levels.reduce((sum, level) => sum + level.missing.length, 0),
+      levels
+    })
+  } catch (error) {
+    console.error('Error computing pedigree gaps:', error)
+    return internalError(error)
+  }
+}
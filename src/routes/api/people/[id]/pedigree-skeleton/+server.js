@@ -0,0 +1,114 @@
+/**
+ * POST /api/people/[id]/pedigree-skeleton
+ * Creates placeholder ancestors for every empty slot in a person's pedigree
+ *
+ * Each placeholder is named after the child it belongs to ("Unknown Father
+ * of John Smith"), gets its gender from the slot (even Ahnentafel numbers
+ * are fathers, odd numbers mothers), and is linked to that child with a
+ * parentOf relationship. Placeholders can later be filled in or resolved
+ * to a real person. Everything is created in one transaction.
+ *
+ * Query parameters:
+ * - generations: 2 to 5, counting the subject (default: 3, i.e. up to grandparents)
+ *
+ * @returns {Response} JSON { personId, generations, created: [{ ahnentafel, person }] } with 201 status
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { people } from '$lib/db/schema.js'
+import { loadFamilyGraph } from '$lib/server/familyGraph.js'
+import { buildAhnentafel, planPedigreeSkeleton } from '$lib/server/pedigree.js'
+import { parseId, transformPersonToAPI, buildPersonInsertValues } from '$lib/server/personHelpers.js'
+import { normalizeRelationship } from '$lib/server/relationshipHelpers.js'
+import { createRelationship } from '$lib/server/relationshipCreation.js'
+import { recordAudit, AUDIT_ENTITY_TYPES, AUDIT_ACTIONS } from '$lib/server/auditLog.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+const DEFAULT_GENERATIONS = 3
+const MIN_GENERATIONS = 2
+const MAX_GENERATIONS = 5
+
+/**
+ * Thrown inside the transaction to roll it back when a parent link is rejected
+ */
+class SkeletonLinkError extends Error {}
+
+/**
+ * Formats a person's name for placeholder names
+ *
+ * @param {Object} person - Person record
+ * @returns {string} "First Last"
+ */
+function displayName(person) {
+  return [person.firstName, person.lastName].filter(Boolean).join(' ')
+}
+
+export async function POST({ params, locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const personId = parseId(params.id)
+    if (personId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    // Validate generations
+    const generationsParam = url?.searchParams?.get('generations')
+    const generations = generationsParam ? Number(generationsParam) : DEFAULT_GENERATIONS
+    if (!Number.isInteger(generations) || generations < MIN_GENERATIONS || generations > MAX_GENERATIONS) {
+      return jsonError(400, `generations must be an integer between ${MIN_GENERATIONS} and ${MAX_GENERATIONS}`)
+    }
+
+    const graph = await loadFamilyGraph(database)
+
+    if (!graph.people.has(personId)) {
+      return jsonError(404, 'Person not found')
+    }
+
+    const slots = buildAhnentafel(graph, personId, generations)
+    const plan = planPedigreeSkeleton(slots, generations)
+
+    let created
+    try {
+      created = database.transaction((tx) => {
+        // Ahnentafel number => person record, for known people and new placeholders alike
+        const occupants = new Map([...slots].map(([number, id]) => [number, graph.people.get(id)]))
+
+        return plan.map(({ number, childNumber, role, gender }) => {
+          const child = occupants.get(childNumber)
+          const inserted = tx
+            .insert(people)
+            .values(buildPersonInsertValues({
+              firstName: 'Unknown',
+              lastName: `${role === 'father' ? 'Father' : 'Mother'} of ${displayName(child)}`,
+              gender
+            }))
+            .returning()
+            .get()
+
+          const person = transformPersonToAPI(inserted)
+          recordAudit(tx, AUDIT_ENTITY_TYPES.person, person.id, AUDIT_ACTIONS.create, { after: person })
+
+          const link = createRelationship(tx, normalizeRelationship(inserted.id, child.id, role))
+          if (link.error) {
+            throw new SkeletonLinkError(link.error)
+          }
+
+          occupants.set(number, inserted)
+          return { ahnentafel: number, person }
+        })
+      }, { behavior: 'immediate' })
+    } catch (linkError) {
+      if (!(linkError instanceof SkeletonLinkError)) throw linkError
+      return jsonError(400, linkError.message)
+    }
+
+    return json({ personId, generations, created }, { status: 201 })
+  } catch (error) {
+    console.error('Error creating pedigree skeleton:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,56 @@
+/**
+ * GET /api/people/[id]/pedigree.svg
+ * Renders a printable pedigree chart of a person's direct ancestors as SVG
+ *
+ * Query parameters:
+ * - generations: 4 or 5, counting the subject (default: 4)
+ *
+ * @returns {Response} SVG document (Content-Type: image/svg+xml)
+ */
+
+import { db } from '$lib/db/client.js'
+import { loadFamilyGraph } from '$lib/server/familyGraph.js'
+import { buildAhnentafel, renderPedigreeSvg } from '$lib/server/pedigree.js'
+import { parseId } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+const ALLOWED_GENERATIONS = [4, 5]
+
+export async function GET({ params, locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const personId = parseId(params.id)
+    if (personId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    // Validate generations
+    const generationsParam = url?.searchParams?.get('generations')
+    const generations = generationsParam ? Number(generationsParam) : 4
+    if (!ALLOWED_GENERATIONS.includes(generations)) {
+      return jsonError(400, 'generations must be 4 or 5')
+    }
+
+    const graph = await loadFamilyGraph(database)
+
+    if (!graph.people.has(personId)) {
+      return jsonError(404, 'Person not found')
+    }
+
+    const slots = buildAhnentafel(graph, personId, generations)
+    const svg = renderPedigreeSvg(graph, slots, generations)
+
+    return new Response(svg, {
+      status: 200,
+      headers: {
+        'Content-Type': 'image/svg+xml'
+      }
+    })
+  } catch (error) {
+    console.error('Error rendering pedigree chart:', error)
+    return internalError(error)
+  }
+}
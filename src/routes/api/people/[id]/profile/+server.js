@@ -0,0 +1,98 @@
+/**
+ * GET /api/people/[id]/profile
+ * Returns everything the profile editor needs for one person in a single call
+ *
+ * Sections:
+ * - person: the person in API format
+ * - living/age: presumed living status (see isPersonLiving) and age in
+ *   years (age at death for deceased people; null when unknown)
+ * - relationships: the person's relationships in the same editable form
+ *   as GET /api/relationships, each with a summary of the related person
+ * - photos: photo metadata (the person's photoUrl, when set)
+ * - aliases: alternate names (nickname and birth surname, when set)
+ *
+ * The data model has no separate source or tag records, so there are no
+ * sources or tags sections. Relationships involving soft-deleted people
+ * are excluded.
+ *
+ * @returns {Response} JSON { person, living, age, relationships: [{ ...relationship,
+ *   relatedPerson: { id, firstName, lastName } }], photos: [{ url, primary }],
+ *   aliases: [{ type, value }] }
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { people, relationships } from '$lib/db/schema.js'
+import { and, asc, eq, inArray, isNull, or } from 'drizzle-orm'
+import { parseId, transformPersonToAPI, isPersonLiving, computeAge } from '$lib/server/personHelpers.js'
+import { transformRelationshipToAPI } from '$lib/server/relationshipHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function GET({ params, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const personId = parseId(params.id)
+    if (personId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    const [person] = await database
+      .select()
+      .from(people)
+      .where(and(eq(people.id, personId), isNull(people.deletedAt)))
+
+    if (!person) {
+      return jsonError(404, 'Person not found')
+    }
+
+    const personRelationships = await database
+      .select()
+      .from(relationships)
+      .where(or(eq(relationships.person1Id, personId), eq(relationships.person2Id, personId)))
+      .orderBy(asc(relationships.id))
+
+    const relatedIds = [...new Set(personRelationships.map((relationship) =>
+      relationship.person1Id === personId ? relationship.person2Id : relationship.person1Id
+    ))]
+
+    const relatedPeople = new Map()
+    if (relatedIds.length > 0) {
+      const rows = await database
+        .select({ id: people.id, firstName: people.firstName, lastName: people.lastName })
+        .from(people)
+        .where(and(inArray(people.id, relatedIds), isNull(people.deletedAt)))
+      for (const row of rows) relatedPeople.set(row.id, row)
+    }
+
+    const profileRelationships = []
+    for (const relationship of personRelationships) {
+      const relatedId = relationship.person1Id === personId ? relationship.person2Id : relationship.person1Id
+      if (!relatedPeople.has(relatedId)) continue
+      profileRelationships.push({
+        ...transformRelationshipToAPI(relationship),
+        relatedPerson: relatedPeople.get(relatedId)
+      })
+    }
+
+    const aliases = []
+    if (person.nickname) aliases.push({ type: 'nickname', value: person.nickname })
+    if (person.birthSurname) aliases.push({ type: 'birthSurname', value: person.birthSurname })
+
+    const today = new Date()
+
+    return json({
+      person: transformPersonToAPI(person),
+      living: isPersonLiving(person, today),
+      age: computeAge(person, today),
+      relationships: profileRelationships,
+      photos: person.photoUrl ? [{ url: person.photoUrl, primary: true }] : [],
+      aliases
+    })
+  } catch (error) {
+    console.error('Error fetching profile:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,47 @@
+/**
+ * GET /api/people/[id]/quality
+ * Returns data quality indicators and an overall grade for a person,
+ * used for profile badges
+ *
+ * Indicators: birth date recorded, death date recorded or presumed living
+ * (by birth date), both parents recorded, and gender set. The grade runs
+ * from A (all indicators met) to F. See personQuality.js.
+ *
+ * @returns {Response} JSON { personId, indicators: { [name]: boolean }, passed, total, grade }
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { loadFamilyGraph } from '$lib/server/familyGraph.js'
+import { parseId } from '$lib/server/personHelpers.js'
+import { assessPersonQuality } from '$lib/server/personQuality.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function GET({ params, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const personId = parseId(params.id)
+    if (personId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    const graph = await loadFamilyGraph(database)
+
+    if (!graph.people.has(personId)) {
+      return jsonError(404, 'Person not found')
+    }
+
+    const parentIds = new Set((graph.parents.get(personId) || []).map((parent) => parent.id))
+
+    return json({
+      personId,
+      ...assessPersonQuality(graph.people.get(personId), parentIds.size)
+    })
+  } catch (error) {
+    console.error('Error assessing person quality:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,63 @@
+/**
+ * GET /api/people/[id]/relationship-label/[otherId]
+ * Returns a human-readable kinship term describing [otherId] relative to [id]
+ *
+ * Examples: "paternal grandmother", "first cousin once removed", "brother-in-law".
+ * The label is derived from the structural path (generations up to the common
+ * ancestor, generations down to the target, and spouse hops) and the target's gender.
+ * Returns "no known relationship" when the two people are not connected.
+ *
+ * Query parameters:
+ * - kinship: "legal" (default) counts adoptive parent edges, "blood" ignores them
+ * - lang: label language, "en" (default) or "es"; unsupported languages fall back to English
+ *
+ * @returns {Response} JSON { person1Id, person2Id, lang, label, related, relationship }
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { loadFamilyGraph, parseKinshipMode } from '$lib/server/familyGraph.js'
+import { describeKinship, resolveKinshipLanguage } from '$lib/server/kinship.js'
+import { parseId } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function GET({ params, locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate IDs
+    const person1Id = parseId(params.id)
+    const person2Id = parseId(params.otherId)
+    if (person1Id === null || person2Id === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    const kinshipMode = parseKinshipMode(url)
+    if (!kinshipMode.valid) {
+      return jsonError(400, kinshipMode.error)
+    }
+
+    const lang = resolveKinshipLanguage(url.searchParams.get('lang'))
+
+    const graph = await loadFamilyGraph(database, { kinship: kinshipMode.mode })
+
+    if (!graph.people.has(person1Id) || !graph.people.has(person2Id)) {
+      return jsonError(404, 'Person not found')
+    }
+
+    const { label, kinship } = describeKinship(graph, person1Id, person2Id, lang)
+
+    return json({
+      person1Id,
+      person2Id,
+      lang,
+      label,
+      related: kinship !== null,
+      relationship: kinship
+    })
+  } catch (error) {
+    console.error('Error computing relationship label:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,62 @@
+/**
+ * GET /api/people/[id]/relationships
+ * Returns every relationship in which a person appears as person1 or person2
+ *
+ * Loads a person detail panel without fetching and filtering the global
+ * relationships list. Relationships involving soft-deleted people are
+ * excluded, as in GET /api/relationships.
+ *
+ * @returns {Response} JSON array of relationships ordered by ID (empty when none)
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { people, relationships } from '$lib/db/schema.js'
+import { and, asc, eq, isNotNull, isNull, notInArray, or } from 'drizzle-orm'
+import { parseId } from '$lib/server/personHelpers.js'
+import { transformRelationshipsToAPI } from '$lib/server/relationshipHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function GET({ params, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const personId = parseId(params.id)
+    if (personId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    const [person] = await database
+      .select({ id: people.id })
+      .from(people)
+      .where(and(eq(people.id, personId), isNull(people.deletedAt)))
+
+    if (!person) {
+      return jsonError(404, 'Person not found')
+    }
+
+    const deletedPeople = database
+      .select({ id: people.id })
+      .from(people)
+      .where(isNotNull(people.deletedAt))
+
+    const personRelationships = await database
+      .select()
+      .from(relationships)
+      .where(
+        and(
+          or(eq(relationships.person1Id, personId), eq(relationships.person2Id, personId)),
+          notInArray(relationships.person1Id, deletedPeople),
+          notInArray(relationships.person2Id, deletedPeople)
+        )
+      )
+      .orderBy(asc(relationships.id))
+
+    return json(transformRelationshipsToAPI(personRelationships))
+  } catch (error) {
+    console.error('Error fetching person relationships:', error)
+    return internalError(error)
+  }
+}
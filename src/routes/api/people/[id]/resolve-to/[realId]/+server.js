@@ -0,0 +1,64 @@
+/**
+ * POST /api/people/[id]/resolve-to/[realId] - Resolve Placeholder
+ *
+ * Replaces placeholder person [id] with real person [realId]: moves all of the
+ * placeholder's relationships, copies placeholder-only metadata, removes
+ * duplicate edges, and deletes the placeholder, all in one transaction.
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { resolvePlaceholder } from '$lib/server/placeholderResolution.js'
+import { parseId, transformPersonToAPI } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+/**
+ * POST /api/people/[id]/resolve-to/[realId]
+ *
+ * Response:
+ * {
+ *   success: true,
+ *   placeholderId: number,
+ *   realId: number,
+ *   relationshipsTransferred: number,
+ *   relationshipsSkipped: number,
+ *   filledFields: string[],
+ *   person: { ... }  // Real person after resolution
+ * }
+ *
+ * Error responses:
+ * - 400: Invalid ID or placeholder and real person are the same
+ * - 404: Person not found
+ * - 500: Server error
+ */
+export async function POST({ params, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    const placeholderId = parseId(params.id)
+    const realId = parseId(params.realId)
+    if (placeholderId === null || realId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    if (placeholderId === realId) {
+      return jsonError(400, 'Cannot resolve a person to themselves')
+    }
+
+    const result = await resolvePlaceholder(placeholderId, realId, database)
+
+    return json({
+      ...result,
+      person: transformPersonToAPI(result.person)
+    })
+  } catch (error) {
+    console.error('Error resolving placeholder:', error)
+
+    if (error.message.includes('not found')) {
+      return jsonError(404, error.message)
+    }
+
+    return internalError(error)
+  }
+}
@@ -0,0 +1,61 @@
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { people } from '$lib/db/schema.js'
+import { eq } from 'drizzle-orm'
+import { parseId, transformPersonToAPI } from '$lib/server/personHelpers.js'
+import { recordAudit, AUDIT_ENTITY_TYPES, AUDIT_ACTIONS } from '$lib/server/auditLog.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+/**
+ * POST /api/people/[id]/restore
+ * Restores a soft-deleted person by clearing deleted_at
+ * Relationships retained during the soft delete become visible again
+ *
+ * @param {Object} params - URL parameters containing id
+ * @returns {Response} JSON of restored person, 404 if not found, 400 if not deleted
+ */
+export async function POST({ params, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const personId = parseId(params.id)
+    if (personId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    // Check if person exists (including soft-deleted)
+    const existing = await database
+      .select()
+      .from(people)
+      .where(eq(people.id, personId))
+      .limit(1)
+
+    if (existing.length === 0) {
+      return jsonError(404, 'Person not found')
+    }
+
+    if (existing[0].deletedAt === null) {
+      return jsonError(400, 'Person is not deleted')
+    }
+
+    // Clear the soft-delete timestamp
+    const restored = database.transaction((tx) => {
+      const person = transformPersonToAPI(
+        tx.update(people)
+          .set({ deletedAt: null })
+          .where(eq(people.id, personId))
+          .returning()
+          .get()
+      )
+      recordAudit(tx, AUDIT_ENTITY_TYPES.person, personId, AUDIT_ACTIONS.restore, { after: person })
+      return person
+    })
+
+    return json(restored)
+  } catch (error) {
+    console.error('Error restoring person:', error)
+    return internalError(error)
+  }
+}
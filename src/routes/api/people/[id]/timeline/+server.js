@@ -0,0 +1,80 @@
+/**
+ * GET /api/people/[id]/timeline
+ * Returns the dated events of a person's life in order, for a biography view
+ *
+ * Events are the person's birth and death, marriages and divorces (from the
+ * start and end dates of spouse relationships), and the births of their
+ * children. Step-children are left out. Events without a date are skipped,
+ * as are soft-deleted spouses and children.
+ *
+ * @returns {Response} JSON { personId, events: [{ date, type, description, personId }] }
+ *   where type is one of TIMELINE_EVENT_TYPES and personId is the spouse or child involved
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { people, relationships } from '$lib/db/schema.js'
+import { and, eq, inArray, isNull, or } from 'drizzle-orm'
+import { parseId } from '$lib/server/personHelpers.js'
+import { parentRoleKind } from '$lib/server/relationshipHelpers.js'
+import { buildPersonTimeline } from '$lib/server/personTimeline.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function GET({ params, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const personId = parseId(params.id)
+    if (personId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    const [person] = await database
+      .select()
+      .from(people)
+      .where(and(eq(people.id, personId), isNull(people.deletedAt)))
+
+    if (!person) {
+      return jsonError(404, 'Person not found')
+    }
+
+    const rows = await database
+      .select()
+      .from(relationships)
+      .where(or(eq(relationships.person1Id, personId), eq(relationships.person2Id, personId)))
+
+    const relatedIds = [...new Set(rows.map((rel) => (rel.person1Id === personId ? rel.person2Id : rel.person1Id)))]
+    const relatedRows = relatedIds.length === 0
+      ? []
+      : await database
+        .select()
+        .from(people)
+        .where(and(inArray(people.id, relatedIds), isNull(people.deletedAt)))
+    const relatedById = new Map(relatedRows.map((row) => [row.id, row]))
+
+    const marriages = rows
+      .filter((rel) => rel.type === 'spouse')
+      .map((rel) => ({
+        spouse: relatedById.get(rel.person1Id === personId ? rel.person2Id : rel.person1Id),
+        startDate: rel.startDate,
+        endDate: rel.endDate
+      }))
+      .filter((marriage) => marriage.spouse)
+
+    const children = rows
+      .filter((rel) => rel.type === 'parentOf' && rel.person1Id === personId)
+      .filter((rel) => (rel.relationKind || parentRoleKind(rel.parentRole)) !== 'step')
+      .map((rel) => relatedById.get(rel.person2Id))
+      .filter(Boolean)
+
+    return json({
+      personId,
+      events: buildPersonTimeline(person, { marriages, children })
+    })
+  } catch (error) {
+    console.error('Error building timeline:', error)
+    return internalError(error)
+  }
+}
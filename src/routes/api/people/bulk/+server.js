@@ -0,0 +1,79 @@
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { people } from '$lib/db/schema.js'
+import {
+  validatePersonData,
+  transformPersonToAPI,
+  buildPersonInsertValues
+} from '$lib/server/personHelpers.js'
+import { recordAudit, AUDIT_ENTITY_TYPES, AUDIT_ACTIONS } from '$lib/server/auditLog.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+/**
+ * Maximum number of people accepted in one bulk request
+ */
+const MAX_BULK_PEOPLE = 1000
+
+/**
+ * POST /api/people/bulk
+ * Creates many people at once, e.g. when transcribing a census
+ *
+ * Every entry is validated with the same rules as POST /api/people before
+ * anything is written, and all inserts run in one transaction: either every
+ * person is created or none are.
+ *
+ * Request body: JSON array of person objects
+ *
+ * @returns {Response} JSON array of created people (same order as the request) with 201 status,
+ *   or 400 JSON { index, error } describing the first invalid entry
+ */
+export async function POST({ request, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Parse request body
+    let data
+    try {
+      data = await request.json()
+    } catch (parseError) {
+      return jsonError(400, 'Invalid JSON')
+    }
+
+    if (!Array.isArray(data) || data.length === 0) {
+      return jsonError(400, 'Request body must be a non-empty array of people')
+    }
+    if (data.length > MAX_BULK_PEOPLE) {
+      return jsonError(400, `At most ${MAX_BULK_PEOPLE} people can be created per request`)
+    }
+
+    // Validate every entry before writing anything
+    for (let index = 0; index < data.length; index++) {
+      const entry = data[index]
+      if (!entry || typeof entry !== 'object' || Array.isArray(entry)) {
+        return json({ index, error: 'Each entry must be a person object' }, { status: 400 })
+      }
+
+      const validation = validatePersonData(entry)
+      if (!validation.valid) {
+        return json({ index, error: validation.error }, { status: 400 })
+      }
+    }
+
+    // Insert all people in one transaction (row by row to keep IDs in request order)
+    const created = database.transaction((tx) =>
+      data.map((entry) => {
+        const person = transformPersonToAPI(
+          tx.insert(people).values(buildPersonInsertValues(entry)).returning().get()
+        )
+        recordAudit(tx, AUDIT_ENTITY_TYPES.person, person.id, AUDIT_ACTIONS.create, { after: person })
+        return person
+      })
+    )
+
+    return json(created, { status: 201 })
+  } catch (error) {
+    console.error('Error creating people in bulk:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,78 @@
+/**
+ * GET /api/people/by-marriage-count?min=2
+ * Returns people with at least `min` recorded spouses, for finding
+ * multiple-marriage cases
+ *
+ * Spouse rows may be stored in either direction (or both), so edges are
+ * counted from both sides and each distinct spouse counts once. Soft-deleted
+ * people are excluded on either side of the edge.
+ *
+ * Query Parameters:
+ *   - min: Minimum number of spouses (positive integer, default: 2)
+ *
+ * @returns {Response} JSON array of people with marriageCount and spouseIds,
+ *   ordered by marriageCount descending then ID
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { people } from '$lib/db/schema.js'
+import { inArray, sql } from 'drizzle-orm'
+import { transformPersonToAPI } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function GET({ locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    const minParam = url?.searchParams?.get('min')
+    const min = minParam === null || minParam === undefined ? 2 : Number(minParam)
+    if (!Number.isInteger(min) || min < 1) {
+      return jsonError(400, 'Invalid min parameter (must be positive integer)')
+    }
+
+    // Spouse edges seen from both ends, grouped per person
+    const counts = database.all(sql`
+      SELECT edges.person_id AS personId,
+             COUNT(DISTINCT edges.spouse_id) AS marriageCount,
+             GROUP_CONCAT(DISTINCT edges.spouse_id) AS spouseIds
+      FROM (
+        SELECT person1_id AS person_id, person2_id AS spouse_id
+        FROM relationships WHERE type = 'spouse'
+        UNION ALL
+        SELECT person2_id AS person_id, person1_id AS spouse_id
+        FROM relationships WHERE type = 'spouse'
+      ) AS edges
+      JOIN people AS person ON person.id = edges.person_id AND person.deleted_at IS NULL
+      JOIN people AS spouse ON spouse.id = edges.spouse_id AND spouse.deleted_at IS NULL
+      GROUP BY edges.person_id
+      HAVING COUNT(DISTINCT edges.spouse_id) >= ${min}
+      ORDER BY marriageCount DESC, edges.person_id
+    `)
+
+    if (counts.length === 0) {
+      return json([])
+    }
+
+    const matchedPeople = await database
+      .select()
+      .from(people)
+      .where(inArray(people.id, counts.map((row) => row.personId)))
+    const peopleById = new Map(matchedPeople.map((person) => [person.id, person]))
+
+    const result = counts.map((row) => ({
+      ...transformPersonToAPI(peopleById.get(row.personId)),
+      marriageCount: row.marriageCount,
+      spouseIds: String(row.spouseIds)
+        .split(',')
+        .map(Number)
+        .sort((a, b) => a - b)
+    }))
+
+    return json(result)
+  } catch (error) {
+    console.error('Error fetching people by marriage count:', error)
+    return internalError(error)
+  }
+}
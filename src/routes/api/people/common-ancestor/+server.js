@@ -0,0 +1,72 @@
+/**
+ * POST /api/people/common-ancestor
+ * Returns the most recent ancestor shared by every person in a group
+ *
+ * Useful for confirming that a whole group descends from one couple.
+ * For two people, see GET /api/people/[id]/common-ancestors/[otherId].
+ *
+ * Request body: { "ids": [1, 2, 3] } (at least 3 distinct person IDs)
+ *
+ * @returns {Response} JSON { personIds, commonAncestor, mostRecentCommonAncestors }
+ *   commonAncestor is null when the group shares no ancestor
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { loadFamilyGraph, findGroupCommonAncestors } from '$lib/server/familyGraph.js'
+import { parseId, transformPersonToAPI } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+const MIN_GROUP_SIZE = 3
+
+export async function POST({ request, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Parse request body
+    let data
+    try {
+      data = await request.json()
+    } catch (parseError) {
+      return jsonError(400, 'Invalid JSON')
+    }
+
+    if (!data || !Array.isArray(data.ids)) {
+      return jsonError(400, 'ids must be an array of person IDs')
+    }
+
+    const parsedIds = data.ids.map((id) => parseId(id))
+    if (parsedIds.some((id) => id === null)) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    const personIds = [...new Set(parsedIds)]
+    if (personIds.length < MIN_GROUP_SIZE) {
+      return jsonError(400, `ids must contain at least ${MIN_GROUP_SIZE} distinct person IDs`)
+    }
+
+    const graph = await loadFamilyGraph(database)
+
+    if (personIds.some((id) => !graph.people.has(id))) {
+      return jsonError(404, 'Person not found')
+    }
+
+    const mostRecent = findGroupCommonAncestors(graph, personIds)
+      .filter((entry) => entry.isMostRecent)
+      .map((entry) => ({
+        person: transformPersonToAPI(graph.people.get(entry.ancestorId)),
+        distances: entry.distances,
+        combinedDistance: entry.combinedDistance
+      }))
+
+    return json({
+      personIds,
+      commonAncestor: mostRecent[0] || null,
+      mostRecentCommonAncestors: mostRecent
+    })
+  } catch (error) {
+    console.error('Error finding group common ancestor:', error)
+    return internalError(error)
+  }
+}
@@ -6,16 +6,21 @@
  *
  * Query Parameters:
  *   - threshold: Confidence threshold (0-100, default: 70)
- *   - limit: Maximum number of duplicate pairs to return (default: unlimited)
+ *   - limit: Maximum number of duplicate pairs (or groups) to return (default: unlimited)
+ *   - groups: When "true", return groups of people whose normalized names match
+ *     and whose birth dates are identical or overlap, instead of fuzzy pairs
  *
- * @returns {Response} JSON array of duplicate pairs with confidence scores
+ * @returns {Response} JSON array of duplicate pairs with confidence scores,
+ *   or with groups=true a JSON array of { name, personIds, confidence }
  */
 
 import { json } from '@sveltejs/kit'
 import { db } from '$lib/db/client.js'
 import { people } from '$lib/db/schema.js'
-import { findAllDuplicates } from '$lib/server/duplicateDetection.js'
+import { isNull } from 'drizzle-orm'
+import { findAllDuplicates, findDuplicateGroups } from '$lib/server/duplicateDetection.js'
 import { transformPeopleToAPI } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
 
 export async function GET({ locals, url }) {
   try {
@@ -31,24 +36,27 @@ export async function GET({ locals, url }) {
 
     // Validate threshold
     if (isNaN(threshold) || threshold < 0 || threshold > 100) {
-      return new Response('Invalid threshold parameter (must be 0-100)', { status: 400 })
+      return jsonError(400, 'Invalid threshold parameter (must be 0-100)')
     }
 
     // Validate limit
     if (limit !== null && (isNaN(limit) || limit < 1)) {
-      return new Response('Invalid limit parameter (must be positive integer)', { status: 400 })
+      return jsonError(400, 'Invalid limit parameter (must be positive integer)')
     }
 
-    // Query all people
+    // Query all people (excluding soft-deleted)
     const allPeople = await database
       .select()
       .from(people)
+      .where(isNull(people.deletedAt))
 
     // Transform to API format
     const transformedPeople = transformPeopleToAPI(allPeople)
 
-    // Find all duplicate pairs
-    let duplicates = findAllDuplicates(transformedPeople, threshold)
+    // Find all duplicate groups or pairs
+    let duplicates = url?.searchParams?.get('groups') === 'true'
+      ? findDuplicateGroups(transformedPeople, threshold)
+      : findAllDuplicates(transformedPeople, threshold)
 
     // Apply limit if specified
     if (limit !== null) {
@@ -58,6 +66,6 @@ export async function GET({ locals, url }) {
     return json(duplicates)
   } catch (error) {
     console.error('Error finding duplicates:', error)
-    return new Response('Internal Server Error', { status: 500 })
+    return internalError(error)
   }
 }
@@ -7,6 +7,7 @@
 
 import { json } from '@sveltejs/kit'
 import { executeMerge } from '$lib/server/personMerge.js'
+import { jsonError } from '$lib/server/errors.js'
 
 /**
  * POST /api/people/merge
@@ -41,15 +42,15 @@ export async function POST({ request, locals }) {
 
     // Validate request
     if (!sourceId) {
-      return json({ error: 'sourceId is required' }, { status: 400 })
+      return jsonError(400, 'sourceId is required')
     }
 
     if (!targetId) {
-      return json({ error: 'targetId is required' }, { status: 400 })
+      return jsonError(400, 'targetId is required')
     }
 
     if (sourceId === targetId) {
-      return json({ error: 'Cannot merge person into themselves' }, { status: 400 })
+      return jsonError(400, 'Cannot merge person into themselves')
     }
 
     // Execute merge
@@ -63,12 +64,12 @@ export async function POST({ request, locals }) {
     const errorMessage = error.message
 
     if (errorMessage.includes('not found')) {
-      return json({ error: errorMessage }, { status: 404 })
+      return jsonError(404, errorMessage)
     }
 
     if (errorMessage.includes('does not belong to') ||
         errorMessage.includes('Cannot merge')) {
-      return json({ error: errorMessage }, { status: 403 })
+      return jsonError(403, errorMessage)
     }
 
     // Generic server error
@@ -6,10 +6,11 @@
  */
 
 import { json } from '@sveltejs/kit'
-import { eq, or } from 'drizzle-orm'
+import { eq, or, and, isNull } from 'drizzle-orm'
 import { db } from '$lib/db/client.js'
 import { people, relationships } from '$lib/db/schema.js'
 import { generateMergePreview } from '$lib/server/mergePreview.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
 
 /**
  * POST /api/people/merge/preview
@@ -46,7 +47,7 @@ export async function POST({ request, locals }) {
 
     // Validate request
     if (!sourceId || !targetId) {
-      return new Response('sourceId and targetId are required', { status: 400 })
+      return jsonError(400, 'sourceId and targetId are required')
     }
 
     // Use locals.db if provided (for testing), otherwise use singleton db
@@ -56,11 +57,11 @@ export async function POST({ request, locals }) {
     const sourceResults = await database
       .select()
       .from(people)
-      .where(eq(people.id, sourceId))
+      .where(and(eq(people.id, sourceId), isNull(people.deletedAt)))
       .limit(1)
 
     if (sourceResults.length === 0) {
-      return new Response('Source person not found', { status: 404 })
+      return jsonError(404, 'Source person not found')
     }
 
     const source = sourceResults[0]
@@ -69,11 +70,11 @@ export async function POST({ request, locals }) {
     const targetResults = await database
       .select()
       .from(people)
-      .where(eq(people.id, targetId))
+      .where(and(eq(people.id, targetId), isNull(people.deletedAt)))
       .limit(1)
 
     if (targetResults.length === 0) {
-      return new Response('Target person not found', { status: 404 })
+      return jsonError(404, 'Target person not found')
     }
 
     const target = targetResults[0]
@@ -112,6 +113,6 @@ export async function POST({ request, locals }) {
     return json(preview)
   } catch (error) {
     console.error('POST /api/people/merge/preview error:', error)
-    return new Response('Internal Server Error', { status: 500 })
+    return internalError(error)
   }
 }
@@ -1,17 +1,21 @@
 import { json } from '@sveltejs/kit'
 import { db } from '$lib/db/client.js'
 import { relationships, people } from '$lib/db/schema.js'
-import { eq, and, or } from 'drizzle-orm'
+import { and, isNotNull, notInArray } from 'drizzle-orm'
 import {
   transformRelationshipsToAPI,
   transformRelationshipToAPI,
   validateRelationshipData,
-  normalizeRelationship
+  normalizeRelationship,
+  relationshipDateValues
 } from '$lib/server/relationshipHelpers.js'
+import { createRelationship } from '$lib/server/relationshipCreation.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
 
 /**
  * GET /api/relationships
  * Returns all relationships from the database
+ * Relationships involving soft-deleted people are excluded
  *
  * @returns {Response} JSON array of relationships
  */
@@ -20,10 +24,21 @@ export async function GET({ locals }) {
     // Use locals.db if provided (for testing), otherwise use singleton db
     const database = locals?.db || db
 
-    // Query all relationships
+    // Query all relationships, hiding those that involve soft-deleted people
+    const deletedPeople = database
+      .select({ id: people.id })
+      .from(people)
+      .where(isNotNull(people.deletedAt))
+
     const allRelationships = await database
       .select()
       .from(relationships)
+      .where(
+        and(
+          notInArray(relationships.person1Id, deletedPeople),
+          notInArray(relationships.person2Id, deletedPeople)
+        )
+      )
 
     // Transform to API format (denormalize parent types)
     const transformedRelationships = transformRelationshipsToAPI(allRelationships)
@@ -31,7 +46,7 @@ export async function GET({ locals }) {
     return json(transformedRelationships)
   } catch (error) {
     console.error('Error fetching relationships:', error)
-    return new Response('Internal Server Error', { status: 500 })
+    return internalError(error)
   }
 }
 
@@ -41,9 +56,17 @@ export async function GET({ locals }) {
  *
  * Business logic:
  * - Normalizes "mother"/"father" to "parentOf" with parent_role
- * - Validates each person can have at most one mother and one father
+ * - Returns 404 when person1 or person2 does not exist
+ * - Validates each person can have at most one (biological) mother and one father;
+ *   adoptive and step parents are not limited
  * - Prevents duplicate relationships
- * - Only accepts valid types: "mother", "father", "spouse"
+ * - With STRICT_SPOUSE_GENDER set, rejects spouses with the same recorded gender
+ *   (any genders are allowed by default)
+ * - Only accepts valid types: "mother", "father", adoptive/step parent roles, "spouse"
+ * - Accepts optional startDate/endDate (YYYY-MM-DD; marriage and divorce
+ *   dates for spouses); endDate cannot be before startDate
+ * - Checks and insert run in one transaction, so concurrent requests cannot
+ *   both pass the checks
  *
  * @param {Request} request - HTTP request with relationship data in body
  * @returns {Response} JSON of created relationship with 201 status
@@ -57,64 +80,37 @@ export async function POST({ request, locals }) {
     let data
     try {
       data = await request.json()
-    } catch (jsonError) {
-      return new Response('Invalid JSON', { status: 400 })
+    } catch (parseError) {
+      return jsonError(400, 'Invalid JSON')
     }
 
     // Validate required fields
     const validation = validateRelationshipData(data)
     if (!validation.valid) {
-      return json({ error: validation.error }, { status: 400 })
+      return jsonError(400, validation.error)
     }
 
     // Normalize relationship (convert mother/father to parentOf)
-    const normalized = normalizeRelationship(data.person1Id, data.person2Id, data.type, data.parentRole)
-
-    // Check if both people exist
-    const personsExist = await checkPersonsExist(
-      database,
-      normalized.person1Id,
-      normalized.person2Id
+    const normalized = normalizeRelationship(
+      data.person1Id,
+      data.person2Id,
+      data.type,
+      data.parentRole,
+      data.relationKind
     )
-    if (!personsExist) {
-      return json({ error: 'One or both persons do not exist' }, { status: 400 })
-    }
 
-    // For parent relationships, validate child doesn't already have this parent role
-    if (normalized.type === 'parentOf' && normalized.parentRole) {
-      const hasParent = await hasParentOfRole(
-        database,
-        normalized.person2Id,
-        normalized.parentRole
-      )
-      if (hasParent) {
-        return json({ error: `Person already has a ${normalized.parentRole}` }, { status: 400 })
-      }
-    }
-
-    // Check for duplicate relationships
-    const exists = await relationshipExists(
-      database,
-      normalized.person1Id,
-      normalized.person2Id,
-      normalized.type
+    // Validate and insert in one IMMEDIATE transaction so concurrent requests
+    // cannot both pass the parent-role or duplicate checks before inserting
+    const result = database.transaction(
+      (tx) => createRelationship(tx, normalized, relationshipDateValues(data)),
+      { behavior: 'immediate' }
     )
-    if (exists) {
-      return json({ error: 'This relationship already exists' }, { status: 400 })
-    }
 
-    // Insert relationship into database
-    const result = await database
-      .insert(relationships)
-      .values({
-        person1Id: normalized.person1Id,
-        person2Id: normalized.person2Id,
-        type: normalized.type,
-        parentRole: normalized.parentRole
-      })
-      .returning()
+    if (result.error) {
+      return jsonError(result.status, result.error)
+    }
 
-    const newRelationship = result[0]
+    const newRelationship = result.relationship
 
     // Transform to API format (denormalize)
     const transformedRelationship = transformRelationshipToAPI(newRelationship)
@@ -122,101 +118,6 @@ export async function POST({ request, locals }) {
     return json(transformedRelationship, { status: 201 })
   } catch (error) {
     console.error('Error creating relationship:', error)
-    return new Response('Internal Server Error', { status: 500 })
-  }
-}
-
-/**
- * Check if a person already has a parent of the specified role
- *
- * @param {Database} database - Drizzle database instance
- * @param {number} childId - ID of the child person
- * @param {string} role - Parent role ("mother" or "father")
- * @returns {Promise<boolean>} True if parent exists
- */
-async function hasParentOfRole(database, childId, role) {
-  const result = await database
-    .select()
-    .from(relationships)
-    .where(
-      and(
-        eq(relationships.person2Id, childId),
-        eq(relationships.type, 'parentOf'),
-        eq(relationships.parentRole, role)
-      )
-    )
-
-  return result.length > 0
-}
-
-/**
- * Check if a relationship already exists (including inverse for bidirectional types)
- *
- * @param {Database} database - Drizzle database instance
- * @param {number} person1Id - First person ID
- * @param {number} person2Id - Second person ID
- * @param {string} type - Relationship type
- * @returns {Promise<boolean>} True if relationship exists
- */
-async function relationshipExists(database, person1Id, person2Id, type) {
-  if (type === 'parentOf') {
-    // For parent relationships, check both directions
-    const result = await database
-      .select()
-      .from(relationships)
-      .where(
-        or(
-          and(
-            eq(relationships.person1Id, person1Id),
-            eq(relationships.person2Id, person2Id),
-            eq(relationships.type, 'parentOf')
-          ),
-          and(
-            eq(relationships.person1Id, person2Id),
-            eq(relationships.person2Id, person1Id),
-            eq(relationships.type, 'parentOf')
-          )
-        )
-      )
-
-    return result.length > 0
+    return internalError(error)
   }
-
-  // For spouse relationships, only check for exact duplicate (same direction)
-  // We allow bidirectional spouse relationships (person1->person2 AND person2->person1)
-  // but prevent exact duplicates (same person1Id and person2Id)
-  const result = await database
-    .select()
-    .from(relationships)
-    .where(
-      and(
-        eq(relationships.type, type),
-        eq(relationships.person1Id, person1Id),
-        eq(relationships.person2Id, person2Id)
-      )
-    )
-
-  return result.length > 0
-}
-
-/**
- * Check if both persons exist
- *
- * @param {Database} database - Drizzle database instance
- * @param {number} person1Id - First person ID
- * @param {number} person2Id - Second person ID
- * @returns {Promise<boolean>} True if both persons exist
- */
-async function checkPersonsExist(database, person1Id, person2Id) {
-  const person1 = await database
-    .select()
-    .from(people)
-    .where(eq(people.id, person1Id))
-
-  const person2 = await database
-    .select()
-    .from(people)
-    .where(eq(people.id, person2Id))
-
-  return person1.length > 0 && person2.length > 0
 }
@@ -1,13 +1,18 @@
 import { json } from '@sveltejs/kit'
 import { db } from '$lib/db/client.js'
-import { relationships, people } from '$lib/db/schema.js'
-import { eq, and, or, ne } from 'drizzle-orm'
+import { relationships } from '$lib/db/schema.js'
+import { eq } from 'drizzle-orm'
 import {
   transformRelationshipToAPI,
   validateRelationshipData,
+  validateRelationshipDates,
   normalizeRelationship,
+  relationshipDateValues,
   parseId
 } from '$lib/server/relationshipHelpers.js'
+import { updateRelationship } from '$lib/server/relationshipCreation.js'
+import { recordAudit, AUDIT_ENTITY_TYPES, AUDIT_ACTIONS } from '$lib/server/auditLog.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
 
 /**
  * GET /api/relationships/[id]
@@ -24,7 +29,7 @@ export async function GET({ params, locals }) {
     // Validate and parse ID
     const id = parseId(params.id)
     if (id === null) {
-      return new Response('Invalid ID', { status: 400 })
+      return jsonError(400, 'Invalid ID')
     }
 
     // Query relationship by ID
@@ -34,7 +39,7 @@ export async function GET({ params, locals }) {
       .where(eq(relationships.id, id))
 
     if (result.length === 0) {
-      return new Response('Relationship not found', { status: 404 })
+      return jsonError(404, 'Relationship not found')
     }
 
     // Transform to API format (denormalize)
@@ -43,7 +48,7 @@ export async function GET({ params, locals }) {
     return json(transformedRelationship)
   } catch (error) {
     console.error('Error fetching relationship:', error)
-    return new Response('Internal Server Error', { status: 500 })
+    return internalError(error)
   }
 }
 
@@ -53,9 +58,14 @@ export async function GET({ params, locals }) {
  *
  * Business logic:
  * - Normalizes "mother"/"father" to "parentOf" with parent_role
- * - Validates each person can have at most one mother and one father
+ * - Validates each person can have at most one (biological) mother and one father;
+ *   adoptive and step parents are not limited
  * - Prevents duplicate relationships (excluding self)
- * - Only accepts valid types: "mother", "father", "spouse"
+ * - With STRICT_SPOUSE_GENDER set, rejects spouses with the same recorded gender
+ * - Only accepts valid types: "mother", "father", adoptive/step parent roles, "spouse"
+ * - startDate/endDate are replaced like other fields (omitted means null)
+ * - Checks and update run in one transaction, so concurrent requests cannot
+ *   both pass the checks
  *
  * @param {Object} params - URL parameters containing id
  * @param {Request} request - HTTP request with relationship data in body
@@ -69,93 +79,179 @@ export async function PUT({ params, request, locals }) {
     // Validate and parse ID
     const id = parseId(params.id)
     if (id === null) {
-      return new Response('Invalid ID', { status: 400 })
+      return jsonError(400, 'Invalid ID')
     }
 
     // Parse request body
     let data
     try {
       data = await request.json()
-    } catch (jsonError) {
-      return new Response('Invalid JSON', { status: 400 })
+    } catch (parseError) {
+      return jsonError(400, 'Invalid JSON')
     }
 
     // Validate required fields
     const validation = validateRelationshipData(data)
     if (!validation.valid) {
-      return new Response(validation.error, { status: 400 })
+      return jsonError(400, validation.error)
     }
 
-    // Check if relationship exists
-    const existing = await database
-      .select()
-      .from(relationships)
-      .where(eq(relationships.id, id))
+    // Normalize relationship (convert mother/father to parentOf)
+    const normalized = normalizeRelationship(
+      data.person1Id,
+      data.person2Id,
+      data.type,
+      data.parentRole,
+      data.relationKind
+    )
 
-    if (existing.length === 0) {
-      return new Response('Relationship not found', { status: 404 })
+    // Check and update in one IMMEDIATE transaction so concurrent requests
+    // cannot both pass the parent-role or duplicate checks before writing
+    const result = database.transaction((tx) => {
+      const existing = findRelationship(tx, id)
+      if (!existing) {
+        return { status: 404, error: 'Relationship not found' }
+      }
+
+      return updateRelationship(tx, existing, normalized, relationshipDateValues(data))
+    }, { behavior: 'immediate' })
+
+    if (result.error) {
+      return jsonError(result.status, result.error)
     }
 
-    // Normalize relationship (convert mother/father to parentOf)
-    const normalized = normalizeRelationship(data.person1Id, data.person2Id, data.type)
+    return json(transformRelationshipToAPI(result.relationship))
+  } catch (error) {
+    console.error('Error updating relationship:', error)
+    return internalError(error)
+  }
+}
 
-    // Verify both people exist
-    const personsExist = await checkPersonsExist(
-      database,
-      normalized.person1Id,
-      normalized.person2Id
-    )
-    if (!personsExist) {
-      return json({ error: 'One or both persons do not exist' }, { status: 400 })
+/**
+ * Fields a PATCH body may contain
+ */
+const PATCHABLE_RELATIONSHIP_FIELDS = [
+  'person1Id',
+  'person2Id',
+  'type',
+  'parentRole',
+  'relationKind',
+  'startDate',
+  'endDate'
+]
+
+/**
+ * Fields that identify who is related and how; changing any of them
+ * re-runs the existence, role, duplicate, and spouse gender checks
+ */
+const LINK_FIELDS = ['person1Id', 'person2Id', 'type', 'parentRole', 'relationKind']
+
+/**
+ * PATCH /api/relationships/[id]
+ * Partially updates a relationship: only fields present in the body change
+ *
+ * Omitted fields keep their current values (so a marriage date can be set
+ * without re-sending the people and type); a field sent as null is cleared.
+ * When a person, type, role, or kind changes, the result is validated like
+ * PUT and the people, role, duplicate, and spouse gender checks run; when
+ * only dates change, only the dates and their order are validated.
+ * Unknown fields are rejected.
+ *
+ * @param {Object} params - URL parameters containing id
+ * @param {Request} request - HTTP request with the fields to change
+ * @returns {Response} JSON of updated relationship or error
+ */
+export async function PATCH({ params, request, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate and parse ID
+    const id = parseId(params.id)
+    if (id === null) {
+      return jsonError(400, 'Invalid ID')
     }
 
-    // For parent relationships, validate child doesn't already have this parent role
-    // (excluding the current relationship being updated)
-    if (normalized.type === 'parentOf' && normalized.parentRole) {
-      const hasParent = await hasParentOfRole(
-        database,
-        normalized.person2Id,
-        normalized.parentRole,
-        id // Exclude current relationship from check
-      )
-      if (hasParent) {
-        return new Response(`Person already has a ${normalized.parentRole}`, { status: 400 })
-      }
+    // Parse request body
+    let changes
+    try {
+      changes = await request.json()
+    } catch (parseError) {
+      return jsonError(400, 'Invalid JSON')
     }
 
-    // Check for duplicate relationships (excluding self)
-    const exists = await relationshipExists(
-      database,
-      normalized.person1Id,
-      normalized.person2Id,
-      normalized.type,
-      id // Exclude current relationship from check
-    )
-    if (exists) {
-      return new Response('This relationship already exists', { status: 400 })
+    if (changes === null || typeof changes !== 'object' || Array.isArray(changes)) {
+      return jsonError(400, 'Request body must be a JSON object')
     }
 
-    // Update relationship in database
-    const result = await database
-      .update(relationships)
-      .set({
-        person1Id: normalized.person1Id,
-        person2Id: normalized.person2Id,
-        type: normalized.type,
-        parentRole: normalized.parentRole
-      })
-      .where(eq(relationships.id, id))
-      .returning()
+    const changedFields = Object.keys(changes)
+    const unknownFields = changedFields.filter((field) => !PATCHABLE_RELATIONSHIP_FIELDS.includes(field))
+    if (unknownFields.length > 0) {
+      return jsonError(400, `Unknown field(s): ${unknownFields.join(', ')}`)
+    }
 
-    const updatedRelationship = result[0]
+    // Merge, check, and update in one IMMEDIATE transaction so the
+    // relationship cannot change between reading and writing it
+    const result = database.transaction((tx) => {
+      const existing = findRelationship(tx, id)
+      if (!existing) {
+        return { status: 404, error: 'Relationship not found' }
+      }
 
-    // Transform to API format (denormalize)
-    const transformedRelationship = transformRelationshipToAPI(updatedRelationship)
+      // Apply the changes to the relationship in its API (denormalized) form
+      const current = transformRelationshipToAPI(existing)
+      const data = {}
+      for (const field of PATCHABLE_RELATIONSHIP_FIELDS) {
+        data[field] = field in changes ? changes[field] : current[field]
+      }
 
-    return json(transformedRelationship)
+      // A new type brings its own role and kind unless they are given
+      if ('type' in changes) {
+        if (!('parentRole' in changes)) {
+          data.parentRole = changes.type === 'parentOf' ? current.parentRole : null
+        }
+        if (!('relationKind' in changes)) {
+          data.relationKind = null
+        }
+      }
+
+      // Date-only changes keep the stored link as it is (older parentOf rows
+      // may lack a parentRole), so only the dates are validated
+      const linkChanged = changedFields.some((field) => LINK_FIELDS.includes(field))
+      if (!linkChanged) {
+        const dateValidation = validateRelationshipDates(data)
+        if (!dateValidation.valid) {
+          return { status: 400, error: dateValidation.error }
+        }
+
+        return updateRelationship(tx, existing, existing, relationshipDateValues(data), { check: false })
+      }
+
+      const validation = validateRelationshipData(data)
+      if (!validation.valid) {
+        return { status: 400, error: validation.error }
+      }
+
+      // Normalize relationship (convert mother/father to parentOf)
+      const normalized = normalizeRelationship(
+        data.person1Id,
+        data.person2Id,
+        data.type,
+        data.parentRole,
+        data.relationKind
+      )
+
+      return updateRelationship(tx, existing, normalized, relationshipDateValues(data))
+    }, { behavior: 'immediate' })
+
+    if (result.error) {
+      return jsonError(result.status, result.error)
+    }
+
+    return json(transformRelationshipToAPI(result.relationship))
   } catch (error) {
-    console.error('Error updating relationship:', error)
-    return new Response('Internal Server Error', { status: 500 })
+    console.error('Error patching relationship:', error)
+    return internalError(error)
   }
 }
 
@@ -174,7 +270,7 @@ export async function DELETE({ params, locals }) {
     // Validate and parse ID
     const id = parseId(params.id)
     if (id === null) {
-      return new Response('Invalid ID', { status: 400 })
+      return jsonError(400, 'Invalid ID')
     }
 
     // Check if relationship exists
@@ -184,147 +280,38 @@ export async function DELETE({ params, locals }) {
       .where(eq(relationships.id, id))
 
     if (existing.length === 0) {
-      return new Response('Relationship not found', { status: 404 })
+      return jsonError(404, 'Relationship not found')
     }
 
     // Delete relationship
-    await database
-      .delete(relationships)
-      .where(eq(relationships.id, id))
+    database.transaction((tx) => {
+      tx.delete(relationships)
+        .where(eq(relationships.id, id))
+        .run()
+
+      recordAudit(tx, AUDIT_ENTITY_TYPES.relationship, id, AUDIT_ACTIONS.delete, {
+        before: transformRelationshipToAPI(existing[0])
+      })
+    })
 
     return new Response(null, { status: 204 })
   } catch (error) {
     console.error('Error deleting relationship:', error)
-    return new Response('Internal Server Error', { status: 500 })
+    return internalError(error)
   }
 }
 
 /**
- * Check if a person already has a parent of the specified role
+ * Loads a relationship row by ID
  *
- * @param {Database} database - Drizzle database instance
- * @param {number} childId - ID of the child person
- * @param {string} role - Parent role ("mother" or "father")
- * @param {number} excludeId - Relationship ID to exclude from check (for updates)
- * @returns {Promise<boolean>} True if parent exists
+ * @param {Database} database - Drizzle database or transaction
+ * @param {number} id - Relationship ID
+ * @returns {Object|undefined} Relationship row, or undefined when not found
  */
-async function hasParentOfRole(database, childId, role, excludeId = null) {
-  let query = database
+function findRelationship(database, id) {
+  return database
     .select()
     .from(relationships)
-    .where(
-      and(
-        eq(relationships.person2Id, childId),
-        eq(relationships.type, 'parentOf'),
-        eq(relationships.parentRole, role)
-      )
-    )
-
-  // Exclude current relationship if updating
-  if (excludeId !== null) {
-    query = database
-      .select()
-      .from(relationships)
-      .where(
-        and(
-          eq(relationships.person2Id, childId),
-          eq(relationships.type, 'parentOf'),
-          eq(relationships.parentRole, role),
-          ne(relationships.id, excludeId)
-        )
-      )
-  }
-
-  const result = await query
-
-  return result.length > 0
-}
-
-/**
- * Check if both persons exist
- *
- * @param {Database} database - Drizzle database instance
- * @param {number} person1Id - First person ID
- * @param {number} person2Id - Second person ID
- * @returns {Promise<boolean>} True if both persons exist
- */
-async function checkPersonsExist(database, person1Id, person2Id) {
-  const person1 = await database
-    .select()
-    .from(people)
-    .where(eq(people.id, person1Id))
-
-  const person2 = await database
-    .select()
-    .from(people)
-    .where(eq(people.id, person2Id))
-
-  return person1.length > 0 && person2.length > 0
-}
-
-/**
- * Check if a relationship already exists (including inverse for bidirectional types)
- *
- * @param {Database} database - Drizzle database instance
- * @param {number} person1Id - First person ID
- * @param {number} person2Id - Second person ID
- * @param {string} type - Relationship type
- * @param {number} excludeId - Relationship ID to exclude from check (for updates)
- * @returns {Promise<boolean>} True if relationship exists
- */
-async function relationshipExists(database, person1Id, person2Id, type, excludeId = null) {
-  if (type === 'parentOf') {
-    // For parent relationships, check both directions
-    let query = database
-      .select()
-      .from(relationships)
-      .where(
-        or(
-          and(
-            eq(relationships.person1Id, person1Id),
-            eq(relationships.person2Id, person2Id),
-            eq(relationships.type, 'parentOf')
-          ),
-          and(
-            eq(relationships.person1Id, person2Id),
-            eq(relationships.person2Id, person1Id),
-            eq(relationships.type, 'parentOf')
-          )
-        )
-      )
-
-    // Exclude current relationship if updating
-    if (excludeId !== null) {
-      const allResults = await query
-      const filtered = allResults.filter((r) => r.id !== excludeId)
-      return filtered.length > 0
-    }
-
-    const result = await query
-    return result.length > 0
-  }
-
-  // For spouse relationships, check both directions
-  let query = database
-    .select()
-    .from(relationships)
-    .where(
-      and(
-        eq(relationships.type, type),
-        or(
-          and(eq(relationships.person1Id, person1Id), eq(relationships.person2Id, person2Id)),
-          and(eq(relationships.person1Id, person2Id), eq(relationships.person2Id, person1Id))
-        )
-      )
-    )
-
-  // Exclude current relationship if updating
-  if (excludeId !== null) {
-    const allResults = await query
-    const filtered = allResults.filter((r) => r.id !== excludeId)
-    return filtered.length > 0
-  }
-
-  const result = await query
-  return result.length > 0
+    .where(eq(relationships.id, id))
+    .get()
 }
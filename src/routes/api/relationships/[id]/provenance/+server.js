@@ -0,0 +1,54 @@
+/**
+ * GET /api/relationships/[id]/provenance
+ * Returns how a relationship came to be: the import that created it (if
+ * any) and its full audit history, oldest first
+ *
+ * History outlives the relationship, so a deleted relationship still
+ * returns its history (with relationship null). The data model has no
+ * source citations yet, so there is no sources section.
+ *
+ * @returns {Response} JSON { relationshipId, relationship, importBatch,
+ *   history: [{ id, entityType, entityId, action, payload, createdAt }] }
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { relationships } from '$lib/db/schema.js'
+import { eq } from 'drizzle-orm'
+import { parseId, transformRelationshipToAPI } from '$lib/server/relationshipHelpers.js'
+import { listAuditEntriesFor, AUDIT_ENTITY_TYPES } from '$lib/server/auditLog.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function GET({ params, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const relationshipId = parseId(params.id)
+    if (relationshipId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    const [relationship] = await database
+      .select()
+      .from(relationships)
+      .where(eq(relationships.id, relationshipId))
+
+    const history = listAuditEntriesFor(database, AUDIT_ENTITY_TYPES.relationship, relationshipId)
+
+    if (!relationship && history.length === 0) {
+      return jsonError(404, 'Relationship not found')
+    }
+
+    return json({
+      relationshipId,
+      relationship: relationship ? transformRelationshipToAPI(relationship) : null,
+      importBatch: relationship?.importBatch ?? null,
+      history
+    })
+  } catch (error) {
+    console.error('Error fetching relationship provenance:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,80 @@
+/**
+ * GET /api/relationships/affinity-path
+ * Explains how two people are connected when the path may run through marriages
+ *
+ * Blood relatives get their usual kinship label. Otherwise the connecting path
+ * is described as a chain of relationships, e.g. "sister's husband's brother".
+ *
+ * Query parameters:
+ * - a: Subject person ID
+ * - b: Target person ID
+ * - kinship: "legal" (default) counts adoptive parent edges, "blood" ignores them
+ *
+ * @returns {Response} JSON { a, b, connected, bloodRelated, label, marriages, path, links }
+ *   where links are [{ fromId, toId, label }] and connected is false when no path exists
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { loadFamilyGraph, parseKinshipMode } from '$lib/server/familyGraph.js'
+import { describeKinship, findBloodRelation, findAffinityPath, NO_RELATIONSHIP_LABEL } from '$lib/server/kinship.js'
+import { parseId } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function GET({ locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    const a = parseId(url.searchParams.get('a'))
+    const b = parseId(url.searchParams.get('b'))
+    if (a === null || b === null) {
+      return jsonError(400, 'a and b must be valid IDs')
+    }
+    if (a === b) {
+      return jsonError(400, 'a and b must be different people')
+    }
+
+    const kinshipMode = parseKinshipMode(url)
+    if (!kinshipMode.valid) {
+      return jsonError(400, kinshipMode.error)
+    }
+
+    const graph = await loadFamilyGraph(database, { kinship: kinshipMode.mode })
+
+    if (!graph.people.has(a) || !graph.people.has(b)) {
+      return jsonError(404, 'Person not found')
+    }
+
+    const path = findAffinityPath(graph, a, b)
+
+    if (!path) {
+      return json({
+        a,
+        b,
+        connected: false,
+        bloodRelated: false,
+        label: NO_RELATIONSHIP_LABEL,
+        marriages: null,
+        path: [],
+        links: []
+      })
+    }
+
+    const bloodRelated = findBloodRelation(graph, a, b) !== null
+
+    return json({
+      a,
+      b,
+      connected: true,
+      bloodRelated,
+      label: bloodRelated ? describeKinship(graph, a, b).label : path.label,
+      marriages: path.marriages,
+      path: path.personIds,
+      links: path.links
+    })
+  } catch (error) {
+    console.error('Error finding affinity path:', error)
+    return internalError(error)
+  }
+}
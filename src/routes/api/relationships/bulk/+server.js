@@ -0,0 +1,118 @@
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import {
+  validateRelationshipData,
+  normalizeRelationship,
+  relationshipDateValues,
+  transformRelationshipToAPI
+} from '$lib/server/relationshipHelpers.js'
+import { createRelationship } from '$lib/server/relationshipCreation.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+/**
+ * Maximum number of relationships accepted in one bulk request
+ */
+const MAX_BULK_RELATIONSHIPS = 1000
+
+/**
+ * Thrown inside the bulk transaction to roll it back when an entry is rejected
+ */
+class BulkEntryError extends Error {
+  constructor(index, error, status = 400) {
+    super(error)
+    this.index = index
+    this.status = status
+  }
+}
+
+/**
+ * Normalizes, checks, and inserts one bulk entry
+ *
+ * @param {Object} tx - Drizzle transaction
+ * @param {Object} entry - Relationship object from the request
+ * @param {number} index - Position of the entry in the request
+ * @returns {Object} Created relationship in API format
+ * @throws {BulkEntryError} If the entry breaks a relationship rule
+ */
+function createEntry(tx, entry, index) {
+  const normalized = normalizeRelationship(
+    entry.person1Id,
+    entry.person2Id,
+    entry.type,
+    entry.parentRole,
+    entry.relationKind
+  )
+
+  const result = createRelationship(tx, normalized, relationshipDateValues(entry))
+  if (result.error) {
+    throw new BulkEntryError(index, result.error, result.status)
+  }
+
+  return transformRelationshipToAPI(result.relationship)
+}
+
+/**
+ * POST /api/relationships/bulk
+ * Creates many relationships at once, e.g. when importing a branch
+ *
+ * Each entry goes through the same normalization and rules as
+ * POST /api/relationships, in request order, inside one transaction. Later
+ * entries see the ones before them, so a batch cannot give a child two
+ * mothers. The first rejected entry rolls the whole batch back.
+ *
+ * Request body: JSON array of relationship objects
+ *
+ * @returns {Response} JSON array of created relationships (same order as the request) with 201 status,
+ *   or JSON { error, status, index } describing the first rejected entry (400, or 404 for a missing person)
+ */
+export async function POST({ request, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Parse request body
+    let data
+    try {
+      data = await request.json()
+    } catch (parseError) {
+      return jsonError(400, 'Invalid JSON')
+    }
+
+    if (!Array.isArray(data) || data.length === 0) {
+      return jsonError(400, 'Request body must be a non-empty array of relationships')
+    }
+    if (data.length > MAX_BULK_RELATIONSHIPS) {
+      return jsonError(400, `At most ${MAX_BULK_RELATIONSHIPS} relationships can be created per request`)
+    }
+
+    // Validate every entry's fields before writing anything
+    for (let index = 0; index < data.length; index++) {
+      const entry = data[index]
+      if (!entry || typeof entry !== 'object' || Array.isArray(entry)) {
+        return jsonError(400, 'Each entry must be a relationship object', { index })
+      }
+
+      const validation = validateRelationshipData(entry)
+      if (!validation.valid) {
+        return jsonError(400, validation.error, { index })
+      }
+    }
+
+    // Check and insert every entry in one IMMEDIATE transaction
+    let created
+    try {
+      created = database.transaction(
+        (tx) => data.map((entry, index) => createEntry(tx, entry, index)),
+        { behavior: 'immediate' }
+      )
+    } catch (entryError) {
+      if (!(entryError instanceof BulkEntryError)) throw entryError
+      return jsonError(entryError.status, entryError.message, { index: entryError.index })
+    }
+
+    return json(created, { status: 201 })
+  } catch (error) {
+    console.error('Error creating relationships in bulk:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,52 @@
+/**
+ * GET /api/relationships/can-link
+ * Checks whether two people could be linked without conflict, without inserting
+ *
+ * A lightweight check the UI calls before enabling a "Link" button.
+ *
+ * Query parameters:
+ * - person1: First person ID (the parent for mother/father/parentOf)
+ * - person2: Second person ID
+ * - type: "mother", "father", "spouse", or "parentOf"
+ * - parentRole: "mother" or "father" (required when type is "parentOf")
+ *
+ * @returns {Response} JSON { allowed: true } or
+ *   { allowed: false, reason, message } where reason is one of
+ *   "self", "missingPerson", "duplicate", "parentRoleConflict", "cycle",
+ *   "sameGenderSpouse" (only with STRICT_SPOUSE_GENDER set)
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { normalizeRelationship, validateRelationshipType } from '$lib/server/relationshipHelpers.js'
+import { checkCanLink } from '$lib/server/relationshipRules.js'
+import { parseId } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function GET({ locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    const person1Id = parseId(url.searchParams.get('person1'))
+    const person2Id = parseId(url.searchParams.get('person2'))
+    if (person1Id === null || person2Id === null) {
+      return jsonError(400, 'person1 and person2 must be valid IDs')
+    }
+
+    const type = url.searchParams.get('type')
+    const parentRole = url.searchParams.get('parentRole') || undefined
+    const typeValidation = validateRelationshipType(type, parentRole)
+    if (!typeValidation.valid) {
+      return jsonError(400, typeValidation.error)
+    }
+
+    const normalized = normalizeRelationship(person1Id, person2Id, type, parentRole)
+    const result = await checkCanLink(database, normalized)
+
+    return json(result)
+  } catch (error) {
+    console.error('Error checking relationship link:', error)
+    return internalError(error)
+  }
+}
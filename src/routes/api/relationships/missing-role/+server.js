@@ -0,0 +1,163 @@
+/**
+ * /api/relationships/missing-role
+ * Maintenance tool for parentOf relationships without a parent role
+ * (legacy rows and imports that only recorded a child link), so users can
+ * say whether the parent is the mother or the father.
+ *
+ * GET lists them; POST assigns a role to one of them.
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { people, relationships } from '$lib/db/schema.js'
+import { and, asc, eq, inArray, isNull } from 'drizzle-orm'
+import {
+  transformRelationshipToAPI,
+  BIOLOGICAL_PARENT_ROLES
+} from '$lib/server/relationshipHelpers.js'
+import { transformPersonToAPI } from '$lib/server/personHelpers.js'
+import { parentRoleForGender } from '$lib/server/textOutlineImporter.js'
+import { recordAudit, AUDIT_ENTITY_TYPES, AUDIT_ACTIONS } from '$lib/server/auditLog.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+/**
+ * GET /api/relationships/missing-role
+ * Relationships involving soft-deleted people are excluded. suggestedRole
+ * follows the parent's gender ("mother" or "father", null when unknown).
+ *
+ * @returns {Response} JSON { count, relationships: [{ relationship, parent, child, suggestedRole }] }
+ *   ordered by relationship ID
+ */
+export async function GET({ locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    const roleless = await database
+      .select()
+      .from(relationships)
+      .where(and(eq(relationships.type, 'parentOf'), isNull(relationships.parentRole)))
+      .orderBy(asc(relationships.id))
+
+    const personIds = [...new Set(roleless.flatMap((row) => [row.person1Id, row.person2Id]))]
+    const peopleById = new Map()
+    if (personIds.length > 0) {
+      const rows = await database
+        .select()
+        .from(people)
+        .where(and(inArray(people.id, personIds), isNull(people.deletedAt)))
+      for (const person of rows) peopleById.set(person.id, person)
+    }
+
+    const listed = roleless
+      .filter((row) => peopleById.has(row.person1Id) && peopleById.has(row.person2Id))
+      .map((row) => {
+        const parent = peopleById.get(row.person1Id)
+        return {
+          relationship: transformRelationshipToAPI(row),
+          parent: transformPersonToAPI(parent),
+          child: transformPersonToAPI(peopleById.get(row.person2Id)),
+          suggestedRole: parentRoleForGender(parent.gender)
+        }
+      })
+
+    return json({ count: listed.length, relationships: listed })
+  } catch (error) {
+    console.error('Error finding relationships without a parent role:', error)
+    return internalError(error)
+  }
+}
+
+/**
+ * POST /api/relationships/missing-role
+ * Assigns a parent role to a parentOf relationship that has none
+ *
+ * Body: { relationshipId, parentRole } where parentRole is "mother" or "father".
+ * Rejected when the relationship already has a role, is not a parent
+ * relationship, or the child already has a parent in that role.
+ *
+ * @returns {Response} JSON of the updated relationship
+ */
+export async function POST({ request, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Parse request body
+    let data
+    try {
+      data = await request.json()
+    } catch (parseError) {
+      return jsonError(400, 'Invalid JSON')
+    }
+
+    const relationshipId = data?.relationshipId
+    if (!Number.isInteger(relationshipId) || relationshipId < 1) {
+      return jsonError(400, 'relationshipId is required and must be a positive integer')
+    }
+
+    if (!BIOLOGICAL_PARENT_ROLES.includes(data.parentRole)) {
+      return jsonError(400, `parentRole must be one of: ${BIOLOGICAL_PARENT_ROLES.join(', ')}`)
+    }
+
+    // Check and update in one transaction so the child cannot gain a second
+    // parent in this role in between
+    const result = database.transaction((tx) => {
+      const existing = tx
+        .select()
+        .from(relationships)
+        .where(eq(relationships.id, relationshipId))
+        .get()
+
+      if (!existing) {
+        return { error: 'Relationship not found', status: 404 }
+      }
+      if (existing.type !== 'parentOf') {
+        return { error: 'Relationship is not a parent relationship' }
+      }
+      if (existing.parentRole) {
+        return { error: `Relationship already has the parent role ${existing.parentRole}` }
+      }
+
+      const sameRole = tx
+        .select({ id: relationships.id })
+        .from(relationships)
+        .where(
+          and(
+            eq(relationships.person2Id, existing.person2Id),
+            eq(relationships.type, 'parentOf'),
+            eq(relationships.parentRole, data.parentRole)
+          )
+        )
+        .all()
+
+      if (sameRole.length > 0) {
+        return { error: `Person already has a ${data.parentRole}` }
+      }
+
+      const updated = tx
+        .update(relationships)
+        .set({ parentRole: data.parentRole })
+        .where(eq(relationships.id, relationshipId))
+        .returning()
+        .get()
+
+      const after = transformRelationshipToAPI(updated)
+      recordAudit(tx, AUDIT_ENTITY_TYPES.relationship, relationshipId, AUDIT_ACTIONS.update, {
+        before: transformRelationshipToAPI(existing),
+        after
+      })
+
+      return { relationship: after }
+    }, { behavior: 'immediate' })
+
+    if (result.error) {
+      return jsonError(result.status || 400, result.error)
+    }
+
+    return json(result.relationship)
+  } catch (error) {
+    console.error('Error assigning parent role:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,50 @@
+/**
+ * /api/relationships/orphaned
+ * Maintenance tool for relationships that reference a person who no longer
+ * exists (possible when rows were written with foreign key enforcement off).
+ * Soft-deleted people still exist, so their relationships are not orphaned.
+ *
+ * GET lists the orphaned relationships as stored; DELETE removes them all
+ * in one transaction.
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { findOrphanedRelationships, deleteOrphanedRelationships } from '$lib/server/integrityCheck.js'
+import { internalError } from '$lib/server/errors.js'
+
+/**
+ * GET /api/relationships/orphaned
+ *
+ * @returns {Response} JSON { count, relationships } with stored relationship rows ordered by ID
+ */
+export async function GET({ locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    const orphaned = findOrphanedRelationships(database)
+
+    return json({ count: orphaned.length, relationships: orphaned })
+  } catch (error) {
+    console.error('Error finding orphaned relationships:', error)
+    return internalError(error)
+  }
+}
+
+/**
+ * DELETE /api/relationships/orphaned
+ *
+ * @returns {Response} JSON { deleted } with the number of relationships removed
+ */
+export async function DELETE({ locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    return json({ deleted: deleteOrphanedRelationships(database) })
+  } catch (error) {
+    console.error('Error deleting orphaned relationships:', error)
+    return internalError(error)
+  }
+}
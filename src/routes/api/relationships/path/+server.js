@@ -0,0 +1,91 @@
+/**
+ * GET /api/relationships/path
+ * Finds the shortest blood path between two people, optionally avoiding one person
+ *
+ * Leaving out a known intermediary (such as the parent two siblings share)
+ * answers "how else are we related". When every blood connection runs
+ * through the excluded person, connected is false.
+ *
+ * Query parameters:
+ * - from: Subject person ID
+ * - to: Target person ID
+ * - exclude: Optional ID of a person the path may not pass through
+ * - kinship: "legal" (default) counts adoptive parent edges, "blood" ignores them
+ *
+ * @returns {Response} JSON { from, to, exclude, connected, label, up, down, path }
+ *   where path lists person IDs from the subject to the target
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { loadFamilyGraph, parseKinshipMode } from '$lib/server/familyGraph.js'
+import { findBloodPath, NO_RELATIONSHIP_LABEL } from '$lib/server/kinship.js'
+import { parseId } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function GET({ locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    const from = parseId(url.searchParams.get('from'))
+    const to = parseId(url.searchParams.get('to'))
+    if (from === null || to === null) {
+      return jsonError(400, 'from and to must be valid IDs')
+    }
+    if (from === to) {
+      return jsonError(400, 'from and to must be different people')
+    }
+
+    let exclude = null
+    if (url.searchParams.has('exclude')) {
+      exclude = parseId(url.searchParams.get('exclude'))
+      if (exclude === null) {
+        return jsonError(400, 'exclude must be a valid ID')
+      }
+      if (exclude === from || exclude === to) {
+        return jsonError(400, 'exclude must differ from from and to')
+      }
+    }
+
+    const kinshipMode = parseKinshipMode(url)
+    if (!kinshipMode.valid) {
+      return jsonError(400, kinshipMode.error)
+    }
+
+    const graph = await loadFamilyGraph(database, { kinship: kinshipMode.mode })
+
+    if (!graph.people.has(from) || !graph.people.has(to)) {
+      return jsonError(404, 'Person not found')
+    }
+
+    const path = findBloodPath(graph, from, to, exclude === null ? [] : [exclude])
+
+    if (!path) {
+      return json({
+        from,
+        to,
+        exclude,
+        connected: false,
+        label: NO_RELATIONSHIP_LABEL,
+        up: null,
+        down: null,
+        path: []
+      })
+    }
+
+    return json({
+      from,
+      to,
+      exclude,
+      connected: true,
+      label: path.label,
+      up: path.up,
+      down: path.down,
+      path: path.personIds
+    })
+  } catch (error) {
+    console.error('Error finding relationship path:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,65 @@
+/**
+ * GET /api/search
+ * Searches people by name, birth and death place, and notes
+ *
+ * Every word of the query must match some field (case-insensitive). Results
+ * are ranked by relevance (see personSearch.js) and carry a snippet of the
+ * best-matching field. Soft-deleted people are excluded.
+ *
+ * Query parameters:
+ * - q: search text (required)
+ * - limit: maximum number of results to return (1-100, default 20)
+ *
+ * @returns {Response} JSON { query, count, results: [{ person, score, field, snippet }] }
+ *   where count is the number of matches before the limit is applied
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { people } from '$lib/db/schema.js'
+import { isNull } from 'drizzle-orm'
+import { transformPersonToAPI } from '$lib/server/personHelpers.js'
+import { searchPeople } from '$lib/server/personSearch.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+const DEFAULT_LIMIT = 20
+const MAX_LIMIT = 100
+
+export async function GET({ locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    const query = (url?.searchParams?.get('q') || '').trim()
+    if (query === '') {
+      return jsonError(400, 'q is required')
+    }
+
+    const limitParam = url?.searchParams?.get('limit')
+    const limit = limitParam ? Number(limitParam) : DEFAULT_LIMIT
+    if (!Number.isInteger(limit) || limit < 1 || limit > MAX_LIMIT) {
+      return jsonError(400, `Invalid limit parameter (must be 1-${MAX_LIMIT})`)
+    }
+
+    const rows = await database
+      .select()
+      .from(people)
+      .where(isNull(people.deletedAt))
+
+    const matches = searchPeople(rows, query)
+
+    return json({
+      query,
+      count: matches.length,
+      results: matches.slice(0, limit).map(({ person, score, field, snippet }) => ({
+        person: transformPersonToAPI(person),
+        score,
+        field,
+        snippet
+      }))
+    })
+  } catch (error) {
+    console.error('Error searching people:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,57 @@
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { createSnapshot, listSnapshots, validateSnapshotLabel } from '$lib/server/snapshots.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+/**
+ * GET /api/snapshots
+ * Lists stored snapshots, newest first, without their data
+ *
+ * @returns {Response} JSON array of { id, label, createdAt, peopleCount, relationshipCount }
+ */
+export async function GET({ locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    return json(await listSnapshots(database))
+  } catch (error) {
+    console.error('Error listing snapshots:', error)
+    return internalError(error)
+  }
+}
+
+/**
+ * POST /api/snapshots
+ * Stores a labeled, timestamped snapshot of all people and relationships
+ *
+ * Request body: { label: string }
+ *
+ * @returns {Response} JSON of the created snapshot with 201 status
+ */
+export async function POST({ request, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Parse request body
+    let data
+    try {
+      data = await request.json()
+    } catch (parseError) {
+      return jsonError(400, 'Invalid JSON')
+    }
+
+    const validation = validateSnapshotLabel(data?.label)
+    if (!validation.valid) {
+      return jsonError(400, validation.error)
+    }
+
+    const snapshot = await createSnapshot(database, data.label)
+
+    return json(snapshot, { status: 201 })
+  } catch (error) {
+    console.error('Error creating snapshot:', error)
+    return internalError(error)
+  }
+}
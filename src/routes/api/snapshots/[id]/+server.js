@@ -0,0 +1,34 @@
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { getSnapshot } from '$lib/server/snapshots.js'
+import { parseId } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+/**
+ * GET /api/snapshots/[id]
+ * Returns a snapshot with the people and relationships it captured
+ *
+ * @returns {Response} JSON { id, label, createdAt, people, relationships }
+ */
+export async function GET({ params, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const snapshotId = parseId(params.id)
+    if (snapshotId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    const snapshot = await getSnapshot(database, snapshotId)
+    if (!snapshot) {
+      return jsonError(404, 'Snapshot not found')
+    }
+
+    return json(snapshot)
+  } catch (error) {
+    console.error('Error fetching snapshot:', error)
+    return internalError(error)
+  }
+}
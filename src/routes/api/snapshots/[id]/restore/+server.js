@@ -0,0 +1,37 @@
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { restoreSnapshot } from '$lib/server/snapshots.js'
+import { parseId } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+/**
+ * POST /api/snapshots/[id]/restore
+ * Replaces the current tree with the people and relationships in a snapshot
+ *
+ * Everything added since the snapshot is removed and everything removed
+ * since is brought back with its original ID.
+ *
+ * @returns {Response} JSON { snapshotId, label, peopleRestored, relationshipsRestored }
+ */
+export async function POST({ params, locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Validate ID
+    const snapshotId = parseId(params.id)
+    if (snapshotId === null) {
+      return jsonError(400, 'Invalid ID')
+    }
+
+    const result = await restoreSnapshot(database, snapshotId)
+    if (!result) {
+      return jsonError(404, 'Snapshot not found')
+    }
+
+    return json(result)
+  } catch (error) {
+    console.error('Error restoring snapshot:', error)
+    return internalError(error)
+  }
+}
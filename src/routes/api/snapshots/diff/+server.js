@@ -0,0 +1,50 @@
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { getSnapshot, diffSnapshots } from '$lib/server/snapshots.js'
+import { parseId } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+/**
+ * GET /api/snapshots/diff
+ * Compares two stored snapshots
+ *
+ * Query parameters:
+ * - from: Earlier snapshot ID
+ * - to: Later snapshot ID
+ *
+ * @returns {Response} JSON {
+ *   from: { id, label, createdAt },
+ *   to: { id, label, createdAt },
+ *   people: { added, removed, changed },
+ *   relationships: { added, removed, changed }
+ * } where changed entries are { id, changes: { field: { from, to } } }
+ */
+export async function GET({ locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    const fromId = parseId(url.searchParams.get('from'))
+    const toId = parseId(url.searchParams.get('to'))
+    if (fromId === null || toId === null) {
+      return jsonError(400, 'from and to must be valid snapshot IDs')
+    }
+
+    const from = await getSnapshot(database, fromId)
+    const to = await getSnapshot(database, toId)
+    if (!from || !to) {
+      return jsonError(404, 'Snapshot not found')
+    }
+
+    const summary = ({ id, label, createdAt }) => ({ id, label, createdAt })
+
+    return json({
+      from: summary(from),
+      to: summary(to),
+      ...diffSnapshots(from, to)
+    })
+  } catch (error) {
+    console.error('Error diffing snapshots:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,52 @@
+/**
+ * GET /api/stats/centrality
+ * Returns people ranked by degree centrality in the relationship graph
+ *
+ * Degree centrality counts how many distinct people are directly related
+ * to each person (parents, children, spouses), identifying the key
+ * connectors of the family network.
+ *
+ * Query Parameters:
+ *   - limit: Maximum number of ranked people to return (default: unlimited)
+ *
+ * @returns {Response} JSON array of { person, degree, score } sorted by degree (highest first)
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { loadFamilyGraph, computeDegreeCentrality } from '$lib/server/familyGraph.js'
+import { transformPersonToAPI } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function GET({ locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Parse query parameters
+    const limitParam = url?.searchParams?.get('limit')
+    const limit = limitParam ? parseInt(limitParam, 10) : null
+
+    // Validate limit
+    if (limit !== null && (isNaN(limit) || limit < 1)) {
+      return jsonError(400, 'Invalid limit parameter (must be positive integer)')
+    }
+
+    const graph = await loadFamilyGraph(database)
+    let ranked = computeDegreeCentrality(graph).map((entry) => ({
+      person: transformPersonToAPI(graph.people.get(entry.personId)),
+      degree: entry.degree,
+      score: entry.score
+    }))
+
+    // Apply limit if specified
+    if (limit !== null) {
+      ranked = ranked.slice(0, limit)
+    }
+
+    return json(ranked)
+  } catch (error) {
+    console.error('Error computing centrality:', error)
+    return internalError(error)
+  }
+}
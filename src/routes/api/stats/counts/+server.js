@@ -0,0 +1,56 @@
+/**
+ * GET /api/stats/counts
+ * Returns totals for people and relationships without loading full rows
+ *
+ * Counts are computed with SQL COUNT/SUM aggregates. Soft-deleted people
+ * and relationships involving them are excluded, matching the list endpoints.
+ *
+ * @returns {Response} JSON { people, relationships, parentOfCount, spouseCount }
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { people, relationships } from '$lib/db/schema.js'
+import { and, count, isNull, isNotNull, notInArray, sql } from 'drizzle-orm'
+import { internalError } from '$lib/server/errors.js'
+
+export async function GET({ locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    const [peopleCounts] = await database
+      .select({ total: count() })
+      .from(people)
+      .where(isNull(people.deletedAt))
+
+    const deletedPeople = database
+      .select({ id: people.id })
+      .from(people)
+      .where(isNotNull(people.deletedAt))
+
+    const [relationshipCounts] = await database
+      .select({
+        total: count(),
+        parentOfCount: sql`COALESCE(SUM(CASE WHEN ${relationships.type} = 'parentOf' THEN 1 ELSE 0 END), 0)`.mapWith(Number),
+        spouseCount: sql`COALESCE(SUM(CASE WHEN ${relationships.type} = 'spouse' THEN 1 ELSE 0 END), 0)`.mapWith(Number)
+      })
+      .from(relationships)
+      .where(
+        and(
+          notInArray(relationships.person1Id, deletedPeople),
+          notInArray(relationships.person2Id, deletedPeople)
+        )
+      )
+
+    return json({
+      people: peopleCounts.total,
+      relationships: relationshipCounts.total,
+      parentOfCount: relationshipCounts.parentOfCount,
+      spouseCount: relationshipCounts.spouseCount
+    })
+  } catch (error) {
+    console.error('Error counting records:', error)
+    return internalError(error)
+  }
+}
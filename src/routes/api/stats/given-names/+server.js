@@ -0,0 +1,59 @@
+/**
+ * GET /api/stats/given-names
+ * Returns the most common first names in the tree, for naming-tradition analysis
+ *
+ * Counts are computed with SQL GROUP BY over first_name. Soft-deleted people
+ * are excluded. Ties are ordered alphabetically.
+ *
+ * Query parameters:
+ * - gender: only count people with this gender (e.g. "female")
+ * - limit: maximum number of names to return (1-100, default 10)
+ *
+ * @returns {Response} JSON { gender, names: [{ firstName, count }] }
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { people } from '$lib/db/schema.js'
+import { and, asc, count, desc, eq, isNull } from 'drizzle-orm'
+import { GENDERS, normalizeGender } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+const DEFAULT_LIMIT = 10
+const MAX_LIMIT = 100
+
+export async function GET({ locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    const gender = normalizeGender(url?.searchParams?.get('gender') ?? null)
+    if (gender !== null && !GENDERS.includes(gender)) {
+      return jsonError(400, `gender must be one of: ${GENDERS.join(', ')}`)
+    }
+
+    const limitParam = url?.searchParams?.get('limit')
+    const limit = limitParam ? Number(limitParam) : DEFAULT_LIMIT
+    if (!Number.isInteger(limit) || limit < 1 || limit > MAX_LIMIT) {
+      return jsonError(400, `Invalid limit parameter (must be 1-${MAX_LIMIT})`)
+    }
+
+    const conditions = [isNull(people.deletedAt)]
+    if (gender !== null) {
+      conditions.push(eq(people.gender, gender))
+    }
+
+    const names = await database
+      .select({ firstName: people.firstName, count: count() })
+      .from(people)
+      .where(and(...conditions))
+      .groupBy(people.firstName)
+      .orderBy(desc(count()), asc(people.firstName))
+      .limit(limit)
+
+    return json({ gender, names })
+  } catch (error) {
+    console.error('Error computing given names:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,35 @@
+/**
+ * GET /api/stats/longest-marriage-chain
+ * Returns the longest chain of people connected purely by spouse edges
+ *
+ * Serial remarriages link people into chains (A married B, who later
+ * married C, ...). The chain is a path in which no person repeats, ordered
+ * from one end to the other; see findLongestSpouseChain for tie-breaking.
+ *
+ * @returns {Response} JSON { length, people } where length is the number of
+ *   marriages in the chain (0 with an empty people list when nobody is married)
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { loadFamilyGraph, findLongestSpouseChain } from '$lib/server/familyGraph.js'
+import { transformPersonToAPI } from '$lib/server/personHelpers.js'
+import { internalError } from '$lib/server/errors.js'
+
+export async function GET({ locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    const graph = await loadFamilyGraph(database)
+    const chain = findLongestSpouseChain(graph)
+
+    return json({
+      length: Math.max(chain.length - 1, 0),
+      people: chain.map((personId) => transformPersonToAPI(graph.people.get(personId)))
+    })
+  } catch (error) {
+    console.error('Error finding longest marriage chain:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,70 @@
+/**
+ * GET /api/stats/summary
+ * Returns aggregate figures for a dashboard
+ *
+ * Figures are computed with SQL aggregates over active (not soft-deleted)
+ * people:
+ * - living/deceased follow isPersonLiving: living means no death date and
+ *   born within MAX_LIFESPAN_YEARS (or no birth date)
+ * - averageLifespanYears covers deceased people with full birth and death
+ *   dates, rounded to one decimal
+ * - genders counts each gender value; no gender counts as "unspecified"
+ * - earliestBirthYear/latestBirthYear use the year of any recorded birth date
+ *
+ * An empty database yields zero counts and null averages and years.
+ *
+ * @returns {Response} JSON { totalPeople, living, deceased, averageLifespanYears,
+ *   genders: { [gender]: count }, earliestBirthYear, latestBirthYear }
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { people } from '$lib/db/schema.js'
+import { count, isNull, sql } from 'drizzle-orm'
+import { MAX_LIFESPAN_YEARS } from '$lib/server/personHelpers.js'
+import { internalError } from '$lib/server/errors.js'
+
+export async function GET({ locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    const oldestLivingBirthYear = new Date().getUTCFullYear() - MAX_LIFESPAN_YEARS
+    const birthYear = sql`CAST(substr(${people.birthDate}, 1, 4) AS INTEGER)`
+    const genderKey = sql`COALESCE(NULLIF(${people.gender}, ''), 'unspecified')`
+
+    const [totals] = await database
+      .select({
+        totalPeople: count(),
+        living: sql`COALESCE(SUM(CASE WHEN ${people.deathDate} IS NULL AND (${people.birthDate} IS NULL OR ${birthYear} >= ${oldestLivingBirthYear}) THEN 1 ELSE 0 END), 0)`.mapWith(Number),
+        averageLifespanYears: sql`ROUND(AVG(CASE WHEN length(${people.birthDate}) = 10 AND length(${people.deathDate}) = 10 THEN (julianday(${people.deathDate}) - julianday(${people.birthDate})) / 365.25 END), 1)`,
+        earliestBirthYear: sql`MIN(CASE WHEN ${people.birthDate} IS NOT NULL THEN ${birthYear} END)`,
+        latestBirthYear: sql`MAX(CASE WHEN ${people.birthDate} IS NOT NULL THEN ${birthYear} END)`
+      })
+      .from(people)
+      .where(isNull(people.deletedAt))
+
+    const genderRows = await database
+      .select({
+        gender: genderKey,
+        count: count()
+      })
+      .from(people)
+      .where(isNull(people.deletedAt))
+      .groupBy(genderKey)
+      .orderBy(genderKey)
+
+    return json({
+      totalPeople: totals.totalPeople,
+      living: totals.living,
+      deceased: totals.totalPeople - totals.living,
+      averageLifespanYears: totals.averageLifespanYears ?? null,
+      genders: Object.fromEntries(genderRows.map((row) => [row.gender, row.count])),
+      earliestBirthYear: totals.earliestBirthYear ?? null,
+      latestBirthYear: totals.latestBirthYear ?? null
+    })
+  } catch (error) {
+    console.error('Error computing summary statistics:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,40 @@
+/**
+ * GET /api/tree
+ * Returns the whole tree as a graph of nodes (people) and edges (relationships)
+ *
+ * Content negotiation: clients sending Accept: application/msgpack receive
+ * the same document encoded as MessagePack, which is considerably smaller
+ * for bandwidth-constrained clients. Everything else gets JSON.
+ * Soft-deleted people and their relationships are excluded.
+ *
+ * @returns {Response} JSON or MessagePack { nodes, edges } where nodes are people and
+ *   edges are relationships, both in API format and ordered by ID
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { loadTree } from '$lib/server/treeExport.js'
+import { encodeMsgpack, prefersMsgpack, MSGPACK_CONTENT_TYPE } from '$lib/server/msgpack.js'
+import { internalError } from '$lib/server/errors.js'
+
+export async function GET({ locals, request }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    const tree = await loadTree(database)
+    const graph = { nodes: tree.people, edges: tree.relationships }
+
+    if (prefersMsgpack(request?.headers?.get('accept'))) {
+      return new Response(encodeMsgpack(graph), {
+        status: 200,
+        headers: { 'Content-Type': MSGPACK_CONTENT_TYPE, Vary: 'Accept' }
+      })
+    }
+
+    return json(graph, { headers: { Vary: 'Accept' } })
+  } catch (error) {
+    console.error('Error loading tree graph:', error)
+    return internalError(error)
+  }
+}
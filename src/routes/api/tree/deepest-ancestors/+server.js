@@ -0,0 +1,48 @@
+/**
+ * GET /api/tree/deepest-ancestors
+ * Returns the earliest ancestors at the top of the tree's longest lines of descent
+ *
+ * These are the people without recorded parents whose lines reach down the
+ * most generations, the natural "brick walls" to research next. Ties are all
+ * returned. See findDeepestAncestors for the exact definition.
+ *
+ * Query parameters:
+ * - kinship: "legal" (default) counts adoptive parent edges, "blood" ignores them
+ *
+ * @returns {Response} JSON { depth, ancestors: [{ person, depth, descendant }] }
+ *   where depth counts generations (parent → child = 1) and descendant is the person at the
+ *   bottom of the line
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { loadFamilyGraph, parseKinshipMode, findDeepestAncestors } from '$lib/server/familyGraph.js'
+import { transformPersonToAPI } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function GET({ locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    const kinshipMode = parseKinshipMode(url)
+    if (!kinshipMode.valid) {
+      return jsonError(400, kinshipMode.error)
+    }
+
+    const graph = await loadFamilyGraph(database, { kinship: kinshipMode.mode })
+    const { depth, ancestors } = findDeepestAncestors(graph)
+
+    return json({
+      depth,
+      ancestors: ancestors.map((ancestor) => ({
+        person: transformPersonToAPI(graph.people.get(ancestor.personId)),
+        depth: ancestor.depth,
+        descendant: transformPersonToAPI(graph.people.get(ancestor.descendantId))
+      }))
+    })
+  } catch (error) {
+    console.error('Error finding deepest ancestors:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,60 @@
+/**
+ * GET /api/tree/pinch-points
+ * Returns the key progenitors: ancestors through whom all (or most) of the
+ * tree's youngest generation traces
+ *
+ * Only the lowest point of each funnel is reported, so a tree funneling
+ * through one couple lists that couple rather than everyone above them.
+ * See findPinchPoints for the exact definition.
+ *
+ * Query parameters:
+ * - threshold: minimum share of the youngest generation covered, greater
+ *   than 0 and at most 1 (default 1, meaning everyone)
+ * - kinship: "legal" (default) counts adoptive parent edges, "blood" ignores them
+ *
+ * @returns {Response} JSON { threshold, leafCount, pinchPoints: [{ person, leaves, coverage }] }
+ *   where leafCount is the size of the youngest generation and leaves is how many of them descend
+ *   from the person
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { loadFamilyGraph, parseKinshipMode, findPinchPoints } from '$lib/server/familyGraph.js'
+import { transformPersonToAPI } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+const DEFAULT_THRESHOLD = 1
+
+export async function GET({ locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    const thresholdParam = url?.searchParams?.get('threshold')
+    const threshold = thresholdParam ? Number(thresholdParam) : DEFAULT_THRESHOLD
+    if (!Number.isFinite(threshold) || threshold <= 0 || threshold > 1) {
+      return jsonError(400, 'Invalid threshold parameter (must be greater than 0 and at most 1)')
+    }
+
+    const kinshipMode = parseKinshipMode(url)
+    if (!kinshipMode.valid) {
+      return jsonError(400, kinshipMode.error)
+    }
+
+    const graph = await loadFamilyGraph(database, { kinship: kinshipMode.mode })
+    const { leafCount, pinchPoints } = findPinchPoints(graph, threshold)
+
+    return json({
+      threshold,
+      leafCount,
+      pinchPoints: pinchPoints.map(({ personId, leaves, coverage }) => ({
+        person: transformPersonToAPI(graph.people.get(personId)),
+        leaves,
+        coverage
+      }))
+    })
+  } catch (error) {
+    console.error('Error finding pinch points:', error)
+    return internalError(error)
+  }
+}
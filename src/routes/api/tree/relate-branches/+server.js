@@ -0,0 +1,86 @@
+/**
+ * POST /api/tree/relate-branches
+ * Tells whether two family lines connect
+ *
+ * Each line is a root person plus all of their descendants. If anyone
+ * descends from both roots, the lines have already merged and those people
+ * are returned, nearest first. Otherwise the closest pair of members is
+ * returned with a description of how they are connected.
+ *
+ * Request body: { "rootIds": [1, 2] } (two distinct person IDs)
+ *
+ * Query parameters:
+ * - kinship: "legal" (default) counts adoptive parent edges, "blood" ignores them
+ *
+ * @returns {Response} JSON { rootIds, merged, sharedDescendants: [{ person, generationsFromA, generationsFromB }], closest }
+ *   where closest is { from, to, steps, path, label } (from in the first line, to in the second),
+ *   or null when the lines merged or do not connect at all
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { loadFamilyGraph, parseKinshipMode } from '$lib/server/familyGraph.js'
+import { relateBranches } from '$lib/server/kinship.js'
+import { parseId, transformPersonToAPI } from '$lib/server/personHelpers.js'
+import { jsonError, internalError } from '$lib/server/errors.js'
+
+export async function POST({ request, locals, url }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    // Parse request body
+    let data
+    try {
+      data = await request.json()
+    } catch (parseError) {
+      return jsonError(400, 'Invalid JSON')
+    }
+
+    if (!data || !Array.isArray(data.rootIds) || data.rootIds.length !== 2) {
+      return jsonError(400, 'rootIds must be an array of two person IDs')
+    }
+
+    const rootIds = data.rootIds.map((id) => parseId(id))
+    if (rootIds.some((id) => id === null)) {
+      return jsonError(400, 'Invalid ID')
+    }
+    if (rootIds[0] === rootIds[1]) {
+      return jsonError(400, 'rootIds must be two different people')
+    }
+
+    const kinshipMode = parseKinshipMode(url)
+    if (!kinshipMode.valid) {
+      return jsonError(400, kinshipMode.error)
+    }
+
+    const graph = await loadFamilyGraph(database, { kinship: kinshipMode.mode })
+
+    if (rootIds.some((id) => !graph.people.has(id))) {
+      return jsonError(404, 'Person not found')
+    }
+
+    const { merged, sharedDescendants, closest } = relateBranches(graph, rootIds[0], rootIds[1])
+    const personFor = (id) => transformPersonToAPI(graph.people.get(id))
+
+    return json({
+      rootIds,
+      merged,
+      sharedDescendants: sharedDescendants.map(({ personId, generationsFromA, generationsFromB }) => ({
+        person: personFor(personId),
+        generationsFromA,
+        generationsFromB
+      })),
+      closest: closest && {
+        from: personFor(closest.fromId),
+        to: personFor(closest.toId),
+        steps: closest.steps,
+        path: closest.path,
+        label: closest.label
+      }
+    })
+  } catch (error) {
+    console.error('Error relating branches:', error)
+    return internalError(error)
+  }
+}
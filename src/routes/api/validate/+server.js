@@ -0,0 +1,40 @@
+/**
+ * GET /api/validate
+ * Read-only "lint" pass over the whole tree reporting data integrity issues:
+ * self-relationships, death before birth, parents younger than their
+ * children, children with two biological mothers (or fathers), and parent
+ * loops. Soft-deleted people and their relationships are not checked.
+ * See findTreeIssues for the rules.
+ *
+ * @returns {Response} JSON { issueCount, issues: [{ type, message, personIds, relationshipIds }] }
+ */
+
+import { json } from '@sveltejs/kit'
+import { db } from '$lib/db/client.js'
+import { people, relationships } from '$lib/db/schema.js'
+import { isNull } from 'drizzle-orm'
+import { findTreeIssues } from '$lib/server/treeValidation.js'
+import { internalError } from '$lib/server/errors.js'
+
+export async function GET({ locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+
+    const activePeople = await database
+      .select()
+      .from(people)
+      .where(isNull(people.deletedAt))
+
+    const allRelationships = await database
+      .select()
+      .from(relationships)
+
+    const issues = findTreeIssues(activePeople, allRelationships)
+
+    return json({ issueCount: issues.length, issues })
+  } catch (error) {
+    console.error('Error validating tree:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,15 @@
+/**
+ * GET /healthz
+ * Liveness check for load balancers
+ *
+ * Always returns 200 while the server process is able to handle requests.
+ * Registered outside /api and excluded from request logging to avoid noise.
+ *
+ * @returns {Response} JSON { status: "ok" }
+ */
+
+import { json } from '@sveltejs/kit'
+
+export async function GET() {
+  return json({ status: 'ok' })
+}
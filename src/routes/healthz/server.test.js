@@ -0,0 +1,18 @@
+/**
+ * Liveness Endpoint - Integration Tests
+ *
+ * Tests the GET /healthz endpoint
+ */
+
+import { describe, it, expect } from 'vitest'
+import { GET } from './+server.js'
+
+describe('GET /healthz', () => {
+  it('should always return 200 with status ok', async () => {
+    const response = await GET()
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toEqual({ status: 'ok' })
+  })
+})
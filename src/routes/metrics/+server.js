@@ -0,0 +1,56 @@
+/**
+ * GET /metrics
+ * Prometheus scrape endpoint
+ *
+ * Exposes request counts and latencies per route and method (recorded by
+ * the server hooks) plus gauges for the number of active people and of
+ * relationships between them. The gauges are counted at scrape time, so
+ * they are always current without hooking every mutation.
+ * Registered outside /api and excluded from request logging to avoid noise.
+ *
+ * @returns {Response} Prometheus text exposition format
+ */
+
+import { db } from '$lib/db/client.js'
+import { people, relationships } from '$lib/db/schema.js'
+import { and, count, isNull, isNotNull, notInArray } from 'drizzle-orm'
+import { metrics, METRICS_CONTENT_TYPE } from '$lib/server/metrics.js'
+import { internalError } from '$lib/server/errors.js'
+
+export async function GET({ locals }) {
+  try {
+    // Use locals.db if provided (for testing), otherwise use singleton db
+    const database = locals?.db || db
+    const registry = locals?.metrics || metrics
+
+    const [peopleCounts] = await database
+      .select({ total: count() })
+      .from(people)
+      .where(isNull(people.deletedAt))
+
+    const deletedPeople = database
+      .select({ id: people.id })
+      .from(people)
+      .where(isNotNull(people.deletedAt))
+
+    const [relationshipCounts] = await database
+      .select({ total: count() })
+      .from(relationships)
+      .where(
+        and(
+          notInArray(relationships.person1Id, deletedPeople),
+          notInArray(relationships.person2Id, deletedPeople)
+        )
+      )
+
+    const body = registry.render([
+      { name: 'familytree_people', help: 'Number of active (not deleted) people', value: peopleCounts.total },
+      { name: 'familytree_relationships', help: 'Number of relationships between active people', value: relationshipCounts.total }
+    ])
+
+    return new Response(body, { headers: { 'Content-Type': METRICS_CONTENT_TYPE } })
+  } catch (error) {
+    console.error('Error rendering metrics:', error)
+    return internalError(error)
+  }
+}
@@ -0,0 +1,68 @@
+/**
+ * Metrics Endpoint - Integration Tests
+ *
+ * Tests the GET /metrics endpoint
+ */
+
+import { describe, it, expect, beforeEach, afterEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from './+server.js'
+import { GET as getPerson } from '../api/people/[id]/+server.js'
+import { createMetrics, measureRequest } from '$lib/server/metrics.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /metrics', () => {
+  let sqlite, db, metrics
+
+  beforeEach(async () => {
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+    await setupTestDatabase(sqlite, db)
+    metrics = createMetrics()
+
+    sqlite.prepare(`
+      INSERT INTO people (id, first_name, last_name) VALUES (1, 'John', 'Doe'), (2, 'Jane', 'Doe'), (3, 'Gone', 'Doe')
+    `).run()
+    sqlite.prepare(`
+      INSERT INTO relationships (person1_id, person2_id, type) VALUES (1, 2, 'spouse'), (1, 3, 'parentOf')
+    `).run()
+    sqlite.prepare('UPDATE people SET deleted_at = CURRENT_TIMESTAMP WHERE id = 3').run()
+  })
+
+  afterEach(() => {
+    sqlite.close()
+  })
+
+  const scrape = async () => {
+    const response = await GET(createMockEvent(db, { locals: { db, metrics } }))
+    return { response, text: await response.text() }
+  }
+
+  it('should count a request after it is made', async () => {
+    const event = createMockEvent(db, {
+      params: { id: '1' },
+      request: { method: 'GET' },
+      route: { id: '/api/people/[id]' }
+    })
+    const series = 'http_requests_total{method="GET",route="/api/people/[id]",status="200"}'
+
+    expect((await scrape()).text).not.toContain(series)
+
+    const response = await measureRequest(metrics, event, getPerson)
+    expect(response.status).toBe(200)
+
+    const { response: scraped, text } = await scrape()
+    expect(scraped.status).toBe(200)
+    expect(scraped.headers.get('Content-Type')).toContain('text/plain')
+    expect(text).toContain(`${series} 1`)
+    expect(text).toContain('http_request_duration_seconds_count{method="GET",route="/api/people/[id]"} 1')
+  })
+
+  it('should report gauges for active people and their relationships', async () => {
+    const { text } = await scrape()
+
+    expect(text).toContain('familytree_people 2\n')
+    expect(text).toContain('familytree_relationships 1\n')
+  })
+})
@@ -0,0 +1,27 @@
+/**
+ * GET /readyz
+ * Readiness check for load balancers
+ *
+ * Pings the database with a trivial query. Returns 200 when the database
+ * is reachable, otherwise 503 with the error message.
+ * Registered outside /api and excluded from request logging to avoid noise.
+ *
+ * @returns {Response} JSON { status: "ok" } or { status: "unavailable", error }
+ */
+
+import { json } from '@sveltejs/kit'
+import { sql } from 'drizzle-orm'
+import { db } from '$lib/db/client.js'
+
+export async function GET({ locals }) {
+  // Use locals.db if provided (for testing), otherwise use singleton db
+  const database = locals?.db || db
+
+  try {
+    await database.get(sql`SELECT 1`)
+  } catch (error) {
+    return json({ status: 'unavailable', error: error.message }, { status: 503 })
+  }
+
+  return json({ status: 'ok' })
+}
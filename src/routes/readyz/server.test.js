@@ -0,0 +1,42 @@
+/**
+ * Readiness Endpoint - Integration Tests
+ *
+ * Tests the GET /readyz endpoint
+ */
+
+import { describe, it, expect, beforeEach } from 'vitest'
+import Database from 'better-sqlite3'
+import { drizzle } from 'drizzle-orm/better-sqlite3'
+import { GET } from './+server.js'
+import { setupTestDatabase, createMockEvent } from '$lib/server/testHelpers.js'
+
+describe('GET /readyz', () => {
+  let sqlite, db
+
+  beforeEach(async () => {
+    sqlite = new Database(':memory:')
+    db = drizzle(sqlite)
+    await setupTestDatabase(sqlite, db)
+  })
+
+  it('should return 200 when the database is reachable', async () => {
+    const response = await GET(createMockEvent(db))
+    const data = await response.json()
+
+    expect(response.status).toBe(200)
+    expect(data).toEqual({ status: 'ok' })
+
+    sqlite.close()
+  })
+
+  it('should return 503 with the error when the database is closed', async () => {
+    sqlite.close()
+
+    const response = await GET(createMockEvent(db))
+    const data = await response.json()
+
+    expect(response.status).toBe(503)
+    expect(data.status).toBe('unavailable')
+    expect(data.error).toEqual(expect.any(String))
+  })
+})
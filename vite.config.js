@@ -1,7 +1,8 @@
 import { sveltekit } from '@sveltejs/kit/vite';
 import { defineConfig, loadEnv } from 'vite';
+import { resolveServerConfig } from './src/lib/server/config.js';
 
-export default defineConfig(({ mode }) => {
+export default defineConfig(({ mode, command }) => {
   // Load env file based on mode (development, production, etc.)
   const env = loadEnv(mode, process.cwd(), '');
 
@@ -12,6 +13,13 @@ export default defineConfig(({ mode }) => {
   const isGitHubPages = process.env.GITHUB_PAGES === 'true';
   const basePath = isGitHubPages ? '/familytree' : '';
 
+  // Resolve listen address and database path (PORT/ADDR, DB_PATH); fails fast on invalid values
+  const serverConfig = resolveServerConfig(process.env);
+  if (command === 'serve') {
+    console.log(`Listening on ${serverConfig.host || 'localhost'}:${serverConfig.port}`);
+    console.log(`Database: ${serverConfig.dbPath}`);
+  }
+
   return {
     plugins: [sveltekit()],
     define: {
@@ -19,7 +27,8 @@ export default defineConfig(({ mode }) => {
       'import.meta.env.VITE_BASE_PATH': JSON.stringify(basePath)
     },
     server: {
-      port: 5173,
+      port: serverConfig.port,
+      host: serverConfig.host,
       fs: {
         allow: ['..']
       }